@@ -53,6 +53,20 @@ describe('referencesForPosition', () => {
         assert.deepEqual(await gatherValues(referencesForPosition(document, position, queryGraphQLFn)), [])
     })
 
+    it('should ignore imprecise results', async () => {
+        const queryGraphQLFn = sinon.spy<QueryGraphQLFn<GenericLSIFResponse<ReferencesResponse | null>>>(() =>
+            makeEnvelope({
+                references: {
+                    nodes: [{ resource: resource1, range: range1 }],
+                    pageInfo: {},
+                    imprecise: true,
+                },
+            })
+        )
+
+        assert.deepEqual(await gatherValues(referencesForPosition(document, position, queryGraphQLFn)), [])
+    })
+
     it('should paginate results', async () => {
         const stub = sinon.stub<
             Parameters<QueryGraphQLFn<GenericLSIFResponse<ReferencesResponse | null>>>,
@@ -19,6 +19,7 @@ export interface ReferencesResponse {
     references: {
         nodes: LocationConnectionNode[]
         pageInfo: { endCursor?: string }
+        imprecise?: boolean
     }
 }
 
@@ -60,6 +61,7 @@ const referencesQuery = gql`
                             pageInfo {
                                 endCursor
                             }
+                            imprecise
                         }
                     }
                 }
@@ -112,7 +114,9 @@ function referenceResponseToLocations(
     textDocument: sourcegraph.TextDocument,
     lsifObject: ReferencesResponse | null
 ): { locations: sourcegraph.Location[] | null; endCursor?: string } {
-    if (!lsifObject) {
+    // Files without precise data are answered with search-based references, which
+    // are provided (and badged) by the search-based provider instead.
+    if (!lsifObject || lsifObject.references.imprecise) {
         return { locations: null }
     }
 
@@ -244,6 +244,7 @@ const USE_PRECISE_CODE_INTEL_MOCK: UsePreciseCodeIntelForPositionResult = {
                     references: {
                         nodes: MOCK_REFERENCES,
                         pageInfo: { endCursor: null, __typename: 'PageInfo' },
+                        imprecise: false,
                         __typename: 'LocationConnection',
                     },
                     implementations: {
@@ -55,6 +55,7 @@ export const USE_PRECISE_CODE_INTEL_FOR_POSITION_QUERY = gql`
             filter: $filter
         ) {
             ...LocationConnectionFields
+            imprecise
         }
         implementations(
             line: $line
@@ -18,6 +18,7 @@ import { PerforceIcon, PhabricatorIcon } from '@sourcegraph/shared/src/component
 import { Link, Code, Text } from '@sourcegraph/wildcard'
 
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
+import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
@@ -26,6 +27,7 @@ import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import goModulesSchemaJSON from '../../../../../schema/go-modules.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
+import mercurialSchemaJSON from '../../../../../schema/mercurial.schema.json'
 import npmPackagesSchemaJSON from '../../../../../schema/npm-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
@@ -1256,6 +1258,33 @@ const PAGURE: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const MERCURIAL: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.MERCURIAL,
+    title: 'Mercurial',
+    icon: GitIcon,
+    jsonSchema: mercurialSchemaJSON,
+    defaultDisplayName: 'Mercurial',
+    defaultConfig: `{
+  "url": "https://hg.example.com",
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of the hgweb server hosting your
+                    Mercurial repositories.
+                </li>
+                <li>
+                    Optionally, set <Field>repos</Field> to the paths of the repositories to mirror. By default, all
+                    repositories listed by the server are mirrored.
+                </li>
+            </ol>
+            <Text>Sourcegraph converts Mercurial repositories to Git, so commit IDs differ from changeset IDs.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const GERRIT: AddExternalServiceOptions = {
     kind: ExternalServiceKind.GERRIT,
     title: 'Gerrit',
@@ -1277,6 +1306,39 @@ const GERRIT: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const AZUREDEVOPS: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: GitIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com",
+  "username": "<admin username>",
+  "token": "<admin token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to <Code>https://dev.azure.com</Code> for Azure
+                    DevOps Services, or to the collection URL of your Azure DevOps Server instance.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>token</Field> to the username and a personal access token
+                    with the <Code>Code (Read)</Code>, <Code>Project and Team (Read)</Code> and{' '}
+                    <Code>User Profile (Read)</Code> scopes.
+                </li>
+                <li>
+                    Set <Field>orgs</Field> and/or <Field>projects</Field> to select the repositories to mirror.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const NPM_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.NPMPACKAGES,
     title: 'npm Dependencies',
@@ -1456,7 +1518,9 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
+    ...(window.context?.experimentalFeatures?.mercurial === 'enabled' ? { mercurial: MERCURIAL } : {}),
     ...(window.context?.experimentalFeatures?.gerrit === 'enabled' ? { gerrit: GERRIT } : {}),
+    ...(window.context?.experimentalFeatures?.azureDevOps === 'enabled' ? { azuredevops: AZUREDEVOPS } : {}),
 }
 
 export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptions> = {
@@ -1479,7 +1543,9 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.AZUREDEVOPS]: AZUREDEVOPS,
     [ExternalServiceKind.PAGURE]: PAGURE,
+    [ExternalServiceKind.MERCURIAL]: MERCURIAL,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
     [ExternalServiceKind.NPMPACKAGES]: NPM_PACKAGES,
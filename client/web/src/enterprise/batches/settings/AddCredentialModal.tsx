@@ -52,15 +52,27 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
             <Code>pipeline:read</Code> permissions.
         </span>
     ),
+    [ExternalServiceKind.AZUREDEVOPS]: (
+        <span>
+            with <Code>Code (Read & Write)</Code>, <Code>Project and Team (Read)</Code>, and{' '}
+            <Code>User Profile (Read)</Code> scopes.
+        </span>
+    ),
+    [ExternalServiceKind.GERRIT]: (
+        <span>
+            with permissions to <Code>Push</Code> to <Code>refs/for/*</Code>, and to <Code>Abandon</Code> and{' '}
+            <Code>Submit</Code> changes.
+        </span>
+    ),
 
     // These are just for type completeness and serve as placeholders for a bright future.
-    [ExternalServiceKind.GERRIT]: <span>Unsupported</span>,
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.GOMODULES]: <span>Unsupported</span>,
     [ExternalServiceKind.PYTHONPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.RUSTPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.RUBYPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.JVMPACKAGES]: <span>Unsupported</span>,
+    [ExternalServiceKind.MERCURIAL]: <span>Unsupported</span>,
     [ExternalServiceKind.NPMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.PERFORCE]: <span>Unsupported</span>,
     [ExternalServiceKind.PHABRICATOR]: <span>Unsupported</span>,
@@ -136,7 +148,11 @@ export const AddCredentialModal: React.FunctionComponent<React.PropsWithChildren
     )
 
     const patLabel =
-        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD ? 'App password' : 'Personal access token'
+        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD
+            ? 'App password'
+            : externalServiceKind === ExternalServiceKind.GERRIT
+            ? 'HTTP password'
+            : 'Personal access token'
 
     return (
         <Modal onDismiss={onCancel} aria-labelledby={labelId}>
@@ -15,11 +15,13 @@ const configInstructionLinks: Record<ExternalServiceKind, string> = {
     [ExternalServiceKind.BITBUCKETSERVER]:
         'https://confluence.atlassian.com/bitbucketserver/ssh-user-keys-for-personal-use-776639793.html',
     [ExternalServiceKind.AWSCODECOMMIT]: 'unsupported',
+    [ExternalServiceKind.AZUREDEVOPS]: 'https://learn.microsoft.com/en-us/azure/devops/repos/git/use-ssh-keys-to-authenticate',
     [ExternalServiceKind.BITBUCKETCLOUD]: 'unsupported',
-    [ExternalServiceKind.GERRIT]: 'unsupported',
+    [ExternalServiceKind.GERRIT]: 'https://gerrit-review.googlesource.com/Documentation/user-upload.html#ssh',
     [ExternalServiceKind.GITOLITE]: 'unsupported',
     [ExternalServiceKind.GOMODULES]: 'unsupported',
     [ExternalServiceKind.JVMPACKAGES]: 'unsupported',
+    [ExternalServiceKind.MERCURIAL]: 'unsupported',
     [ExternalServiceKind.NPMPACKAGES]: 'unsupported',
     [ExternalServiceKind.OTHER]: 'unsupported',
     [ExternalServiceKind.PERFORCE]: 'unsupported',
@@ -272,10 +272,14 @@ const getLsifData = ({
 
     const extractedData = dataOrThrowErrors(result)
 
-    // If there weren't any errors and we just didn't receive any data
+    // If there weren't any errors and we just didn't receive any data. Files without precise
+    // data answer references with search-based results, which we fetch and badge ourselves.
     if (!extractedData || !extractedData.repository?.commit?.blob?.lsif) {
         return undefined
     }
+    if (extractedData.repository.commit.blob.lsif.references.imprecise) {
+        return undefined
+    }
 
     const lsif = extractedData.repository?.commit?.blob?.lsif
 
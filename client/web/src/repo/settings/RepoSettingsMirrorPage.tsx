@@ -31,6 +31,8 @@ import {
     CheckMirrorRepositoryConnectionVariables,
     RecloneRepositoryResult,
     RecloneRepositoryVariables,
+    RepositoryMaintenanceLogsResult,
+    RepositoryMaintenanceLogsVariables,
     SettingsAreaRepositoryFields,
     SettingsAreaRepositoryResult,
     SettingsAreaRepositoryVariables,
@@ -45,7 +47,7 @@ import {
 import { eventLogger } from '../../tracking/eventLogger'
 import { DirectImportRepoAlert } from '../DirectImportRepoAlert'
 
-import { FETCH_SETTINGS_AREA_REPOSITORY_GQL } from './backend'
+import { FETCH_SETTINGS_AREA_REPOSITORY_GQL, REPOSITORY_MAINTENANCE_LOGS } from './backend'
 import { ActionContainer, BaseActionContainer } from './components/ActionContainer'
 
 import styles from './RepoSettingsMirrorPage.module.scss'
@@ -278,6 +280,100 @@ const CorruptionLogsContainer: React.FunctionComponent<CorruptionLogProps> = pro
     )
 }
 
+interface MaintenanceLogProps {
+    repo: SettingsAreaRepositoryFields
+}
+
+const MaintenanceLogsContainer: React.FunctionComponent<MaintenanceLogProps> = props => {
+    const { data, error } = useQuery<RepositoryMaintenanceLogsResult, RepositoryMaintenanceLogsVariables>(
+        REPOSITORY_MAINTENANCE_LOGS,
+        {
+            variables: { name: props.repo.name },
+            pollInterval: 30000,
+        }
+    )
+    const logs = data?.repository?.mirrorInfo.maintenanceLogs ?? []
+
+    // The logs are ordered from most recent to least, so the first entry of a
+    // task tells us when it runs next.
+    const nextRuns = new Map<string, string>()
+    for (const log of logs) {
+        if (!nextRuns.has(log.task)) {
+            nextRuns.set(log.task, log.nextRunAt)
+        }
+    }
+    const schedule: JSX.Element[] = [...nextRuns].map(([task, nextRunAt]) => (
+        <li key={task} className="list-group-item px-2 py-1 d-flex justify-content-between">
+            <Code>{task}</Code>
+            <small className="text-muted mb-0">
+                Next run <Timestamp date={nextRunAt} />
+            </small>
+        </li>
+    ))
+    const logEvents: JSX.Element[] = logs.map(log => (
+        <li key={`${log.task}#${log.startedAt}`} className="list-group-item px-2 py-1">
+            <div className="d-flex flex-column align-items-center justify-content-between">
+                <Text className="mb-0">
+                    <Code>{log.task}</Code> {log.error ? 'failed' : 'succeeded'}
+                </Text>
+                {log.error && (
+                    <Text className={classNames('overflow-auto', 'text-monospace', styles.log)}>{log.error}</Text>
+                )}
+                <small className="text-muted mb-0">
+                    <Timestamp date={log.startedAt} />
+                </small>
+            </div>
+        </li>
+    ))
+
+    const [isOpened, setIsOpened] = useState(false)
+    const hasLogs = logEvents.length !== 0
+
+    return (
+        <BaseActionContainer
+            title="Repository maintenance"
+            description={
+                <span>
+                    Scheduled maintenance tasks, such as writing the commit-graph or repacking, that ran on this
+                    repository.
+                </span>
+            }
+            details={
+                <div className="flex-1">
+                    {error && <ErrorAlert error={error} />}
+                    {schedule.length > 0 && <ul className="list-group mt-2">{schedule}</ul>}
+                    <Collapse isOpen={isOpened} onOpenChange={setIsOpened}>
+                        <CollapseHeader
+                            as={Button}
+                            outline={true}
+                            focusLocked={true}
+                            variant="secondary"
+                            className="w-100 my-2"
+                            disabled={!hasLogs}
+                        >
+                            {hasLogs ? (
+                                <>
+                                    Show maintenance history
+                                    <Icon
+                                        aria-hidden={true}
+                                        svgPath={isOpened ? mdiChevronUp : mdiChevronDown}
+                                        className="mr-1"
+                                    />
+                                </>
+                            ) : (
+                                'No maintenance history'
+                            )}
+                        </CollapseHeader>
+                        <CollapsePanel>
+                            <ul className="list-group">{logEvents}</ul>
+                        </CollapsePanel>
+                    </Collapse>
+                </div>
+            }
+        />
+    )
+}
+
 interface RepoSettingsMirrorPageProps extends RouteComponentProps<{}> {
     repo: SettingsAreaRepositoryFields
     history: H.History
@@ -417,6 +513,7 @@ export const RepoSettingsMirrorPage: React.FunctionComponent<
                     </Alert>
                 )}
                 <CorruptionLogsContainer repo={repo} history={props.history} />
+                <MaintenanceLogsContainer repo={repo} />
             </Container>
         </>
     )
@@ -90,3 +90,20 @@ export const EXCLUDE_REPO_FROM_EXTERNAL_SERVICES = gql`
         }
     }
 `
+
+export const REPOSITORY_MAINTENANCE_LOGS = gql`
+    query RepositoryMaintenanceLogs($name: String!) {
+        repository(name: $name) {
+            id
+            mirrorInfo {
+                maintenanceLogs {
+                    task
+                    startedAt
+                    finishedAt
+                    error
+                    nextRunAt
+                }
+            }
+        }
+    }
+`
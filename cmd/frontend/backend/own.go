@@ -3,11 +3,18 @@ package backend
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 
 	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
 )
@@ -18,16 +25,22 @@ type OwnService interface {
 	// OwnersFile returns a CODEOWNERS file from a given repository at given commit ID.
 	// In the case the file cannot be found, `nil` `*codeownerspb.File` and `nil` `error` is returned.
 	OwnersFile(context.Context, api.RepoName, api.CommitID) (*codeownerspb.File, error)
+
+	// Ownership returns the owners of a file or directory in a given repository at given
+	// commit ID, ordered by decreasing confidence. Owners are merged from all ownership
+	// signals: the CODEOWNERS file, owners assigned on Sourcegraph and recent contributors.
+	Ownership(context.Context, types.MinimalRepo, api.CommitID, string) ([]*Owner, error)
 }
 
 var _ OwnService = ownService{}
 
-func NewOwnService(g gitserver.Client) OwnService {
-	return ownService{gitserverClient: g}
+func NewOwnService(g gitserver.Client, db database.DB) OwnService {
+	return ownService{gitserverClient: g, db: db}
 }
 
 type ownService struct {
 	gitserverClient gitserver.Client
+	db              database.DB
 }
 
 // codeownersLocations contains the locations where CODEOWNERS file
@@ -38,10 +51,12 @@ type ownService struct {
 var codeownersLocations = []string{
 	"CODEOWNERS",
 	".github/CODEOWNERS",
-	".gitlab/CODEOWNERS",
+	gitlabCodeownersLocation,
 	"docs/CODEOWNERS",
 }
 
+const gitlabCodeownersLocation = ".gitlab/CODEOWNERS"
+
 // OwnersFile makes a best effort attempt to return a CODEOWNERS file from one of
 // the possible codeownersLocations. It returns nil if no match is found.
 func (s ownService) OwnersFile(ctx context.Context, repoName api.RepoName, commitID api.CommitID) (*codeownerspb.File, error) {
@@ -54,8 +69,243 @@ func (s ownService) OwnersFile(ctx context.Context, repoName api.RepoName, commi
 			path,
 		)
 		if content != nil && err == nil {
-			return codeowners.Parse(bytes.NewReader(content))
+			file, err := codeowners.Parse(bytes.NewReader(content))
+			if err != nil {
+				return nil, err
+			}
+			// A CODEOWNERS file in the .gitlab directory is evaluated by GitLab,
+			// even if it does not declare any sections.
+			if path == gitlabCodeownersLocation {
+				file.MatchingMode = codeownerspb.MatchingMode_MATCHING_MODE_GITLAB
+			}
+			return file, nil
 		}
 	}
 	return nil, nil
 }
+
+// OwnershipReasonKind identifies the signal an owner was derived from.
+type OwnershipReasonKind string
+
+const (
+	// OwnershipReasonCodeownersFileEntry is used for owners listed in the
+	// CODEOWNERS file for the path.
+	OwnershipReasonCodeownersFileEntry OwnershipReasonKind = "CODEOWNERS_FILE_ENTRY"
+	// OwnershipReasonAssignedOwner is used for users explicitly assigned as
+	// owners of the path or one of its parent directories.
+	OwnershipReasonAssignedOwner OwnershipReasonKind = "ASSIGNED_OWNER"
+	// OwnershipReasonRecentContributor is used for authors of recent commits
+	// that modified the path.
+	OwnershipReasonRecentContributor OwnershipReasonKind = "RECENT_CONTRIBUTOR"
+)
+
+// The confidence in each of the ownership signals, between 0 and 1.
+const (
+	assignedOwnerConfidence         = 1.0
+	assignedOwnerOfParentConfidence = 0.9
+	codeownersConfidence            = 0.9
+	// recentContributorConfidence is the confidence in a recent contributor
+	// that authored all of the recent commits to the path. It is scaled by
+	// the share of recent commits authored by a contributor.
+	recentContributorConfidence = 0.6
+)
+
+const (
+	// recentContributorsWindow is how far back commits are considered to find
+	// recent contributors.
+	recentContributorsWindow = 90 * 24 * time.Hour
+	// recentContributorsCommitLimit is the maximum number of commits
+	// considered to find recent contributors.
+	recentContributorsCommitLimit = 100
+)
+
+// OwnershipReason explains why someone was found to be an owner.
+type OwnershipReason struct {
+	Kind OwnershipReasonKind
+	// Confidence in the reason, between 0 and 1.
+	Confidence float64
+	// Description is a human-readable explanation of the reason.
+	Description string
+}
+
+// Owner is someone found to own a file or directory, along with all the
+// reasons they were found to be an owner. At least one of Handle, Email and
+// User is set.
+type Owner struct {
+	// Handle is a user or team handle, without the leading `@`.
+	Handle string
+	Email  string
+	// Name is the name of a recent contributor.
+	Name string
+	// User is the Sourcegraph user the owner was resolved to, if any.
+	User *types.User
+
+	Reasons []OwnershipReason
+}
+
+// Confidence returns the combined confidence of all the reasons, which
+// increases with every independent signal for the same owner.
+func (o *Owner) Confidence() float64 {
+	doubt := 1.0
+	for _, r := range o.Reasons {
+		doubt *= 1 - r.Confidence
+	}
+	return 1 - doubt
+}
+
+func (s ownService) Ownership(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID, path string) ([]*Owner, error) {
+	m := ownersMerger{db: s.db}
+
+	file, err := s.OwnersFile(ctx, repo.Name, commitID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range file.FindOwners(path) {
+		err := m.add(ctx, &Owner{Handle: o.GetHandle(), Email: o.GetEmail()}, OwnershipReason{
+			Kind:        OwnershipReasonCodeownersFileEntry,
+			Confidence:  codeownersConfidence,
+			Description: "Listed as an owner in the CODEOWNERS file",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	assigned, err := s.db.AssignedOwners().ListForPath(ctx, repo.ID, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assigned {
+		user, err := s.db.Users().GetByID(ctx, a.OwnerUserID)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		reason := OwnershipReason{
+			Kind:        OwnershipReasonAssignedOwner,
+			Confidence:  assignedOwnerConfidence,
+			Description: "Assigned as an owner",
+		}
+		if a.FilePath != strings.Trim(path, "/") {
+			reason.Confidence = assignedOwnerOfParentConfidence
+			reason.Description = fmt.Sprintf("Assigned as an owner of %s", assignedPathDisplay(a.FilePath))
+		}
+		if err := m.add(ctx, &Owner{Handle: user.Username, User: user}, reason); err != nil {
+			return nil, err
+		}
+	}
+
+	commits, err := s.gitserverClient.Commits(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, gitserver.CommitsOptions{
+		Range: string(commitID),
+		Path:  path,
+		N:     recentContributorsCommitLimit,
+		After: time.Now().Add(-recentContributorsWindow).Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+	type contributor struct {
+		name, email string
+		commits     int
+	}
+	var contributors []*contributor
+	byEmail := map[string]*contributor{}
+	for _, c := range commits {
+		email := strings.ToLower(c.Author.Email)
+		if _, ok := byEmail[email]; !ok {
+			byEmail[email] = &contributor{name: c.Author.Name, email: c.Author.Email}
+			contributors = append(contributors, byEmail[email])
+		}
+		byEmail[email].commits++
+	}
+	for _, c := range contributors {
+		err := m.add(ctx, &Owner{Name: c.name, Email: c.email}, OwnershipReason{
+			Kind:        OwnershipReasonRecentContributor,
+			Confidence:  recentContributorConfidence * float64(c.commits) / float64(len(commits)),
+			Description: fmt.Sprintf("Authored %d of the last %d commits", c.commits, len(commits)),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(m.owners, func(i, j int) bool {
+		return m.owners[i].Confidence() > m.owners[j].Confidence()
+	})
+	return m.owners, nil
+}
+
+func assignedPathDisplay(path string) string {
+	if path == "" {
+		return "the repository"
+	}
+	return path
+}
+
+// ownersMerger merges owners derived from different signals that refer to
+// the same person, either by resolving them to the same Sourcegraph user or
+// by having the same handle or email.
+type ownersMerger struct {
+	db     database.DB
+	owners []*Owner
+}
+
+func (m *ownersMerger) add(ctx context.Context, o *Owner, reason OwnershipReason) error {
+	if o.User == nil {
+		user, err := m.resolveUser(ctx, o)
+		if err != nil {
+			return err
+		}
+		o.User = user
+	}
+
+	for _, existing := range m.owners {
+		if sameOwner(existing, o) {
+			existing.Reasons = append(existing.Reasons, reason)
+			if existing.User == nil {
+				existing.User = o.User
+			}
+			if existing.Email == "" {
+				existing.Email = o.Email
+			}
+			if existing.Name == "" {
+				existing.Name = o.Name
+			}
+			return nil
+		}
+	}
+	o.Reasons = append(o.Reasons, reason)
+	m.owners = append(m.owners, o)
+	return nil
+}
+
+// resolveUser returns the Sourcegraph user with the verified email of the
+// owner, or nil if there is none. Handles are code host handles, which anyone
+// can claim as their Sourcegraph username, so they are not resolved.
+func (m *ownersMerger) resolveUser(ctx context.Context, o *Owner) (*types.User, error) {
+	if o.Email == "" {
+		return nil, nil
+	}
+	user, err := m.db.Users().GetByVerifiedEmail(ctx, o.Email)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func sameOwner(a, b *Owner) bool {
+	if a.User != nil && b.User != nil {
+		return a.User.ID == b.User.ID
+	}
+	if a.Email != "" && strings.EqualFold(a.Email, b.Email) {
+		return true
+	}
+	// The handle of a Sourcegraph user is their username, which needn't be
+	// their code host handle.
+	if a.User != nil || b.User != nil {
+		return false
+	}
+	return a.Handle != "" && strings.EqualFold(a.Handle, b.Handle)
+}
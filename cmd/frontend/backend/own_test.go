@@ -4,13 +4,17 @@ import (
 	"context"
 	"testing"
 
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
@@ -50,13 +54,31 @@ func TestOwnersServesFilesAtVariousLocations(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			git := gitserver.NewMockClient()
 			git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
-			got, err := backend.NewOwnService(git).OwnersFile(context.Background(), "repo", "SHA")
+			got, err := backend.NewOwnService(git, database.NewMockDB()).OwnersFile(context.Background(), "repo", "SHA")
 			require.NoError(t, err)
 			assert.Equal(t, codeownersText, got.Repr())
 		})
 	}
 }
 
+func TestOwnersMatchingModeForGitLabLocation(t *testing.T) {
+	codeownersText := "README.md owner@example.com\n"
+	for location, want := range map[string]codeownerspb.MatchingMode{
+		"CODEOWNERS":         codeownerspb.MatchingMode_MATCHING_MODE_GITHUB,
+		".github/CODEOWNERS": codeownerspb.MatchingMode_MATCHING_MODE_GITHUB,
+		".gitlab/CODEOWNERS": codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	} {
+		t.Run(location, func(t *testing.T) {
+			repo := repoFiles{{"repo", "SHA", location}: codeownersText}
+			git := gitserver.NewMockClient()
+			git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+			got, err := backend.NewOwnService(git, database.NewMockDB()).OwnersFile(context.Background(), "repo", "SHA")
+			require.NoError(t, err)
+			assert.Equal(t, want, got.GetMatchingMode())
+		})
+	}
+}
+
 func TestOwnersCannotFindFile(t *testing.T) {
 	codeownersFile := &codeownerspb.File{
 		Rule: []*codeownerspb.Rule{
@@ -71,7 +93,86 @@ func TestOwnersCannotFindFile(t *testing.T) {
 	}
 	git := gitserver.NewMockClient()
 	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
-	got, err := backend.NewOwnService(git).OwnersFile(context.Background(), "repo", "SHA")
+	got, err := backend.NewOwnService(git, database.NewMockDB()).OwnersFile(context.Background(), "repo", "SHA")
 	require.NoError(t, err)
 	assert.Nil(t, got)
 }
+
+func TestOwnership(t *testing.T) {
+	alice := &types.User{ID: 1, Username: "alice"}
+	bob := &types.User{ID: 2, Username: "bob"}
+
+	repo := repoFiles{
+		{"repo", "SHA", "CODEOWNERS"}: "/cmd/ @bob @search-team alice@example.com\n",
+	}
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+	git.CommitsFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, opts gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
+		assert.Equal(t, "SHA", opts.Range)
+		assert.Equal(t, "cmd/main.go", opts.Path)
+		return []*gitdomain.Commit{
+			{Author: gitdomain.Signature{Name: "Bob", Email: "bob@example.com"}},
+			{Author: gitdomain.Signature{Name: "Carol", Email: "carol@example.com"}},
+			{Author: gitdomain.Signature{Name: "Bob", Email: "BOB@example.com"}},
+			{Author: gitdomain.Signature{Name: "Bob", Email: "bob@example.com"}},
+		}, nil
+	})
+
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		if id == bob.ID {
+			return bob, nil
+		}
+		return nil, database.NewUserNotFoundError(id)
+	})
+	users.GetByVerifiedEmailFunc.SetDefaultHook(func(_ context.Context, email string) (*types.User, error) {
+		switch email {
+		case "alice@example.com":
+			return alice, nil
+		case "bob@example.com":
+			return bob, nil
+		}
+		return nil, database.NewUserNotFoundError(0)
+	})
+	assignedOwners := database.NewMockAssignedOwnersStore()
+	assignedOwners.ListForPathFunc.SetDefaultReturn([]*database.AssignedOwnerSummary{
+		{OwnerUserID: bob.ID, RepoID: 1, FilePath: "cmd"},
+	}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.AssignedOwnersFunc.SetDefaultReturn(assignedOwners)
+
+	got, err := backend.NewOwnService(git, db).Ownership(context.Background(), types.MinimalRepo{ID: 1, Name: "repo"}, "SHA", "cmd/main.go")
+	require.NoError(t, err)
+
+	type summary struct {
+		Handle, Email string
+		UserID        int32
+		Reasons       []backend.OwnershipReasonKind
+	}
+	var summaries []summary
+	for _, o := range got {
+		s := summary{Handle: o.Handle, Email: o.Email}
+		if o.User != nil {
+			s.UserID = o.User.ID
+		}
+		for _, r := range o.Reasons {
+			s.Reasons = append(s.Reasons, r.Kind)
+		}
+		summaries = append(summaries, s)
+	}
+	assert.Equal(t, []summary{
+		// Assigned owner of the parent directory and author of 3 of the 4 commits.
+		{Handle: "bob", Email: "bob@example.com", UserID: bob.ID, Reasons: []backend.OwnershipReasonKind{backend.OwnershipReasonAssignedOwner, backend.OwnershipReasonRecentContributor}},
+		// Handles in CODEOWNERS are code host handles, which aren't resolved
+		// to the Sourcegraph user with the same username.
+		{Handle: "bob", Reasons: []backend.OwnershipReasonKind{backend.OwnershipReasonCodeownersFileEntry}},
+		{Handle: "search-team", Reasons: []backend.OwnershipReasonKind{backend.OwnershipReasonCodeownersFileEntry}},
+		{Email: "alice@example.com", UserID: alice.ID, Reasons: []backend.OwnershipReasonKind{backend.OwnershipReasonCodeownersFileEntry}},
+		{Email: "carol@example.com", Reasons: []backend.OwnershipReasonKind{backend.OwnershipReasonRecentContributor}},
+	}, summaries)
+	mockassert.NotCalled(t, users.GetByUsernameFunc)
+
+	assert.InDelta(t, 1-(1-0.9)*(1-0.6*3/4), got[0].Confidence(), 0.0001)
+	assert.InDelta(t, 0.6/4, got[4].Confidence(), 0.0001)
+}
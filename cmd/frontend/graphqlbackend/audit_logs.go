@@ -0,0 +1,163 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/syncx"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const auditLogIDKind = "AuditLog"
+
+type ListAuditLogsArgs struct {
+	First    int32             `json:"first"`
+	After    *string           `json:"after"`
+	ActorUID *string           `json:"actorUID"`
+	Entity   *string           `json:"entity"`
+	Since    *gqlutil.DateTime `json:"since"`
+	Until    *gqlutil.DateTime `json:"until"`
+}
+
+func (r *schemaResolver) AuditLogs(ctx context.Context, args ListAuditLogsArgs) (*auditLogConnectionResolver, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts := database.AuditLogListOpts{
+		LimitOffset: &database.LimitOffset{
+			Limit: int(args.First),
+		},
+	}
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, errors.Newf("cannot parse offset %q", *args.After)
+		}
+		opts.Offset = offset
+	}
+	if args.ActorUID != nil {
+		opts.ActorUID = *args.ActorUID
+	}
+	if args.Entity != nil {
+		opts.Entity = *args.Entity
+	}
+	if args.Since != nil {
+		opts.Since = args.Since.Time
+	}
+	if args.Until != nil {
+		opts.Until = args.Until.Time
+	}
+
+	return newAuditLogConnectionResolver(ctx, r.db.AuditLogs(), opts), nil
+}
+
+func marshalAuditLogID(id int64) graphql.ID {
+	return relay.MarshalID(auditLogIDKind, id)
+}
+
+type auditLogConnectionResolver struct {
+	nodes      func() ([]*database.AuditLog, error)
+	totalCount func() (int32, error)
+	first      int
+	offset     int
+}
+
+func newAuditLogConnectionResolver(ctx context.Context, store database.AuditLogStore, opts database.AuditLogListOpts) *auditLogConnectionResolver {
+	first := opts.Limit
+	countOpts := opts
+	countOpts.LimitOffset = nil
+
+	return &auditLogConnectionResolver{
+		nodes: syncx.OnceValues(func() ([]*database.AuditLog, error) {
+			opts.Limit += 1
+			return store.List(ctx, opts)
+		}),
+		totalCount: syncx.OnceValues(func() (int32, error) {
+			count, err := store.Count(ctx, countOpts)
+			return int32(count), err
+		}),
+		first:  first,
+		offset: opts.Offset,
+	}
+}
+
+func (r *auditLogConnectionResolver) Nodes() ([]*auditLogResolver, error) {
+	logs, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(logs) > r.first {
+		logs = logs[:r.first]
+	}
+
+	resolvers := make([]*auditLogResolver, len(logs))
+	for i, l := range logs {
+		resolvers[i] = &auditLogResolver{log: l}
+	}
+	return resolvers, nil
+}
+
+func (r *auditLogConnectionResolver) TotalCount() (int32, error) {
+	return r.totalCount()
+}
+
+func (r *auditLogConnectionResolver) PageInfo() (*graphqlutil.PageInfo, error) {
+	logs, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(logs) > r.first {
+		return graphqlutil.NextPageCursor(strconv.Itoa(r.first + r.offset)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+type auditLogResolver struct {
+	log *database.AuditLog
+}
+
+func (r *auditLogResolver) ID() graphql.ID {
+	return marshalAuditLogID(r.log.ID)
+}
+
+func (r *auditLogResolver) AuditID() string {
+	return r.log.AuditID
+}
+
+func (r *auditLogResolver) Entity() string {
+	return r.log.Entity
+}
+
+func (r *auditLogResolver) Action() string {
+	return r.log.Action
+}
+
+func (r *auditLogResolver) ActorUID() string {
+	return r.log.ActorUID
+}
+
+func (r *auditLogResolver) IP() string {
+	return r.log.IP
+}
+
+func (r *auditLogResolver) ForwardedFor() string {
+	return r.log.ForwardedFor
+}
+
+func (r *auditLogResolver) Fields() JSONValue {
+	return JSONValue{Value: r.log.Fields}
+}
+
+func (r *auditLogResolver) Timestamp() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.log.Timestamp}
+}
@@ -0,0 +1,126 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestSchemaResolver_AuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"auditLogs"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					auditLogs {
+						nodes {
+							auditID
+						}
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		until := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+		store := database.NewMockAuditLogStore()
+		store.CountFunc.SetDefaultHook(func(_ context.Context, opts database.AuditLogListOpts) (int, error) {
+			assert.Nil(t, opts.LimitOffset)
+			assert.Equal(t, "1", opts.ActorUID)
+			return 3, nil
+		})
+		store.ListFunc.SetDefaultHook(func(_ context.Context, opts database.AuditLogListOpts) ([]*database.AuditLog, error) {
+			// The limit is +1 because the resolver adds an extra item for
+			// pagination purposes.
+			assert.Equal(t, 2, opts.Limit)
+			assert.Equal(t, 1, opts.Offset)
+			assert.Equal(t, "1", opts.ActorUID)
+			assert.Equal(t, "security events", opts.Entity)
+			assert.True(t, since.Equal(opts.Since))
+			assert.True(t, until.Equal(opts.Until))
+
+			return []*database.AuditLog{
+				{ID: 2, Entry: audit.Entry{AuditID: "b", Entity: "security events", Action: "SignInSucceeded", ActorUID: "1", IP: "127.0.0.1", ForwardedFor: "unknown", Fields: json.RawMessage(`{"event":{"URL":""}}`), Timestamp: until.Add(-time.Hour)}},
+				{ID: 1, Entry: audit.Entry{AuditID: "a", Entity: "security events", Action: "SignInAttempted", ActorUID: "1", Fields: json.RawMessage(`{}`), Timestamp: since}},
+			}, nil
+		})
+
+		db := database.NewMockDB()
+		db.AuditLogsFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					auditLogs(first: 1, after: "1", actorUID: "1", entity: "security events", since: "2023-01-01T00:00:00Z", until: "2023-01-02T00:00:00Z") {
+						nodes {
+							id
+							auditID
+							entity
+							action
+							actorUID
+							ip
+							forwardedFor
+							fields
+							timestamp
+						}
+						totalCount
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"auditLogs": {
+						"nodes": [
+							{
+								"id": "QXVkaXRMb2c6Mg==",
+								"auditID": "b",
+								"entity": "security events",
+								"action": "SignInSucceeded",
+								"actorUID": "1",
+								"ip": "127.0.0.1",
+								"forwardedFor": "unknown",
+								"fields": {"event": {"URL": ""}},
+								"timestamp": "2023-01-01T23:00:00Z"
+							}
+						],
+						"totalCount": 3,
+						"pageInfo": {
+							"hasNextPage": true,
+							"endCursor": "2"
+						}
+					}
+				}
+			`,
+		})
+
+		mockassert.CalledOnce(t, store.CountFunc)
+		mockassert.CalledOnce(t, store.ListFunc)
+	})
+}
@@ -0,0 +1,152 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type OwnershipArgs struct {
+	First int32
+}
+
+func (r *GitTreeEntryResolver) Ownership(ctx context.Context, args OwnershipArgs) (*ownershipConnectionResolver, error) {
+	repo := types.MinimalRepo{
+		ID:   r.Repository().IDInt32(),
+		Name: r.Repository().RepoName(),
+	}
+	owners, err := backend.NewOwnService(r.gitserverClient, r.db).Ownership(ctx, repo, api.CommitID(r.commit.OID()), r.Path())
+	if err != nil {
+		return nil, err
+	}
+	return &ownershipConnectionResolver{db: r.db, owners: owners, first: int(args.First)}, nil
+}
+
+type ownershipConnectionResolver struct {
+	db     database.DB
+	owners []*backend.Owner
+	first  int
+}
+
+func (r *ownershipConnectionResolver) Nodes() []*ownershipResolver {
+	owners := r.owners
+	if r.first >= 0 && len(owners) > r.first {
+		owners = owners[:r.first]
+	}
+	resolvers := make([]*ownershipResolver, 0, len(owners))
+	for _, o := range owners {
+		resolvers = append(resolvers, &ownershipResolver{db: r.db, owner: o})
+	}
+	return resolvers
+}
+
+func (r *ownershipConnectionResolver) TotalCount() int32 {
+	return int32(len(r.owners))
+}
+
+func (r *ownershipConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	return graphqlutil.HasNextPage(r.first >= 0 && len(r.owners) > r.first)
+}
+
+type ownershipResolver struct {
+	db    database.DB
+	owner *backend.Owner
+}
+
+func (r *ownershipResolver) Handle() *string { return nonEmptyStrptr(r.owner.Handle) }
+
+func (r *ownershipResolver) Email() *string { return nonEmptyStrptr(r.owner.Email) }
+
+func (r *ownershipResolver) Name() *string { return nonEmptyStrptr(r.owner.Name) }
+
+func (r *ownershipResolver) User() *UserResolver {
+	if r.owner.User == nil {
+		return nil
+	}
+	return NewUserResolver(r.db, r.owner.User)
+}
+
+func (r *ownershipResolver) Confidence() float64 { return r.owner.Confidence() }
+
+func (r *ownershipResolver) Reasons() []*ownershipReasonResolver {
+	resolvers := make([]*ownershipReasonResolver, 0, len(r.owner.Reasons))
+	for _, reason := range r.owner.Reasons {
+		resolvers = append(resolvers, &ownershipReasonResolver{reason: reason})
+	}
+	return resolvers
+}
+
+func nonEmptyStrptr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+type ownershipReasonResolver struct {
+	reason backend.OwnershipReason
+}
+
+func (r *ownershipReasonResolver) Kind() string { return string(r.reason.Kind) }
+
+func (r *ownershipReasonResolver) Confidence() float64 { return r.reason.Confidence }
+
+func (r *ownershipReasonResolver) Description() string { return r.reason.Description }
+
+type AssignedOwnerArgs struct {
+	Repository graphql.ID
+	Path       string
+	User       graphql.ID
+}
+
+func (args AssignedOwnerArgs) unmarshal() (repoID api.RepoID, userID int32, err error) {
+	if repoID, err = UnmarshalRepositoryID(args.Repository); err != nil {
+		return 0, 0, err
+	}
+	if userID, err = UnmarshalUserID(args.User); err != nil {
+		return 0, 0, err
+	}
+	return repoID, userID, nil
+}
+
+func (r *schemaResolver) AssignOwner(ctx context.Context, args AssignedOwnerArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may assign owners.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, userID, err := args.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.AssignedOwners().Insert(ctx, userID, repoID, args.Path, actor.FromContext(ctx).UID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) RemoveAssignedOwner(ctx context.Context, args AssignedOwnerArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may remove assigned owners.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, userID, err := args.unmarshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.AssignedOwners().Delete(ctx, userID, repoID, args.Path); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
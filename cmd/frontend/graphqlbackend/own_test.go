@@ -0,0 +1,134 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestSchemaResolver_AssignOwner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"assignOwner"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					assignOwner(repository: "UmVwb3NpdG9yeTox", path: "cmd/frontend", user: "VXNlcjoy") {
+						alwaysNil
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, admin, _ := fakeUser(t, context.Background(), db, true)
+
+		assignedOwners := database.NewMockAssignedOwnersStore()
+		assignedOwners.InsertFunc.SetDefaultHook(func(_ context.Context, ownerUserID int32, repoID api.RepoID, filePath string, whoAssignedUserID int32) error {
+			assert.Equal(t, int32(2), ownerUserID)
+			assert.Equal(t, api.RepoID(1), repoID)
+			assert.Equal(t, "cmd/frontend", filePath)
+			assert.Equal(t, admin.ID, whoAssignedUserID)
+			return nil
+		})
+		db.AssignedOwnersFunc.SetDefaultReturn(assignedOwners)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					assignOwner(repository: "UmVwb3NpdG9yeTox", path: "cmd/frontend", user: "VXNlcjoy") {
+						alwaysNil
+					}
+				}
+			`,
+			ExpectedResult: `{"assignOwner": {"alwaysNil": null}}`,
+		})
+
+		mockassert.CalledOnce(t, assignedOwners.InsertFunc)
+	})
+}
+
+func TestSchemaResolver_RemoveAssignedOwner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"removeAssignedOwner"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					removeAssignedOwner(repository: "UmVwb3NpdG9yeTox", path: "cmd/frontend", user: "VXNlcjoy") {
+						alwaysNil
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		assignedOwners := database.NewMockAssignedOwnersStore()
+		db.AssignedOwnersFunc.SetDefaultReturn(assignedOwners)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					removeAssignedOwner(repository: "UmVwb3NpdG9yeTox", path: "cmd/frontend", user: "VXNlcjoy") {
+						alwaysNil
+					}
+				}
+			`,
+			ExpectedResult: `{"removeAssignedOwner": {"alwaysNil": null}}`,
+		})
+
+		mockassert.CalledOnceWith(t, assignedOwners.DeleteFunc, mockassert.Values(mockassert.Skip, int32(2), api.RepoID(1), "cmd/frontend"))
+	})
+}
+
+func TestOwnershipConnectionResolver(t *testing.T) {
+	owners := []*backend.Owner{
+		{Handle: "alice", Reasons: []backend.OwnershipReason{{Kind: backend.OwnershipReasonCodeownersFileEntry, Confidence: 0.9}}},
+		{Email: "bob@example.com", Name: "Bob", Reasons: []backend.OwnershipReason{{Kind: backend.OwnershipReasonRecentContributor, Confidence: 0.6}}},
+	}
+
+	r := &ownershipConnectionResolver{db: database.NewMockDB(), owners: owners, first: 1}
+	nodes := r.Nodes()
+	if len(nodes) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(nodes))
+	}
+	assert.Equal(t, "alice", *nodes[0].Handle())
+	assert.Nil(t, nodes[0].Email())
+	assert.Nil(t, nodes[0].User())
+	assert.Equal(t, int32(2), r.TotalCount())
+	assert.True(t, r.PageInfo().HasNextPage())
+}
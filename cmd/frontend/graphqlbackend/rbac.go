@@ -0,0 +1,240 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/syncx"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	roleIDKind       = "Role"
+	permissionIDKind = "Permission"
+)
+
+type ListRolesArgs struct {
+	First int32   `json:"first"`
+	After *string `json:"after"`
+}
+
+func (r *schemaResolver) Roles(ctx context.Context, args ListRolesArgs) (*roleConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may list roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts := database.RolesListOptions{
+		LimitOffset: &database.LimitOffset{
+			Limit: int(args.First),
+		},
+	}
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, errors.Newf("cannot parse offset %q", *args.After)
+		}
+		opts.Offset = offset
+	}
+
+	return newRoleConnectionResolver(ctx, r.db, opts), nil
+}
+
+type UserRoleArgs struct {
+	Role graphql.ID
+	User graphql.ID
+	Org  *graphql.ID
+}
+
+func (args UserRoleArgs) opts() (opts database.UserRoleOpts, err error) {
+	if opts.RoleID, err = unmarshalRoleID(args.Role); err != nil {
+		return opts, err
+	}
+	if opts.UserID, err = UnmarshalUserID(args.User); err != nil {
+		return opts, err
+	}
+	if args.Org != nil {
+		if opts.OrgID, err = UnmarshalOrgID(*args.Org); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+func (r *schemaResolver) AssignRoleToUser(ctx context.Context, args UserRoleArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may assign roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts, err := args.opts()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.db.UserRoles().Create(ctx, database.CreateUserRoleOpts(opts)); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) RemoveRoleFromUser(ctx context.Context, args UserRoleArgs) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may remove roles.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts, err := args.opts()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.UserRoles().Delete(ctx, database.DeleteUserRoleOpts(opts)); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func marshalRoleID(id int32) graphql.ID {
+	return relay.MarshalID(roleIDKind, id)
+}
+
+func unmarshalRoleID(id graphql.ID) (roleID int32, err error) {
+	if kind := relay.UnmarshalKind(id); kind != roleIDKind {
+		return 0, errors.Newf("invalid role id of kind %q", kind)
+	}
+	err = relay.UnmarshalSpec(id, &roleID)
+	return
+}
+
+func marshalPermissionID(id int32) graphql.ID {
+	return relay.MarshalID(permissionIDKind, id)
+}
+
+type roleConnectionResolver struct {
+	db         database.DB
+	nodes      func() ([]*types.Role, error)
+	totalCount func() (int32, error)
+	first      int
+	offset     int
+}
+
+func newRoleConnectionResolver(ctx context.Context, db database.DB, opts database.RolesListOptions) *roleConnectionResolver {
+	first := opts.Limit
+
+	return &roleConnectionResolver{
+		db: db,
+		nodes: syncx.OnceValues(func() ([]*types.Role, error) {
+			opts.Limit += 1
+			return db.Roles().List(ctx, opts)
+		}),
+		totalCount: syncx.OnceValues(func() (int32, error) {
+			count, err := db.Roles().Count(ctx, database.RolesListOptions{})
+			return int32(count), err
+		}),
+		first:  first,
+		offset: opts.Offset,
+	}
+}
+
+func (r *roleConnectionResolver) Nodes(ctx context.Context) ([]*roleResolver, error) {
+	roles, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) > r.first {
+		roles = roles[:r.first]
+	}
+
+	resolvers := make([]*roleResolver, len(roles))
+	for i, role := range roles {
+		resolvers[i] = &roleResolver{db: r.db, role: role}
+	}
+	return resolvers, nil
+}
+
+func (r *roleConnectionResolver) TotalCount() (int32, error) {
+	return r.totalCount()
+}
+
+func (r *roleConnectionResolver) PageInfo() (*graphqlutil.PageInfo, error) {
+	roles, err := r.nodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) > r.first {
+		return graphqlutil.NextPageCursor(strconv.Itoa(r.first + r.offset)), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+type roleResolver struct {
+	db   database.DB
+	role *types.Role
+}
+
+func (r *roleResolver) ID() graphql.ID {
+	return marshalRoleID(r.role.ID)
+}
+
+func (r *roleResolver) Name() string {
+	return r.role.Name
+}
+
+func (r *roleResolver) ReadOnly() bool {
+	return r.role.ReadOnly
+}
+
+func (r *roleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.role.CreatedAt}
+}
+
+func (r *roleResolver) Permissions(ctx context.Context) ([]*permissionResolver, error) {
+	rps, err := r.db.RolePermissions().GetByRoleID(ctx, database.GetRolePermissionOpts{RoleID: r.role.ID})
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[int32]struct{}, len(rps))
+	for _, rp := range rps {
+		granted[rp.PermissionID] = struct{}{}
+	}
+
+	permissions, err := r.db.Permissions().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*permissionResolver, 0, len(rps))
+	for _, p := range permissions {
+		if _, ok := granted[p.ID]; ok {
+			resolvers = append(resolvers, &permissionResolver{permission: p})
+		}
+	}
+	return resolvers, nil
+}
+
+type permissionResolver struct {
+	permission *types.Permission
+}
+
+func (r *permissionResolver) ID() graphql.ID {
+	return marshalPermissionID(r.permission.ID)
+}
+
+func (r *permissionResolver) Namespace() string {
+	return r.permission.Namespace
+}
+
+func (r *permissionResolver) Action() string {
+	return r.permission.Action
+}
@@ -0,0 +1,204 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSchemaResolver_Roles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"roles"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					roles {
+						nodes {
+							name
+						}
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		roles := database.NewMockRoleStore()
+		roles.ListFunc.SetDefaultHook(func(_ context.Context, opts database.RolesListOptions) ([]*types.Role, error) {
+			// The limit is +1 because the resolver adds an extra item for
+			// pagination purposes.
+			assert.Equal(t, 2, opts.Limit)
+			assert.Equal(t, 0, opts.Offset)
+			return []*types.Role{
+				{ID: 1, Name: "DEFAULT", ReadOnly: true, CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 2, Name: "BATCH CHANGERS", CreatedAt: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+			}, nil
+		})
+		roles.CountFunc.SetDefaultReturn(3, nil)
+
+		rolePermissions := database.NewMockRolePermissionStore()
+		rolePermissions.GetByRoleIDFunc.SetDefaultReturn([]*types.RolePermission{{RoleID: 1, PermissionID: 2}}, nil)
+
+		permissions := database.NewMockPermissionStore()
+		permissions.ListFunc.SetDefaultReturn([]*types.Permission{
+			{ID: 1, Namespace: "BATCHCHANGES", Action: "READ"},
+			{ID: 2, Namespace: "BATCHCHANGES", Action: "WRITE"},
+		}, nil)
+
+		db := database.NewMockDB()
+		db.RolesFunc.SetDefaultReturn(roles)
+		db.RolePermissionsFunc.SetDefaultReturn(rolePermissions)
+		db.PermissionsFunc.SetDefaultReturn(permissions)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					roles(first: 1) {
+						nodes {
+							id
+							name
+							readOnly
+							createdAt
+							permissions {
+								id
+								namespace
+								action
+							}
+						}
+						totalCount
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"roles": {
+						"nodes": [
+							{
+								"id": "Um9sZTox",
+								"name": "DEFAULT",
+								"readOnly": true,
+								"createdAt": "2023-01-01T00:00:00Z",
+								"permissions": [
+									{
+										"id": "UGVybWlzc2lvbjoy",
+										"namespace": "BATCHCHANGES",
+										"action": "WRITE"
+									}
+								]
+							}
+						],
+						"totalCount": 3,
+						"pageInfo": {
+							"hasNextPage": true,
+							"endCursor": "1"
+						}
+					}
+				}
+			`,
+		})
+	})
+}
+
+func TestSchemaResolver_AssignRoleToUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"assignRoleToUser"}, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					assignRoleToUser(role: "Um9sZTox", user: "VXNlcjoy") {
+						alwaysNil
+					}
+				}
+			`,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		userRoles := database.NewMockUserRoleStore()
+		userRoles.CreateFunc.SetDefaultHook(func(_ context.Context, opts database.CreateUserRoleOpts) (*types.UserRole, error) {
+			assert.Equal(t, database.CreateUserRoleOpts{UserID: 2, RoleID: 1, OrgID: 3}, opts)
+			return &types.UserRole{UserID: opts.UserID, RoleID: opts.RoleID, OrgID: opts.OrgID}, nil
+		})
+
+		db := database.NewMockDB()
+		db.UserRolesFunc.SetDefaultReturn(userRoles)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					assignRoleToUser(role: "Um9sZTox", user: "VXNlcjoy", org: "T3JnOjM=") {
+						alwaysNil
+					}
+				}
+			`,
+			ExpectedResult: `{"assignRoleToUser": {"alwaysNil": null}}`,
+		})
+
+		mockassert.CalledOnce(t, userRoles.CreateFunc)
+	})
+}
+
+func TestSchemaResolver_RemoveRoleFromUser(t *testing.T) {
+	t.Parallel()
+
+	userRoles := database.NewMockUserRoleStore()
+	userRoles.DeleteFunc.SetDefaultHook(func(_ context.Context, opts database.DeleteUserRoleOpts) error {
+		assert.Equal(t, database.DeleteUserRoleOpts{UserID: 2, RoleID: 1}, opts)
+		return nil
+	})
+
+	db := database.NewMockDB()
+	db.UserRolesFunc.SetDefaultReturn(userRoles)
+	ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+	RunTest(t, &Test{
+		Context: ctx,
+		Schema:  mustParseGraphQLSchema(t, db),
+		Query: `
+			mutation {
+				removeRoleFromUser(role: "Um9sZTox", user: "VXNlcjoy") {
+					alwaysNil
+				}
+			}
+		`,
+		ExpectedResult: `{"removeRoleFromUser": {"alwaysNil": null}}`,
+	})
+
+	mockassert.CalledOnce(t, userRoles.DeleteFunc)
+}
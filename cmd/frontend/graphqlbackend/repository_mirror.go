@@ -198,6 +198,53 @@ func (r *corruptionLogResolver) Reason() (string, error) {
 	return r.log.Reason, nil
 }
 
+func (r *repositoryMirrorInfoResolver) MaintenanceLogs(ctx context.Context) ([]*maintenanceLogResolver, error) {
+	// 🚨 SECURITY: The output of failed maintenance tasks reveals internal
+	// details of the instance that only the admin should be able to see.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	info, err := r.computeGitserverRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*maintenanceLogResolver, 0, len(info.MaintenanceLogs))
+	for _, l := range info.MaintenanceLogs {
+		logs = append(logs, &maintenanceLogResolver{log: l})
+	}
+
+	return logs, nil
+}
+
+type maintenanceLogResolver struct {
+	log types.RepoMaintenanceLog
+}
+
+func (r *maintenanceLogResolver) Task() string {
+	return r.log.Task
+}
+
+func (r *maintenanceLogResolver) StartedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.log.StartedAt}
+}
+
+func (r *maintenanceLogResolver) FinishedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.log.FinishedAt}
+}
+
+func (r *maintenanceLogResolver) Error() *string {
+	if r.log.Error == "" {
+		return nil
+	}
+	return &r.log.Error
+}
+
+func (r *maintenanceLogResolver) NextRunAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.log.NextRunAt}
+}
+
 func (r *repositoryMirrorInfoResolver) ByteSize(ctx context.Context) (BigInt, error) {
 	info, err := r.computeGitserverRepo(ctx)
 	if err != nil {
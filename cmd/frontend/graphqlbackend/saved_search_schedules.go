@@ -0,0 +1,274 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var errSavedSearchNotUserOwned = errors.New("only saved searches owned by a user can be scheduled")
+
+func (r savedSearchResolver) Schedule(ctx context.Context) (*savedSearchScheduleResolver, error) {
+	schedule, err := r.db.SavedSearchRuns().GetSchedule(ctx, r.s.ID)
+	if err != nil || schedule == nil {
+		return nil, err
+	}
+	return &savedSearchScheduleResolver{schedule: schedule}, nil
+}
+
+func (r savedSearchResolver) Runs(ctx context.Context, args *struct{ First int32 }) ([]*savedSearchRunResolver, error) {
+	runs, err := r.db.SavedSearchRuns().ListRuns(ctx, r.s.ID, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedSearchRunResolver, 0, len(runs))
+	for _, run := range runs {
+		resolvers = append(resolvers, &savedSearchRunResolver{run: run})
+	}
+	return resolvers, nil
+}
+
+func (r savedSearchResolver) Subscriptions(ctx context.Context) ([]*savedSearchSubscriptionResolver, error) {
+	subs, err := r.db.SavedSearchRuns().ListSubscriptions(ctx, r.s.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*savedSearchSubscriptionResolver, 0, len(subs))
+	for _, sub := range subs {
+		resolvers = append(resolvers, &savedSearchSubscriptionResolver{db: r.db, sub: sub})
+	}
+	return resolvers, nil
+}
+
+type savedSearchScheduleResolver struct {
+	schedule *database.SavedSearchSchedule
+}
+
+func (r *savedSearchScheduleResolver) IntervalMinutes() int32 { return r.schedule.IntervalMinutes }
+
+func (r *savedSearchScheduleResolver) NextRunAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.schedule.NextRunAt}
+}
+
+type savedSearchRunResolver struct {
+	run *database.SavedSearchRun
+}
+
+func (r *savedSearchRunResolver) ID() graphql.ID {
+	return relay.MarshalID("SavedSearchRun", int32(r.run.ID))
+}
+
+func (r *savedSearchRunResolver) State() string { return r.run.State }
+
+func (r *savedSearchRunResolver) FailureMessage() *string { return r.run.FailureMessage }
+
+func (r *savedSearchRunResolver) QueuedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.run.QueuedAt}
+}
+
+func (r *savedSearchRunResolver) StartedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.run.StartedAt)
+}
+
+func (r *savedSearchRunResolver) FinishedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.run.FinishedAt)
+}
+
+func (r *savedSearchRunResolver) ResultCount() int32 { return int32(len(r.run.Results)) }
+
+func (r *savedSearchRunResolver) AddedResults() []*savedSearchResultResolver {
+	return toSavedSearchResultResolvers(r.run.AddedResults)
+}
+
+func (r *savedSearchRunResolver) RemovedResults() []*savedSearchResultResolver {
+	return toSavedSearchResultResolvers(r.run.RemovedResults)
+}
+
+func toSavedSearchResultResolvers(results []database.SavedSearchResult) []*savedSearchResultResolver {
+	resolvers := make([]*savedSearchResultResolver, 0, len(results))
+	for i := range results {
+		resolvers = append(resolvers, &savedSearchResultResolver{result: &results[i]})
+	}
+	return resolvers
+}
+
+type savedSearchResultResolver struct {
+	result *database.SavedSearchResult
+}
+
+func (r *savedSearchResultResolver) Type() string { return r.result.Type }
+
+func (r *savedSearchResultResolver) Repository() string { return r.result.Repo }
+
+func (r *savedSearchResultResolver) Path() *string { return nonEmptyStrptr(r.result.Path) }
+
+func (r *savedSearchResultResolver) Commit() *string { return nonEmptyStrptr(r.result.Commit) }
+
+func (r *savedSearchResultResolver) Preview() *string { return nonEmptyStrptr(r.result.Preview) }
+
+func (r *savedSearchResultResolver) URL() string { return r.result.URL }
+
+type savedSearchSubscriptionResolver struct {
+	db  database.DB
+	sub *database.SavedSearchSubscription
+}
+
+func marshalSavedSearchSubscriptionID(id int32) graphql.ID {
+	return relay.MarshalID("SavedSearchSubscription", id)
+}
+
+func unmarshalSavedSearchSubscriptionID(id graphql.ID) (subscriptionID int32, err error) {
+	err = relay.UnmarshalSpec(id, &subscriptionID)
+	return
+}
+
+func (r *savedSearchSubscriptionResolver) ID() graphql.ID {
+	return marshalSavedSearchSubscriptionID(r.sub.ID)
+}
+
+func (r *savedSearchSubscriptionResolver) User(ctx context.Context) (*UserResolver, error) {
+	if r.sub.UserID == nil {
+		return nil, nil
+	}
+	return UserByIDInt32(ctx, r.db, *r.sub.UserID)
+}
+
+func (r *savedSearchSubscriptionResolver) SlackWebhookURL() *string { return r.sub.SlackWebhookURL }
+
+func (r *savedSearchSubscriptionResolver) WebhookURL() *string { return r.sub.WebhookURL }
+
+func (r *savedSearchSubscriptionResolver) IncludeResults() bool { return r.sub.IncludeResults }
+
+func (r *savedSearchSubscriptionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.sub.CreatedAt}
+}
+
+// scheduledSavedSearchByID returns the saved search if the current user may
+// manage its schedule and subscriptions.
+func (r *schemaResolver) scheduledSavedSearchByID(ctx context.Context, id int32) (*api.SavedQuerySpecAndConfig, error) {
+	ss, err := r.db.SavedSearches().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scheduled saved searches run as their owner, so only saved searches
+	// owned by a user can be scheduled.
+	if ss.Config.UserID == nil {
+		return nil, errSavedSearchNotUserOwned
+	}
+
+	// 🚨 SECURITY: Make sure the current user has permission to manage the
+	// saved search of the specified user.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, *ss.Config.UserID); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+func (r *schemaResolver) SetSavedSearchSchedule(ctx context.Context, args *struct {
+	SavedSearch     graphql.ID
+	IntervalMinutes *int32
+}) (*savedSearchResolver, error) {
+	id, err := unmarshalSavedSearchID(args.SavedSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := r.scheduledSavedSearchByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.IntervalMinutes == nil {
+		err = r.db.SavedSearchRuns().DeleteSchedule(ctx, id)
+	} else if *args.IntervalMinutes <= 0 {
+		err = errors.New("intervalMinutes must be positive")
+	} else {
+		_, err = r.db.SavedSearchRuns().SetSchedule(ctx, id, *args.IntervalMinutes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toSavedSearchResolver(savedSearchFromConfig(id, ss)), nil
+}
+
+func (r *schemaResolver) SubscribeToSavedSearch(ctx context.Context, args *struct {
+	SavedSearch     graphql.ID
+	Email           *bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+	IncludeResults  bool
+}) (*savedSearchSubscriptionResolver, error) {
+	id, err := unmarshalSavedSearchID(args.SavedSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.scheduledSavedSearchByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	sub := &database.SavedSearchSubscription{
+		SavedSearchID:   id,
+		SlackWebhookURL: args.SlackWebhookURL,
+		WebhookURL:      args.WebhookURL,
+		IncludeResults:  args.IncludeResults,
+	}
+	targets := 0
+	if args.Email != nil && *args.Email {
+		uid := actor.FromContext(ctx).UID
+		sub.UserID = &uid
+		targets++
+	}
+	if args.SlackWebhookURL != nil {
+		targets++
+	}
+	if args.WebhookURL != nil {
+		targets++
+	}
+	if targets != 1 {
+		return nil, errors.New("exactly one of email, slackWebhookURL and webhookURL must be set")
+	}
+
+	sub, err = r.db.SavedSearchRuns().CreateSubscription(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	return &savedSearchSubscriptionResolver{db: r.db, sub: sub}, nil
+}
+
+func (r *schemaResolver) UnsubscribeFromSavedSearch(ctx context.Context, args *struct {
+	ID graphql.ID
+}) (*EmptyResponse, error) {
+	id, err := unmarshalSavedSearchSubscriptionID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := r.db.SavedSearchRuns().GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Users may always remove their own email subscriptions,
+	// other subscriptions require permission to manage the saved search.
+	if sub.UserID == nil || *sub.UserID != actor.FromContext(ctx).UID {
+		if _, err := r.scheduledSavedSearchByID(ctx, sub.SavedSearchID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.db.SavedSearchRuns().DeleteSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
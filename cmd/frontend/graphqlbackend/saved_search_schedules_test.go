@@ -0,0 +1,155 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newScheduledSavedSearchMockDB(owner *api.SavedQuerySpecAndConfig, currentUser *types.User) (*database.MockDB, *database.MockSavedSearchRunStore) {
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultReturn(currentUser, nil)
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(currentUser, nil)
+
+	ss := database.NewMockSavedSearchStore()
+	ss.GetByIDFunc.SetDefaultReturn(owner, nil)
+
+	runs := database.NewMockSavedSearchRunStore()
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.SavedSearchesFunc.SetDefaultReturn(ss)
+	db.SavedSearchRunsFunc.SetDefaultReturn(runs)
+	return db, runs
+}
+
+func TestSetSavedSearchSchedule(t *testing.T) {
+	userID := int32(1)
+	userOwned := &api.SavedQuerySpecAndConfig{Config: api.ConfigSavedQuery{Key: "1", UserID: &userID}}
+	ctx := actor.WithActor(context.Background(), actor.FromUser(userID))
+
+	t.Run("schedules the saved search", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: userID})
+
+		interval := int32(60)
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).SetSavedSearchSchedule(ctx, &struct {
+			SavedSearch     graphql.ID
+			IntervalMinutes *int32
+		}{SavedSearch: marshalSavedSearchID(1), IntervalMinutes: &interval})
+		require.NoError(t, err)
+		mockrequire.CalledOnceWith(t, runs.SetScheduleFunc, mockrequire.Values(mockrequire.Skip, int32(1), int32(60)))
+	})
+
+	t.Run("removes the schedule", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: userID})
+
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).SetSavedSearchSchedule(ctx, &struct {
+			SavedSearch     graphql.ID
+			IntervalMinutes *int32
+		}{SavedSearch: marshalSavedSearchID(1)})
+		require.NoError(t, err)
+		mockrequire.CalledOnceWith(t, runs.DeleteScheduleFunc, mockrequire.Values(mockrequire.Skip, int32(1)))
+	})
+
+	t.Run("rejects org saved searches", func(t *testing.T) {
+		orgID := int32(3)
+		orgOwned := &api.SavedQuerySpecAndConfig{Config: api.ConfigSavedQuery{Key: "1", OrgID: &orgID}}
+		db, runs := newScheduledSavedSearchMockDB(orgOwned, &types.User{ID: userID})
+
+		interval := int32(60)
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).SetSavedSearchSchedule(ctx, &struct {
+			SavedSearch     graphql.ID
+			IntervalMinutes *int32
+		}{SavedSearch: marshalSavedSearchID(1), IntervalMinutes: &interval})
+		require.ErrorIs(t, err, errSavedSearchNotUserOwned)
+		mockrequire.NotCalled(t, runs.SetScheduleFunc)
+	})
+
+	t.Run("rejects other users", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: 2})
+
+		interval := int32(60)
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).SetSavedSearchSchedule(actor.WithActor(context.Background(), actor.FromUser(2)), &struct {
+			SavedSearch     graphql.ID
+			IntervalMinutes *int32
+		}{SavedSearch: marshalSavedSearchID(1), IntervalMinutes: &interval})
+		require.Error(t, err)
+		mockrequire.NotCalled(t, runs.SetScheduleFunc)
+	})
+}
+
+func TestSubscribeToSavedSearch(t *testing.T) {
+	userID := int32(1)
+	userOwned := &api.SavedQuerySpecAndConfig{Config: api.ConfigSavedQuery{Key: "1", UserID: &userID}}
+	ctx := actor.WithActor(context.Background(), actor.FromUser(userID))
+
+	type subscribeArgs = struct {
+		SavedSearch     graphql.ID
+		Email           *bool
+		SlackWebhookURL *string
+		WebhookURL      *string
+		IncludeResults  bool
+	}
+
+	t.Run("email subscribes the current user", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: userID})
+		runs.CreateSubscriptionFunc.SetDefaultHook(func(_ context.Context, sub *database.SavedSearchSubscription) (*database.SavedSearchSubscription, error) {
+			require.Equal(t, int32(1), sub.SavedSearchID)
+			require.Equal(t, &userID, sub.UserID)
+			require.Nil(t, sub.WebhookURL)
+			return sub, nil
+		})
+
+		email := true
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).SubscribeToSavedSearch(ctx, &subscribeArgs{SavedSearch: marshalSavedSearchID(1), Email: &email})
+		require.NoError(t, err)
+		mockrequire.CalledOnce(t, runs.CreateSubscriptionFunc)
+	})
+
+	t.Run("requires exactly one target", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: userID})
+
+		email := true
+		url := "https://example.com/hook"
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).SubscribeToSavedSearch(ctx, &subscribeArgs{SavedSearch: marshalSavedSearchID(1), Email: &email, WebhookURL: &url})
+		require.Error(t, err)
+
+		_, err = newSchemaResolver(db, gitserver.NewClient(db)).SubscribeToSavedSearch(ctx, &subscribeArgs{SavedSearch: marshalSavedSearchID(1)})
+		require.Error(t, err)
+		mockrequire.NotCalled(t, runs.CreateSubscriptionFunc)
+	})
+}
+
+func TestUnsubscribeFromSavedSearch(t *testing.T) {
+	userID := int32(1)
+	otherUserID := int32(2)
+	userOwned := &api.SavedQuerySpecAndConfig{Config: api.ConfigSavedQuery{Key: "1", UserID: &userID}}
+
+	t.Run("subscribers can remove their own email subscription", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: otherUserID})
+		runs.GetSubscriptionFunc.SetDefaultReturn(&database.SavedSearchSubscription{ID: 5, SavedSearchID: 1, UserID: &otherUserID}, nil)
+
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).UnsubscribeFromSavedSearch(actor.WithActor(context.Background(), actor.FromUser(otherUserID)), &struct{ ID graphql.ID }{ID: marshalSavedSearchSubscriptionID(5)})
+		require.NoError(t, err)
+		mockrequire.CalledOnceWith(t, runs.DeleteSubscriptionFunc, mockrequire.Values(mockrequire.Skip, int32(5)))
+	})
+
+	t.Run("other users cannot remove webhook subscriptions", func(t *testing.T) {
+		db, runs := newScheduledSavedSearchMockDB(userOwned, &types.User{ID: otherUserID})
+		url := "https://example.com/hook"
+		runs.GetSubscriptionFunc.SetDefaultReturn(&database.SavedSearchSubscription{ID: 5, SavedSearchID: 1, WebhookURL: &url}, nil)
+
+		_, err := newSchemaResolver(db, gitserver.NewClient(db)).UnsubscribeFromSavedSearch(actor.WithActor(context.Background(), actor.FromUser(otherUserID)), &struct{ ID graphql.ID }{ID: marshalSavedSearchSubscriptionID(5)})
+		require.Error(t, err)
+		mockrequire.NotCalled(t, runs.DeleteSubscriptionFunc)
+	})
+}
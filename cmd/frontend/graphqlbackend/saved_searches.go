@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -56,20 +57,20 @@ func (r *schemaResolver) savedSearchByID(ctx context.Context, id graphql.ID) (*s
 		return nil, errors.New("failed to get saved search: no Org ID or User ID associated with saved search")
 	}
 
-	savedSearch := &savedSearchResolver{
-		db: r.db,
-		s: types.SavedSearch{
-			ID:              intID,
-			Description:     ss.Config.Description,
-			Query:           ss.Config.Query,
-			Notify:          ss.Config.Notify,
-			NotifySlack:     ss.Config.NotifySlack,
-			UserID:          ss.Config.UserID,
-			OrgID:           ss.Config.OrgID,
-			SlackWebhookURL: ss.Config.SlackWebhookURL,
-		},
+	return r.toSavedSearchResolver(savedSearchFromConfig(intID, ss)), nil
+}
+
+func savedSearchFromConfig(id int32, ss *api.SavedQuerySpecAndConfig) types.SavedSearch {
+	return types.SavedSearch{
+		ID:              id,
+		Description:     ss.Config.Description,
+		Query:           ss.Config.Query,
+		Notify:          ss.Config.Notify,
+		NotifySlack:     ss.Config.NotifySlack,
+		UserID:          ss.Config.UserID,
+		OrgID:           ss.Config.OrgID,
+		SlackWebhookURL: ss.Config.SlackWebhookURL,
 	}
-	return savedSearch, nil
 }
 
 func (r savedSearchResolver) ID() graphql.ID {
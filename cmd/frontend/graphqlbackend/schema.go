@@ -68,3 +68,18 @@ var insightsAggregationsSchema string
 //
 //go:embed outbound_webhooks.graphql
 var outboundWebhooksSchema string
+
+// auditLogsSchema is the audit log raw GraphQL schema.
+//
+//go:embed audit_logs.graphql
+var auditLogsSchema string
+
+// rbacSchema is the role-based access control raw GraphQL schema.
+//
+//go:embed rbac.graphql
+var rbacSchema string
+
+// ownSchema is the code ownership raw GraphQL schema.
+//
+//go:embed own.graphql
+var ownSchema string
@@ -50,6 +50,8 @@ type SearchContextResolver interface {
 	ViewerHasAsDefault(ctx context.Context) bool
 	ViewerHasStarred(ctx context.Context) bool
 	Repositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
+	QueryRepositories(ctx context.Context) ([]SearchContextRepositoryRevisionsResolver, error)
+	QueryRepositoriesUpdatedAt() *gqlutil.DateTime
 	Query() string
 }
 
@@ -72,6 +72,9 @@ func NewHandler(db database.DB, logger log.Logger, githubAppSetupHandler http.Ha
 	// Usage statistics ZIP download
 	r.Get(router.UsageStatsDownload).Handler(trace.Route(usageStatsArchiveHandler(db)))
 
+	// Audit log CSV/JSONL export
+	r.Get(router.AuditLogExport).Handler(trace.Route(auditLogExportHandler(db, logger)))
+
 	// One-click export ZIP download
 	r.Get(router.OneClickExportArchive).Handler(trace.Route(oneClickExportHandler(db, logger)))
 
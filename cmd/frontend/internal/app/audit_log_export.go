@@ -0,0 +1,156 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// auditLogExportPageSize is the number of audit log records that are read from
+// the database at once while exporting.
+const auditLogExportPageSize = 1000
+
+var auditLogCSVHeader = []string{"id", "audit_id", "timestamp", "entity", "action", "actor_uid", "ip", "forwarded_for", "fields"}
+
+// auditLogJSONL is the representation of an audit log record in JSONL exports.
+type auditLogJSONL struct {
+	ID           int64           `json:"id"`
+	AuditID      string          `json:"audit_id"`
+	Timestamp    time.Time       `json:"timestamp"`
+	Entity       string          `json:"entity"`
+	Action       string          `json:"action"`
+	ActorUID     string          `json:"actor_uid"`
+	IP           string          `json:"ip"`
+	ForwardedFor string          `json:"forwarded_for"`
+	Fields       json.RawMessage `json:"fields"`
+}
+
+// auditLogExportHandler streams all audit log records matching the actor,
+// entity, since and until query parameters as CSV or JSONL, depending on the
+// format query parameter.
+func auditLogExportHandler(db database.DB, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// 🚨SECURITY: Only site admins may export the audit log.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, db); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		opts, err := auditLogExportOpts(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var write func(*database.AuditLog) error
+		var flush func() error
+		switch format := r.URL.Query().Get("format"); format {
+		case "", "csv":
+			cw := csv.NewWriter(w)
+			write = func(l *database.AuditLog) error {
+				return cw.Write([]string{
+					strconv.FormatInt(l.ID, 10),
+					l.AuditID,
+					l.Timestamp.UTC().Format(time.RFC3339Nano),
+					l.Entity,
+					l.Action,
+					l.ActorUID,
+					l.IP,
+					l.ForwardedFor,
+					string(l.Fields),
+				})
+			}
+			flush = func() error {
+				cw.Flush()
+				return cw.Error()
+			}
+
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", "attachment; filename=\"SourcegraphAuditLog.csv\"")
+			if err := cw.Write(auditLogCSVHeader); err != nil {
+				return
+			}
+		case "jsonl":
+			enc := json.NewEncoder(w)
+			write = func(l *database.AuditLog) error {
+				return enc.Encode(auditLogJSONL{
+					ID:           l.ID,
+					AuditID:      l.AuditID,
+					Timestamp:    l.Timestamp.UTC(),
+					Entity:       l.Entity,
+					Action:       l.Action,
+					ActorUID:     l.ActorUID,
+					IP:           l.IP,
+					ForwardedFor: l.ForwardedFor,
+					Fields:       l.Fields,
+				})
+			}
+			flush = func() error { return nil }
+
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("Content-Disposition", "attachment; filename=\"SourcegraphAuditLog.jsonl\"")
+		default:
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		// Records are read in pages of decreasing IDs, so that records
+		// created during the export don't shift the pages.
+		store := db.AuditLogs()
+		opts.LimitOffset = &database.LimitOffset{Limit: auditLogExportPageSize}
+		for {
+			logs, err := store.List(ctx, opts)
+			if err != nil {
+				// The response has already started, so all we can do is log
+				// the error and end the export early.
+				logger.Error("listing audit logs for export", log.Error(err))
+				return
+			}
+
+			for _, l := range logs {
+				if err := write(l); err != nil {
+					return
+				}
+			}
+			if err := flush(); err != nil {
+				return
+			}
+
+			if len(logs) < auditLogExportPageSize {
+				return
+			}
+			opts.BeforeID = logs[len(logs)-1].ID
+		}
+	}
+}
+
+func auditLogExportOpts(r *http.Request) (database.AuditLogListOpts, error) {
+	q := r.URL.Query()
+	opts := database.AuditLogListOpts{
+		ActorUID: q.Get("actor"),
+		Entity:   q.Get("entity"),
+	}
+
+	for param, t := range map[string]*time.Time{"since": &opts.Since, "until": &opts.Until} {
+		if v := q.Get(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return opts, errors.Newf("invalid %s parameter %q: must be an RFC 3339 timestamp", param, v)
+			}
+			*t = parsed
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestAuditLogExportHandler(t *testing.T) {
+	logger := logtest.Scoped(t)
+	timestamp := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	store := database.NewMockAuditLogStore()
+	store.ListFunc.SetDefaultHook(func(_ context.Context, opts database.AuditLogListOpts) ([]*database.AuditLog, error) {
+		assert.Equal(t, "1", opts.ActorUID)
+		assert.Equal(t, "security events", opts.Entity)
+		assert.Equal(t, timestamp.Add(-time.Hour), opts.Since.UTC())
+		assert.Equal(t, auditLogExportPageSize, opts.Limit)
+
+		return []*database.AuditLog{
+			{ID: 1, Entry: audit.Entry{AuditID: "a", Entity: "security events", Action: "SignInSucceeded", ActorUID: "1", IP: "127.0.0.1", ForwardedFor: "unknown", Fields: json.RawMessage(`{"event":{"source":"BACKEND"}}`), Timestamp: timestamp}},
+		}, nil
+	})
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(nil, database.ErrNoCurrentUser)
+
+	db := database.NewMockDB()
+	db.AuditLogsFunc.SetDefaultReturn(store)
+	db.UsersFunc.SetDefaultReturn(users)
+
+	export := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/site-admin/audit-log/export?actor=1&entity=security+events&since=2023-01-02T02:04:05Z&"+query, nil)
+		rec := httptest.NewRecorder()
+		auditLogExportHandler(db, logger)(rec, req.WithContext(actor.WithInternalActor(context.Background())))
+		return rec
+	}
+
+	t.Run("non-admins can't export", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "", nil)
+		rec := httptest.NewRecorder()
+		auditLogExportHandler(db, logger)(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		rec := export("format=csv")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "id,audit_id,timestamp,entity,action,actor_uid,ip,forwarded_for,fields\n"+
+			`1,a,2023-01-02T03:04:05Z,security events,SignInSucceeded,1,127.0.0.1,unknown,"{""event"":{""source"":""BACKEND""}}"`+"\n", rec.Body.String())
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		rec := export("format=jsonl")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"id": 1,
+			"audit_id": "a",
+			"timestamp": "2023-01-02T03:04:05Z",
+			"entity": "security events",
+			"action": "SignInSucceeded",
+			"actor_uid": "1",
+			"ip": "127.0.0.1",
+			"forwarded_for": "unknown",
+			"fields": {"event": {"source": "BACKEND"}}
+		}`, rec.Body.String())
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, export("format=xml").Code)
+		assert.Equal(t, http.StatusBadRequest, export("until=yesterday").Code)
+	})
+}
@@ -37,6 +37,8 @@ const (
 
 	UsageStatsDownload = "usage-stats.download"
 
+	AuditLogExport = "audit-log.export"
+
 	OneClickExportArchive = "one-click-export.archive"
 
 	LatestPing = "pings.latest"
@@ -101,6 +103,8 @@ func newRouter() *mux.Router {
 
 	base.Path("/site-admin/usage-statistics/archive").Methods("GET").Name(UsageStatsDownload)
 
+	base.Path("/site-admin/audit-log/export").Methods("GET").Name(AuditLogExport)
+
 	base.Path("/site-admin/data-export/archive").Methods("POST").Name(OneClickExportArchive)
 
 	base.Path("/site-admin/pings/latest").Methods("GET").Name(LatestPing)
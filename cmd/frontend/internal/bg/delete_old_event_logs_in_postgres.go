@@ -6,6 +6,8 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 )
 
@@ -38,3 +40,14 @@ func DeleteOldSecurityEventLogsInPostgres(ctx context.Context, db database.DB) {
 		time.Sleep(time.Hour)
 	}
 }
+
+func DeleteOldAuditLogsInPostgres(ctx context.Context, db database.DB) {
+	for {
+		// The retention is configurable so that compliance requirements can be met.
+		retention := audit.Retention(conf.SiteConfig())
+		if err := db.AuditLogs().DeleteStale(ctx, retention); err != nil {
+			log15.Error("deleting expired rows from audit_logs table", "error", err)
+		}
+		time.Sleep(time.Hour)
+	}
+}
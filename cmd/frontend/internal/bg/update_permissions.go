@@ -7,6 +7,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 // UpdatePermissions is a startup process that compares the permissions in the database against those
@@ -45,11 +46,52 @@ func UpdatePermissions(ctx context.Context, logger log.Logger, db database.DB) {
 
 	if len(toBeAdded) > 0 {
 		// Adding new permissions to the database
-		_, err = pstore.BulkCreate(ctx, toBeAdded)
+		var added []*types.Permission
+		added, err = pstore.BulkCreate(ctx, toBeAdded)
 		if err != nil {
 			scopedLog.Error("creating new permissions", log.Error(err))
 			return
 		}
+
+		// Permissions of default applied namespaces are granted to the DEFAULT
+		// role, so that enforcing them doesn't take away access from users.
+		err = grantToDefaultRole(ctx, tx, added)
+		if err != nil {
+			scopedLog.Error("granting new permissions to the default role", log.Error(err))
+			return
+		}
+	}
+}
+
+func grantToDefaultRole(ctx context.Context, db database.DB, perms []*types.Permission) error {
+	var defaultRole *types.Role
+	roles, err := db.Roles().List(ctx, database.RolesListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if r.Name == types.DefaultRoleName {
+			defaultRole = r
+			break
+		}
+	}
+	if defaultRole == nil {
+		defaultRole, err = db.Roles().Create(ctx, types.DefaultRoleName, true)
+		if err != nil {
+			return err
+		}
 	}
 
+	for _, p := range perms {
+		if !rbac.IsDefaultApplied(p.Namespace) {
+			continue
+		}
+		if _, err := db.RolePermissions().Create(ctx, database.CreateRolePermissionOpts{
+			RoleID:       defaultRole.ID,
+			PermissionID: p.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -145,6 +145,11 @@ func NewHandler(
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
 
+	searchExports := newSearchExportHandler(logger, db)
+	m.Get(apirouter.SearchExport).Handler(trace.Route(handler(searchExports.serveCreate)))
+	m.Get(apirouter.SearchExportStatus).Handler(trace.Route(handler(searchExports.serveStatus)))
+	m.Get(apirouter.SearchExportDownload).Handler(trace.Route(handler(searchExports.serveDownload)))
+
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
 
@@ -18,6 +18,10 @@ const (
 	ComputeStream  = "compute.stream"
 	GitBlameStream = "git.blame.stream"
 
+	SearchExport         = "search.export"
+	SearchExportStatus   = "search.export.status"
+	SearchExportDownload = "search.export.download"
+
 	SrcCli             = "src-cli"
 	SrcCliVersionCache = "src-cli.version-cache"
 
@@ -74,6 +78,9 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/export").Methods("POST").Name(SearchExport)
+	base.Path("/search/export/{id}").Methods("GET").Name(SearchExportStatus)
+	base.Path("/search/export/{id}/download").Methods("GET").Name(SearchExportDownload)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -203,6 +204,15 @@ func (h *searchIndexerServer) serveConfiguration(w http.ResponseWriter, r *http.
 			Fork:       repo.Fork,
 			Archived:   repo.Archived,
 			GetVersion: getVersion,
+			ListRefs: func() ([]gitdomain.Ref, error) {
+				refs, err := h.gitserverClient.ListRefs(ctx, repo.Name)
+				if err != nil && (errcode.HTTP(err) == http.StatusNotFound || gitdomain.IsRepoNotExist(err)) {
+					// Like a missing rev, an empty or missing repo has no
+					// refs to index.
+					return nil, nil
+				}
+				return refs, err
+			},
 
 			DocumentRanksVersion: documentRanksVersion,
 		}, nil
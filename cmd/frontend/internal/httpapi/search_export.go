@@ -0,0 +1,183 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// searchExportHandler serves the endpoints that enqueue search exports,
+// report their status, and download their results. The exports themselves
+// are produced by the search-exporter worker job.
+type searchExportHandler struct {
+	db database.DB
+	// uploadStore is nil if the upload store of search exports is
+	// misconfigured, in which case exports are unavailable.
+	uploadStore uploadstore.Store
+}
+
+func newSearchExportHandler(logger log.Logger, db database.DB) *searchExportHandler {
+	h := &searchExportHandler{db: db}
+
+	exports.ConfigInst.Load()
+	if err := exports.ConfigInst.Validate(); err != nil {
+		logger.Error("search exports are unavailable: invalid configuration", log.Error(err))
+		return h
+	}
+
+	uploadStore, err := exports.NewStore(context.Background(), observation.NewContext(logger), exports.ConfigInst)
+	if err != nil {
+		logger.Error("search exports are unavailable: failed to create upload store", log.Error(err))
+		return h
+	}
+	h.uploadStore = uploadStore
+	return h
+}
+
+var errSearchExportsUnavailable = &errcode.HTTPErr{Status: http.StatusServiceUnavailable, Err: errors.New("search exports are not configured")}
+
+// searchExportStateCompleted is the worker state of an export whose results
+// can be downloaded.
+const searchExportStateCompleted = "completed"
+
+type searchExportRequest struct {
+	Query  string `json:"query"`
+	Format string `json:"format"`
+}
+
+type searchExportStatus struct {
+	ID             int64      `json:"id"`
+	Query          string     `json:"query"`
+	Format         string     `json:"format"`
+	State          string     `json:"state"`
+	FailureMessage *string    `json:"failureMessage,omitempty"`
+	ResultCount    int        `json:"resultCount"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+	DownloadURL    string     `json:"downloadURL,omitempty"`
+}
+
+// serveCreate enqueues the export of the results of a search on behalf of
+// the current user.
+func (h *searchExportHandler) serveCreate(w http.ResponseWriter, r *http.Request) error {
+	if h.uploadStore == nil {
+		return errSearchExportsUnavailable
+	}
+
+	a := actor.FromContext(r.Context())
+	if !a.IsAuthenticated() {
+		return &errcode.HTTPErr{Status: http.StatusUnauthorized, Err: errors.New("search exports require an authenticated user")}
+	}
+
+	var req searchExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.Wrap(err, "decoding request")}
+	}
+	if req.Query == "" {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("query is required")}
+	}
+	if req.Format == "" {
+		req.Format = exports.FormatCSV
+	}
+	if err := exports.ValidateFormat(req.Format); err != nil {
+		return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+	}
+
+	job, err := h.db.SearchExportJobs().Create(r.Context(), a.UID, req.Query, req.Format)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	return writeJSON(w, newSearchExportStatus(job))
+}
+
+// serveStatus reports the status of an export job.
+func (h *searchExportHandler) serveStatus(w http.ResponseWriter, r *http.Request) error {
+	job, err := h.getJob(r)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, newSearchExportStatus(job))
+}
+
+// serveDownload streams the results of a completed export job.
+func (h *searchExportHandler) serveDownload(w http.ResponseWriter, r *http.Request) error {
+	if h.uploadStore == nil {
+		return errSearchExportsUnavailable
+	}
+
+	job, err := h.getJob(r)
+	if err != nil {
+		return err
+	}
+	if job.State != searchExportStateCompleted || job.ObjectKey == nil {
+		return &errcode.HTTPErr{Status: http.StatusConflict, Err: errors.Errorf("search export %d is %s", job.ID, job.State)}
+	}
+
+	rc, err := h.uploadStore.Get(r.Context(), *job.ObjectKey)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", exports.ContentType(job.Format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=search-export-%d.%s", job.ID, job.Format))
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// getJob returns the export job of the request.
+//
+// 🚨 SECURITY: Only the user who requested an export and site admins can see
+// it, since it contains the results of the user's search.
+func (h *searchExportHandler) getJob(r *http.Request) (*types.SearchExportJob, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.Wrap(err, "invalid search export ID")}
+	}
+
+	job, err := h.db.SearchExportJobs().GetByID(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.CheckSiteAdminOrSameUser(r.Context(), h.db, job.UserID); err != nil {
+		// Don't reveal the existence of other users' exports.
+		return nil, &errcode.HTTPErr{Status: http.StatusNotFound, Err: errors.Newf("search export %d not found", id)}
+	}
+	return job, nil
+}
+
+func newSearchExportStatus(job *types.SearchExportJob) *searchExportStatus {
+	status := &searchExportStatus{
+		ID:             job.ID,
+		Query:          job.Query,
+		Format:         job.Format,
+		State:          job.State,
+		FailureMessage: job.FailureMessage,
+		ResultCount:    job.ResultCount,
+		CreatedAt:      job.CreatedAt,
+		FinishedAt:     job.FinishedAt,
+	}
+	if job.State == searchExportStateCompleted && job.ObjectKey != nil {
+		status.DownloadURL = fmt.Sprintf("/.api/search/export/%d/download", job.ID)
+	}
+	return status
+}
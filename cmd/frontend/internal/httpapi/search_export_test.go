@@ -0,0 +1,81 @@
+package httpapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+func TestSearchExportHandler(t *testing.T) {
+	objectKey := "search-exports/1.csv"
+	job := &types.SearchExportJob{ID: 1, UserID: 1, Query: "foo", Format: "csv", State: "completed", ResultCount: 1, ObjectKey: &objectKey}
+
+	exportStore := database.NewMockSearchExportJobStore()
+	exportStore.CreateFunc.SetDefaultHook(func(_ context.Context, userID int32, query, format string) (*types.SearchExportJob, error) {
+		return &types.SearchExportJob{ID: 2, UserID: userID, Query: query, Format: format, State: "queued"}, nil
+	})
+	exportStore.GetByIDFunc.SetDefaultReturn(job, nil)
+
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 2}, nil)
+
+	db := database.NewMockDB()
+	db.SearchExportJobsFunc.SetDefaultReturn(exportStore)
+	db.UsersFunc.SetDefaultReturn(users)
+
+	uploadStore := mocks.NewMockStore()
+	uploadStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("repo\na\n")), nil)
+
+	h := &searchExportHandler{db: db, uploadStore: uploadStore}
+
+	withID := func(r *http.Request, id string) *http.Request {
+		return mux.SetURLVars(r, map[string]string{"id": id})
+	}
+
+	t.Run("create", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/search/export", strings.NewReader(`{"query":"foo"}`))
+		w := httptest.NewRecorder()
+		require.NoError(t, h.serveCreate(w, r.WithContext(actor.WithActor(r.Context(), actor.FromUser(1)))))
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"format":"csv"`)
+	})
+
+	t.Run("create unauthenticated", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/search/export", strings.NewReader(`{"query":"foo"}`))
+		err := h.serveCreate(httptest.NewRecorder(), r)
+		assert.Equal(t, http.StatusUnauthorized, errcode.HTTP(err))
+	})
+
+	t.Run("create invalid format", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/search/export", strings.NewReader(`{"query":"foo","format":"xml"}`))
+		err := h.serveCreate(httptest.NewRecorder(), r.WithContext(actor.WithActor(r.Context(), actor.FromUser(1))))
+		assert.Equal(t, http.StatusBadRequest, errcode.HTTP(err))
+	})
+
+	t.Run("download", func(t *testing.T) {
+		r := withID(httptest.NewRequest("GET", "/search/export/1/download", nil), "1")
+		w := httptest.NewRecorder()
+		require.NoError(t, h.serveDownload(w, r.WithContext(actor.WithActor(r.Context(), actor.FromUser(1)))))
+		assert.Equal(t, "repo\na\n", w.Body.String())
+		assert.Equal(t, "attachment; filename=search-export-1.csv", w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		r := withID(httptest.NewRequest("GET", "/search/export/1", nil), "1")
+		err := h.serveStatus(httptest.NewRecorder(), r.WithContext(actor.WithActor(r.Context(), actor.FromUser(2))))
+		assert.Equal(t, http.StatusNotFound, errcode.HTTP(err))
+	})
+}
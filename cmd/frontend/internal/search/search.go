@@ -172,6 +172,11 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 		defer eventHandler.Done()
 
 		batchedStream := streaming.NewBatchingStream(50*time.Millisecond, eventHandler)
+		if inputs.Features != nil && inputs.Features.Ranking {
+			// Results are sorted by rank within each batch, not across the
+			// whole stream.
+			batchedStream = streaming.NewRankingBatchingStream(50*time.Millisecond, eventHandler)
+		}
 		defer batchedStream.Done()
 
 		return h.searchClient.Execute(ctx, batchedStream, inputs)
@@ -294,6 +299,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -484,6 +491,17 @@ func fromRepository(rm *result.RepoMatch, repoCache map[api.RepoID]*types.Search
 	return repoEvent
 }
 
+func fromOwner(om *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:         streamhttp.OwnerMatchType,
+		RepositoryID: int32(om.Repo.ID),
+		Repository:   string(om.Repo.Name),
+		Commit:       string(om.CommitID),
+		Handle:       om.Handle,
+		Email:        om.Email,
+	}
+}
+
 func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCommitMatch {
 	hls := commit.Body().ToHighlightedString()
 	ranges := make([][3]int32, len(hls.Highlights))
@@ -60,6 +60,9 @@ const (
 	gitGCModeJanitorAutoGC = 2
 	// gitGCModeMaintenance is when during janitor jobs we run sg maintenance.
 	gitGCModeMaintenance = 3
+	// gitGCModeScheduledMaintenance is when during janitor jobs we run the
+	// maintenance tasks of the maintenance policies which are due.
+	gitGCModeScheduledMaintenance = 4
 )
 
 // gitGCMode describes which mode we should be running git gc.
@@ -74,6 +77,12 @@ var gitGCMode = func() int {
 	// SRC_ENABLE_SG_MAINTENANCE.
 	enableSGMaintenance, _ := strconv.ParseBool(env.Get("SRC_ENABLE_SG_MAINTENANCE", "false", "Use sg maintenance during janitorial cleanup phases"))
 
+	// The maintenance scheduler replaces both git gc and sg maintenance.
+	enableScheduler, _ := strconv.ParseBool(env.Get("SRC_ENABLE_MAINTENANCE_SCHEDULER", "false", "Run the maintenance tasks of the gitServerMaintenancePolicies site configuration during janitorial cleanup phases"))
+	if enableScheduler {
+		return gitGCModeScheduledMaintenance
+	}
+
 	if enableGCAuto && !enableSGMaintenance {
 		return gitGCModeJanitorAutoGC
 	}
@@ -169,7 +178,7 @@ const reposStatsName = "repos-stats.json"
 // 7. Perform garbage collection
 // 8. Re-clone repos after a while. (simulate git gc)
 // 9. Remove repos based on disk pressure.
-// 10. Perform sg-maintenance
+// 10. Perform sg-maintenance or scheduled maintenance
 // 11. Git prune
 // 12. Only during first run: Set sizes of repos which don't have it in a database.
 func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitServerAddresses) {
@@ -233,7 +242,10 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 			return
 		}
 
-		if !s.hostnameMatch(addr) {
+		// Repos copied here by a rebalance are not on the wrong shard, even
+		// though routing has not been switched over yet. Neither are
+		// replicas of a repo.
+		if !s.hostnameMatch(addr) && !s.ownedAfterRebalance(bCtx, name, gitServerAddrs) && !s.isReplica(bCtx, name, gitServerAddrs) {
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
 
@@ -301,6 +313,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 	}
 
 	scrubRemoteURL := func(dir GitDir) (done bool, err error) {
+		// Partial clones keep their promisor settings in the origin remote,
+		// but never store its URL.
+		if isPartialClone(dir) {
+			return false, nil
+		}
 		cmd := exec.Command("git", "remote", "remove", "origin")
 		dir.Set(cmd)
 		// ignore error since we fail if the remote has already been scrubbed.
@@ -348,10 +365,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 			}
 		}
 
-		// We believe converting a Perforce depot to a Git repository is generally a
-		// very expensive operation, therefore we do not try to re-clone/redo the
-		// conversion only because it is old or slow to do "git gc".
-		if repoType == "perforce" && reason != maybeCorrupt {
+		// We believe converting a Perforce depot or a Mercurial repository to a Git
+		// repository is generally a very expensive operation, therefore we do not
+		// try to re-clone/redo the conversion only because it is old or slow to do
+		// "git gc".
+		if (repoType == "perforce" || repoType == "mercurial") && reason != maybeCorrupt {
 			reason = ""
 		}
 
@@ -446,6 +464,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 		return false, sgMaintenance(s.Logger, dir)
 	}
 
+	maintenancePolicies := currentMaintenancePolicies(logger)
+	performScheduledMaintenance := func(dir GitDir) (done bool, err error) {
+		return false, s.scheduledMaintenance(bCtx, dir, repoToSize[s.name(dir)], maintenancePolicies)
+	}
+
 	performGitPrune := func(dir GitDir) (done bool, err error) {
 		return false, pruneIfNeeded(dir, looseObjectsLimit)
 	}
@@ -493,6 +516,14 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 		cleanups = append(cleanups, cleanupFn{"git prune", performGitPrune})
 	}
 
+	if gitGCMode == gitGCModeScheduledMaintenance {
+		// Run the maintenance tasks which are due according to the maintenance
+		// policy matching the size of the repository. Like "sg maintenance",
+		// this must not be enabled at the same time as "garbage collect".
+		cleanups = append(cleanups, cleanupFn{"scheduled maintenance", performScheduledMaintenance})
+		cleanups = append(cleanups, cleanupFn{"git prune", performGitPrune})
+	}
+
 	if !conf.Get().DisableAutoGitUpdates {
 		// Old git clones accumulate loose git objects that waste space and slow down git
 		// operations. Periodically do a fresh clone to avoid these problems. git gc is
@@ -1400,7 +1431,7 @@ func gitSetAutoGC(dir GitDir) error {
 	case gitGCModeGitAutoGC:
 		return gitConfigUnset(dir, "gc.auto")
 
-	case gitGCModeJanitorAutoGC, gitGCModeMaintenance:
+	case gitGCModeJanitorAutoGC, gitGCModeMaintenance, gitGCModeScheduledMaintenance:
 		return gitConfigSet(dir, "gc.auto", "0")
 
 	default:
@@ -11,6 +11,16 @@ import (
 
 func (s *Server) maybeStartClone(ctx context.Context, logger log.Logger, repo api.RepoName) (notFound *protocol.NotFoundPayload, cloned bool) {
 	dir := s.dir(repo)
+	if s.isReplica(ctx, repo, currentGitserverAddresses()) {
+		// Replicas never clone repo from its code host, and only serve it
+		// once they have synced with the primary. Until then, clients read
+		// repo from the primary instead.
+		if s.replicaSynced(ctx, repo, dir) {
+			return nil, true
+		}
+		return &protocol.NotFoundPayload{}, false
+	}
+
 	if repoCloned(dir) {
 		return nil, true
 	}
@@ -80,9 +80,21 @@ func (s *Server) gitServiceHandler() *gitservice.Handler {
 			return string(s.dir(api.RepoName(d)))
 		},
 
-		// Limit rate of stdout from git.
 		CommandHook: func(cmd *exec.Cmd) {
+			// Limit rate of stdout from git.
 			cmd.Stdout = flowrateWriter(logger, cmd.Stdout)
+
+			// The repository is the last argument.
+			if dir := GitDir(cmd.Args[len(cmd.Args)-1]); isPartialClone(dir) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				defer cancel()
+				remoteURL, err := s.partialCloneRemoteURL(ctx, s.name(dir))
+				if err != nil {
+					// Clients which filter blobs can still be served.
+					logger.Warn("failed to get remote URL of partial clone", log.String("dir", string(dir)), log.Error(err))
+				}
+				serveFromPartialClone(cmd, remoteURL)
+			}
 		},
 
 		Trace: func(ctx context.Context, svc, repo, protocol string) func(error) {
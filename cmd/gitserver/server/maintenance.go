@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maintenanceTask is a maintenance task the scheduler runs on a repository.
+type maintenanceTask string
+
+const (
+	maintenanceTaskCommitGraph       maintenanceTask = "commit-graph"
+	maintenanceTaskMultiPackIndex    maintenanceTask = "multi-pack-index"
+	maintenanceTaskIncrementalRepack maintenanceTask = "incremental-repack"
+	maintenanceTaskGeometricRepack   maintenanceTask = "geometric-repack"
+	maintenanceTaskLooseObjects      maintenanceTask = "loose-objects"
+)
+
+// maintenanceTaskArgs are the git arguments of each maintenance task.
+var maintenanceTaskArgs = map[maintenanceTask][]string{
+	maintenanceTaskCommitGraph:       {"maintenance", "run", "--task=commit-graph"},
+	maintenanceTaskMultiPackIndex:    {"multi-pack-index", "write", "--bitmap"},
+	maintenanceTaskIncrementalRepack: {"maintenance", "run", "--task=incremental-repack"},
+	maintenanceTaskGeometricRepack:   {"repack", "-d", "--geometric=2", "--write-midx", "--write-bitmap-index"},
+	maintenanceTaskLooseObjects:      {"maintenance", "run", "--task=loose-objects"},
+}
+
+// gitConfigMaintenancePrefix is the prefix of the git config keys which store
+// the last time a maintenance task ran on a repository.
+const gitConfigMaintenancePrefix = "sourcegraph.maintenance."
+
+var maintenanceTaskStatus = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "src_gitserver_maintenance_task_duration_seconds",
+	Help:    "Duration of the scheduled maintenance tasks by task and whether they succeeded",
+	Buckets: []float64{0.1, 1, 10, 60, 300, 3600, 7200},
+}, []string{"task", "success"})
+
+// maintenancePolicy runs Task at most once per Interval on repositories whose
+// size in bytes is in [MinSize, MaxSize). A MaxSize of 0 means unlimited.
+type maintenancePolicy struct {
+	Task     maintenanceTask
+	MinSize  int64
+	MaxSize  int64
+	Interval time.Duration
+}
+
+// largeRepoSize is the size from which the default policies treat a repository
+// as large. Rewriting all packfiles of a large repository is too expensive, so
+// we maintain them incrementally and more often.
+const largeRepoSize = 1 << 30
+
+var defaultMaintenancePolicies = []maintenancePolicy{
+	{Task: maintenanceTaskCommitGraph, MaxSize: largeRepoSize, Interval: 24 * time.Hour},
+	{Task: maintenanceTaskGeometricRepack, MaxSize: largeRepoSize, Interval: 24 * time.Hour},
+	{Task: maintenanceTaskCommitGraph, MinSize: largeRepoSize, Interval: time.Hour},
+	{Task: maintenanceTaskMultiPackIndex, MinSize: largeRepoSize, Interval: 6 * time.Hour},
+	{Task: maintenanceTaskIncrementalRepack, MinSize: largeRepoSize, Interval: 24 * time.Hour},
+}
+
+// currentMaintenancePolicies returns the maintenance policies of the site
+// configuration, or the default policies if none are configured.
+func currentMaintenancePolicies(logger log.Logger) []maintenancePolicy {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures == nil || len(cfg.ExperimentalFeatures.GitServerMaintenancePolicies) == 0 {
+		return defaultMaintenancePolicies
+	}
+	return parseMaintenancePolicies(logger, cfg.ExperimentalFeatures.GitServerMaintenancePolicies)
+}
+
+func parseMaintenancePolicies(logger log.Logger, configured []*schema.GitServerMaintenancePolicy) []maintenancePolicy {
+	policies := make([]maintenancePolicy, 0, len(configured))
+	for _, p := range configured {
+		task := maintenanceTask(p.Task)
+		if _, ok := maintenanceTaskArgs[task]; !ok {
+			logger.Warn("ignoring maintenance policy with unknown task", log.String("task", p.Task))
+			continue
+		}
+		interval, err := time.ParseDuration(p.Interval)
+		if err != nil || interval <= 0 {
+			logger.Warn("ignoring maintenance policy with invalid interval", log.String("task", p.Task), log.String("interval", p.Interval))
+			continue
+		}
+		policies = append(policies, maintenancePolicy{
+			Task:     task,
+			MinSize:  int64(p.MinRepoSizeBytes),
+			MaxSize:  int64(p.MaxRepoSizeBytes),
+			Interval: interval,
+		})
+	}
+	return policies
+}
+
+// policiesForSize returns the policies which apply to a repository of the
+// given size. For each task, the first matching policy wins.
+func policiesForSize(policies []maintenancePolicy, size int64) []maintenancePolicy {
+	var matched []maintenancePolicy
+	seen := map[maintenanceTask]bool{}
+	for _, p := range policies {
+		if seen[p.Task] || size < p.MinSize || (p.MaxSize > 0 && size >= p.MaxSize) {
+			continue
+		}
+		seen[p.Task] = true
+		matched = append(matched, p)
+	}
+	return matched
+}
+
+// scheduledMaintenance runs the maintenance tasks which are due on the
+// repository at dir according to policies, and logs each run to the database.
+func (s *Server) scheduledMaintenance(ctx context.Context, dir GitDir, size int64, policies []maintenancePolicy) error {
+	var due []maintenancePolicy
+	for _, p := range policiesForSize(policies, size) {
+		last, err := getMaintenanceTime(dir, p.Task)
+		if err != nil {
+			return err
+		}
+		if time.Since(last) >= p.Interval {
+			due = append(due, p)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	// Maintenance tasks rewrite packfiles, so they must not run concurrently
+	// with each other or with git gc.
+	err, unlock := lockRepoForGC(dir)
+	if err != nil {
+		return errors.Wrap(err, "could not lock repository for maintenance")
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			s.Logger.Error("failed to unlock repository after maintenance", log.String("dir", string(dir)), log.Error(err))
+		}
+	}()
+
+	var multi error
+	for _, p := range due {
+		start := time.Now()
+		runErr := runMaintenanceTask(dir, p.Task)
+		finish := time.Now()
+		maintenanceTaskStatus.WithLabelValues(string(p.Task), strconv.FormatBool(runErr == nil)).Observe(finish.Sub(start).Seconds())
+
+		// We record failed runs as well, so that a failing task is retried on
+		// the next interval instead of on every janitor run.
+		if err := setMaintenanceTime(dir, p.Task, start); err != nil {
+			multi = errors.Append(multi, err)
+		}
+
+		entry := types.RepoMaintenanceLog{
+			Task:       string(p.Task),
+			StartedAt:  start,
+			FinishedAt: finish,
+			NextRunAt:  start.Add(p.Interval),
+		}
+		if runErr != nil {
+			entry.Error = runErr.Error()
+			multi = errors.Append(multi, runErr)
+		}
+		if err := s.DB.GitserverRepos().LogMaintenance(ctx, s.name(dir), entry); err != nil {
+			s.Logger.Warn("failed to log repo maintenance", log.String("repo", string(s.name(dir))), log.Error(err))
+		}
+	}
+	return multi
+}
+
+// runMaintenanceTask runs a single maintenance task in dir.
+func runMaintenanceTask(dir GitDir, task maintenanceTask) error {
+	switch task {
+	case maintenanceTaskMultiPackIndex, maintenanceTaskIncrementalRepack:
+		// Both fail if there is no packfile to index yet.
+		if ok, err := hasPackfiles(dir); err != nil || !ok {
+			return err
+		}
+	}
+
+	cmd := exec.Command("git", maintenanceTaskArgs[task]...)
+	dir.Set(cmd)
+	// The output is included in the error, which ends up in the maintenance
+	// log of the repository.
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "maintenance task %s failed: %s", task, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func hasPackfiles(dir GitDir) (bool, error) {
+	entries, err := os.ReadDir(dir.Path("objects", "pack"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".pack") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getMaintenanceTime returns the last time task ran on the repository at dir,
+// or the zero time if it never ran.
+func getMaintenanceTime(dir GitDir, task maintenanceTask) (time.Time, error) {
+	value, err := gitConfigGet(dir, gitConfigMaintenancePrefix+string(task))
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+	sec, err := strconv.ParseInt(value, 10, 0)
+	if err != nil {
+		// If the value is bad, treat the task as never run.
+		return time.Time{}, nil
+	}
+	return time.Unix(sec, 0), nil
+}
+
+func setMaintenanceTime(dir GitDir, task maintenanceTask, now time.Time) error {
+	return gitConfigSet(dir, gitConfigMaintenancePrefix+string(task), strconv.FormatInt(now.Unix(), 10))
+}
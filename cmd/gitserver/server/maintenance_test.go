@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPoliciesForSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want []maintenanceTask
+	}{
+		{size: 0, want: []maintenanceTask{maintenanceTaskCommitGraph, maintenanceTaskGeometricRepack}},
+		{size: largeRepoSize - 1, want: []maintenanceTask{maintenanceTaskCommitGraph, maintenanceTaskGeometricRepack}},
+		{size: largeRepoSize, want: []maintenanceTask{maintenanceTaskCommitGraph, maintenanceTaskMultiPackIndex, maintenanceTaskIncrementalRepack}},
+	}
+	for _, tc := range tests {
+		var got []maintenanceTask
+		for _, p := range policiesForSize(defaultMaintenancePolicies, tc.size) {
+			got = append(got, p.Task)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("unexpected tasks for size %d (-want +got):\n%s", tc.size, diff)
+		}
+	}
+
+	// Large repos run commit-graph hourly.
+	policies := policiesForSize(defaultMaintenancePolicies, largeRepoSize)
+	if policies[0].Interval != time.Hour {
+		t.Errorf("want hourly commit-graph for large repos, got %s", policies[0].Interval)
+	}
+}
+
+func TestParseMaintenancePolicies(t *testing.T) {
+	got := parseMaintenancePolicies(logtest.Scoped(t), []*schema.GitServerMaintenancePolicy{
+		{Task: "commit-graph", Interval: "1h", MaxRepoSizeBytes: 100},
+		{Task: "geometric-repack", Interval: "30m", MinRepoSizeBytes: 100},
+		{Task: "unknown", Interval: "1h"},
+		{Task: "loose-objects", Interval: "not a duration"},
+	})
+	want := []maintenancePolicy{
+		{Task: maintenanceTaskCommitGraph, MaxSize: 100, Interval: time.Hour},
+		{Task: maintenanceTaskGeometricRepack, MinSize: 100, Interval: 30 * time.Minute},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected policies (-want +got):\n%s", diff)
+	}
+}
+
+func TestScheduledMaintenance(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	work := filepath.Join(root, "work")
+	for _, args := range [][]string{
+		{"init", work},
+		{"-C", work, "-c", "user.name=a", "-c", "user.email=a@example.com", "commit", "--allow-empty", "-m", "initial"},
+		{"clone", "--bare", "--no-local", work, filepath.Join(repo, ".git")},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s: %s", args, err, out)
+		}
+	}
+	if err := os.RemoveAll(work); err != nil {
+		t.Fatal(err)
+	}
+	dir := GitDir(filepath.Join(repo, ".git"))
+
+	gsStore := database.NewMockGitserverRepoStore()
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gsStore)
+
+	s := &Server{
+		ReposDir: root,
+		Logger:   logtest.Scoped(t),
+		DB:       db,
+	}
+
+	policies := []maintenancePolicy{
+		{Task: maintenanceTaskCommitGraph, Interval: time.Hour},
+		{Task: maintenanceTaskMultiPackIndex, Interval: time.Hour},
+		{Task: maintenanceTaskGeometricRepack, MinSize: 1 << 40, Interval: time.Hour},
+	}
+	if err := s.scheduledMaintenance(context.Background(), dir, 1, policies); err != nil {
+		t.Fatal(err)
+	}
+
+	// The geometric-repack policy does not apply to small repos.
+	var ran []string
+	for _, call := range gsStore.LogMaintenanceFunc.History() {
+		if call.Arg1 != api.RepoName("repo") {
+			t.Fatalf("unexpected log call for %q", call.Arg1)
+		}
+		if call.Arg2.Error != "" {
+			t.Fatalf("unexpected error for task %s: %s", call.Arg2.Task, call.Arg2.Error)
+		}
+		if want := call.Arg2.StartedAt.Add(time.Hour); !call.Arg2.NextRunAt.Equal(want) {
+			t.Fatalf("want next run at %s, got %s", want, call.Arg2.NextRunAt)
+		}
+		ran = append(ran, call.Arg2.Task)
+	}
+	if diff := cmp.Diff([]string{"commit-graph", "multi-pack-index"}, ran); diff != "" {
+		t.Fatalf("unexpected tasks (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(dir.Path("objects", "pack", "multi-pack-index")); err != nil {
+		t.Fatalf("expected a multi-pack-index: %s", err)
+	}
+	if _, err := os.Stat(dir.Path(gcLockFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected the gc lock to be released: %v", err)
+	}
+
+	// Nothing is due on the next run.
+	if err := s.scheduledMaintenance(context.Background(), dir, 1, policies); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(gsStore.LogMaintenanceFunc.History()); n != 2 {
+		t.Fatalf("want no new runs, got %d runs in total", n)
+	}
+
+	// Unless the last run is older than the interval.
+	if err := setMaintenanceTime(dir, maintenanceTaskCommitGraph, time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.scheduledMaintenance(context.Background(), dir, 1, policies); err != nil {
+		t.Fatal(err)
+	}
+	history := gsStore.LogMaintenanceFunc.History()
+	if len(history) != 3 || history[2].Arg2.Task != "commit-graph" {
+		t.Fatalf("want commit-graph to run again, got %d runs in total", len(history))
+	}
+}
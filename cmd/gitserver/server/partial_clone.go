@@ -0,0 +1,228 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var partialCloneBlobFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_partial_clone_blob_fetches_total",
+	Help: "The number of blobs fetched in bulk from the code host for partial clones",
+}, []string{"status"})
+
+// setPartialCloneConfig configures the repository at dir as a partial clone
+// whose origin remote only sends blobs smaller than limit bytes. The URL of
+// origin is not stored, it is passed on the command line whenever git needs
+// to fetch from it.
+func setPartialCloneConfig(dir GitDir, limit int64) error {
+	for _, kv := range [][2]string{
+		// Repository extensions require repository format version 1.
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialClone", "origin"},
+		{"remote.origin.promisor", "true"},
+		{"remote.origin.partialclonefilter", blobLimitFilter(limit)},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blobLimitFilter(limit int64) string {
+	return "blob:limit=" + strconv.FormatInt(limit, 10)
+}
+
+// isPartialClone returns true if the repository at dir is a partial clone.
+//
+// We read the config file directly instead of running git config, since this
+// is checked on every exec request.
+func isPartialClone(dir GitDir) bool {
+	b, err := os.ReadFile(dir.Path("config"))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(b), []byte("partialclone"))
+}
+
+// fetchFromRemote makes cmd use remoteURL for origin, so that git can fetch
+// the blobs missing from a partial clone on demand.
+func fetchFromRemote(cmd *exec.Cmd, remoteURL *vcs.URL) {
+	cmd.Args = append([]string{cmd.Args[0], "-c", "remote.origin.url=" + remoteURL.String()}, cmd.Args[1:]...)
+	configureRemoteGitCommand(cmd, tlsExternal())
+}
+
+// partialCloneRemoteURLTTL is how long the remote URL of a partial clone is
+// cached. Remote URLs may contain credentials which expire.
+const partialCloneRemoteURLTTL = time.Minute
+
+type cachedRemoteURL struct {
+	url     *vcs.URL
+	expires time.Time
+}
+
+// partialCloneRemoteURL returns the URL from which the blobs missing from the
+// partial clone of repo are fetched. It is needed for every exec request, so
+// it is cached instead of being looked up every time.
+func (s *Server) partialCloneRemoteURL(ctx context.Context, repo api.RepoName) (*vcs.URL, error) {
+	s.partialCloneRemoteURLsMu.Lock()
+	cached, ok := s.partialCloneRemoteURLs[repo]
+	s.partialCloneRemoteURLsMu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.url, nil
+	}
+
+	remoteURL, err := s.getRemoteURL(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	s.partialCloneRemoteURLsMu.Lock()
+	if s.partialCloneRemoteURLs == nil {
+		s.partialCloneRemoteURLs = make(map[api.RepoName]cachedRemoteURL)
+	}
+	s.partialCloneRemoteURLs[repo] = cachedRemoteURL{url: remoteURL, expires: time.Now().Add(partialCloneRemoteURLTTL)}
+	s.partialCloneRemoteURLsMu.Unlock()
+	return remoteURL, nil
+}
+
+// serveFromPartialClone makes the git upload-pack cmd serve a partial clone.
+// Clients which filter blobs, like the copies of partial clones made by other
+// gitserver instances, get packs without the blobs missing from the partial
+// clone. Other clients, like zoekt, get all blobs, so the missing ones are
+// first fetched from remoteURL. Without remoteURL, only clients which filter
+// blobs can be served.
+func serveFromPartialClone(cmd *exec.Cmd, remoteURL *vcs.URL) {
+	// upload-pack appends the pack-objects command it would have run to the
+	// hook, which includes --filter if the client sent a filter. pack-objects
+	// doesn't fetch missing blobs when run by upload-pack, so the hook fetches
+	// them in a single batch beforehand. Since git only runs the hook from
+	// protected config, we pass it on the command line.
+	const hook = `f() { case " $* " in ` +
+		`*" --filter="*) "$@" --missing=allow-promisor ;; ` +
+		`*) git rev-list --objects --missing=print --all | sed -n "s/^?//p" | ` +
+		`git -c fetch.negotiationAlgorithm=noop fetch --no-tags --no-write-fetch-head --recurse-submodules=no --filter=blob:none --stdin origin && "$@" ;; ` +
+		`esac; }; f`
+	cmd.Args = append([]string{cmd.Args[0], "-c", "uploadpack.packObjectsHook=" + hook}, cmd.Args[1:]...)
+	if remoteURL != nil {
+		fetchFromRemote(cmd, remoteURL)
+	}
+}
+
+// missingBlobs returns the IDs of the blobs of treeish matching pathspecs which
+// are missing from the partial clone at dir. It never fetches from the remote.
+func missingBlobs(ctx context.Context, dir GitDir, treeish string, pathspecs []string) ([]string, error) {
+	args := append([]string{"rev-list", "--objects", "--missing=print", "--no-walk", treeish, "--"}, pathspecs...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "failed to list missing blobs")
+	}
+
+	var oids []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "?") {
+			oids = append(oids, line[1:])
+		}
+	}
+	return oids, nil
+}
+
+// missingBlobPaths returns the paths of treeish whose blob is one of oids.
+func missingBlobPaths(ctx context.Context, dir GitDir, treeish string, oids []string) ([]string, error) {
+	missing := make(map[string]struct{}, len(oids))
+	for _, oid := range oids {
+		missing[oid] = struct{}{}
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-z", "--full-tree", treeish)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "failed to list tree")
+	}
+
+	var paths []string
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// Each entry is "<mode> SP <type> SP <object> TAB <path>".
+		meta, path, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		if _, ok := missing[fields[2]]; ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// fetchMissingBlobs fetches the blobs oids from remoteURL into the partial
+// clone at dir in a single request. Git would otherwise fetch them one at a
+// time when reading them.
+func fetchMissingBlobs(ctx context.Context, dir GitDir, remoteURL *vcs.URL, oids []string) error {
+	if len(oids) == 0 {
+		return nil
+	}
+
+	// These are the arguments git itself uses to fetch missing objects from a
+	// promisor remote.
+	cmd := exec.CommandContext(ctx, "git",
+		"-c", "remote.origin.url="+remoteURL.String(),
+		"-c", "fetch.negotiationAlgorithm=noop",
+		"fetch", "--no-tags", "--no-write-fetch-head", "--recurse-submodules=no",
+		"--filter=blob:none", "--stdin", "origin")
+	dir.Set(cmd)
+	cmd.Stdin = strings.NewReader(strings.Join(oids, "\n") + "\n")
+	if output, err := runWith(ctx, cmd, true, nil); err != nil {
+		partialCloneBlobFetches.WithLabelValues("error").Add(float64(len(oids)))
+		return errors.Wrap(&GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}, "failed to fetch missing blobs")
+	}
+	partialCloneBlobFetches.WithLabelValues("success").Add(float64(len(oids)))
+	return nil
+}
+
+// prepareArchiveOfPartialClone prepares git archive of treeish and pathspecs
+// in the partial clone at dir. If skipMissing is true, it returns pathspecs
+// which leave out the files whose blob is missing. Otherwise it fetches the
+// missing blobs in bulk.
+func (s *Server) prepareArchiveOfPartialClone(ctx context.Context, repo api.RepoName, dir GitDir, treeish string, pathspecs []string, skipMissing bool) ([]string, error) {
+	oids, err := missingBlobs(ctx, dir, treeish, pathspecs)
+	if err != nil || len(oids) == 0 {
+		return nil, err
+	}
+
+	if skipMissing {
+		paths, err := missingBlobPaths(ctx, dir, treeish, oids)
+		if err != nil {
+			return nil, err
+		}
+		excludes := make([]string, len(paths))
+		for i, p := range paths {
+			excludes[i] = ":(exclude,literal)" + p
+		}
+		return excludes, nil
+	}
+
+	remoteURL, err := s.partialCloneRemoteURL(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return nil, fetchMissingBlobs(ctx, dir, remoteURL, oids)
+}
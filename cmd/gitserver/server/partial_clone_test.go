@@ -0,0 +1,289 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestPartialClone(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	src := filepath.Join(root, "src")
+	if err := os.MkdirAll(filepath.Join(src, "dir"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	srcCmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, src, name, arg...)
+	}
+	srcCmd("git", "init", ".")
+	// The local transport only filters blobs if the source allows it.
+	srcCmd("git", "config", "uploadpack.allowFilter", "true")
+	srcCmd("git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	writeFile := func(name, content string, n int) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(src, name), bytes.Repeat([]byte(content), n), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("small.txt", "a", 10)
+	writeFile("large.bin", "b", 3<<20)
+	writeFile("dir/large.bin", "c", 3<<20)
+	srcCmd("git", "add", ".")
+	srcCmd("git", "commit", "-m", "initial")
+	head := strings.TrimSpace(srcCmd("git", "rev-parse", "HEAD"))
+
+	remoteURL, err := vcs.ParseURL(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncer := &GitRepoSyncer{BlobSizeLimit: 1 << 20}
+	dir := GitDir(filepath.Join(root, "repo", ".git"))
+	cmd, err := syncer.CloneCommand(ctx, remoteURL, string(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runWith(ctx, cmd, true, nil); err != nil {
+		t.Fatalf("clone failed: %s: %s", err, out)
+	}
+	if !isPartialClone(dir) {
+		t.Fatal("expected a partial clone")
+	}
+
+	missing, err := missingBlobs(ctx, dir, head, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 2 {
+		t.Fatalf("want 2 missing blobs, got %v", missing)
+	}
+	paths, err := missingBlobPaths(ctx, dir, head, missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"dir/large.bin", "large.bin"}, paths); diff != "" {
+		t.Fatalf("unexpected missing paths (-want +got):\n%s", diff)
+	}
+
+	s := &Server{
+		Logger:           logtest.Scoped(t),
+		ReposDir:         root,
+		GetRemoteURLFunc: staticGetRemoteURL(src),
+	}
+
+	archivedPaths := func(excludes []string) []string {
+		t.Helper()
+		args := append([]string{"archive", "--worktree-attributes", "--format=tar", head, "--"}, excludes...)
+		cmd := exec.Command("git", args...)
+		dir.Set(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git archive failed: %s", err)
+		}
+		list := exec.Command("tar", "-t")
+		list.Stdin = bytes.NewReader(out)
+		names, err := list.Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Fields(string(names))
+	}
+
+	// Searcher skips the missing blobs.
+	excludes, err := s.prepareArchiveOfPartialClone(ctx, "repo", dir, head, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"small.txt"}, archivedPaths(excludes)); diff != "" {
+		t.Fatalf("unexpected archive (-want +got):\n%s", diff)
+	}
+
+	// Everyone else gets the missing blobs of the archived paths fetched in
+	// bulk.
+	for i, pathspecs := range [][]string{{":(literal)dir"}, nil} {
+		excludes, err = s.prepareArchiveOfPartialClone(ctx, "repo", dir, head, pathspecs, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(excludes) != 0 {
+			t.Fatalf("want no excludes, got %v", excludes)
+		}
+		if missing, err = missingBlobs(ctx, dir, head, nil); err != nil {
+			t.Fatal(err)
+		}
+		if want := 1 - i; len(missing) != want {
+			t.Fatalf("want %d missing blobs, got %v", want, missing)
+		}
+	}
+	if diff := cmp.Diff([]string{"dir/", "dir/large.bin", "large.bin", "small.txt"}, archivedPaths(nil)); diff != "" {
+		t.Fatalf("unexpected archive (-want +got):\n%s", diff)
+	}
+
+	// New large blobs are not fetched either.
+	writeFile("other.bin", "d", 3<<20)
+	srcCmd("git", "add", ".")
+	srcCmd("git", "commit", "-m", "other")
+	head = strings.TrimSpace(srcCmd("git", "rev-parse", "HEAD"))
+	if err := syncer.Fetch(ctx, remoteURL, dir, ""); err != nil {
+		t.Fatal(err)
+	}
+	if missing, err = missingBlobs(ctx, dir, head, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 {
+		t.Fatalf("want 1 missing blob, got %v", missing)
+	}
+
+	// Reading a missing blob fetches it on demand.
+	cmd = exec.Command("git", "show", head+":other.bin")
+	dir.Set(cmd)
+	fetchFromRemote(cmd, remoteURL)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git show failed: %s", err)
+	}
+	if len(out) != 3<<20 {
+		t.Fatalf("want %d bytes, got %d", 3<<20, len(out))
+	}
+
+	// The remote URL used by the archives above is cached.
+	s.GetRemoteURLFunc = func(context.Context, api.RepoName) (string, error) {
+		return "", errors.New("remote URL looked up again")
+	}
+	if _, err := s.partialCloneRemoteURL(ctx, "repo"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPartialCloneFetchCommand(t *testing.T) {
+	remoteURL, err := vcs.ParseURL("https://github.com/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &GitRepoSyncer{BlobSizeLimit: 1 << 20}
+
+	refspecOverrides = []string{"+refs/heads/main:refs/heads/main"}
+	t.Cleanup(func() { refspecOverrides = nil })
+	cmd, _, err := s.fetchCommand(context.Background(), remoteURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"git", "-c", "remote.origin.url=https://github.com/foo/bar", "fetch", "--filter=blob:limit=1048576",
+		"--no-auto-gc", "--progress", "--prune", "origin", "+refs/heads/main:refs/heads/main",
+	}
+	if diff := cmp.Diff(want, cmd.Args); diff != "" {
+		t.Fatalf("unexpected args (-want +got):\n%s", diff)
+	}
+
+	customGitFetch = func() map[string][]string {
+		return map[string][]string{"github.com/foo/bar": {"echo", "fetch"}}
+	}
+	t.Cleanup(func() {
+		customGitFetch = func() map[string][]string { return buildCustomFetchMappings(nil) }
+	})
+	if _, _, err := s.fetchCommand(context.Background(), remoteURL); err == nil {
+		t.Fatal("expected custom fetch command to be rejected for partial clone")
+	}
+}
+
+func TestGitServicePartialClone(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	src := filepath.Join(root, "src")
+	if err := os.MkdirAll(src, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	srcCmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, src, name, arg...)
+	}
+	srcCmd("git", "init", ".")
+	srcCmd("git", "config", "uploadpack.allowFilter", "true")
+	srcCmd("git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	if err := os.WriteFile(filepath.Join(src, "small.txt"), []byte("small"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "large.bin"), bytes.Repeat([]byte("b"), 3<<20), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	srcCmd("git", "add", ".")
+	srcCmd("git", "commit", "-m", "initial")
+	head := strings.TrimSpace(srcCmd("git", "rev-parse", "HEAD"))
+
+	remoteURL, err := vcs.ParseURL(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncer := &GitRepoSyncer{BlobSizeLimit: 1 << 20}
+	clone := func(remoteURL *vcs.URL, dir GitDir) {
+		t.Helper()
+		cmd, err := syncer.CloneCommand(ctx, remoteURL, string(dir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out, err := runWith(ctx, cmd, true, nil); err != nil {
+			t.Fatalf("clone failed: %s: %s", err, out)
+		}
+	}
+	clone(remoteURL, GitDir(filepath.Join(root, "repo", ".git")))
+
+	// The code host is unavailable, so serving the partial clone to clients
+	// which filter blobs must not fetch the large blob from it.
+	if err := os.Rename(src, src+".unavailable"); err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		Logger:           logtest.Scoped(t),
+		ReposDir:         root,
+		GetRemoteURLFunc: staticGetRemoteURL(src),
+	}
+	srv := httptest.NewServer(s.gitServiceHandler())
+	defer srv.Close()
+
+	shardURL, err := vcs.ParseURL(srv.URL + "/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	copyDir := GitDir(filepath.Join(t.TempDir(), ".git"))
+	clone(shardURL, copyDir)
+
+	missing, err := missingBlobs(ctx, copyDir, head, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 {
+		t.Fatalf("want 1 missing blob, got %v", missing)
+	}
+
+	// Clients which don't filter blobs, like zoekt, get the large blob
+	// fetched from the code host.
+	if err := os.Rename(src+".unavailable", src); err != nil {
+		t.Fatal(err)
+	}
+	fullDir := filepath.Join(t.TempDir(), ".git")
+	if out, err := exec.Command("git", "clone", "--bare", shardURL.String(), fullDir).CombinedOutput(); err != nil {
+		t.Fatalf("clone without filter failed: %s: %s", err, out)
+	}
+	if missing, err = missingBlobs(ctx, GitDir(fullDir), head, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 0 {
+		t.Fatalf("want no missing blobs, got %v", missing)
+	}
+}
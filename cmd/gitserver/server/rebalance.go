@@ -0,0 +1,234 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// rebalanceBatchSize is the number of repositories we ask the new owner about
+// in a single repo-clone-progress request.
+const rebalanceBatchSize = 100
+
+// rebalanceDoer is used to talk to the other gitserver instances. It is a
+// variable so that tests can point it at a fake gitserver.
+var rebalanceDoer httpcli.Doer = httpcli.InternalDoer
+
+var (
+	rebalancePendingRepos = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_rebalance_pending_repos",
+		Help: "The number of repos on this shard which have not been copied to their owner in the rebalance target yet",
+	})
+	rebalanceCloneRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_rebalance_clone_requests_total",
+		Help: "The number of repos this shard asked their owner in the rebalance target to clone",
+	})
+)
+
+// RebalanceRepos copies the repositories stored on this shard to the shard
+// that owns them in the rebalance target of the site configuration, and is
+// expected to run in a background goroutine. Routing is not changed, so this
+// shard keeps serving its repositories until the site admin switches over to
+// the target placement.
+func (s *Server) RebalanceRepos(ctx context.Context, interval time.Duration) {
+	for {
+		target, ok := currentRebalanceTarget()
+		if !ok {
+			rebalancePendingRepos.Set(0)
+		} else if err := s.rebalanceRepos(ctx, currentGitserverAddresses(), target); err != nil {
+			s.Logger.Error("Rebalancing repos", log.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// currentRebalanceTarget returns the gitserver placement repositories should
+// be copied to. It returns false if no rebalance is configured.
+func currentRebalanceTarget() (gitserver.GitServerAddresses, bool) {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures == nil || cfg.ExperimentalFeatures.GitServerRebalance == nil {
+		return gitserver.GitServerAddresses{}, false
+	}
+	rebalance := cfg.ExperimentalFeatures.GitServerRebalance
+
+	target := currentGitserverAddresses()
+	if len(rebalance.Addresses) > 0 {
+		target.Addresses = rebalance.Addresses
+	}
+	target.ShardingAlgorithm = gitserver.ShardingAlgorithmRendezvous
+	if rebalance.ShardingAlgorithm != "" {
+		target.ShardingAlgorithm = gitserver.ShardingAlgorithm(rebalance.ShardingAlgorithm)
+	}
+	return target, true
+}
+
+// ownedAfterRebalance returns true if repo is assigned to this shard by the
+// rebalance target. It returns false if no rebalance is configured.
+func (s *Server) ownedAfterRebalance(ctx context.Context, repo api.RepoName, current gitserver.GitServerAddresses) bool {
+	target, ok := currentRebalanceTarget()
+	if !ok {
+		return false
+	}
+	if len(target.Addresses) == 0 {
+		target.Addresses = current.Addresses
+	}
+	addr, err := s.addrForRepo(ctx, repo, target)
+	if err != nil {
+		return false
+	}
+	return s.hostnameMatch(addr)
+}
+
+// rebalanceRepos asks the owner in target of every repository stored on this
+// shard to clone it from this shard, unless it already has it.
+func (s *Server) rebalanceRepos(ctx context.Context, current, target gitserver.GitServerAddresses) error {
+	var self string
+	for _, addr := range current.Addresses {
+		if s.hostnameMatch(addr) {
+			self = addr
+			break
+		}
+	}
+	if self == "" {
+		return errors.Newf("current shard %q is not included in the list of known gitserver shards", s.Hostname)
+	}
+	if len(target.Addresses) == 0 {
+		return errors.New("rebalance target has no gitserver addresses")
+	}
+
+	moves, err := s.rebalanceMoves(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for addr, repos := range moves {
+		for len(repos) > 0 {
+			batch := repos
+			if len(batch) > rebalanceBatchSize {
+				batch = batch[:rebalanceBatchSize]
+			}
+			repos = repos[len(batch):]
+
+			n, err := s.rebalanceBatch(ctx, self, addr, batch)
+			if err != nil {
+				// The new owner may be unavailable, for example during a
+				// rollout. We try again on the next run.
+				s.Logger.Warn("failed to rebalance repos", log.String("target-shard", addr), log.Error(err))
+				n = len(batch)
+			}
+			pending += n
+		}
+	}
+	rebalancePendingRepos.Set(float64(pending))
+	return nil
+}
+
+// rebalanceMoves returns the repositories stored on this shard which are
+// owned by a different shard in target, grouped by the address of the owner.
+func (s *Server) rebalanceMoves(ctx context.Context, target gitserver.GitServerAddresses) (map[string][]api.RepoName, error) {
+	moves := map[string][]api.RepoName{}
+	err := bestEffortWalk(s.ReposDir, func(dir string, fi fs.FileInfo) error {
+		if s.ignorePath(dir) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Look for $GIT_DIR
+		if !fi.IsDir() || fi.Name() != ".git" {
+			return nil
+		}
+
+		name := s.name(GitDir(dir))
+		addr, err := s.addrForRepo(ctx, name, target)
+		if err != nil {
+			return err
+		}
+		if !s.hostnameMatch(addr) {
+			moves[addr] = append(moves[addr], name)
+		}
+		return filepath.SkipDir
+	})
+	return moves, err
+}
+
+// rebalanceBatch asks the gitserver at addr to clone the repos it does not
+// have yet from self. It returns the number of repos which are not cloned on
+// addr yet.
+func (s *Server) rebalanceBatch(ctx context.Context, self, addr string, repos []api.RepoName) (pending int, err error) {
+	var progress protocol.RepoCloneProgressResponse
+	if err := postGitserver(ctx, addr, "repo-clone-progress", &protocol.RepoCloneProgressRequest{Repos: repos}, &progress); err != nil {
+		return 0, err
+	}
+
+	for _, repo := range repos {
+		p := progress.Results[repo]
+		if p != nil && p.Cloned {
+			continue
+		}
+		pending++
+		if p != nil && p.CloneInProgress {
+			continue
+		}
+
+		var resp protocol.RepoCloneResponse
+		req := &protocol.RepoCloneRequest{Repo: repo, CloneFromShard: "http://" + self}
+		if err := postGitserver(ctx, addr, "repo-clone", req, &resp); err != nil {
+			return pending, err
+		}
+		if resp.Error != "" {
+			s.Logger.Warn("failed to request clone from new owner", log.String("repo", string(repo)), log.String("target-shard", addr), log.String("error", resp.Error))
+			continue
+		}
+		rebalanceCloneRequests.Inc()
+	}
+	return pending, nil
+}
+
+// postGitserver sends payload as JSON to the given method of the gitserver at
+// addr and decodes the JSON response into result.
+func postGitserver(ctx context.Context, addr, method string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+addr+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Set header so that the server knows the request is from us.
+	req.Header.Set("X-Requested-With", "Sourcegraph")
+
+	resp, err := rebalanceDoer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s: http status %d", method, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
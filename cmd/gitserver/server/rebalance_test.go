@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestRebalanceRepos(t *testing.T) {
+	root := t.TempDir()
+	var repos []api.RepoName
+	for _, name := range []string{"repo-a", "repo-b", "repo-c", "repo-d", "repo-e", "repo-f"} {
+		if err := exec.Command("git", "--bare", "init", path.Join(root, name, ".git")).Run(); err != nil {
+			t.Fatal(err)
+		}
+		repos = append(repos, api.RepoName(name))
+	}
+
+	// The fake new owner already has repo-a cloned and is cloning repo-b.
+	var (
+		mu     sync.Mutex
+		clones = map[api.RepoName]string{}
+	)
+	newOwner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Requested-With") != "Sourcegraph" {
+			http.Error(w, "missing header", http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/repo-clone-progress":
+			var req protocol.RepoCloneProgressRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			resp := protocol.RepoCloneProgressResponse{Results: map[api.RepoName]*protocol.RepoCloneProgress{}}
+			for _, repo := range req.Repos {
+				resp.Results[repo] = &protocol.RepoCloneProgress{
+					Cloned:          repo == "repo-a",
+					CloneInProgress: repo == "repo-b",
+				}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/repo-clone":
+			var req protocol.RepoCloneRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			clones[req.Repo] = req.CloneFromShard
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(protocol.RepoCloneResponse{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(newOwner.Close)
+	newOwnerAddr := strings.TrimPrefix(newOwner.URL, "http://")
+
+	old := rebalanceDoer
+	rebalanceDoer = http.DefaultClient
+	t.Cleanup(func() { rebalanceDoer = old })
+
+	s := &Server{
+		ReposDir:       root,
+		Logger:         logtest.Scoped(t),
+		ObservationCtx: observation.TestContextTB(t),
+		DB:             database.NewMockDB(),
+		Hostname:       "gitserver-0",
+	}
+
+	current := gitserver.GitServerAddresses{Addresses: []string{"gitserver-0"}}
+	// Pin every repo to the new owner so that the test does not depend on
+	// the hash of the fake server address.
+	target := gitserver.GitServerAddresses{
+		Addresses:         []string{"gitserver-0", newOwnerAddr},
+		ShardingAlgorithm: gitserver.ShardingAlgorithmRendezvous,
+		PinnedServers:     map[string]string{},
+	}
+	for _, repo := range repos {
+		if repo != "repo-f" {
+			target.PinnedServers[string(repo)] = newOwnerAddr
+		} else {
+			target.PinnedServers[string(repo)] = "gitserver-0"
+		}
+	}
+
+	moves, err := s.rebalanceMoves(context.Background(), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string][]api.RepoName{newOwnerAddr: repos[:5]}, moves); diff != "" {
+		t.Fatalf("unexpected moves (-want +got):\n%s", diff)
+	}
+
+	if err := s.rebalanceRepos(context.Background(), current, target); err != nil {
+		t.Fatal(err)
+	}
+
+	// repo-a is already cloned and repo-b is being cloned, so we only ask for
+	// the others to be cloned from us.
+	want := map[api.RepoName]string{
+		"repo-c": "http://gitserver-0",
+		"repo-d": "http://gitserver-0",
+		"repo-e": "http://gitserver-0",
+	}
+	if diff := cmp.Diff(want, clones); diff != "" {
+		t.Fatalf("unexpected clone requests (-want +got):\n%s", diff)
+	}
+
+	t.Run("unknown shard", func(t *testing.T) {
+		err := s.rebalanceRepos(context.Background(), gitserver.GitServerAddresses{Addresses: []string{"gitserver-1"}}, target)
+		if err == nil {
+			t.Fatal("expected an error when this shard is not a known gitserver address")
+		}
+	})
+}
+
+func TestCloneFromShardKeepsRepositoryType(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := api.RepoName("perforce/depot")
+	sourceDir := t.TempDir()
+	remote := path.Join(sourceDir, string(repo))
+	if err := os.MkdirAll(remote, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	makeSingleCommitRepo(func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	})
+
+	source := httptest.NewServer(makeTestServer(ctx, t, sourceDir, remote, nil).Handler())
+	defer source.Close()
+
+	// The new owner would sync the repo from Perforce, but copies it from the
+	// source shard with git.
+	s := makeTestServer(ctx, t, t.TempDir(), "", nil)
+	s.GetVCSSyncer = func(context.Context, api.RepoName) (VCSSyncer, error) {
+		return &PerforceDepotSyncer{}, nil
+	}
+
+	if _, err := s.cloneRepo(ctx, repo, &cloneOptions{Block: true, CloneFromShard: source.URL}); err != nil {
+		t.Fatal(err)
+	}
+	typ, err := getRepositoryType(s.dir(repo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ != "perforce" {
+		t.Fatalf("got repository type %q, want %q", typ, "perforce")
+	}
+
+	if err := s.doBackgroundRepoUpdate(repo, "", source.URL); err != nil {
+		t.Fatal(err)
+	}
+	typ, err = getRepositoryType(s.dir(repo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ != "perforce" {
+		t.Fatalf("got repository type %q after update, want %q", typ, "perforce")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
-	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
 
 // HACK(keegancsmith) workaround to experiment with cloning less in a large
@@ -21,6 +20,8 @@ func useRefspecOverrides() bool {
 
 // HACK(keegancsmith) workaround to experiment with cloning less in a large
 // monorepo. https://github.com/sourcegraph/customer/issues/19
-func refspecOverridesFetchCmd(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
-	return exec.CommandContext(ctx, "git", append([]string{"fetch", "--no-auto-gc", "--progress", "--prune", remoteURL.String()}, refspecOverrides...)...)
+// fetchArgs are the git arguments up to and including the fetch subcommand.
+func refspecOverridesFetchCmd(ctx context.Context, fetchArgs []string, remote string) *exec.Cmd {
+	args := append(append([]string{}, fetchArgs...), "--no-auto-gc", "--progress", "--prune", remote)
+	return exec.CommandContext(ctx, "git", append(args, refspecOverrides...)...)
 }
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+var replicaUpdateRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_replica_update_requests_total",
+	Help: "The number of requests this shard sent to replicas to fetch a repo from it",
+}, []string{"status"})
+
+// replicaAddrs returns the addresses of the gitserver instances which store
+// repo. The first address is the primary. It returns no addresses if repo is
+// not replicated.
+func (s *Server) replicaAddrs(ctx context.Context, repo api.RepoName, gitServerAddrs gitserver.GitServerAddresses) ([]string, error) {
+	if len(gitServerAddrs.Addresses) == 0 || gitServerAddrs.ReplicatedRepos[string(protocol.NormalizeRepo(repo))] <= 1 {
+		return nil, nil
+	}
+	return gitserver.ReplicaAddrsForRepo(ctx, filepath.Base(os.Args[0]), s.DB, repo, gitServerAddrs)
+}
+
+// isReplica returns true if this shard stores a replica of repo.
+func (s *Server) isReplica(ctx context.Context, repo api.RepoName, gitServerAddrs gitserver.GitServerAddresses) bool {
+	addrs, err := s.replicaAddrs(ctx, repo, gitServerAddrs)
+	if err != nil || len(addrs) == 0 {
+		return false
+	}
+	for _, addr := range addrs[1:] {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateReplicas asks the replicas of repo to fetch it from this shard. It is
+// a no-op unless this shard is the primary of a replicated repo. Requests are
+// sent in the background. Until a replica has synced with this shard, it
+// reports repo as not found and clients read it from this shard instead.
+func (s *Server) updateReplicas(repo api.RepoName) {
+	// Replicas which do not have the repo yet clone it before responding.
+	ctx, cancel := context.WithTimeout(context.Background(), conf.GitLongCommandTimeout())
+
+	addrs, err := s.replicaAddrs(ctx, repo, currentGitserverAddresses())
+	if err != nil || len(addrs) < 2 || !s.hostnameMatch(addrs[0]) {
+		cancel()
+		return
+	}
+
+	go func() {
+		defer cancel()
+		req := &protocol.RepoUpdateRequest{Repo: repo, CloneFromShard: "http://" + addrs[0]}
+		for _, addr := range addrs[1:] {
+			var resp protocol.RepoUpdateResponse
+			if err := postGitserver(ctx, addr, "repo-update", req, &resp); err != nil {
+				replicaUpdateRequests.WithLabelValues("error").Inc()
+				s.Logger.Warn("failed to update replica", log.String("repo", string(repo)), log.String("replica", addr), log.Error(err))
+				continue
+			}
+			replicaUpdateRequests.WithLabelValues("success").Inc()
+		}
+	}()
+}
+
+// gitConfigReplicaSyncedAt is the git config key under which a replica stores
+// when it started the last successful sync with the primary, in nanoseconds
+// since the Unix epoch.
+const gitConfigReplicaSyncedAt = "sourcegraph.replicaSyncedAt"
+
+// replicaSynced returns true if the replica of repo at dir has synced with the
+// primary since the primary last fetched repo from its code host. Only synced
+// replicas serve reads for repo.
+func (s *Server) replicaSynced(ctx context.Context, repo api.RepoName, dir GitDir) bool {
+	if !repoCloned(dir) {
+		return false
+	}
+
+	value, err := gitConfigGet(dir, gitConfigReplicaSyncedAt)
+	if err != nil || value == "" {
+		return false
+	}
+	nsec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	lastFetched, err := s.getPrimaryLastFetched(ctx, repo)
+	if err != nil {
+		s.Logger.Warn("failed to get last fetched time of replicated repo", log.String("repo", string(repo)), log.Error(err))
+		return false
+	}
+	return !time.Unix(0, nsec).Before(lastFetched)
+}
+
+// primaryLastFetchedTTL is how long a replica caches when the primary last
+// fetched a repo. For up to this long after the primary fetched new commits,
+// the replica may serve reads which miss them.
+const primaryLastFetchedTTL = 5 * time.Second
+
+type cachedLastFetched struct {
+	lastFetched time.Time
+	expires     time.Time
+}
+
+// getPrimaryLastFetched returns when the primary last fetched repo from its
+// code host. It is needed for every read of a replicated repo, so it is cached
+// instead of being looked up every time.
+func (s *Server) getPrimaryLastFetched(ctx context.Context, repo api.RepoName) (time.Time, error) {
+	s.primaryLastFetchedMu.Lock()
+	cached, ok := s.primaryLastFetched[repo]
+	s.primaryLastFetchedMu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.lastFetched, nil
+	}
+
+	gr, err := s.DB.GitserverRepos().GetByName(ctx, repo)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.primaryLastFetchedMu.Lock()
+	if s.primaryLastFetched == nil {
+		s.primaryLastFetched = make(map[api.RepoName]cachedLastFetched)
+	}
+	s.primaryLastFetched[repo] = cachedLastFetched{lastFetched: gr.LastFetched, expires: time.Now().Add(primaryLastFetchedTTL)}
+	s.primaryLastFetchedMu.Unlock()
+	return gr.LastFetched, nil
+}
+
+// setReplicaSyncedAt records that a sync of the replica at dir with the
+// primary which started at start succeeded.
+func setReplicaSyncedAt(dir GitDir, start time.Time) error {
+	return gitConfigSet(dir, gitConfigReplicaSyncedAt, strconv.FormatInt(start.UnixNano(), 10))
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestReplicaServesOnlySyncedRepos(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				GitServerReplicatedRepos: map[string]int{"repo": 2},
+			},
+		},
+		ServiceConnectionConfig: conftypes.ServiceConnections{
+			GitServers: []string{"gitserver-1:3178", "gitserver-2:3178"},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	ctx := context.Background()
+	lastFetched := time.Now()
+	gr := database.NewMockGitserverRepoStore()
+	gr.GetByNameFunc.SetDefaultReturn(&types.GitserverRepo{LastFetched: lastFetched}, nil)
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gr)
+
+	root := t.TempDir()
+	s := makeTestServer(ctx, t, root, "", db)
+	s.GetRemoteURLFunc = func(context.Context, api.RepoName) (string, error) {
+		t.Error("replica must not clone from the code host")
+		return "", nil
+	}
+
+	addrs, err := s.replicaAddrs(ctx, "repo", currentGitserverAddresses())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Hostname = strings.Split(addrs[1], ":")[0]
+
+	repo := api.RepoName("repo")
+	if _, cloned := s.maybeStartClone(ctx, s.Logger, repo); cloned {
+		t.Fatal("replica served repo it has not cloned")
+	}
+	if _, cloning := s.locker.Status(s.dir(repo)); cloning {
+		t.Fatal("replica started cloning repo")
+	}
+
+	dir := s.dir(repo)
+	if err := exec.Command("git", "--bare", "init", string(dir)).Run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, cloned := s.maybeStartClone(ctx, s.Logger, repo); cloned {
+		t.Fatal("replica served repo it has never synced")
+	}
+
+	if err := setReplicaSyncedAt(dir, lastFetched.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, cloned := s.maybeStartClone(ctx, s.Logger, repo); cloned {
+		t.Fatal("replica served repo it synced before the primary last fetched it")
+	}
+
+	if err := setReplicaSyncedAt(dir, lastFetched); err != nil {
+		t.Fatal(err)
+	}
+	if _, cloned := s.maybeStartClone(ctx, s.Logger, repo); !cloned {
+		t.Fatal("replica did not serve synced repo")
+	}
+
+	// When the primary last fetched the repo is cached.
+	if n := len(gr.GetByNameFunc.History()); n != 1 {
+		t.Errorf("GetByName called %d times", n)
+	}
+}
+
+func TestCloneFromShardLeavesDatabaseState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := api.RepoName("example.com/foo/bar")
+	sourceDir := t.TempDir()
+	remote := filepath.Join(sourceDir, string(repo))
+	if err := os.MkdirAll(remote, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	makeSingleCommitRepo(func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	})
+
+	source := httptest.NewServer(makeTestServer(ctx, t, sourceDir, remote, nil).Handler())
+	defer source.Close()
+
+	gr := database.NewMockGitserverRepoStore()
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gr)
+	s := makeTestServer(ctx, t, t.TempDir(), "", db)
+
+	if _, err := s.cloneRepo(ctx, repo, &cloneOptions{Block: true, CloneFromShard: source.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if !repoCloned(s.dir(repo)) {
+		t.Fatal("expected repo to be cloned from the source shard")
+	}
+
+	// The source shard owns the database state of the repo.
+	if n := len(gr.SetCloneStatusFunc.History()); n != 0 {
+		t.Errorf("SetCloneStatus called %d times", n)
+	}
+	if n := len(gr.SetLastFetchedFunc.History()); n != 0 {
+		t.Errorf("SetLastFetched called %d times", n)
+	}
+	if n := len(gr.SetRepoSizeFunc.History()); n != 0 {
+		t.Errorf("SetRepoSize called %d times", n)
+	}
+	if n := len(gr.SetLastErrorFunc.History()); n != 0 {
+		t.Errorf("SetLastError called %d times", n)
+	}
+}
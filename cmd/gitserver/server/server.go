@@ -53,6 +53,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/repos/webhooks"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/syncx"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -344,6 +345,12 @@ type Server struct {
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
 
+	partialCloneRemoteURLsMu sync.Mutex // protects the map below
+	partialCloneRemoteURLs   map[api.RepoName]cachedRemoteURL
+
+	primaryLastFetchedMu sync.Mutex // protects the map below
+	primaryLastFetched   map[api.RepoName]cachedLastFetched
+
 	// GlobalBatchLogSemaphore is a semaphore shared between all requests to ensure that a
 	// maximum number of Git subprocesses are active for all /batch-log requests combined.
 	GlobalBatchLogSemaphore *semaphore.Weighted
@@ -574,7 +581,7 @@ func (s *Server) SyncRepoState(interval time.Duration, batchSize, perSecond int)
 		addrs := gitServerAddrs.Addresses
 		// We turn addrs into a string here for easy comparison and storage of previous
 		// addresses since we'd need to take a copy of the slice anyway.
-		currentAddrs := strings.Join(addrs, ",")
+		currentAddrs := strings.Join(addrs, ",") + ";" + string(gitServerAddrs.ShardingAlgorithm)
 		fullSync := currentAddrs != previousAddrs
 		previousAddrs = currentAddrs
 
@@ -608,6 +615,8 @@ func currentGitserverAddresses() gitserver.GitServerAddresses {
 	}
 	if cfg.ExperimentalFeatures != nil {
 		gitServerAddrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		gitServerAddrs.ShardingAlgorithm = gitserver.ShardingAlgorithm(cfg.ExperimentalFeatures.GitServerShardingAlgorithm)
+		gitServerAddrs.ReplicatedRepos = cfg.ExperimentalFeatures.GitServerReplicatedRepos
 	}
 
 	return gitServerAddrs
@@ -680,8 +689,10 @@ func (s *Server) cloneJobConsumer(ctx context.Context, jobs <-chan *cloneJob) {
 			if err != nil {
 				logger.Error("failed to clone repo", log.Error(err))
 			}
-			// Use a different context in case we failed because the original context failed.
-			s.setLastErrorNonFatal(s.ctx, job.repo, err)
+			if !job.options.fromShard() {
+				// Use a different context in case we failed because the original context failed.
+				s.setLastErrorNonFatal(s.ctx, job.repo, err)
+			}
 		}(j)
 	}
 }
@@ -1045,7 +1056,9 @@ func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
 		var statusErr, updateErr error
 
 		if debounce(req.Repo, req.Since) {
-			updateErr = s.doRepoUpdate(ctx, req.Repo, "")
+			// A non-empty CloneFromShard on a cloned repo means we are a
+			// replica which is asked to catch up with its primary.
+			updateErr = s.doRepoUpdate(ctx, req.Repo, "", req.CloneFromShard)
 		}
 
 		// attempts to acquire these values are not contingent on the success of
@@ -1095,7 +1108,7 @@ func (s *Server) handleRepoClone(w http.ResponseWriter, r *http.Request) {
 	var resp protocol.RepoCloneResponse
 	req.Repo = protocol.NormalizeRepo(req.Repo)
 
-	_, err := s.cloneRepo(context.Background(), req.Repo, &cloneOptions{Block: false})
+	_, err := s.cloneRepo(context.Background(), req.Repo, &cloneOptions{Block: false, CloneFromShard: req.CloneFromShard})
 	if err != nil {
 		logger.Warn("error cloning repo", log.String("repo", string(req.Repo)), log.Error(err))
 		resp.Error = err.Error()
@@ -1115,6 +1128,8 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 		repo      = q.Get("repo")
 		format    = q.Get("format")
 		pathspecs = q["path"]
+
+		skipMissingBlobs = q.Get("skipMissingBlobs") == "true"
 	)
 
 	// Log which which actor is accessing the repo.
@@ -1162,6 +1177,15 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 	req.Args = append(req.Args, treeish, "--")
 	req.Args = append(req.Args, pathspecs...)
 
+	if dir := s.dir(req.Repo); isPartialClone(dir) {
+		excludes, err := s.prepareArchiveOfPartialClone(r.Context(), req.Repo, dir, treeish, pathspecs, skipMissingBlobs)
+		if err != nil {
+			// git archive fetches the missing blobs itself, one at a time.
+			logger.Warn("failed to prepare archive of partial clone", log.String("repo", repo), log.Error(err))
+		}
+		req.Args = append(req.Args, excludes...)
+	}
+
 	s.exec(w, r, req)
 }
 
@@ -1256,36 +1280,11 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 	}
 
 	dir := s.dir(args.Repo)
-	if !repoCloned(dir) {
-		if conf.Get().DisableAutoGitUpdates {
-			s.Logger.Debug("not cloning on demand as DisableAutoGitUpdates is set")
-			return false, &gitdomain.RepoNotExistError{
-				Repo: args.Repo,
-			}
-		}
-
-		cloneProgress, cloneInProgress := s.locker.Status(dir)
-		if cloneInProgress {
-			return false, &gitdomain.RepoNotExistError{
-				Repo:            args.Repo,
-				CloneInProgress: true,
-				CloneProgress:   cloneProgress,
-			}
-		}
-
-		cloneProgress, err := s.cloneRepo(ctx, args.Repo, nil)
-		if err != nil {
-			s.Logger.Debug("error starting repo clone", log.String("repo", string(args.Repo)), log.Error(err))
-			return false, &gitdomain.RepoNotExistError{
-				Repo:            args.Repo,
-				CloneInProgress: false,
-			}
-		}
-
+	if notFound, cloned := s.maybeStartClone(ctx, s.Logger, args.Repo); !cloned {
 		return false, &gitdomain.RepoNotExistError{
 			Repo:            args.Repo,
-			CloneInProgress: true,
-			CloneProgress:   cloneProgress,
+			CloneInProgress: notFound.CloneInProgress,
+			CloneProgress:   notFound.CloneProgress,
 		}
 	}
 
@@ -1762,6 +1761,17 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	cmdStart = time.Now()
 	cmd := exec.CommandContext(ctx, "git", req.Args...)
 	dir.Set(cmd)
+	redactor := &urlRedactor{}
+	if isPartialClone(dir) {
+		// Let git fetch the blobs missing from the partial clone when it reads
+		// them.
+		if remoteURL, err := s.partialCloneRemoteURL(ctx, req.Repo); err != nil {
+			logger.Warn("failed to get remote URL of partial clone", log.Error(err))
+		} else {
+			fetchFromRemote(cmd, remoteURL)
+			redactor = newURLRedactor(remoteURL)
+		}
+	}
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
 	cmd.Stdin = bytes.NewReader(req.Stdin)
@@ -1772,7 +1782,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	stdoutN = stdoutW.n
 	stderrN = stderrW.n
 
-	stderr := stderrBuf.String()
+	stderr := redactor.redact(stderrBuf.String())
 	s.logIfCorrupt(ctx, req.Repo, dir, stderr)
 
 	// write trailer
@@ -2051,6 +2061,50 @@ type cloneOptions struct {
 	CloneFromShard string
 }
 
+// fromShard returns true if the repo is cloned from another gitserver
+// instance. That instance owns the database state of the repo, so the clone
+// must not update it.
+func (o *cloneOptions) fromShard() bool {
+	return o != nil && o.CloneFromShard != ""
+}
+
+// shardRemoteURL returns the URL from which repo can be fetched from the
+// gitserver instance shard.
+func (s *Server) shardRemoteURL(shard string, repo api.RepoName) (*vcs.URL, error) {
+	// are we fetching from the same gitserver instance?
+	if s.hostnameMatch(strings.TrimPrefix(shard, "http://")) {
+		return nil, errors.Errorf("cannot clone from the same gitserver instance")
+	}
+
+	remoteURL, err := vcs.ParseURL(shard)
+	if err != nil {
+		return nil, err
+	}
+	return remoteURL.JoinPath("git", string(repo)), nil
+}
+
+// shardSyncer returns the syncer used to fetch a repo from another gitserver
+// instance. Gitserver instances serve every repo with git, whatever its code
+// host, but the copy keeps the type of syncer for its code host so that we
+// don't overwrite its sourcegraph.type with "git".
+func shardSyncer(syncer VCSSyncer) VCSSyncer {
+	git := &GitRepoSyncer{}
+	if gs, ok := syncer.(*GitRepoSyncer); ok {
+		// Copies of partial clones stay partial.
+		git.BlobSizeLimit = gs.BlobSizeLimit
+	}
+	return &shardVCSSyncer{GitRepoSyncer: git, typ: syncer.Type()}
+}
+
+type shardVCSSyncer struct {
+	*GitRepoSyncer
+	typ string
+}
+
+func (s *shardVCSSyncer) Type() string {
+	return s.typ
+}
+
 // cloneRepo performs a clone operation for the given repository. It is
 // non-blocking by default.
 func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOptions) (cloneProgress string, err error) {
@@ -2058,8 +2112,12 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 		return "This will never finish cloning", nil
 	}
 
-	// We always want to store whether there was an error cloning the repo
+	// We always want to store whether there was an error cloning the repo,
+	// unless we clone it from the shard which owns it.
 	defer func() {
+		if opts.fromShard() {
+			return
+		}
 		// Use a different context in case we failed because the original context failed.
 		s.setLastErrorNonFatal(s.ctx, repo, err)
 	}()
@@ -2078,17 +2136,12 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 	}
 
 	var remoteURL *vcs.URL
-	if opts != nil && opts.CloneFromShard != "" {
-		// are we cloning from the same gitserver instance?
-		if s.hostnameMatch(strings.TrimPrefix(opts.CloneFromShard, "http://")) {
-			return "", errors.Errorf("cannot clone from the same gitserver instance")
-		}
-
-		remoteURL, err = vcs.ParseURL(opts.CloneFromShard)
+	if opts.fromShard() {
+		remoteURL, err = s.shardRemoteURL(opts.CloneFromShard, repo)
 		if err != nil {
 			return "", err
 		}
-		remoteURL = remoteURL.JoinPath("git", string(repo))
+		syncer = shardSyncer(syncer)
 	} else {
 		// We may be attempting to clone a private repo so we need an internal actor.
 		remoteURL, err = s.getRemoteURL(actor.WithInternalActor(ctx), repo)
@@ -2192,6 +2245,21 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		}
 	}
 
+	defer func() {
+		if opts.fromShard() {
+			return
+		}
+		// 🚨 SECURITY: The error could include the clone URL, which may contain
+		// a sensitive token.
+		var cloneErr error
+		if err != nil {
+			cloneErr = errors.New(newURLRedactor(remoteURL).redact(err.Error()))
+		}
+		// Use a background context to ensure the webhook is enqueued even if
+		// we time out.
+		webhooks.EnqueueRepoClone(context.Background(), logger, s.DB, repo, cloneErr)
+	}()
+
 	tmpPath, err := s.tempDir("clone-")
 	if err != nil {
 		return err
@@ -2200,15 +2268,19 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 	tmpPath = filepath.Join(tmpPath, ".git")
 	tmp := GitDir(tmpPath)
 
-	// It may already be cloned
-	if !repoCloned(dir) {
-		s.setCloneStatusNonFatal(ctx, repo, types.CloneStatusCloning)
+	// The shard we clone from owns the clone status of the repo.
+	if !opts.fromShard() {
+		// It may already be cloned
+		if !repoCloned(dir) {
+			s.setCloneStatusNonFatal(ctx, repo, types.CloneStatusCloning)
+		}
+		defer func() {
+			// Use a background context to ensure we still update the DB even if we time out
+			s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
+		}()
 	}
-	defer func() {
-		// Use a background context to ensure we still update the DB even if we time out
-		s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
-	}()
 
+	start := time.Now()
 	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
 	if err != nil {
 		return errors.Wrap(err, "get clone command")
@@ -2260,6 +2332,14 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		return err
 	}
 
+	if opts.fromShard() {
+		// Replicas only serve the repo once they have synced with the
+		// primary.
+		if err := setReplicaSyncedAt(tmp, start); err != nil {
+			return err
+		}
+	}
+
 	if overwrite {
 		// remove the current repo by putting it into our temporary directory
 		err := fileutil.RenameAndSync(dstPath, filepath.Join(filepath.Dir(tmpPath), "old"))
@@ -2275,15 +2355,19 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		return err
 	}
 
-	// Successfully updated, best-effort updating of db fetch state based on
-	// disk state.
-	if err := s.setLastFetched(ctx, repo); err != nil {
-		logger.Warn("failed setting last fetch in DB", log.Error(err))
-	}
+	// Like in doBackgroundRepoUpdate, the shard we cloned from owns the
+	// database state of the repo.
+	if !opts.fromShard() {
+		// Successfully updated, best-effort updating of db fetch state based on
+		// disk state.
+		if err := s.setLastFetched(ctx, repo); err != nil {
+			logger.Warn("failed setting last fetch in DB", log.Error(err))
+		}
 
-	// Successfully updated, best-effort calculation of the repo size.
-	if err := s.setRepoSize(ctx, repo); err != nil {
-		logger.Warn("failed setting repo size", log.Error(err))
+		// Successfully updated, best-effort calculation of the repo size.
+		if err := s.setRepoSize(ctx, repo); err != nil {
+			logger.Warn("failed setting repo size", log.Error(err))
+		}
 	}
 
 	logger.Info("repo cloned")
@@ -2489,7 +2573,9 @@ func honeySampleRate(cmd string, actor *actor.Actor) uint {
 
 var headBranchPattern = lazyregexp.New(`HEAD branch: (.+?)\n`)
 
-func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec string) error {
+// doRepoUpdate fetches repo from its code host, or from the gitserver instance
+// fromShard if it is non-empty.
+func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec, fromShard string) error {
 	span, ctx := ot.StartSpanFromContext(ctx, "Server.doRepoUpdate") //nolint:staticcheck // OT is deprecated
 	span.SetTag("repo", repo)
 	defer span.Finish()
@@ -2527,7 +2613,7 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 			l.once = new(sync.Once) // Make new requests wait for next update.
 			s.repoUpdateLocksMu.Unlock()
 
-			err = s.doBackgroundRepoUpdate(repo, revspec)
+			err = s.doBackgroundRepoUpdate(repo, revspec, fromShard)
 			if err != nil {
 				// We don't want to spam our logs when the rate limiter has been set to block all
 				// updates
@@ -2541,7 +2627,9 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 					s.logIfCorrupt(ctx, repo, s.dir(repo), gitErr.Output)
 				}
 			}
-			s.setLastErrorNonFatal(s.ctx, repo, err)
+			if fromShard == "" {
+				s.setLastErrorNonFatal(s.ctx, repo, err)
+			}
 		})
 	}()
 
@@ -2556,7 +2644,7 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 
 var doBackgroundRepoUpdateMock func(api.RepoName) error
 
-func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error {
+func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec, fromShard string) error {
 	logger := s.Logger.Scoped("backgroundRepoUpdate", "").With(log.String("repo", string(repo)))
 
 	if doBackgroundRepoUpdateMock != nil {
@@ -2586,7 +2674,12 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 	repo = protocol.NormalizeRepo(repo)
 	dir := s.dir(repo)
 
-	remoteURL, err := s.getRemoteURL(ctx, repo)
+	var remoteURL *vcs.URL
+	if fromShard != "" {
+		remoteURL, err = s.shardRemoteURL(fromShard, repo)
+	} else {
+		remoteURL, err = s.getRemoteURL(ctx, repo)
+	}
 	if err != nil {
 		return errors.Wrap(err, "failed to determine Git remote URL")
 	}
@@ -2595,6 +2688,9 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 	if err != nil {
 		return errors.Wrap(err, "get VCS syncer")
 	}
+	if fromShard != "" {
+		syncer = shardSyncer(syncer)
+	}
 
 	// drop temporary pack files after a fetch. this function won't
 	// return until this fetch has completed or definitely-failed,
@@ -2602,6 +2698,7 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 	// when the cleanup happens, just that it does.
 	defer s.cleanTmpFiles(dir)
 
+	start := time.Now()
 	err = syncer.Fetch(ctx, remoteURL, dir, revspec)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch repo %q", repo)
@@ -2622,6 +2719,14 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		logger.Warn("failed to update last changed time", log.Error(err))
 	}
 
+	if fromShard != "" {
+		// We are a replica. The primary owns the database state of the repo.
+		if err := setReplicaSyncedAt(dir, start); err != nil {
+			return errors.Wrapf(err, "failed to record replica sync for repo %q", repo)
+		}
+		return nil
+	}
+
 	// Successfully updated, best-effort updating of db fetch state based on
 	// disk state.
 	if err := s.setLastFetched(ctx, repo); err != nil {
@@ -2633,6 +2738,8 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		logger.Warn("failed to set repo size", log.Error(err))
 	}
 
+	s.updateReplicas(repo)
+
 	return nil
 }
 
@@ -2886,8 +2993,13 @@ func (s *Server) ensureRevision(ctx context.Context, repo api.RepoName, rev stri
 	if err := cmd.Run(); err == nil {
 		return false
 	}
+	if s.isReplica(ctx, repo, currentGitserverAddresses()) {
+		// Replicas are only updated from the primary. Clients retry reads
+		// of revisions a replica does not have yet against the primary.
+		return false
+	}
 	// Revision not found, update before returning.
-	err := s.doRepoUpdate(ctx, repo, rev)
+	err := s.doRepoUpdate(ctx, repo, rev, "")
 	if err != nil {
 		s.Logger.Warn("failed to perform background repo update", log.Error(err), log.String("repo", string(repo)), log.String("rev", rev))
 	}
@@ -545,6 +545,12 @@ func makeTestServer(ctx context.Context, t *testing.T, repoDir, remote string, d
 		mDB := database.NewMockDB()
 		gr := database.NewMockGitserverRepoStore()
 		mDB.GitserverReposFunc.SetDefaultReturn(gr)
+		repos := database.NewMockRepoStore()
+		repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+			return &types.Repo{Name: name}, nil
+		})
+		mDB.ReposFunc.SetDefaultReturn(repos)
+		mDB.OutboundWebhookJobsFunc.SetDefaultReturn(database.NewMockOutboundWebhookJobStore())
 		db = mDB
 	}
 	s := &Server{
@@ -815,7 +821,7 @@ func testHandleRepoDelete(t *testing.T, deletedInDB bool) {
 		t.Fatal(err)
 	}
 
-	cmpIgnored := cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs")
+	cmpIgnored := cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")
 
 	// We don't expect an error
 	if diff := cmp.Diff(want, fromDB, cmpIgnored); diff != "" {
@@ -864,7 +870,7 @@ func testHandleRepoDelete(t *testing.T, deletedInDB bool) {
 		t.Fatal(err)
 	}
 
-	cmpIgnored = cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs")
+	cmpIgnored = cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")
 
 	// We don't expect an error
 	if diff := cmp.Diff(want, fromDB, cmpIgnored); diff != "" {
@@ -938,7 +944,7 @@ func TestHandleRepoUpdate(t *testing.T) {
 	}
 
 	// We don't care exactly what the error is here
-	cmpIgnored := cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "LastError", "CorruptionLogs")
+	cmpIgnored := cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "LastError", "CorruptionLogs", "MaintenanceLogs")
 	// But we do care that it exists
 	if fromDB.LastError == "" {
 		t.Errorf("Expected an error when trying to clone from an invalid URL")
@@ -967,7 +973,7 @@ func TestHandleRepoUpdate(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	cmpIgnored = cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs")
+	cmpIgnored = cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")
 
 	// We don't expect an error
 	if diff := cmp.Diff(want, fromDB, cmpIgnored); diff != "" {
@@ -1097,19 +1103,23 @@ func TestHandleRepoUpdateFromShard(t *testing.T) {
 		t.Fatalf("unexpected error: %s", resp.Error)
 	}
 
-	size := dirSize(s.dir(repoName).Path("."))
+	if !repoCloned(s.dir(repoName)) {
+		t.Fatal("expected repo to be cloned from the source server")
+	}
+
+	// The source server owns the database state of the repo, so cloning from
+	// it must not change it.
 	want := &types.GitserverRepo{
-		RepoID:        dbRepo.ID,
-		ShardID:       "",
-		CloneStatus:   types.CloneStatusCloned,
-		RepoSizeBytes: size,
+		RepoID:      dbRepo.ID,
+		ShardID:     "",
+		CloneStatus: types.CloneStatusNotCloned,
 	}
 	fromDB, err := db.GitserverRepos().GetByID(ctx, dbRepo.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	cmpIgnored := cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs")
+	cmpIgnored := cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "RepoSizeBytes", "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")
 
 	// We don't expect an error
 	if diff := cmp.Diff(want, fromDB, cmpIgnored); diff != "" {
@@ -27,7 +27,12 @@ func (e *GitCommandError) Unwrap() error {
 }
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// BlobSizeLimit, if positive, makes the syncer create partial clones which
+	// only contain the blobs smaller than BlobSizeLimit bytes. Larger blobs are
+	// fetched from the code host when they are read.
+	BlobSizeLimit int64
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -71,15 +76,31 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 	if err := cmd.Run(); err != nil {
 		return nil, errors.Wrapf(&GitCommandError{Err: err}, "clone setup failed")
 	}
+	if s.BlobSizeLimit > 0 {
+		if err := setPartialCloneConfig(GitDir(tmpPath), s.BlobSizeLimit); err != nil {
+			return nil, errors.Wrap(err, "clone setup failed")
+		}
+	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	if cmd, _, err = s.fetchCommand(ctx, remoteURL); err != nil {
+		return nil, errors.Wrap(err, "clone setup failed")
+	}
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, revspec string) error {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	if s.BlobSizeLimit > 0 {
+		// The limit may have changed since the repository was cloned.
+		if err := setPartialCloneConfig(dir, s.BlobSizeLimit); err != nil {
+			return errors.Wrap(err, "failed to update")
+		}
+	}
+	cmd, configRemoteOpts, err := s.fetchCommand(ctx, remoteURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to update")
+	}
 	dir.Set(cmd)
 	if output, err := runWith(ctx, cmd, configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(&GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}, "failed to update")
@@ -92,19 +113,34 @@ func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.UR
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
 }
 
-func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
+// fetchCommand returns the command to fetch from remoteURL. Custom fetch
+// commands can't make partial clones, so they are rejected if BlobSizeLimit is
+// set.
+func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool, err error) {
+	args := []string{"fetch"}
+	remote := remoteURL.String()
+	if s.BlobSizeLimit > 0 {
+		// Partial clones must fetch from their promisor remote, whose URL we
+		// don't store in the repository.
+		args = []string{"-c", "remote.origin.url=" + remote, "fetch", "--filter=" + blobLimitFilter(s.BlobSizeLimit)}
+		remote = "origin"
+	}
+
 	configRemoteOpts = true
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
+		if s.BlobSizeLimit > 0 {
+			return nil, false, errors.New("custom git fetch commands can't be used for partial clones, unset partialCloneBlobSizeLimit or the custom fetch command")
+		}
 		cmd = customCmd
 		configRemoteOpts = false
 	} else if useRefspecOverrides() {
-		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
+		cmd = refspecOverridesFetchCmd(ctx, args, remote)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
+		cmd = exec.CommandContext(ctx, "git", append(args,
 			// We already have janitor jobs that run git gc. We disable git gc here to avoid
 			// a possible corruption of repositories by competing gc processes.
 			"--no-auto-gc",
-			"--progress", "--prune", remoteURL.String(),
+			"--progress", "--prune", remote,
 			// Normal git refs
 			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
 			// GitHub pull requests
@@ -116,7 +152,7 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 			// Gerrit changesets
 			"+refs/changes/*:refs/changes/*",
 			// Possibly deprecated refs for sourcegraph zap experiment?
-			"+refs/sourcegraph/*:refs/sourcegraph/*")
+			"+refs/sourcegraph/*:refs/sourcegraph/*")...)
 	}
-	return cmd, configRemoteOpts
+	return cmd, configRemoteOpts, nil
 }
@@ -0,0 +1,612 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MercurialSyncer is a syncer for Mercurial repositories. It keeps a mirror of
+// the Mercurial repository inside the Git directory, and on every fetch
+// converts the changesets it has not converted yet to Git commits, much like
+// hg-fast-export does.
+type MercurialSyncer struct{}
+
+const (
+	// hgMirrorDir is the directory inside the Git directory which holds the
+	// mirror of the Mercurial repository.
+	hgMirrorDir = "hg"
+	// hgMapFile maps every converted changeset to its Git commit, one
+	// "<node> <commit>" pair per line.
+	hgMapFile = "sg_hgmap"
+	// hgIndexFile is the Git index used to build the trees of the commits.
+	hgIndexFile = "sg_hgindex"
+
+	hgNullID      = "0000000000000000000000000000000000000000"
+	hgPatchHeader = "# HG changeset patch\n"
+)
+
+func (s *MercurialSyncer) Type() string {
+	return "mercurial"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *MercurialSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	args := append(hgRemoteArgs(), "identify", remoteURL.String())
+	if output, err := runWith(ctx, hgCommand(ctx, "", args...), false, nil); err != nil {
+		return errors.Wrapf(err, "failed to check remote access with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// CloneCommand clones and converts the Mercurial repository right away and
+// returns a no-op command, like the package syncers do.
+func (s *MercurialSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed with output %q", string(output))
+	}
+
+	if err := s.Fetch(ctx, remoteURL, GitDir(tmpPath), ""); err != nil {
+		return nil, err
+	}
+
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch pulls the new changesets of the Mercurial repository into the mirror
+// and converts them to Git commits.
+func (s *MercurialSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	mirror := dir.Path(hgMirrorDir)
+	if _, err := os.Stat(mirror); os.IsNotExist(err) {
+		// We pull into an empty repository instead of cloning, since hg clone
+		// would store the remote URL including its credentials.
+		if output, err := runWith(ctx, hgCommand(ctx, "", "init", mirror), false, nil); err != nil {
+			return errors.Wrapf(err, "failed to create Mercurial mirror with output %q", string(output))
+		}
+	} else if err != nil {
+		return err
+	}
+
+	args := append(hgRemoteArgs(), "pull", "--quiet", remoteURL.String())
+	if output, err := runWith(ctx, hgCommand(ctx, mirror, args...), false, nil); err != nil {
+		return errors.Wrapf(err, "failed to pull with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+
+	return convertHgToGit(ctx, dir)
+}
+
+// RemoteShowCommand returns the command to be executed for showing the Git
+// remote of a converted Mercurial repository.
+func (s *MercurialSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// hgCommand returns an hg command operating on the Mercurial repository at
+// repo, or on no repository if repo is empty.
+func hgCommand(ctx context.Context, repo string, args ...string) *exec.Cmd {
+	if repo != "" {
+		args = append([]string{"--repository", repo}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "hg", append([]string{"--noninteractive"}, args...)...)
+	// HGPLAIN disables all configuration which changes the output of hg.
+	cmd.Env = append(os.Environ(), "HGPLAIN=1", "HGENCODING=utf-8")
+	return cmd
+}
+
+// hgRemoteArgs returns the arguments which apply the TLS settings of the site
+// configuration to hg commands talking to the remote.
+func hgRemoteArgs() []string {
+	var args []string
+	tlsConf := tlsExternal()
+	if tlsConf.SSLNoVerify {
+		args = append(args, "--insecure")
+	}
+	if tlsConf.SSLCAInfo != "" {
+		args = append(args, "--config", "web.cacerts="+tlsConf.SSLCAInfo)
+	}
+	return args
+}
+
+func runHg(ctx context.Context, repo string, args ...string) ([]byte, error) {
+	cmd := hgCommand(ctx, repo, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, wrapCmdError(cmd, err)
+	}
+	return out, nil
+}
+
+// runHgJSON runs an hg command with JSON output and decodes it into v.
+func runHgJSON(ctx context.Context, repo string, v any, args ...string) error {
+	out, err := runHg(ctx, repo, append(args, "--template", "json")...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		return errors.Wrapf(err, "failed to decode output of hg %s", args[0])
+	}
+	return nil
+}
+
+// hgChangeset is a changeset as printed by hg log --template json.
+type hgChangeset struct {
+	Node string `json:"node"`
+	User string `json:"user"`
+	// Date is the Unix timestamp and the offset of the time zone in seconds
+	// west of UTC.
+	Date    [2]float64 `json:"date"`
+	Desc    string     `json:"desc"`
+	Parents []string   `json:"parents"`
+}
+
+// convertHgToGit converts the changesets of the Mercurial mirror in dir which
+// have not been converted yet to Git commits, and points the Git refs to the
+// commits of the Mercurial branches, bookmarks and tags.
+func convertHgToGit(ctx context.Context, dir GitDir) error {
+	mirror := dir.Path(hgMirrorDir)
+	commits, err := readHgMap(dir)
+	if err != nil {
+		return err
+	}
+
+	// Changesets are numbered in the order they were pulled into the mirror,
+	// so all changesets after the first one we have not converted need
+	// converting.
+	out, err := runHg(ctx, mirror, "log", "--rev", "all()", "--template", "{rev} {node}\n")
+	if err != nil {
+		return err
+	}
+	var start string
+	for _, line := range strings.Split(string(out), "\n") {
+		rev, node, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if _, ok := commits[node]; !ok {
+			start = rev
+			break
+		}
+	}
+
+	if start != "" {
+		if err := convertHgChangesets(ctx, dir, commits, start+":"); err != nil {
+			return err
+		}
+	}
+
+	return updateRefsFromHg(ctx, dir, commits)
+}
+
+func convertHgChangesets(ctx context.Context, dir GitDir, commits map[string]string, revset string) error {
+	mirror := dir.Path(hgMirrorDir)
+
+	var changesets []*hgChangeset
+	if err := runHgJSON(ctx, mirror, &changesets, "log", "--rev", revset); err != nil {
+		return err
+	}
+
+	mapFile, err := os.OpenFile(dir.Path(hgMapFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer mapFile.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := hgCommand(ctx, mirror, "export", "--git", "--rev", revset)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	c := &hgConverter{dir: dir, commits: commits, mapFile: mapFile}
+	if err := c.convert(ctx, changesets, stdout); err != nil {
+		// Stop hg export, which might be blocked writing output we do not read.
+		cancel()
+		_ = cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return errors.Wrapf(err, "hg export failed with stderr: %s", stderr.String())
+	}
+	return mapFile.Close()
+}
+
+// hgConverter converts changesets to Git commits by applying their diffs to
+// the tree of the commit of their first parent.
+type hgConverter struct {
+	dir GitDir
+	// commits maps the nodes of the converted changesets to their commits.
+	commits map[string]string
+	mapFile io.Writer
+
+	// indexCommit is the commit whose tree the index holds, if indexLoaded is
+	// true.
+	indexCommit string
+	indexLoaded bool
+}
+
+// convert converts changesets, whose diffs are read from export, the output
+// of hg export --git.
+func (c *hgConverter) convert(ctx context.Context, changesets []*hgChangeset, export io.Reader) error {
+	r := &hgExportReader{r: bufio.NewReader(export)}
+	for _, cs := range changesets {
+		diff, err := r.next(cs)
+		if err != nil {
+			return err
+		}
+		if err := c.commit(ctx, cs, diff); err != nil {
+			return errors.Wrapf(err, "failed to convert changeset %s", cs.Node)
+		}
+	}
+	// Let hg export finish.
+	_, err := io.Copy(io.Discard, export)
+	return err
+}
+
+func (c *hgConverter) commit(ctx context.Context, cs *hgChangeset, diff []byte) error {
+	var parents []string
+	for _, p := range cs.Parents {
+		if p == hgNullID {
+			continue
+		}
+		commit, ok := c.commits[p]
+		if !ok {
+			return errors.Errorf("parent %s has not been converted", p)
+		}
+		parents = append(parents, commit)
+	}
+
+	// hg export diffs against the first parent, so the index has to hold its
+	// tree. It usually does already, since most changesets follow the one
+	// converted before them.
+	var base string
+	if len(parents) > 0 {
+		base = parents[0]
+	}
+	if !c.indexLoaded || c.indexCommit != base {
+		args := []string{"read-tree", "--empty"}
+		if base != "" {
+			args = []string{"read-tree", base}
+		}
+		if _, err := c.git(ctx, nil, nil, args...); err != nil {
+			return err
+		}
+		c.indexCommit, c.indexLoaded = base, true
+	}
+
+	// Merges and changesets which only close a branch have no diff.
+	if bytes.Contains(diff, []byte("diff --git ")) {
+		// Make sure the index is reloaded if applying the diff fails halfway.
+		c.indexLoaded = false
+		if _, err := c.git(ctx, diff, nil, "apply", "--cached", "--binary", "--whitespace=nowarn", "-"); err != nil {
+			return err
+		}
+	}
+	tree, err := c.git(ctx, nil, nil, "write-tree")
+	if err != nil {
+		return err
+	}
+
+	args := []string{"commit-tree", tree}
+	for _, p := range parents {
+		args = append(args, "-p", p)
+	}
+	name, email := parseHgUser(cs.User)
+	date := gitDate(cs.Date)
+	env := []string{
+		"GIT_AUTHOR_NAME=" + name,
+		"GIT_AUTHOR_EMAIL=" + email,
+		"GIT_AUTHOR_DATE=" + date,
+		"GIT_COMMITTER_NAME=" + name,
+		"GIT_COMMITTER_EMAIL=" + email,
+		"GIT_COMMITTER_DATE=" + date,
+	}
+	message := cs.Desc
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+	commit, err := c.git(ctx, []byte(message), env, args...)
+	if err != nil {
+		return err
+	}
+
+	c.commits[cs.Node] = commit
+	c.indexCommit, c.indexLoaded = commit, true
+	_, err = fmt.Fprintf(c.mapFile, "%s %s\n", cs.Node, commit)
+	return err
+}
+
+// git runs a git command on the index of the converter and returns its
+// trimmed output.
+func (c *hgConverter) git(ctx context.Context, stdin []byte, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+c.dir.Path(hgIndexFile))
+	cmd.Env = append(cmd.Env, env...)
+	c.dir.Set(cmd)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", wrapCmdError(cmd, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// hgExportReader splits the output of hg export into the diffs of the
+// individual changesets.
+type hgExportReader struct {
+	r *bufio.Reader
+	// line is a line which was read but not consumed yet.
+	line string
+}
+
+func (r *hgExportReader) readLine() (string, error) {
+	if line := r.line; line != "" {
+		r.line = ""
+		return line, nil
+	}
+	line, err := r.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return line, nil
+	}
+	return line, err
+}
+
+// next returns the diff of cs, which must be the next changeset of the export.
+func (r *hgExportReader) next(cs *hgChangeset) ([]byte, error) {
+	line, err := r.readLine()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read patch of changeset %s", cs.Node)
+	}
+	if line != hgPatchHeader {
+		return nil, errors.Errorf("unexpected line %q at the start of the patch of changeset %s", line, cs.Node)
+	}
+
+	// The header ends with the node ID and the parents of the changeset.
+	sawNode := false
+	for {
+		if line, err = r.readLine(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read patch of changeset %s", cs.Node)
+		}
+		if strings.HasPrefix(line, "# Node ID ") {
+			if node := strings.TrimSpace(strings.TrimPrefix(line, "# Node ID ")); node != cs.Node {
+				return nil, errors.Errorf("expected patch of changeset %s, got %s", cs.Node, node)
+			}
+			sawNode = true
+		} else if sawNode && !strings.HasPrefix(line, "# Parent ") {
+			break
+		}
+	}
+
+	// line is the first line of the description, which hg writes without
+	// trailing whitespace. We skip as many lines as it has rather than look
+	// for the start of the diff, since the description could contain diffs
+	// itself.
+	desc := strings.TrimRight(cs.Desc, " \t\n\r\v\f")
+	for i := strings.Count(desc, "\n"); i > 0; i-- {
+		if _, err = r.readLine(); err != nil {
+			return nil, errors.Wrapf(err, "failed to read description of changeset %s", cs.Node)
+		}
+	}
+
+	var diff bytes.Buffer
+	for {
+		line, err = r.readLine()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to read diff of changeset %s", cs.Node)
+		}
+		if line == hgPatchHeader {
+			r.line = line
+			break
+		}
+		diff.WriteString(line)
+	}
+	return diff.Bytes(), nil
+}
+
+// parseHgUser splits the user of a changeset, usually "Name <email>", into the
+// name and email of a Git identity. Git does not allow empty names.
+func parseHgUser(user string) (name, email string) {
+	name = user
+	if i := strings.IndexByte(user, '<'); i >= 0 {
+		if j := strings.IndexByte(user[i:], '>'); j >= 0 {
+			name, email = user[:i], user[i+1:i+j]
+		}
+	} else if strings.Contains(user, "@") && !strings.Contains(user, " ") {
+		email = user
+	}
+	name, email = strings.TrimSpace(name), strings.TrimSpace(email)
+	if name == "" {
+		name = email
+	}
+	if name == "" {
+		name = "unknown"
+	}
+	return name, email
+}
+
+// gitDate formats the date of a changeset for Git. Mercurial stores the offset
+// of the time zone in seconds west of UTC, while Git expects hours and minutes
+// east of it.
+func gitDate(date [2]float64) string {
+	ts, offset := int64(date[0]), int64(date[1])
+	sign := '+'
+	if offset > 0 {
+		sign = '-'
+	} else {
+		offset = -offset
+	}
+	return fmt.Sprintf("@%d %c%02d%02d", ts, sign, offset/3600, offset%3600/60)
+}
+
+// readHgMap reads the commits of the converted changesets from dir, keyed by
+// node.
+func readHgMap(dir GitDir) (map[string]string, error) {
+	commits := make(map[string]string)
+	f, err := os.Open(dir.Path(hgMapFile))
+	if os.IsNotExist(err) {
+		return commits, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// Skip the last line if a conversion was interrupted while writing it.
+		node, commit, ok := strings.Cut(sc.Text(), " ")
+		if ok && len(node) == 40 && len(commit) == 40 {
+			commits[node] = commit
+		}
+	}
+	return commits, sc.Err()
+}
+
+var refNameReplacer = strings.NewReplacer(
+	" ", "_", "~", "_", "^", "_", ":", "_", "?", "_", "*", "_", "[", "_", "\\", "_", "..", "_", "@{", "_",
+)
+
+// validRefName reports whether git check-ref-format accepts ref.
+func validRefName(ref string) bool {
+	if ref == "@" || strings.HasSuffix(ref, ".") || strings.Contains(ref, "..") || strings.Contains(ref, "@{") {
+		return false
+	}
+	for _, component := range strings.Split(ref, "/") {
+		if component == "" || strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return false
+		}
+	}
+	for _, r := range ref {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateRefsFromHg points refs/heads to the commits of the Mercurial branches
+// and bookmarks, and refs/tags to the commits of the Mercurial tags. Every head
+// of the Mercurial repository also gets a ref under refs/hg/heads, so that git
+// gc does not prune converted commits which are not reachable from a branch.
+func updateRefsFromHg(ctx context.Context, dir GitDir, commits map[string]string) error {
+	mirror := dir.Path(hgMirrorDir)
+	refs := make(map[string]string)
+	add := func(prefix, name, node string) {
+		ref := prefix + refNameReplacer.Replace(name)
+		commit, ok := commits[node]
+		if _, exists := refs[ref]; !ok || exists || !validRefName(ref) {
+			return
+		}
+		refs[ref] = commit
+	}
+
+	// Branches take precedence over bookmarks of the same name.
+	var branches []struct{ Branch, Node string }
+	if err := runHgJSON(ctx, mirror, &branches, "branches", "--closed"); err != nil {
+		return err
+	}
+	for _, b := range branches {
+		add("refs/heads/", b.Branch, b.Node)
+	}
+	var bookmarks []struct{ Bookmark, Node string }
+	if err := runHgJSON(ctx, mirror, &bookmarks, "bookmarks"); err != nil {
+		return err
+	}
+	for _, b := range bookmarks {
+		add("refs/heads/", b.Bookmark, b.Node)
+	}
+	var tags []struct{ Tag, Node, Type string }
+	if err := runHgJSON(ctx, mirror, &tags, "tags"); err != nil {
+		return err
+	}
+	for _, t := range tags {
+		if t.Tag != "tip" && t.Type != "local" {
+			add("refs/tags/", t.Tag, t.Node)
+		}
+	}
+	out, err := runHg(ctx, mirror, "log", "--rev", "heads(all())", "--template", "{node}\n")
+	if err != nil {
+		return err
+	}
+	for _, node := range strings.Fields(string(out)) {
+		add("refs/hg/heads/", node, node)
+	}
+
+	// Git cannot have both refs/heads/a and refs/heads/a/b, which Mercurial
+	// allows. Keep the latter.
+	for ref := range refs {
+		for i := range ref {
+			if ref[i] == '/' {
+				delete(refs, ref[:i])
+			}
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(refname) %(objectname)", "refs/heads", "refs/tags", "refs/hg")
+	dir.Set(cmd)
+	out, err = cmd.Output()
+	if err != nil {
+		return wrapCmdError(cmd, err)
+	}
+	var updates strings.Builder
+	existing := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		if ref, commit, ok := strings.Cut(line, " "); ok {
+			existing[ref] = commit
+		}
+	}
+	for ref := range existing {
+		if _, ok := refs[ref]; !ok {
+			fmt.Fprintf(&updates, "delete %s\n", ref)
+		}
+	}
+	for ref, commit := range refs {
+		if existing[ref] != commit {
+			fmt.Fprintf(&updates, "update %s %s\n", ref, commit)
+		}
+	}
+	if updates.Len() > 0 {
+		cmd = exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+		dir.Set(cmd)
+		cmd.Stdin = strings.NewReader(updates.String())
+		if _, err := cmd.Output(); err != nil {
+			return wrapCmdError(cmd, err)
+		}
+	}
+
+	// Mercurial checks out the default branch when cloning.
+	if _, ok := refs["refs/heads/default"]; ok {
+		cmd = exec.CommandContext(ctx, "git", "symbolic-ref", "HEAD", "refs/heads/default")
+		dir.Set(cmd)
+		if _, err := cmd.Output(); err != nil {
+			return wrapCmdError(cmd, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestHgConverter(t *testing.T) {
+	ctx := context.Background()
+	dir := GitDir(filepath.Join(t.TempDir(), ".git"))
+	if err := os.MkdirAll(string(dir), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	runCmd(t, string(dir), "git", "init", "--bare", ".")
+
+	node := func(c byte) string { return strings.Repeat(string(c), 40) }
+	changesets := []*hgChangeset{{
+		Node:    node('1'),
+		User:    "Alice <alice@example.com>",
+		Date:    [2]float64{1674494000, -3600},
+		Desc:    "initial",
+		Parents: []string{hgNullID},
+	}, {
+		Node: node('2'),
+		User: "bob",
+		Date: [2]float64{1674495000, 18000},
+		// Diffs in descriptions must not be applied.
+		Desc:    "second\n\ndiff --git a/x b/x\n+not a diff\n",
+		Parents: []string{node('1')},
+	}, {
+		Node:    node('3'),
+		User:    "Alice <alice@example.com>",
+		Date:    [2]float64{1674496000, 0},
+		Desc:    "stable",
+		Parents: []string{node('1')},
+	}, {
+		Node:    node('4'),
+		User:    "Alice <alice@example.com>",
+		Date:    [2]float64{1674497000, 0},
+		Desc:    "merge",
+		Parents: []string{node('2'), node('3')},
+	}}
+	export := `# HG changeset patch
+# User Alice <alice@example.com>
+# Date 1674494000 -3600
+#      Mon Jan 23 18:13:20 2023 +0100
+# Node ID 1111111111111111111111111111111111111111
+# Parent  0000000000000000000000000000000000000000
+initial
+
+diff --git a/a.txt b/a.txt
+new file mode 100644
+--- /dev/null
++++ b/a.txt
+@@ -0,0 +1,1 @@
++a
+diff --git a/b.txt b/b.txt
+new file mode 100644
+--- /dev/null
++++ b/b.txt
+@@ -0,0 +1,1 @@
++b
+# HG changeset patch
+# User bob
+# Date 1674495000 18000
+#      Mon Jan 23 12:30:00 2023 -0500
+# Node ID 2222222222222222222222222222222222222222
+# Parent  1111111111111111111111111111111111111111
+second
+
+diff --git a/x b/x
++not a diff
+
+diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,1 +1,1 @@
+-a
++a2
+diff --git a/b.txt b/c.txt
+rename from b.txt
+rename to c.txt
+# HG changeset patch
+# User Alice <alice@example.com>
+# Date 1674496000 0
+#      Mon Jan 23 17:46:40 2023 +0000
+# Branch stable
+# Node ID 3333333333333333333333333333333333333333
+# Parent  1111111111111111111111111111111111111111
+stable
+
+diff --git a/d.txt b/d.txt
+new file mode 100644
+--- /dev/null
++++ b/d.txt
+@@ -0,0 +1,1 @@
++d
+# HG changeset patch
+# User Alice <alice@example.com>
+# Date 1674497000 0
+#      Mon Jan 23 18:03:20 2023 +0000
+# Node ID 4444444444444444444444444444444444444444
+# Parent  2222222222222222222222222222222222222222
+# Parent  3333333333333333333333333333333333333333
+merge
+
+diff --git a/d.txt b/d.txt
+new file mode 100644
+--- /dev/null
++++ b/d.txt
+@@ -0,0 +1,1 @@
++d
+`
+
+	var mapFile bytes.Buffer
+	c := &hgConverter{dir: dir, commits: make(map[string]string), mapFile: &mapFile}
+	if err := c.convert(ctx, changesets, strings.NewReader(export)); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		dir.Set(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %s failed: %s", strings.Join(args, " "), err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	for n, want := range map[byte]string{
+		'1': "a.txt:a b.txt:b",
+		'2': "a.txt:a2 c.txt:b",
+		'3': "a.txt:a b.txt:b d.txt:d",
+		'4': "a.txt:a2 c.txt:b d.txt:d",
+	} {
+		commit := c.commits[node(n)]
+		var files []string
+		for _, name := range strings.Fields(git("ls-tree", "--name-only", commit)) {
+			files = append(files, name+":"+git("show", commit+":"+name))
+		}
+		if got := strings.Join(files, " "); got != want {
+			t.Errorf("unexpected tree of changeset %c: want %q, got %q", n, want, got)
+		}
+	}
+
+	got := git("log", "--format=%P|%an|%ae|%ad|%B", "--date=raw", "-n1", c.commits[node('2')])
+	want := c.commits[node('1')] + "|bob||1674495000 -0500|second\n\ndiff --git a/x b/x\n+not a diff"
+	if got != want {
+		t.Errorf("unexpected commit of changeset 2: want %q, got %q", want, got)
+	}
+	got = git("log", "--format=%P|%an|%ae|%ad", "--date=raw", "-n1", c.commits[node('4')])
+	want = c.commits[node('2')] + " " + c.commits[node('3')] + "|Alice|alice@example.com|1674497000 +0000"
+	if got != want {
+		t.Errorf("unexpected commit of changeset 4: want %q, got %q", want, got)
+	}
+
+	// The map file allows converting incrementally.
+	if err := os.WriteFile(dir.Path(hgMapFile), mapFile.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	commits, err := readHgMap(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(c.commits, commits); diff != "" {
+		t.Fatalf("unexpected map (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseHgUser(t *testing.T) {
+	for user, want := range map[string][2]string{
+		"Alice <alice@example.com>": {"Alice", "alice@example.com"},
+		"<alice@example.com>":       {"alice@example.com", "alice@example.com"},
+		"alice@example.com":         {"alice@example.com", "alice@example.com"},
+		"alice":                     {"alice", ""},
+		"":                          {"unknown", ""},
+	} {
+		if name, email := parseHgUser(user); name != want[0] || email != want[1] {
+			t.Errorf("parseHgUser(%q): want %q, got %q", user, want, [2]string{name, email})
+		}
+	}
+}
+
+func TestMercurialSyncer(t *testing.T) {
+	if _, err := exec.LookPath("hg"); err != nil {
+		t.Skip("hg not found in $PATH")
+	}
+
+	ctx := context.Background()
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	hg := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("hg", args...)
+		cmd.Dir = src
+		cmd.Env = append(os.Environ(), "HGPLAIN=1", "HGUSER=Alice <alice@example.com>")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("hg %s failed: %s\nOutput: %s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(src, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	hg("init")
+	writeFile("README", "hello\n")
+	writeFile("logo.png", "\x89PNG\x00\x01\x02")
+	hg("commit", "--addremove", "--date", "1674494000 -3600", "--message", "initial")
+
+	remoteURL, err := vcs.ParseURL(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncer := &MercurialSyncer{}
+	if err := syncer.IsCloneable(ctx, remoteURL); err != nil {
+		t.Fatal(err)
+	}
+	dir := GitDir(filepath.Join(root, "repo", ".git"))
+	cmd, err := syncer.CloneCommand(ctx, remoteURL, string(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runWith(ctx, cmd, true, nil); err != nil {
+		t.Fatalf("clone failed: %s: %s", err, out)
+	}
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		dir.Set(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git %s failed: %s", strings.Join(args, " "), err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	initial := git("rev-parse", "refs/heads/default")
+	if got, want := git("show", "HEAD:logo.png"), "\x89PNG\x00\x01\x02"; got != want {
+		t.Fatalf("unexpected binary file: want %q, got %q", want, got)
+	}
+
+	// Fetching converts the new changesets, branches, bookmarks and tags.
+	hg("branch", "stable")
+	writeFile("README", "hello stable\n")
+	hg("commit", "--date", "1674495000 0", "--message", "stable")
+	hg("tag", "--date", "1674496000 0", "v1.0")
+	hg("update", "default")
+	hg("bookmark", "feature")
+	writeFile("NEW", "new\n")
+	hg("commit", "--addremove", "--date", "1674497000 0", "--message", "feature")
+	if err := syncer.Fetch(ctx, remoteURL, dir, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := git("rev-parse", "refs/heads/default~1"); got != initial {
+		t.Fatalf("commits changed when converting incrementally: want %s, got %s", initial, got)
+	}
+	refs := strings.Fields(git("for-each-ref", "--format=%(refname)", "refs/heads", "refs/tags"))
+	if diff := cmp.Diff([]string{"refs/heads/default", "refs/heads/feature", "refs/heads/stable", "refs/tags/v1.0"}, refs); diff != "" {
+		t.Fatalf("unexpected refs (-want +got):\n%s", diff)
+	}
+	if got, want := git("show", "refs/tags/v1.0:README"), "hello stable"; got != want {
+		t.Fatalf("unexpected file at tag: want %q, got %q", want, got)
+	}
+	if got, want := git("symbolic-ref", "HEAD"), "refs/heads/default"; got != want {
+		t.Fatalf("unexpected HEAD: want %q, got %q", want, got)
+	}
+}
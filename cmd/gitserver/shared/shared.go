@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -66,6 +67,8 @@ var (
 	wantPctFree     = env.MustGetInt("SRC_REPOS_DESIRED_PERCENT_FREE", 10, "Target percentage of free space on disk.")
 	janitorInterval = env.MustGetDuration("SRC_REPOS_JANITOR_INTERVAL", 1*time.Minute, "Interval between cleanup runs")
 
+	rebalanceInterval = env.MustGetDuration("SRC_REPOS_REBALANCE_INTERVAL", 5*time.Minute, "Interval between runs copying repos to their owner in the gitServerRebalance target")
+
 	syncRepoStateInterval          = env.MustGetDuration("SRC_REPOS_SYNC_STATE_INTERVAL", 10*time.Minute, "Interval between state syncs")
 	syncRepoStateBatchSize         = env.MustGetInt("SRC_REPOS_SYNC_STATE_BATCH_SIZE", 500, "Number of updates to perform per batch")
 	syncRepoStateUpdatePerSecond   = env.MustGetInt("SRC_REPOS_SYNC_STATE_UPSERT_PER_SEC", 500, "The number of updated rows allowed per second across all gitserver instances")
@@ -189,6 +192,12 @@ func Main(enterpriseInit EnterpriseInit) {
 	go debugserver.NewServerRoutine(ready).Start()
 	go gitserver.Janitor(actor.WithInternalActor(ctx), janitorInterval)
 	go gitserver.SyncRepoState(syncRepoStateInterval, syncRepoStateBatchSize, syncRepoStateUpdatePerSecond)
+	go gitserver.RebalanceRepos(actor.WithInternalActor(ctx), rebalanceInterval)
+
+	// Persist audit log records, so that site admins can query and export them.
+	auditLogSink := audit.NewBufferedSink(logger.Scoped("auditLogSink", "persists audit log records"), db.AuditLogs().Insert)
+	audit.SetSink(auditLogSink)
+	go auditLogSink.Start()
 
 	gitserver.StartClonePipeline(ctx)
 
@@ -236,6 +245,9 @@ func Main(enterpriseInit EnterpriseInit) {
 		logger.Error("shutting down http server", log.Error(err))
 	}
 
+	// Flush the audit log records that haven't been persisted yet.
+	auditLogSink.Stop()
+
 	// The most important thing this does is kill all our clones. If we just
 	// shutdown they will be orphaned and continue running.
 	gitserver.Stop()
@@ -482,6 +494,8 @@ func getVCSSyncer(
 			FusionConfig: configureFusionClient(c),
 			P4Home:       p4Home,
 		}, nil
+	case extsvc.TypeMercurial:
+		return &server.MercurialSyncer{}, nil
 	case extsvc.TypeJVMPackages:
 		var c schema.JVMPackagesConnection
 		if _, err := extractOptions(&c); err != nil {
@@ -529,7 +543,33 @@ func getVCSSyncer(
 		cli := rubygems.NewClient(urn, c.Repository, httpcli.ExternalDoer)
 		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
 	}
-	return &server.GitRepoSyncer{}, nil
+
+	return &server.GitRepoSyncer{BlobSizeLimit: partialCloneBlobSizeLimit(ctx, externalServiceStore, r)}, nil
+}
+
+// partialCloneBlobSizeLimit returns the partialCloneBlobSizeLimit option shared
+// by all Git code host connections, or 0 if no connection of r sets it. Since
+// most repos are not partial clones, we don't fail to sync them if a
+// connection can't be read, we clone them in full instead.
+func partialCloneBlobSizeLimit(ctx context.Context, externalServiceStore database.ExternalServiceStore, r *types.Repo) int64 {
+	logger := log.Scoped("partialCloneBlobSizeLimit", "reads the partial clone option of a repo")
+
+	for _, info := range r.Sources {
+		extSvc, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+		if err != nil {
+			logger.Warn("failed to get external service", log.String("repo", string(r.Name)), log.Error(err))
+			continue
+		}
+		rawConfig, err := extSvc.Config.Decrypt(ctx)
+		if err != nil {
+			logger.Warn("failed to decrypt external service config", log.String("repo", string(r.Name)), log.Error(err))
+			continue
+		}
+		if limit := gjson.Get(rawConfig, "partialCloneBlobSizeLimit").Int(); limit > 0 {
+			return limit
+		}
+	}
+	return 0
 }
 
 func syncExternalServiceRateLimiters(ctx context.Context, store database.ExternalServiceStore) error {
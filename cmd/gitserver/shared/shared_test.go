@@ -191,3 +191,52 @@ func TestGetVCSSyncer(t *testing.T) {
 		t.Fatalf("Want *server.PerforceDepotSyncer, got %T", s)
 	}
 }
+
+func TestGetVCSSyncerPartialClone(t *testing.T) {
+	repo := api.RepoName("github.com/foo/bar")
+	repoStore := database.NewMockRepoStore()
+	repoStore.GetByNameFunc.SetDefaultReturn(&types.Repo{
+		Name: repo,
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: extsvc.TypeGitHub,
+		},
+		Sources: map[string]*types.SourceInfo{
+			"a": {
+				ID:       "extsvc:github:1",
+				CloneURL: "https://github.com/foo/bar",
+			},
+		},
+	}, nil)
+
+	for _, tc := range []struct {
+		name      string
+		config    string
+		getErr    error
+		wantLimit int64
+	}{
+		{name: "unset", config: `{"url": "https://github.com"}`},
+		{name: "set", config: `{"url": "https://github.com", "partialCloneBlobSizeLimit": 10485760}`, wantLimit: 10485760},
+		{name: "unreadable", getErr: errors.New("boom")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			extsvcStore := database.NewMockExternalServiceStore()
+			extsvcStore.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+				ID:     1,
+				Kind:   extsvc.KindGitHub,
+				Config: extsvc.NewUnencryptedConfig(tc.config),
+			}, tc.getErr)
+
+			s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, new(dependencies.Service), repo, t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			syncer, ok := s.(*server.GitRepoSyncer)
+			if !ok {
+				t.Fatalf("Want *server.GitRepoSyncer, got %T", s)
+			}
+			if syncer.BlobSizeLimit != tc.wantLimit {
+				t.Fatalf("got blob size limit %d, want %d", syncer.BlobSizeLimit, tc.wantLimit)
+			}
+		})
+	}
+}
@@ -36,7 +36,7 @@ type Server struct {
 	SourcegraphDotComMode bool
 	Scheduler             interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
-		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error)
 	}
 	ChangesetSyncRegistry batches.ChangesetSyncRegistry
 	RateLimitSyncer       interface {
@@ -75,7 +75,11 @@ func (s *Server) handleRepoUpdateSchedulerInfo(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	result := s.Scheduler.ScheduleInfo(args.ID)
+	result, err := s.Scheduler.ScheduleInfo(r.Context(), args.ID)
+	if err != nil {
+		s.respond(w, http.StatusInternalServerError, err)
+		return
+	}
 	s.respond(w, http.StatusOK, result)
 }
 
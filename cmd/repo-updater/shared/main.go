@@ -156,6 +156,12 @@ func Main(enterpriseInit EnterpriseInit) {
 	}
 
 	updateScheduler := repos.NewUpdateScheduler(logger, db)
+	// Restore the schedule before any repos are added to it, so that repos
+	// aren't all fetched at once after a restart.
+	if err := updateScheduler.LoadSchedule(ctx); err != nil {
+		// This is not fatal, the scheduler will just start with an empty schedule.
+		logger.Error("failed to load persisted repo update schedule", log.Error(err))
+	}
 	server := &repoupdater.Server{
 		Logger:                logger,
 		ObservationCtx:        observationCtx,
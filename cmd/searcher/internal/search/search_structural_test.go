@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	zoektquery "github.com/sourcegraph/zoekt/query"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
@@ -533,6 +534,19 @@ func TestBuildQuery(t *testing.T) {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("literal fragments prefilter", func(t *testing.T) {
+		q, err := buildQuery(&search.TextPatternInfo{Pattern: "ParseInt(:[args]) if err"}, nil, &zoektquery.Const{Value: true}, false)
+		require.NoError(t, err)
+
+		var got []string
+		zoektquery.VisitAtoms(q, func(q zoektquery.Q) {
+			if s, ok := q.(*zoektquery.Substring); ok {
+				got = append(got, s.Pattern)
+			}
+		})
+		require.Equal(t, []string{"ParseInt(", "err"}, got)
+	})
 }
 
 func Test_chunkRanges(t *testing.T) {
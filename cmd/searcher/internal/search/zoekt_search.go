@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/sourcegraph/zoekt"
 	zoektquery "github.com/sourcegraph/zoekt/query"
@@ -44,6 +45,9 @@ func handleFilePathPatterns(query *search.TextPatternInfo) (zoektquery.Q, error)
 	return zoektquery.NewAnd(and...), nil
 }
 
+// ngramSize is the length in runes of the ngrams in Zoekt's index.
+const ngramSize = 3
+
 func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, filePathPatterns zoektquery.Q, shortcircuit bool) (zoektquery.Q, error) {
 	regexString := comby.StructuralPatToRegexpQuery(args.Pattern, shortcircuit)
 	if len(regexString) == 0 {
@@ -53,15 +57,31 @@ func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRep
 	if err != nil {
 		return nil, err
 	}
-	return zoektquery.NewAnd(
+	and := []zoektquery.Q{
 		&zoektquery.BranchesRepos{List: branchRepos},
 		filePathPatterns,
-		&zoektquery.Regexp{
-			Regexp:        re,
+	}
+	// Every structural match contains the literal fragments of the pattern,
+	// so we ask Zoekt for them explicitly. These are resolved from the
+	// ngram index and prune candidate files before the regexp (and later
+	// comby) is run. Fragments shorter than an ngram can't be resolved from
+	// the index and would make Zoekt scan every file, so we skip them.
+	for _, literal := range comby.StructuralPatToLiterals(args.Pattern) {
+		if utf8.RuneCountInString(literal) < ngramSize {
+			continue
+		}
+		and = append(and, &zoektquery.Substring{
+			Pattern:       literal,
 			CaseSensitive: true,
 			Content:       true,
-		},
-	), nil
+		})
+	}
+	and = append(and, &zoektquery.Regexp{
+		Regexp:        re,
+		CaseSensitive: true,
+		Content:       true,
+	})
+	return zoektquery.NewAnd(and...), nil
 }
 
 // zoektSearch searches repositories using zoekt, returning file contents for
@@ -153,9 +153,12 @@ func run(logger log.Logger) error {
 				// We pass in a nil sub-repo permissions checker and an internal actor here since
 				// searcher needs access to all data in the archive.
 				ctx = actor.WithInternalActor(ctx)
+				// Blobs missing from partial clones are larger than the files we
+				// search by default, so we skip them instead of fetching them.
 				return git.ArchiveReader(ctx, nil, repo, gitserver.ArchiveOptions{
-					Treeish: string(commit),
-					Format:  gitserver.ArchiveFormatTar,
+					Treeish:          string(commit),
+					Format:           gitserver.ArchiveFormatTar,
+					SkipMissingBlobs: true,
 				})
 			},
 			FetchTarPaths: func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) (io.ReadCloser, error) {
@@ -167,9 +170,10 @@ func run(logger log.Logger) error {
 				// searcher needs access to all data in the archive.
 				ctx = actor.WithInternalActor(ctx)
 				return git.ArchiveReader(ctx, nil, repo, gitserver.ArchiveOptions{
-					Treeish:   string(commit),
-					Format:    gitserver.ArchiveFormatTar,
-					Pathspecs: pathspecs,
+					Treeish:          string(commit),
+					Format:           gitserver.ArchiveFormatTar,
+					Pathspecs:        pathspecs,
+					SkipMissingBlobs: true,
 				})
 			},
 			FilterTar:         search.NewFilter,
@@ -0,0 +1,127 @@
+package searchcontexts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// queryReposRefreshInterval is how often the repositories of each
+	// query-based search context are re-evaluated.
+	queryReposRefreshInterval = time.Hour
+	// queryReposBatchSize is the maximum number of search contexts evaluated
+	// per run of the handler.
+	queryReposBatchSize = 20
+	// queryReposSearchTimeout bounds the search that evaluates a single context.
+	queryReposSearchTimeout = time.Minute
+)
+
+type queryReposUpdater struct{}
+
+var _ job.Job = &queryReposUpdater{}
+
+func NewQueryReposUpdater() job.Job {
+	return &queryReposUpdater{}
+}
+
+func (j *queryReposUpdater) Description() string {
+	return "searchcontexts.QueryReposUpdater periodically evaluates which repositories the query of each query-based search context resolves to."
+}
+
+func (j *queryReposUpdater) Config() []env.Config {
+	return nil
+}
+
+func (j *queryReposUpdater) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "search.context-query-repos-updater", "evaluates the repositories of query-based search contexts",
+			time.Minute, &queryReposHandler{
+				db:           db,
+				searchClient: client.NewSearchClient(observationCtx.Logger, db, search.Indexed(), search.SearcherURLs()),
+				logger:       observationCtx.Logger,
+			},
+		),
+	}, nil
+}
+
+type queryReposHandler struct {
+	db           database.DB
+	searchClient client.SearchClient
+	logger       log.Logger
+}
+
+var (
+	_ goroutine.Handler      = &queryReposHandler{}
+	_ goroutine.ErrorHandler = &queryReposHandler{}
+)
+
+func (h *queryReposHandler) Handle(ctx context.Context) error {
+	// The repositories are evaluated on behalf of all users of the context and
+	// filtered by repository permissions when they are read.
+	ctx = actor.WithInternalActor(ctx)
+
+	scs, err := h.db.SearchContexts().ListSearchContextsWithStaleQueryRepositories(ctx, time.Now().Add(-queryReposRefreshInterval), queryReposBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, sc := range scs {
+		if err := h.updateQueryRepos(ctx, sc.ID, sc.Query); err != nil {
+			// Keep evaluating the other contexts; this one is retried on the
+			// next run since its repositories are still stale.
+			h.logger.Warn("failed to evaluate search context query repositories", log.Int64("searchContextID", sc.ID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (h *queryReposHandler) updateQueryRepos(ctx context.Context, searchContextID int64, contextQuery string) error {
+	ctx, cancel := context.WithTimeout(ctx, queryReposSearchTimeout)
+	defer cancel()
+
+	inputs, err := h.searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		searchcontexts.QueryRepositoriesSearchQuery(contextQuery),
+		search.Precise,
+		search.Streaming,
+		&schema.Settings{},
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return err
+	}
+
+	agg := streaming.NewAggregatingStream()
+	if _, err := h.searchClient.Execute(ctx, agg, inputs); err != nil {
+		return err
+	}
+
+	return h.db.SearchContexts().SetSearchContextQueryRepositoryRevisions(ctx, searchContextID, searchcontexts.QueryRepositoryRevisions(agg.Results))
+}
+
+func (h *queryReposHandler) HandleError(err error) {
+	h.logger.Error("error evaluating search context query repositories", log.Error(err))
+}
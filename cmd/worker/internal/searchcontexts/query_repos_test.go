@@ -0,0 +1,69 @@
+package searchcontexts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestQueryReposHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	searchContexts := database.NewMockSearchContextsStore()
+	searchContexts.ListSearchContextsWithStaleQueryRepositoriesFunc.SetDefaultHook(func(ctx context.Context, _ time.Time, _ int) ([]*types.SearchContext, error) {
+		assert.True(t, actor.FromContext(ctx).IsInternal())
+		return []*types.SearchContext{
+			{ID: 1, Query: "repo:^github\\.com/example/"},
+			{ID: 2, Query: "repo:broken"},
+		}, nil
+	})
+
+	db := database.NewMockDB()
+	db.SearchContextsFunc.SetDefaultReturn(searchContexts)
+
+	searchClient := client.NewMockSearchClient()
+	searchClient.PlanFunc.SetDefaultHook(func(_ context.Context, _ string, _ *string, q string, _ search.Mode, _ search.Protocol, _ *schema.Settings, _ bool) (*search.Inputs, error) {
+		if q == "(repo:broken) select:repo count:all" {
+			return nil, errors.New("invalid query")
+		}
+		assert.Equal(t, "(repo:^github\\.com/example/) select:repo count:all", q)
+		return &search.Inputs{}, nil
+	})
+	searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			&result.RepoMatch{ID: 1, Name: "github.com/example/a"},
+		}})
+		return nil, nil
+	})
+
+	h := &queryReposHandler{
+		db:           db,
+		searchClient: searchClient,
+		logger:       logtest.Scoped(t),
+	}
+	require.NoError(t, h.Handle(ctx))
+
+	// The failing context is skipped, the other one is stored.
+	mockassert.CalledN(t, searchClient.PlanFunc, 2)
+	mockassert.CalledOnce(t, searchContexts.SetSearchContextQueryRepositoryRevisionsFunc)
+	call := searchContexts.SetSearchContextQueryRepositoryRevisionsFunc.History()[0]
+	assert.Equal(t, int64(1), call.Arg1)
+	assert.Equal(t, []*types.SearchContextRepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: 1, Name: "github.com/example/a"}, Revisions: []string{"HEAD"}},
+	}, call.Arg2)
+}
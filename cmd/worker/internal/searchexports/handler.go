@@ -0,0 +1,59 @@
+package searchexports
+
+import (
+	"context"
+	"io"
+
+	"github.com/sourcegraph/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type handler struct {
+	store        database.SearchExportJobStore
+	searchClient client.SearchClient
+	uploadStore  uploadstore.Store
+	maxResults   int
+}
+
+var _ workerutil.Handler[*types.SearchExportJob] = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, job *types.SearchExportJob) error {
+	// 🚨 SECURITY: The search runs as the user who requested the export, so
+	// that it only exports results from repositories the user can access.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+
+	key := exports.ObjectKey(job.ID, job.Format)
+
+	// Stream the results into the upload store as they are found rather than
+	// buffering the whole export.
+	pr, pw := io.Pipe()
+	var rowCount int
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		n, err := exports.Export(gctx, h.searchClient, job.Query, job.Format, h.maxResults, pw)
+		rowCount = n
+		pw.CloseWithError(err)
+		return errors.Wrap(err, "searching")
+	})
+	g.Go(func() error {
+		_, err := h.uploadStore.Upload(gctx, key, pr)
+		// Unblock the search if the upload fails.
+		pr.CloseWithError(err)
+		return errors.Wrap(err, "uploading export")
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	logger.Info("exported search results", log.Int64("job.id", job.ID), log.Int("rows", rowCount))
+	return h.store.MarkExported(ctx, job.ID, key, rowCount)
+}
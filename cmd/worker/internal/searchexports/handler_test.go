@@ -0,0 +1,72 @@
+package searchexports
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestHandler_Handle(t *testing.T) {
+	job := &types.SearchExportJob{ID: 42, UserID: 7, Query: "foo", Format: "csv"}
+
+	newSearchClient := func(executeErr error) *client.MockSearchClient {
+		searchClient := client.NewMockSearchClient()
+		searchClient.PlanFunc.SetDefaultHook(func(ctx context.Context, _ string, _ *string, _ string, _ search.Mode, _ search.Protocol, _ *schema.Settings, _ bool) (*search.Inputs, error) {
+			// The search must run as the user who requested the export.
+			assert.Equal(t, int32(7), actor.FromContext(ctx).UID)
+			return &search.Inputs{}, nil
+		})
+		searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, stream streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+			stream.Send(streaming.SearchEvent{Results: result.Matches{&result.RepoMatch{Name: "a"}}})
+			return nil, executeErr
+		})
+		return searchClient
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uploadStore := mocks.NewMockStore()
+		var uploaded string
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+			assert.Equal(t, "search-exports/42.csv", key)
+			b, err := io.ReadAll(r)
+			uploaded = string(b)
+			return int64(len(b)), err
+		})
+		store := database.NewMockSearchExportJobStore()
+
+		h := &handler{store: store, searchClient: newSearchClient(nil), uploadStore: uploadStore, maxResults: 10}
+		require.NoError(t, h.Handle(context.Background(), logtest.Scoped(t), job))
+
+		assert.Equal(t, "repo,path,line,preview,commit\na,,,,\n", uploaded)
+		mockassert.CalledOnceWith(t, store.MarkExportedFunc, mockassert.Values(mockassert.Skip, int64(42), "search-exports/42.csv", 1))
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		uploadStore := mocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+			_, err := io.ReadAll(r)
+			return 0, err
+		})
+		store := database.NewMockSearchExportJobStore()
+
+		h := &handler{store: store, searchClient: newSearchClient(errors.New("boom")), uploadStore: uploadStore, maxResults: 10}
+		assert.Error(t, h.Handle(context.Background(), logtest.Scoped(t), job))
+		mockassert.NotCalled(t, store.MarkExportedFunc)
+	})
+}
@@ -0,0 +1,48 @@
+package searchexports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const janitorFrequency = 1 * time.Hour
+
+// makeJanitor creates a background goroutine to delete search export jobs
+// and their exported results once they are older than ttl.
+func makeJanitor(observationCtx *observation.Context, store database.SearchExportJobStore, uploadStore uploadstore.Store, ttl time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), "search-exports.janitor", "cleans up expired search exports",
+		janitorFrequency, goroutine.HandlerFunc(func(ctx context.Context) error {
+			err := deleteExpired(ctx, store, uploadStore, ttl)
+			if err != nil {
+				observationCtx.Logger.Error("search export janitor error", log.Error(err))
+			}
+			return err
+		}),
+	)
+}
+
+// deleteExpired deletes the jobs which finished more than ttl ago, and then
+// the exports in the upload store which are older than the jobs that are
+// kept. Exports are expired by age rather than by the keys of the deleted
+// jobs, so that exports whose deletion failed are retried by the next run
+// instead of being left behind.
+func deleteExpired(ctx context.Context, store database.SearchExportJobStore, uploadStore uploadstore.Store, ttl time.Duration) error {
+	if err := store.DeleteFinishedBefore(ctx, time.Now().Add(-ttl)); err != nil {
+		return errors.Wrap(err, "deleting expired jobs")
+	}
+	// Exports are uploaded before their job finishes, so they are kept until
+	// the run after the one that deleted their job.
+	if err := uploadStore.ExpireObjects(ctx, exports.ObjectKeyPrefix, ttl+janitorFrequency); err != nil {
+		return errors.Wrap(err, "deleting expired exports")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package searchexports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestDeleteExpired(t *testing.T) {
+	ttl := 24 * time.Hour
+
+	t.Run("success", func(t *testing.T) {
+		store := database.NewMockSearchExportJobStore()
+		uploadStore := mocks.NewMockStore()
+
+		require.NoError(t, deleteExpired(context.Background(), store, uploadStore, ttl))
+
+		mockassert.CalledOnce(t, store.DeleteFinishedBeforeFunc)
+		before := store.DeleteFinishedBeforeFunc.History()[0].Arg1
+		assert.WithinDuration(t, time.Now().Add(-ttl), before, time.Minute)
+		mockassert.CalledOnceWith(t, uploadStore.ExpireObjectsFunc, mockassert.Values(mockassert.Skip, "search-exports/", ttl+janitorFrequency))
+	})
+
+	t.Run("exports are kept if jobs can't be deleted", func(t *testing.T) {
+		store := database.NewMockSearchExportJobStore()
+		store.DeleteFinishedBeforeFunc.SetDefaultReturn(errors.New("boom"))
+		uploadStore := mocks.NewMockStore()
+
+		require.Error(t, deleteExpired(context.Background(), store, uploadStore, ttl))
+		mockassert.NotCalled(t, uploadStore.ExpireObjectsFunc)
+	})
+}
@@ -0,0 +1,91 @@
+package searchexports
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/exports"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type exporter struct{}
+
+func NewExporter() job.Job {
+	return &exporter{}
+}
+
+func (e *exporter) Description() string {
+	return "Exports the results of searches to the upload store."
+}
+
+func (*exporter) Config() []env.Config {
+	return []env.Config{exports.ConfigInst}
+}
+
+func (e *exporter) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("searchexports", "search results exporter"))
+
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "initialising database")
+	}
+
+	uploadStore, err := exports.NewStore(startupCtx, observationCtx, exports.ConfigInst)
+	if err != nil {
+		return nil, errors.Wrap(err, "initialising upload store")
+	}
+
+	workerStore := makeStore(observationCtx, db.Handle())
+	handler := &handler{
+		store:        db.SearchExportJobs(),
+		searchClient: client.NewSearchClient(observationCtx.Logger, db, search.Indexed(), search.SearcherURLs()),
+		uploadStore:  uploadStore,
+		maxResults:   exports.ConfigInst.MaxResults,
+	}
+
+	return []goroutine.BackgroundRoutine{
+		makeWorker(observationCtx, workerStore, handler),
+		makeResetter(observationCtx, workerStore),
+		makeJanitor(observationCtx, db.SearchExportJobs(), uploadStore, exports.ConfigInst.TTL),
+	}, nil
+}
+
+func makeWorker(
+	observationCtx *observation.Context,
+	workerStore store.Store[*types.SearchExportJob],
+	handler *handler,
+) *workerutil.Worker[*types.SearchExportJob] {
+	return dbworker.NewWorker[*types.SearchExportJob](
+		context.Background(), workerStore, handler, workerutil.WorkerOptions{
+			Name:              "search_export_worker",
+			Interval:          5 * time.Second,
+			NumHandlers:       2,
+			HeartbeatInterval: 10 * time.Second,
+			Metrics:           workerutil.NewMetrics(observationCtx, "search_export_worker"),
+		},
+	)
+}
+
+func makeResetter(
+	observationCtx *observation.Context,
+	workerStore store.Store[*types.SearchExportJob],
+) *dbworker.Resetter[*types.SearchExportJob] {
+	return dbworker.NewResetter(
+		observationCtx.Logger, workerStore, dbworker.ResetterOptions{
+			Name:     "search_export_resetter",
+			Interval: 5 * time.Minute,
+			Metrics:  dbworker.NewResetterMetrics(observationCtx, "search_export_resetter"),
+		},
+	)
+}
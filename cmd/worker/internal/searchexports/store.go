@@ -0,0 +1,25 @@
+package searchexports
+
+import (
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+func makeStore(observationCtx *observation.Context, db basestore.TransactableHandle) store.Store[*types.SearchExportJob] {
+	return store.New(observationCtx, db, store.Options[*types.SearchExportJob]{
+		Name:              "search_export_worker_store",
+		TableName:         "search_export_jobs",
+		ColumnExpressions: database.SearchExportJobColumns,
+		Scan:              store.BuildWorkerScan(database.ScanSearchExportJob),
+		OrderByExpression: sqlf.Sprintf("id"),
+		MaxNumResets:      3,
+		StalledMaxAge:     time.Minute,
+	})
+}
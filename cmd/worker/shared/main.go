@@ -19,6 +19,8 @@ import (
 	workermigrations "github.com/sourcegraph/sourcegraph/cmd/worker/internal/migrations"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/outboundwebhooks"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchexports"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/webhooks"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/zoektrepos"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
@@ -53,14 +55,16 @@ func Start(observationCtx *observation.Context, additionalJobs map[string]job.Jo
 	registerMigrators := oobmigration.ComposeRegisterMigratorsFuncs(migrations.RegisterOSSMigrators, registerEnterpriseMigrators)
 
 	builtins := map[string]job.Job{
-		"webhook-log-janitor":       webhooks.NewJanitor(),
-		"out-of-band-migrations":    workermigrations.NewMigrator(registerMigrators),
-		"codeintel-crates-syncer":   codeintel.NewCratesSyncerJob(),
-		"gitserver-metrics":         gitserver.NewMetricsJob(),
-		"record-encrypter":          encryption.NewRecordEncrypterJob(),
-		"repo-statistics-compactor": repostatistics.NewCompactor(),
-		"zoekt-repos-updater":       zoektrepos.NewUpdater(),
-		"outbound-webhook-sender":   outboundwebhooks.NewSender(),
+		"webhook-log-janitor":                webhooks.NewJanitor(),
+		"out-of-band-migrations":             workermigrations.NewMigrator(registerMigrators),
+		"codeintel-crates-syncer":            codeintel.NewCratesSyncerJob(),
+		"gitserver-metrics":                  gitserver.NewMetricsJob(),
+		"record-encrypter":                   encryption.NewRecordEncrypterJob(),
+		"repo-statistics-compactor":          repostatistics.NewCompactor(),
+		"zoekt-repos-updater":                zoektrepos.NewUpdater(),
+		"outbound-webhook-sender":            outboundwebhooks.NewSender(),
+		"search-context-query-repos-updater": searchcontexts.NewQueryReposUpdater(),
+		"search-exporter":                    searchexports.NewExporter(),
 	}
 
 	jobs := map[string]job.Job{}
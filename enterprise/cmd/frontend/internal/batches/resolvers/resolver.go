@@ -28,6 +28,7 @@ import (
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -56,7 +57,39 @@ func batchChangesCreateAccess(ctx context.Context, db database.DB) error {
 	if !act.IsAuthenticated() {
 		return auth.ErrNotAuthenticated
 	}
-	return nil
+
+	// 🚨 SECURITY: Only users that are granted the permission may create batch changes. The
+	// permission can be granted within an org, which the service checks once the namespace
+	// is known.
+	return rbac.CheckCurrentUserHasPermissionInAnyOrg(ctx, db, rbac.BatchChangesNamespace, rbac.WriteAction)
+}
+
+// batchChangesWriteAccess returns an error if the current user doesn't have batch changes
+// enabled for them or is not granted the permission to modify batch changes in any
+// namespace. Whether the user can modify a specific batch change, including whether they
+// are granted the permission within the org that owns it, is checked by the service.
+func batchChangesWriteAccess(ctx context.Context, db database.DB) error {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, db); err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only users that are granted the permission may modify batch changes.
+	return rbac.CheckCurrentUserHasPermissionInAnyOrg(ctx, db, rbac.BatchChangesNamespace, rbac.WriteAction)
+}
+
+// batchChangesReadAccess returns an error if the current user doesn't have batch changes
+// enabled for them or, if they're signed in, is not granted the permission to view batch
+// changes in the org with the given ID. If namespaceOrgID is zero, the permission must be
+// granted globally.
+func batchChangesReadAccess(ctx context.Context, db database.DB, namespaceOrgID int32) error {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, db); err != nil {
+		return err
+	}
+
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return nil
+	}
+	return rbac.CheckCurrentUserHasOrgPermission(ctx, db, namespaceOrgID, rbac.BatchChangesNamespace, rbac.ReadAction)
 }
 
 // checkLicense returns the current plan's configured Batch Changes feature.
@@ -180,10 +213,6 @@ func (r *Resolver) batchChangeByID(ctx context.Context, id graphql.ID) (graphqlb
 }
 
 func (r *Resolver) BatchChange(ctx context.Context, args *graphqlbackend.BatchChangeArgs) (graphqlbackend.BatchChangeResolver, error) {
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
-		return nil, err
-	}
-
 	opts := store.GetBatchChangeOpts{Name: args.Name}
 
 	err := graphqlbackend.UnmarshalNamespaceID(graphql.ID(args.Namespace), &opts.NamespaceUserID, &opts.NamespaceOrgID)
@@ -191,6 +220,10 @@ func (r *Resolver) BatchChange(ctx context.Context, args *graphqlbackend.BatchCh
 		return nil, err
 	}
 
+	if err := batchChangesReadAccess(ctx, r.store.DatabaseDB(), opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
 	batchChange, err := r.store.GetBatchChange(ctx, opts)
 	if err != nil {
 		if err == store.ErrNoResults {
@@ -483,7 +516,7 @@ func addPublicationStatesToOptions(in *[]graphqlbackend.ChangesetSpecPublication
 }
 
 func (r *Resolver) applyOrCreateBatchChange(ctx context.Context, args *graphqlbackend.ApplyBatchChangeArgs, opts service.ApplyBatchChangeOpts) (*btypes.BatchChange, error) {
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -634,7 +667,7 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -678,7 +711,7 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -707,11 +740,20 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 }
 
 func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBatchChangesArgs) (graphqlbackend.BatchChangesConnectionResolver, error) {
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
-		return nil, err
+	opts := store.ListBatchChangesOpts{}
+
+	if args.Namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	opts := store.ListBatchChangesOpts{}
+	// 🚨 SECURITY: Listing the batch changes of an org requires the permission within the
+	// org, listing all batch changes requires it globally.
+	if err := batchChangesReadAccess(ctx, r.store.DatabaseDB(), opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
 
 	state, err := parseBatchChangeState(args.State)
 	if err != nil {
@@ -758,13 +800,6 @@ func (r *Resolver) BatchChanges(ctx context.Context, args *graphqlbackend.ListBa
 		opts.ExcludeDraftsNotOwnedByUserID = actor.UID
 	}
 
-	if args.Namespace != nil {
-		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	if args.Repo != nil {
 		repoID, err := graphqlbackend.UnmarshalRepositoryID(*args.Repo)
 		if err != nil {
@@ -953,7 +988,7 @@ func (r *Resolver) CloseBatchChange(ctx context.Context, args *graphqlbackend.Cl
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -987,7 +1022,7 @@ func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncC
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1015,7 +1050,7 @@ func (r *Resolver) ReenqueueChangeset(ctx context.Context, args *graphqlbackend.
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1167,7 +1202,7 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud || externalServiceType == extsvc.TypeAzureDevOps || externalServiceType == extsvc.TypeGerrit {
 		a = &extsvcauth.BasicAuthWithSSH{
 			BasicAuth:  extsvcauth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -1256,7 +1291,7 @@ func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.De
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1291,7 +1326,7 @@ func (r *Resolver) CreateChangesetComments(ctx context.Context, args *graphqlbac
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1335,7 +1370,7 @@ func (r *Resolver) ReenqueueChangesets(ctx context.Context, args *graphqlbackend
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1370,7 +1405,7 @@ func (r *Resolver) MergeChangesets(ctx context.Context, args *graphqlbackend.Mer
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1408,7 +1443,7 @@ func (r *Resolver) CloseChangesets(ctx context.Context, args *graphqlbackend.Clo
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1445,7 +1480,7 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1478,7 +1513,7 @@ func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatc
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesReadAccess(ctx, r.store.DatabaseDB(), 0); err != nil {
 		return nil, err
 	}
 
@@ -1525,7 +1560,7 @@ func (r *Resolver) CreateEmptyBatchChange(ctx context.Context, args *graphqlback
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1559,7 +1594,7 @@ func (r *Resolver) UpsertEmptyBatchChange(ctx context.Context, args *graphqlback
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1628,7 +1663,7 @@ func (r *Resolver) ExecuteBatchSpec(ctx context.Context, args *graphqlbackend.Ex
 		tr.SetError(err)
 		tr.Finish()
 	}()
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1666,7 +1701,7 @@ func (r *Resolver) CancelBatchSpecExecution(ctx context.Context, args *graphqlba
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1697,7 +1732,7 @@ func (r *Resolver) RetryBatchSpecWorkspaceExecution(ctx context.Context, args *g
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1736,7 +1771,7 @@ func (r *Resolver) ReplaceBatchSpecInput(ctx context.Context, args *graphqlbacke
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -1824,7 +1859,7 @@ func (r *Resolver) RetryBatchSpecExecution(ctx context.Context, args *graphqlbac
 		tr.Finish()
 	}()
 
-	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+	if err := batchChangesWriteAccess(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
 	}
 
@@ -18,6 +18,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/rbac/rbactest"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -30,6 +32,10 @@ func insertTestUser(t *testing.T, db database.DB, name string, isAdmin bool) *ty
 	err = db.Users().SetIsSiteAdmin(context.Background(), u.ID, isAdmin)
 	require.NoError(t, err)
 
+	// Like on a real instance, all users are granted the permissions of code
+	// monitors.
+	rbactest.GrantToDefaultRole(t, db, rbac.CodeMonitorsNamespace)
+
 	return u
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -58,6 +59,11 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 }
 
 func (r *Resolver) Monitors(ctx context.Context, userID int32, args *graphqlbackend.ListMonitorsArgs) (graphqlbackend.MonitorConnectionResolver, error) {
+	// 🚨 SECURITY: Only users that are granted the permission may view code monitors.
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsNamespace, rbac.ReadAction); err != nil {
+		return nil, err
+	}
+
 	// Request one extra to determine if there are more pages
 	newArgs := *args
 	newArgs.First += 1
@@ -99,7 +105,7 @@ func (r *Resolver) Monitors(ctx context.Context, userID int32, args *graphqlback
 }
 
 func (r *Resolver) MonitorByID(ctx context.Context, id graphql.ID) (graphqlbackend.MonitorResolver, error) {
-	err := r.isAllowedToEdit(ctx, id)
+	err := r.isAllowedToRead(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -647,6 +653,22 @@ func (r *Resolver) transact(ctx context.Context) (*Resolver, error) {
 	}, nil
 }
 
+// isAllowedToRead checks whether an actor is allowed to view a given monitor.
+func (r *Resolver) isAllowedToRead(ctx context.Context, id graphql.ID) error {
+	monitorID, err := unmarshalMonitorID(id)
+	if err != nil {
+		return err
+	}
+	owner, err := r.ownerForID64(ctx, monitorID)
+	if err != nil {
+		return err
+	}
+	if err := r.isOwner(ctx, owner); err != nil {
+		return err
+	}
+	return rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsNamespace, rbac.ReadAction)
+}
+
 // isAllowedToEdit checks whether an actor is allowed to edit a given monitor.
 func (r *Resolver) isAllowedToEdit(ctx context.Context, id graphql.ID) error {
 	monitorID, err := unmarshalMonitorID(id)
@@ -660,13 +682,24 @@ func (r *Resolver) isAllowedToEdit(ctx context.Context, id graphql.ID) error {
 	return r.isAllowedToCreate(ctx, owner)
 }
 
-// isAllowedToCreate compares the owner of a monitor (user or org) to the actor of
-// the request. A user can create a monitor if either of the following statements
-// is true:
+// isAllowedToCreate checks whether an actor is allowed to create a monitor owned
+// by the given namespace. The actor has to be the owner and be granted the
+// permission to write code monitors. Monitors can only be owned by users, so
+// permissions granted within an org don't apply to them.
+func (r *Resolver) isAllowedToCreate(ctx context.Context, owner graphql.ID) error {
+	if err := r.isOwner(ctx, owner); err != nil {
+		return err
+	}
+	return rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.CodeMonitorsNamespace, rbac.WriteAction)
+}
+
+// isOwner compares the owner of a monitor (user or org) to the actor of
+// the request. The actor is considered the owner if either of the following
+// statements is true:
 // - she is the owner
 // - she is a member of the organization which is the owner of the monitor
 // - she is a site-admin
-func (r *Resolver) isAllowedToCreate(ctx context.Context, owner graphql.ID) error {
+func (r *Resolver) isOwner(ctx context.Context, owner graphql.ID) error {
 	var ownerInt32 int32
 	err := relay.UnmarshalSpec(owner, &ownerInt32)
 	if err != nil {
@@ -505,6 +505,30 @@ func (r *searchContextResolver) Repositories(ctx context.Context) ([]graphqlback
 	return searchContextRepositories, nil
 }
 
+func (r *searchContextResolver) QueryRepositories(ctx context.Context) ([]graphqlbackend.SearchContextRepositoryRevisionsResolver, error) {
+	if searchcontexts.IsAutoDefinedSearchContext(r.sc) || r.sc.Query == "" {
+		return []graphqlbackend.SearchContextRepositoryRevisionsResolver{}, nil
+	}
+
+	repoRevs, err := r.db.SearchContexts().GetSearchContextQueryRepositoryRevisions(ctx, r.sc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	searchContextRepositories := make([]graphqlbackend.SearchContextRepositoryRevisionsResolver, len(repoRevs))
+	for idx, repoRev := range repoRevs {
+		searchContextRepositories[idx] = &searchContextRepositoryRevisionsResolver{graphqlbackend.NewRepositoryResolver(r.db, gitserver.NewClient(r.db), repoRev.Repo.ToRepo()), repoRev.Revisions}
+	}
+	return searchContextRepositories, nil
+}
+
+func (r *searchContextResolver) QueryRepositoriesUpdatedAt() *gqlutil.DateTime {
+	if r.sc.QueryReposUpdatedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.sc.QueryReposUpdatedAt}
+}
+
 func (r *searchContextResolver) Query() string {
 	return r.sc.Query
 }
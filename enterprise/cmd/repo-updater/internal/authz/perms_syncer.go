@@ -918,6 +918,14 @@ func (s *PermsSyncer) syncPerms(ctx context.Context, syncGroups map[requestType]
 			} else {
 				logger.Debug("succeeded in syncing permissions",
 					providerStates.SummaryField())
+
+				sync := permssync.CompletedSync{CompletedAt: s.clock()}
+				if request.Type == requestTypeUser {
+					sync.UserID = request.ID
+				} else {
+					sync.RepoID = api.RepoID(request.ID)
+				}
+				permssync.EnqueueCompletedSync(ctx, logger, s.db, sync)
 			}
 
 			s.collectQueueSize()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/azuredevops"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
@@ -63,6 +64,7 @@ func ProvidersFromConfig(
 			extsvc.KindBitbucketServer,
 			extsvc.KindBitbucketCloud,
 			extsvc.KindPerforce,
+			extsvc.KindAzureDevOps,
 		},
 		LimitOffset: &database.LimitOffset{
 			Limit: 500, // The number is randomly chosen
@@ -75,6 +77,7 @@ func ProvidersFromConfig(
 		bitbucketServerConns []*types.BitbucketServerConnection
 		perforceConns        []*types.PerforceConnection
 		bitbucketCloudConns  []*types.BitbucketCloudConnection
+		azureDevOpsConns     []*types.AzureDevOpsConnection
 	)
 	for {
 		svcs, err := store.List(ctx, opt)
@@ -129,6 +132,11 @@ func ProvidersFromConfig(
 					URN:                svc.URN(),
 					PerforceConnection: c,
 				})
+			case *schema.AzureDevOpsConnection:
+				azureDevOpsConns = append(azureDevOpsConns, &types.AzureDevOpsConnection{
+					URN:                   svc.URN(),
+					AzureDevOpsConnection: c,
+				})
 			default:
 				logger.Error("ProvidersFromConfig", log.Error(errors.Errorf("unexpected connection type: %T", cfg)))
 				continue
@@ -186,6 +194,14 @@ func ProvidersFromConfig(
 		invalidConnections = append(invalidConnections, bbcloudInvalidConnections...)
 	}
 
+	if len(azureDevOpsConns) > 0 {
+		adoProviders, adoProblems, adoWarnings, adoInvalidConnections := azuredevops.NewAuthzProviders(azureDevOpsConns)
+		providers = append(providers, adoProviders...)
+		seriousProblems = append(seriousProblems, adoProblems...)
+		warnings = append(warnings, adoWarnings...)
+		invalidConnections = append(invalidConnections, adoInvalidConnections...)
+	}
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
 	if cfg.SiteConfig().PermissionsUserMapping != nil &&
 		cfg.SiteConfig().PermissionsUserMapping.Enabled {
@@ -0,0 +1,41 @@
+package azuredevops
+
+import (
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// NewAuthzProviders returns the set of Azure DevOps authz providers derived
+// from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+func NewAuthzProviders(conns []*types.AzureDevOpsConnection) (ps []authz.Provider, problems []string, warnings []string, invalidConnections []string) {
+	for _, c := range conns {
+		// If permissions are not enforced for this connection, we do not need
+		// an authz provider.
+		if !c.EnforcePermissions {
+			continue
+		}
+
+		if err := licensing.Check(licensing.FeatureACLs); err != nil {
+			invalidConnections = append(invalidConnections, extsvc.TypeAzureDevOps)
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		p, err := NewProvider(c)
+		if err != nil {
+			invalidConnections = append(invalidConnections, extsvc.TypeAzureDevOps)
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		ps = append(ps, p)
+	}
+
+	return ps, problems, warnings, invalidConnections
+}
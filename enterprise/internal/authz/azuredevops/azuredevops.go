@@ -0,0 +1,245 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider implements authz.Provider for Azure DevOps. Azure DevOps grants
+// access to repositories through project membership, so a user has access to
+// all repositories of the projects whose groups they are (transitively) a
+// member of.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+	// orgs are the organizations the connection syncs repositories from.
+	orgs []string
+}
+
+// NewProvider returns a new Azure DevOps authz provider for the given
+// connection.
+func NewProvider(conn *types.AzureDevOpsConnection) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := azuredevops.NewClient(conn.URN, conn.AzureDevOpsConnection, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		urn:      conn.URN,
+		client:   cli,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeAzureDevOps),
+		orgs:     orgsFromConnection(conn),
+	}, nil
+}
+
+// orgsFromConnection returns the deduplicated, sorted list of organizations
+// referenced by the "orgs" and "projects" fields of the connection.
+func orgsFromConnection(conn *types.AzureDevOpsConnection) []string {
+	set := make(map[string]struct{})
+	for _, org := range conn.Orgs {
+		set[org] = struct{}{}
+	}
+	for _, project := range conn.Projects {
+		org, _, ok := strings.Cut(project, "/")
+		if !ok {
+			continue
+		}
+		set[org] = struct{}{}
+	}
+
+	orgs := make([]string, 0, len(set))
+	for org := range set {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+	return orgs
+}
+
+// FetchAccount looks up the user on Azure DevOps by their verified email
+// addresses. Azure DevOps assigns a user a different subject descriptor in
+// every organization, so the descriptors of all configured organizations are
+// stored with the account.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.Account, verifiedEmails []string) (*extsvc.Account, error) {
+	for _, email := range verifiedEmails {
+		data := azuredevops.AccountData{
+			Descriptors: make(map[string]string),
+			Email:       email,
+		}
+
+		for _, org := range p.orgs {
+			identities, err := p.client.ListIdentitiesByEmail(ctx, org, email)
+			if err != nil {
+				return nil, errors.Wrapf(err, "listing identities in organization %q", org)
+			}
+			for _, identity := range identities {
+				if !identity.IsActive || identity.SubjectDescriptor == "" {
+					continue
+				}
+				data.Descriptors[org] = identity.SubjectDescriptor
+				if data.DisplayName == "" {
+					data.DisplayName = identity.ProviderDisplayName
+				}
+				break
+			}
+		}
+
+		if len(data.Descriptors) == 0 {
+			continue
+		}
+
+		acctData, err := jsoniter.Marshal(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshaling account data")
+		}
+
+		return &extsvc.Account{
+			UserID: user.ID,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: p.codeHost.ServiceType,
+				ServiceID:   p.codeHost.ServiceID,
+				AccountID:   email,
+			},
+			AccountData: extsvc.AccountData{
+				Data: extsvc.NewUnencryptedData(acctData),
+			},
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// FetchUserPerms returns the IDs of all repositories in the projects the given
+// account is a member of, either directly or through nested groups.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if !extsvc.IsHostOfAccount(p.codeHost, account) {
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	data, err := azuredevops.GetExternalAccountData(ctx, &account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting external account data")
+	} else if data == nil {
+		return nil, errors.New("no account data provided")
+	}
+
+	perms := &authz.ExternalUserPermissions{}
+	for _, org := range p.orgs {
+		descriptor, ok := data.Descriptors[org]
+		if !ok {
+			continue
+		}
+
+		projectIDs, err := p.projectIDsForSubject(ctx, org, descriptor)
+		if err != nil {
+			return perms, err
+		}
+
+		for _, projectID := range projectIDs {
+			repos, err := p.client.ListRepositoriesByProjectOrOrg(ctx, azuredevops.ListRepositoriesByProjectOrOrgArgs{
+				ProjectOrOrgName: fmt.Sprintf("%s/%s", org, projectID),
+			})
+			if err != nil {
+				return perms, errors.Wrapf(err, "listing repositories of project %q", projectID)
+			}
+			for _, repo := range repos {
+				perms.Exacts = append(perms.Exacts, extsvc.RepoID(repo.ID))
+			}
+		}
+	}
+
+	return perms, nil
+}
+
+// projectIDsForSubject walks the group memberships of the given subject
+// upwards and returns the IDs of the projects any of its groups are scoped to.
+func (p *Provider) projectIDsForSubject(ctx context.Context, org, descriptor string) ([]string, error) {
+	var projectIDs []string
+	seenProjects := make(map[string]struct{})
+	seenGroups := make(map[string]struct{})
+
+	queue := []string{descriptor}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		memberships, err := p.client.ListGroupMemberships(ctx, org, current)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing group memberships in organization %q", org)
+		}
+
+		for _, m := range memberships {
+			if _, ok := seenGroups[m.ContainerDescriptor]; ok {
+				continue
+			}
+			seenGroups[m.ContainerDescriptor] = struct{}{}
+			queue = append(queue, m.ContainerDescriptor)
+
+			group, err := p.client.GetGroup(ctx, org, m.ContainerDescriptor)
+			if err != nil {
+				return nil, errors.Wrapf(err, "getting group in organization %q", org)
+			}
+			projectID := group.ProjectID()
+			if projectID == "" {
+				continue
+			}
+			if _, ok := seenProjects[projectID]; ok {
+				continue
+			}
+			seenProjects[projectID] = struct{}{}
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+
+	return projectIDs, nil
+}
+
+// FetchRepoPerms is not implemented for Azure DevOps, since listing the members
+// of a project requires walking all of its groups.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "azuredevops.FetchRepoPerms"}
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ValidateConnection validates that the configured credentials can be used to
+// talk to Azure DevOps.
+func (p *Provider) ValidateConnection(ctx context.Context) (warnings []string) {
+	if _, err := p.client.GetAuthorizedProfile(ctx); err != nil {
+		return []string{
+			fmt.Sprintf("Unable to authenticate with Azure DevOps: %v", err),
+		}
+	}
+
+	return []string{}
+}
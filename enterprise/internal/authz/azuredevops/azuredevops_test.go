@@ -0,0 +1,199 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestOrgsFromConnection(t *testing.T) {
+	conn := &types.AzureDevOpsConnection{
+		AzureDevOpsConnection: &schema.AzureDevOpsConnection{
+			Orgs:     []string{"solarsystem", "milkyway"},
+			Projects: []string{"solarsystem/earth", "andromeda/m31", "invalid"},
+		},
+	}
+
+	want := []string{"andromeda", "milkyway", "solarsystem"}
+	if diff := cmp.Diff(want, orgsFromConnection(conn)); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	userEmail := "test-email@example.com"
+
+	testCases := []struct {
+		name            string
+		client          mockClient
+		wantAccount     bool
+		wantDescriptors map[string]string
+	}{
+		{
+			name: "no identity found",
+			client: mockClient{
+				mockListIdentitiesByEmail: func(ctx context.Context, org, email string) ([]azuredevops.IdentityRecord, error) {
+					return nil, nil
+				},
+			},
+			wantAccount: false,
+		},
+		{
+			name: "identity found in one organization",
+			client: mockClient{
+				mockListIdentitiesByEmail: func(ctx context.Context, org, email string) ([]azuredevops.IdentityRecord, error) {
+					if org != "solarsystem" {
+						return nil, nil
+					}
+					return []azuredevops.IdentityRecord{
+						{ID: "1", SubjectDescriptor: "inactive", IsActive: false},
+						{ID: "2", SubjectDescriptor: "aad.earth", ProviderDisplayName: "Test User", IsActive: true},
+					}, nil
+				},
+			},
+			wantAccount:     true,
+			wantDescriptors: map[string]string{"solarsystem": "aad.earth"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestProvider(&tc.client)
+			user := types.User{ID: 42, Username: "test-user"}
+
+			acct, err := p.FetchAccount(context.Background(), &user, nil, []string{userEmail})
+			if err != nil {
+				t.Fatalf("error fetching account: %s", err)
+			}
+			if !tc.wantAccount {
+				if acct != nil {
+					t.Fatalf("expected no account, got %+v", acct)
+				}
+				return
+			}
+			if acct == nil {
+				t.Fatal("account was nil")
+			}
+			if acct.AccountID != userEmail {
+				t.Fatalf("unexpected account ID: %q", acct.AccountID)
+			}
+
+			data, err := azuredevops.GetExternalAccountData(context.Background(), &acct.AccountData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantDescriptors, data.Descriptors); diff != "" {
+				t.Fatalf("descriptors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	// The user is a direct member of the "Earth" team, which is nested in the
+	// project-scoped "Contributors" group. The user is also a member of an
+	// organization-wide group, which isn't scoped to a project.
+	memberships := map[string][]azuredevops.GraphMembership{
+		"aad.user": {
+			{ContainerDescriptor: "vssgp.earth-team", MemberDescriptor: "aad.user"},
+			{ContainerDescriptor: "vssgp.org-wide", MemberDescriptor: "aad.user"},
+		},
+		"vssgp.earth-team": {
+			{ContainerDescriptor: "vssgp.contributors", MemberDescriptor: "vssgp.earth-team"},
+		},
+		"vssgp.contributors": {
+			// Cycles must not cause an endless loop.
+			{ContainerDescriptor: "vssgp.earth-team", MemberDescriptor: "vssgp.contributors"},
+		},
+	}
+	groups := map[string]azuredevops.GraphGroup{
+		"vssgp.earth-team":   {Descriptor: "vssgp.earth-team", Domain: "vstfs:///Classification/TeamProject/earth-id"},
+		"vssgp.contributors": {Descriptor: "vssgp.contributors", Domain: "vstfs:///Classification/TeamProject/earth-id"},
+		"vssgp.org-wide":     {Descriptor: "vssgp.org-wide", Domain: "vstfs:///Framework/IdentityDomain/org-id"},
+	}
+
+	client := &mockClient{
+		mockListGroupMemberships: func(ctx context.Context, org, subjectDescriptor string) ([]azuredevops.GraphMembership, error) {
+			return memberships[subjectDescriptor], nil
+		},
+		mockGetGroup: func(ctx context.Context, org, descriptor string) (azuredevops.GraphGroup, error) {
+			g, ok := groups[descriptor]
+			if !ok {
+				return azuredevops.GraphGroup{}, errors.Newf("unknown group %q", descriptor)
+			}
+			return g, nil
+		},
+		mockListRepositoriesByProjectOrOrg: func(ctx context.Context, opts azuredevops.ListRepositoriesByProjectOrOrgArgs) ([]azuredevops.Repository, error) {
+			if opts.ProjectOrOrgName != "solarsystem/earth-id" {
+				return nil, errors.Newf("unexpected project %q", opts.ProjectOrOrgName)
+			}
+			return []azuredevops.Repository{{ID: "repo-1"}, {ID: "repo-2"}}, nil
+		},
+	}
+
+	p := NewTestProvider(client)
+
+	data, err := json.Marshal(azuredevops.AccountData{
+		Descriptors: map[string]string{"solarsystem": "aad.user"},
+		Email:       "test-email@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account := &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   "test-email@example.com",
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(data),
+		},
+	}
+
+	perms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Slice(perms.Exacts, func(i, j int) bool { return perms.Exacts[i] < perms.Exacts[j] })
+	want := []extsvc.RepoID{"repo-1", "repo-2"}
+	if diff := cmp.Diff(want, perms.Exacts); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	p := NewTestProvider(&mockClient{
+		mockGetAuthorizedProfile: func(ctx context.Context) (azuredevops.Profile, error) {
+			return azuredevops.Profile{}, errors.New("fake error")
+		},
+	})
+
+	want := []string{"Unable to authenticate with Azure DevOps: fake error"}
+	if diff := cmp.Diff(want, p.ValidateConnection(context.Background())); diff != "" {
+		t.Fatalf("warnings did not match: %s", diff)
+	}
+}
+
+func NewTestProvider(client client) *Provider {
+	baseURL, _ := url.Parse("https://dev.azure.com")
+	return &Provider{
+		urn:      "AzureDevOps",
+		client:   client,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeAzureDevOps),
+		orgs:     []string{"solarsystem"},
+	}
+}
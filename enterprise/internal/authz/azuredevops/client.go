@@ -0,0 +1,60 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+type client interface {
+	GetAuthorizedProfile(ctx context.Context) (azuredevops.Profile, error)
+	ListIdentitiesByEmail(ctx context.Context, org, email string) ([]azuredevops.IdentityRecord, error)
+	ListGroupMemberships(ctx context.Context, org, subjectDescriptor string) ([]azuredevops.GraphMembership, error)
+	GetGroup(ctx context.Context, org, descriptor string) (azuredevops.GraphGroup, error)
+	ListRepositoriesByProjectOrOrg(ctx context.Context, opts azuredevops.ListRepositoriesByProjectOrOrgArgs) ([]azuredevops.Repository, error)
+}
+
+var _ client = (*azuredevops.Client)(nil)
+
+type mockClient struct {
+	mockGetAuthorizedProfile           func(ctx context.Context) (azuredevops.Profile, error)
+	mockListIdentitiesByEmail          func(ctx context.Context, org, email string) ([]azuredevops.IdentityRecord, error)
+	mockListGroupMemberships           func(ctx context.Context, org, subjectDescriptor string) ([]azuredevops.GraphMembership, error)
+	mockGetGroup                       func(ctx context.Context, org, descriptor string) (azuredevops.GraphGroup, error)
+	mockListRepositoriesByProjectOrOrg func(ctx context.Context, opts azuredevops.ListRepositoriesByProjectOrOrgArgs) ([]azuredevops.Repository, error)
+}
+
+func (m *mockClient) GetAuthorizedProfile(ctx context.Context) (azuredevops.Profile, error) {
+	if m.mockGetAuthorizedProfile != nil {
+		return m.mockGetAuthorizedProfile(ctx)
+	}
+	return azuredevops.Profile{}, nil
+}
+
+func (m *mockClient) ListIdentitiesByEmail(ctx context.Context, org, email string) ([]azuredevops.IdentityRecord, error) {
+	if m.mockListIdentitiesByEmail != nil {
+		return m.mockListIdentitiesByEmail(ctx, org, email)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) ListGroupMemberships(ctx context.Context, org, subjectDescriptor string) ([]azuredevops.GraphMembership, error) {
+	if m.mockListGroupMemberships != nil {
+		return m.mockListGroupMemberships(ctx, org, subjectDescriptor)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) GetGroup(ctx context.Context, org, descriptor string) (azuredevops.GraphGroup, error) {
+	if m.mockGetGroup != nil {
+		return m.mockGetGroup(ctx, org, descriptor)
+	}
+	return azuredevops.GraphGroup{}, nil
+}
+
+func (m *mockClient) ListRepositoriesByProjectOrOrg(ctx context.Context, opts azuredevops.ListRepositoriesByProjectOrOrgArgs) ([]azuredevops.Repository, error) {
+	if m.mockListRepositoriesByProjectOrOrg != nil {
+		return m.mockListRepositoriesByProjectOrOrg(ctx, opts)
+	}
+	return nil, nil
+}
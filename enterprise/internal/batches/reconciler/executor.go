@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
@@ -186,7 +187,7 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
+	opts := buildCommitOpts(e.targetRepo, e.spec, e.ch, pushConf)
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
@@ -650,7 +651,7 @@ func (e *executor) enqueueWebhook(ctx context.Context, eventType string) {
 	webhooks.EnqueueChangeset(ctx, e.logger, e.tx, eventType, e.ch)
 }
 
-func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
+func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, ch *btypes.Changeset, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
 	// IMPORTANT: We add a trailing newline here, otherwise `git apply`
 	// will fail with "corrupt patch at line <N>" where N is the last line.
 	patch := append([]byte{}, spec.Diff...)
@@ -680,6 +681,15 @@ func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *pro
 		Push:         pushOpts,
 	}
 
+	// Gerrit doesn't have branches for changes. Instead, a commit is pushed
+	// to the magic refs/for/<branch> ref, and the Change-Id trailer of the
+	// commit message determines whether it creates a new change or a new
+	// patch set of an existing one.
+	if repo.ExternalRepo.ServiceType == extsvc.TypeGerrit {
+		opts.TargetRef = "refs/for/" + strings.TrimPrefix(spec.BaseRef, "refs/heads/")
+		opts.CommitInfo.Message = sources.AppendGerritChangeID(spec.CommitMessage, sources.GerritChangeID(ch))
+	}
+
 	return opts
 }
 
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -921,6 +922,9 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
 		return nil, err
 	}
+	if err := checkWritePermission(ctx, s.store.DatabaseDB(), batchChange.NamespaceOrgID); err != nil {
+		return nil, err
+	}
 	// Check if current user has access to target namespace if set.
 	if opts.NewNamespaceOrgID != 0 || opts.NewNamespaceUserID != 0 {
 		err = s.CheckNamespaceAccess(ctx, opts.NewNamespaceUserID, opts.NewNamespaceOrgID)
@@ -961,6 +965,9 @@ func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangeset
 	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
 		return nil, err
 	}
+	if err := checkWritePermission(ctx, s.store.DatabaseDB(), batchChange.NamespaceOrgID); err != nil {
+		return nil, err
+	}
 
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
@@ -1004,6 +1011,9 @@ func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
 		return err
 	}
+	if err := checkWritePermission(ctx, s.store.DatabaseDB(), batchChange.NamespaceOrgID); err != nil {
+		return err
+	}
 
 	s.enqueueBatchChangeWebhook(ctx, webhooks.BatchChangeDelete, batchChange)
 	return s.store.DeleteBatchChange(ctx, id)
@@ -1041,6 +1051,9 @@ func (s *Service) EnqueueChangesetSync(ctx context.Context, id int64) (err error
 
 	for _, c := range batchChanges {
 		err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), c.CreatorID)
+		if err == nil {
+			err = checkWritePermission(ctx, s.store.DatabaseDB(), c.NamespaceOrgID)
+		}
 		if err != nil {
 			authErr = err
 		} else {
@@ -1092,6 +1105,9 @@ func (s *Service) ReenqueueChangeset(ctx context.Context, id int64) (changeset *
 
 	for _, c := range attachedBatchChanges {
 		err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), c.CreatorID)
+		if err == nil {
+			err = checkWritePermission(ctx, s.store.DatabaseDB(), c.NamespaceOrgID)
+		}
 		if err != nil {
 			authErr = err
 		} else {
@@ -1141,12 +1157,25 @@ func (s *Service) CanAdministerInNamespace(ctx context.Context, namespaceUserID,
 
 func (s *Service) checkNamespaceAccessWithDB(ctx context.Context, db database.DB, namespaceUserID, namespaceOrgID int32) (err error) {
 	if namespaceOrgID != 0 {
-		return auth.CheckOrgAccessOrSiteAdmin(ctx, db, namespaceOrgID)
+		err = auth.CheckOrgAccessOrSiteAdmin(ctx, db, namespaceOrgID)
 	} else if namespaceUserID != 0 {
-		return auth.CheckSiteAdminOrSameUser(ctx, db, namespaceUserID)
+		err = auth.CheckSiteAdminOrSameUser(ctx, db, namespaceUserID)
 	} else {
 		return ErrNoNamespace
 	}
+	if err != nil {
+		return err
+	}
+	return checkWritePermission(ctx, db, namespaceOrgID)
+}
+
+// checkWritePermission returns an error if the current user isn't granted the
+// permission to write batch changes, either globally or within the org that
+// owns the batch change. namespaceOrgID is zero for batch changes owned by a
+// user, which only global grants apply to.
+func checkWritePermission(ctx context.Context, db database.DB, namespaceOrgID int32) error {
+	// 🚨 SECURITY: Only users that are granted the permission may modify batch changes.
+	return rbac.CheckCurrentUserHasOrgPermission(ctx, db, namespaceOrgID, rbac.BatchChangesNamespace, rbac.WriteAction)
 }
 
 // ErrNoNamespace is returned by checkNamespaceAccess if no valid namespace ID is given.
@@ -1245,6 +1274,9 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
 		return bulkGroupID, err
 	}
+	if err := checkWritePermission(ctx, s.store.DatabaseDB(), batchChange.NamespaceOrgID); err != nil {
+		return bulkGroupID, err
+	}
 
 	// Construct list options.
 	opts := listOpts
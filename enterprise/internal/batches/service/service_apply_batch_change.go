@@ -68,6 +68,9 @@ func (s *Service) ApplyBatchChange(
 	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchSpec.UserID); err != nil {
 		return nil, err
 	}
+	if err := checkWritePermission(ctx, s.store.DatabaseDB(), batchSpec.NamespaceOrgID); err != nil {
+		return nil, err
+	}
 
 	// Validate ChangesetSpecs and return error if they're invalid and the
 	// BatchSpec can't be applied safely.
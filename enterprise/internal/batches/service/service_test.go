@@ -29,8 +29,10 @@ import (
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -3100,3 +3102,30 @@ func assertNoAuthError(t *testing.T, err error) {
 		t.Fatalf("got auth error")
 	}
 }
+
+func TestCheckNamespaceAccessOrgPermission(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	orgMembers := database.NewMockOrgMemberStore()
+	orgMembers.GetByOrgIDAndUserIDFunc.SetDefaultReturn(&types.OrgMembership{OrgID: 2, UserID: 1}, nil)
+	permissions := database.NewMockPermissionStore()
+	// The user is only granted the permission within org 2.
+	permissions.ListForUserFunc.SetDefaultReturn([]*types.GrantedPermission{
+		{Namespace: rbac.BatchChangesNamespace, Action: rbac.WriteAction, OrgID: 2},
+	}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.OrgMembersFunc.SetDefaultReturn(orgMembers)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	svc := &Service{}
+
+	assert.NoError(t, svc.checkNamespaceAccessWithDB(ctx, db, 0, 2))
+	assert.Equal(t,
+		&rbac.ErrNotAuthorized{Namespace: rbac.BatchChangesNamespace, Action: rbac.WriteAction},
+		svc.checkNamespaceAccessWithDB(ctx, db, 0, 3))
+	assert.Equal(t,
+		&rbac.ErrNotAuthorized{Namespace: rbac.BatchChangesNamespace, Action: rbac.WriteAction},
+		svc.checkNamespaceAccessWithDB(ctx, db, 1, 0))
+}
@@ -0,0 +1,351 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOpsSource struct {
+	client *azuredevops.Client
+}
+
+var (
+	_ ChangesetSource      = AzureDevOpsSource{}
+	_ DraftChangesetSource = AzureDevOpsSource{}
+)
+
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := azuredevops.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Azure DevOps client")
+	}
+
+	return &AzureDevOpsSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AzureDevOpsSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AzureDevOpsSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AzureDevOpsSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s AzureDevOpsSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthorizedProfile(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AzureDevOpsSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, args)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args.OrgProjectRepoArgs, &pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, false)
+}
+
+// CreateDraftChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AzureDevOpsSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, true)
+}
+
+func (s AzureDevOpsSource) createChangeset(ctx context.Context, cs *Changeset, draft bool) (bool, error) {
+	args, err := s.orgProjectRepoArgs(cs)
+	if err != nil {
+		return false, err
+	}
+
+	input := s.changesetToPullRequestInput(cs)
+	input.IsDraft = draft
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, args, input)
+	if err != nil {
+		// Azure DevOps responds with a 409 Conflict if there is already an
+		// active pull request for the source and target branch.
+		if azuredevops.HTTPErrorCode(err) != http.StatusConflict {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		existing, err := s.findActivePullRequest(ctx, args, input)
+		if err != nil {
+			return false, err
+		}
+		pr = *existing
+		exists = true
+	}
+
+	if err := s.setChangesetMetadata(ctx, args, &pr, cs); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (s AzureDevOpsSource) findActivePullRequest(ctx context.Context, args azuredevops.OrgProjectRepoArgs, input azuredevops.CreatePullRequestInput) (*azuredevops.PullRequest, error) {
+	prs, err := s.client.ListPullRequests(ctx, args, azuredevops.ListPullRequestsArgs{
+		SourceRefName: input.SourceRefName,
+		TargetRefName: input.TargetRefName,
+		Status:        azuredevops.PullRequestStatusActive,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pull requests")
+	}
+	if len(prs) == 0 {
+		return nil, errors.New("pull request already exists but could not be found")
+	}
+
+	return &prs[0], nil
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s AzureDevOpsSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	isDraft := false
+	updated, err := s.client.UpdatePullRequest(ctx, args, azuredevops.PullRequestUpdateInput{
+		IsDraft: &isDraft,
+	})
+	if err != nil {
+		return errors.Wrap(err, "undrafting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args.OrgProjectRepoArgs, &updated, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Azure DevOps).
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.client.AbandonPullRequest(ctx, args)
+	if err != nil {
+		return errors.Wrap(err, "abandoning pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args.OrgProjectRepoArgs, &updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	input := s.changesetToPullRequestInput(cs)
+	updated, err := s.client.UpdatePullRequest(ctx, args, azuredevops.PullRequestUpdateInput{
+		Title:         &input.Title,
+		Description:   &input.Description,
+		TargetRefName: &input.TargetRefName,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args.OrgProjectRepoArgs, &updated, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.client.ReactivatePullRequest(ctx, args)
+	if err != nil {
+		return errors.Wrap(err, "reactivating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, args.OrgProjectRepoArgs, &updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AzureDevOpsSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.CreatePullRequestCommentThread(ctx, args, azuredevops.PullRequestCommentInput{
+		Comments: []azuredevops.PullRequestCommentForInput{
+			{
+				ParentCommentID: 0,
+				Content:         comment,
+				CommentType:     1,
+			},
+		},
+	})
+	return err
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	args, err := s.pullRequestCommonArgs(cs)
+	if err != nil {
+		return err
+	}
+
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	var mergeStrategy *azuredevops.PullRequestMergeStrategy
+	if squash {
+		ms := azuredevops.PullRequestMergeStrategySquash
+		mergeStrategy = &ms
+	}
+
+	updated, err := s.client.CompletePullRequest(ctx, args, azuredevops.PullRequestCompleteInput{
+		CommitID:      pr.LastMergeSourceCommit.CommitID,
+		MergeStrategy: mergeStrategy,
+	})
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "merging pull request")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.setChangesetMetadata(ctx, args.OrgProjectRepoArgs, &updated, cs)
+}
+
+func (s AzureDevOpsSource) annotatePullRequest(ctx context.Context, args azuredevops.OrgProjectRepoArgs, pr *azuredevops.PullRequest) (*adobatches.AnnotatedPullRequest, error) {
+	statuses, err := s.client.GetPullRequestStatuses(ctx, azuredevops.PullRequestCommonArgs{
+		PullRequestID:      strconv.Itoa(pr.ID),
+		OrgProjectRepoArgs: args,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pull request statuses")
+	}
+
+	return &adobatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Statuses:    statuses,
+	}, nil
+}
+
+func (s AzureDevOpsSource) setChangesetMetadata(ctx context.Context, args azuredevops.OrgProjectRepoArgs, pr *azuredevops.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, args, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+func (s AzureDevOpsSource) orgProjectRepoArgs(cs *Changeset) (azuredevops.OrgProjectRepoArgs, error) {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	args, err := repo.OrgProjectRepoArgs()
+	if err != nil {
+		return azuredevops.OrgProjectRepoArgs{}, errors.Wrap(err, "getting repository arguments")
+	}
+	return args, nil
+}
+
+func (s AzureDevOpsSource) pullRequestCommonArgs(cs *Changeset) (azuredevops.PullRequestCommonArgs, error) {
+	args, err := s.orgProjectRepoArgs(cs)
+	if err != nil {
+		return azuredevops.PullRequestCommonArgs{}, err
+	}
+	return azuredevops.PullRequestCommonArgs{
+		PullRequestID:      cs.ExternalID,
+		OrgProjectRepoArgs: args,
+	}, nil
+}
+
+func (s AzureDevOpsSource) changesetToPullRequestInput(cs *Changeset) azuredevops.CreatePullRequestInput {
+	return azuredevops.CreatePullRequestInput{
+		Title:         cs.Title,
+		Description:   cs.Body,
+		SourceRefName: gitdomain.EnsureRefPrefix(cs.HeadRef),
+		TargetRefName: gitdomain.EnsureRefPrefix(cs.BaseRef),
+	}
+}
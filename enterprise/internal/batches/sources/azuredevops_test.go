@@ -0,0 +1,209 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// The test fixtures and golden files were generated against the sgtestazure
+// organization on dev.azure.com.
+var testAzureDevOpsRepo = &types.Repo{
+	Metadata: &azuredevops.Repository{
+		ID:     "c4d186ef-18a6-4de4-a610-aa9ebd4e1faa",
+		Name:   "sgtestazure",
+		APIURL: "https://dev.azure.com/sgtestazure/e1e4e2a0-3b0c-4bcd-8a1e-5c3b6c7dd1a0/_apis/git/repositories/c4d186ef-18a6-4de4-a610-aa9ebd4e1faa",
+		Project: azuredevops.Project{
+			ID:   "e1e4e2a0-3b0c-4bcd-8a1e-5c3b6c7dd1a0",
+			Name: "sgtestazure",
+		},
+	},
+}
+
+func TestAzureDevOpsSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs: &Changeset{
+				RemoteRepo: testAzureDevOpsRepo,
+				TargetRepo: testAzureDevOpsRepo,
+				Changeset:  &btypes.Changeset{ExternalID: "42"},
+			},
+		},
+		{
+			name: "not-found",
+			cs: &Changeset{
+				RemoteRepo: testAzureDevOpsRepo,
+				TargetRepo: testAzureDevOpsRepo,
+				Changeset:  &btypes.Changeset{ExternalID: "100000"},
+			},
+			err: "Changeset with external ID 100000 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AzureDevOpsSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			ctx := context.Background()
+			src := newTestAzureDevOpsSource(t, ctx, cf)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangeset(ctx, tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			meta := tc.cs.Changeset.Metadata.(*adobatches.AnnotatedPullRequest)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), meta)
+		})
+	}
+}
+
+func TestAzureDevOpsSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cs     *Changeset
+		err    string
+		exists bool
+	}{
+		{
+			name: "success",
+			cs: &Changeset{
+				Title:      "This is a test PR",
+				Body:       "This is the body of a test PR",
+				BaseRef:    "master",
+				HeadRef:    "azure-devops-test-2",
+				RemoteRepo: testAzureDevOpsRepo,
+				TargetRepo: testAzureDevOpsRepo,
+				Changeset:  &btypes.Changeset{},
+			},
+		},
+		{
+			name: "already-exists",
+			cs: &Changeset{
+				Title:      "This is a test PR",
+				Body:       "This is the body of a test PR",
+				BaseRef:    "refs/heads/master",
+				HeadRef:    "refs/heads/azure-devops-test",
+				RemoteRepo: testAzureDevOpsRepo,
+				TargetRepo: testAzureDevOpsRepo,
+				Changeset:  &btypes.Changeset{},
+			},
+			// CreateChangeset is idempotent so if the PR already exists
+			// it is not an error
+			exists: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AzureDevOpsSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			ctx := context.Background()
+			src := newTestAzureDevOpsSource(t, ctx, cf)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			exists, err := src.CreateChangeset(ctx, tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if have, want := exists, tc.exists; have != want {
+				t.Errorf("exists:\nhave: %t\nwant: %t", have, want)
+			}
+
+			meta := tc.cs.Changeset.Metadata.(*adobatches.AnnotatedPullRequest)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), meta)
+		})
+	}
+}
+
+func TestAzureDevOpsSource_WithAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	src := newTestAzureDevOpsSource(t, ctx, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{Username: "user", Password: "token"},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "token"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				newSrc, err := src.WithAuthenticator(tc)
+				assert.NoError(t, err)
+				assert.NotNil(t, newSrc)
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":         nil,
+			"OAuthBearer": &auth.OAuthBearerToken{Token: "abcdef"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := src.WithAuthenticator(tc)
+				assert.Error(t, err)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+}
+
+func newTestAzureDevOpsSource(t *testing.T, ctx context.Context, cf *httpcli.Factory) *AzureDevOpsSource {
+	t.Helper()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.AzureDevOpsConnection{
+			Url:      "https://dev.azure.com",
+			Username: os.Getenv("AZURE_DEV_OPS_USERNAME"),
+			Token:    os.Getenv("AZURE_DEV_OPS_TOKEN"),
+		})),
+	}
+
+	src, err := NewAzureDevOpsSource(ctx, svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
@@ -0,0 +1,286 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Unlike other code hosts,
+// Gerrit creates changes when a commit is pushed to refs/for/<branch>, so most
+// of the work of publishing a changeset happens when pushing the commit. See
+// GerritChangeID for how changesets are mapped to changes.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ ChangesetSource      = GerritSource{}
+	_ DraftChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GerritChangeID returns the Change-Id used for the given changeset. Once the
+// changeset has been published, this is its external ID. Before that, a
+// Change-Id is derived from the changeset, so that pushing the same changeset
+// again updates the same change instead of creating a new one.
+func GerritChangeID(cs *btypes.Changeset) string {
+	if cs.ExternalID != "" {
+		return cs.ExternalID
+	}
+
+	h := sha1.New()
+	h.Write([]byte(strconv.FormatInt(int64(cs.RepoID), 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(cs.ID, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(cs.CreatedAt.UTC().String()))
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// AppendGerritChangeID adds a Change-Id trailer to the given commit message,
+// unless it already contains one.
+func AppendGerritChangeID(message, changeID string) string {
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "Change-Id: ") {
+			return message
+		}
+	}
+	return strings.TrimRight(message, "\n") + "\n\nChange-Id: " + changeID + "\n"
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(ctx, change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+//
+// On Gerrit, the change has already been created or updated by pushing the
+// commit, so this only loads it and always reports that it didn't exist.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.getPushedChange(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.setChangesetMetadata(ctx, change, cs); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// CreateDraftChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+//
+// Gerrit calls draft changes "work in progress", so the pushed change is
+// marked as such.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	change, err := s.getPushedChange(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	if !change.WorkInProgress {
+		if err := s.client.SetWorkInProgress(ctx, change.ChangeID); err != nil {
+			return false, errors.Wrap(err, "marking change as work in progress")
+		}
+		if change, err = s.client.GetChange(ctx, change.ChangeID); err != nil {
+			return false, errors.Wrap(err, "getting change")
+		}
+	}
+
+	if err := s.setChangesetMetadata(ctx, change, cs); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (s GerritSource) getPushedChange(ctx context.Context, cs *Changeset) (*gerrit.Change, error) {
+	change, err := s.client.GetChange(ctx, GerritChangeID(cs.Changeset))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, errors.New("change was not created by pushing the commit")
+		}
+		return nil, errors.Wrap(err, "getting change")
+	}
+	return change, nil
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.SetReadyForReview(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets.
+//
+// The subject and description of a Gerrit change are taken from the commit
+// message of its current patch set, which is updated by pushing a new commit.
+// The only thing left to update here is the target branch.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if branch := strings.TrimPrefix(cs.BaseRef, "refs/heads/"); branch != change.Branch {
+		if err := s.client.MoveChange(ctx, cs.ExternalID, branch); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "restoring change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.WriteReviewComment(ctx, cs.ExternalID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit applies the submit type configured for the project, so squash is
+// ignored. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	if err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		// Gerrit responds with a 409 Conflict if the submit requirements of
+		// the change are not satisfied.
+		if gerrit.HTTPErrorCode(err) == http.StatusConflict {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "submitting change")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+func (s GerritSource) annotateChange(ctx context.Context, change *gerrit.Change) (*gerritbatches.AnnotatedChange, error) {
+	reviewers, err := s.client.ListChangeReviewers(ctx, change.ChangeID)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing reviewers")
+	}
+
+	return &gerritbatches.AnnotatedChange{
+		Change:      change,
+		Reviewers:   reviewers,
+		CodeHostURL: s.client.URL.String(),
+	}, nil
+}
+
+func (s GerritSource) setChangesetMetadata(ctx context.Context, change *gerrit.Change, cs *Changeset) error {
+	ac, err := s.annotateChange(ctx, change)
+	if err != nil {
+		return errors.Wrap(err, "annotating change")
+	}
+
+	if err := cs.SetMetadata(ac); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
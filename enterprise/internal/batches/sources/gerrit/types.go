@@ -0,0 +1,53 @@
+package gerrit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	Reviewers []gerrit.Reviewer
+	// CodeHostURL is the base URL of the Gerrit instance, which is required
+	// to build the web URL of the change.
+	CodeHostURL string
+}
+
+// WebURL returns the URL of the change in the Gerrit web UI.
+func (c *AnnotatedChange) WebURL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// CurrentCommit returns the commit of the current revision of the change, or
+// nil if the change was fetched without it.
+func (c *AnnotatedChange) CurrentCommit() *gerrit.Commit {
+	if rev, ok := c.Revisions[c.CurrentRevision]; ok {
+		return rev.Commit
+	}
+	return nil
+}
+
+// Body returns the description of the change. Gerrit has no separate
+// description field, so this is the commit message of the current revision
+// without the subject line and the trailing Change-Id footer.
+func (c *AnnotatedChange) Body() string {
+	commit := c.CurrentCommit()
+	if commit == nil {
+		return ""
+	}
+
+	_, body, _ := strings.Cut(commit.Message, "\n")
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "Change-Id: ") {
+			lines = append(lines[:i], lines[i+1:]...)
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,263 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// The test fixtures and golden files were generated against the sourcegraph
+// project on gerrit.sgdev.org.
+var testGerritRepo = &types.Repo{
+	Metadata: &gerrit.Project{
+		ID:   "sourcegraph",
+		Name: "sourcegraph",
+	},
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs: &Changeset{
+				RemoteRepo: testGerritRepo,
+				TargetRepo: testGerritRepo,
+				Changeset:  &btypes.Changeset{ExternalID: "I5de4ad1b4d1c1bfb33b0b0b5d44b0a1ef5ca3c2e"},
+			},
+		},
+		{
+			name: "not-found",
+			cs: &Changeset{
+				RemoteRepo: testGerritRepo,
+				TargetRepo: testGerritRepo,
+				Changeset:  &btypes.Changeset{ExternalID: "I0000000000000000000000000000000000000000"},
+			},
+			err: "Changeset with external ID I0000000000000000000000000000000000000000 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			ctx := context.Background()
+			src := newTestGerritSource(t, ctx, cf)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangeset(ctx, tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			meta := tc.cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), meta)
+		})
+	}
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	// The change of the changeset is created when pushing the commit with the
+	// Change-Id derived from the changeset.
+	pushed := &btypes.Changeset{
+		ID:        1,
+		RepoID:    1,
+		CreatedAt: time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "success",
+			cs: &Changeset{
+				Title:      "This is a test change",
+				Body:       "This is the body of a test change",
+				BaseRef:    "refs/heads/main",
+				HeadRef:    "refs/heads/gerrit-test",
+				RemoteRepo: testGerritRepo,
+				TargetRepo: testGerritRepo,
+				Changeset:  pushed,
+			},
+		},
+		{
+			name: "not-pushed",
+			cs: &Changeset{
+				Title:      "This is a test change",
+				Body:       "This is the body of a test change",
+				BaseRef:    "refs/heads/main",
+				HeadRef:    "refs/heads/gerrit-test",
+				RemoteRepo: testGerritRepo,
+				TargetRepo: testGerritRepo,
+				Changeset: &btypes.Changeset{
+					ID:        2,
+					RepoID:    1,
+					CreatedAt: time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			err: "change was not created by pushing the commit",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, tc.name)
+			defer save(t)
+
+			ctx := context.Background()
+			src := newTestGerritSource(t, ctx, cf)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			wantID := GerritChangeID(tc.cs.Changeset)
+			exists, err := src.CreateChangeset(ctx, tc.cs)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if exists {
+				t.Errorf("unexpectedly reported existing changeset")
+			}
+
+			if have, want := tc.cs.ExternalID, wantID; have != want {
+				t.Errorf("wrong external ID:\nhave: %q\nwant: %q", have, want)
+			}
+
+			meta := tc.cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), meta)
+		})
+	}
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	src := newTestGerritSource(t, ctx, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{Username: "user", Password: "pass"},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				newSrc, err := src.WithAuthenticator(tc)
+				assert.NoError(t, err)
+				assert.NotNil(t, newSrc)
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"nil":         nil,
+			"OAuthBearer": &auth.OAuthBearerToken{Token: "abcdef"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := src.WithAuthenticator(tc)
+				assert.Error(t, err)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+}
+
+func TestGerritChangeID(t *testing.T) {
+	cs := &btypes.Changeset{
+		ID:        1,
+		RepoID:    1,
+		CreatedAt: time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	id := GerritChangeID(cs)
+	assert.Regexp(t, "^I[0-9a-f]{40}$", id)
+	assert.Equal(t, id, GerritChangeID(cs), "Change-Id must be stable")
+
+	other := *cs
+	other.ID = 2
+	assert.NotEqual(t, id, GerritChangeID(&other))
+
+	published := *cs
+	published.ExternalID = "Iabc"
+	assert.Equal(t, "Iabc", GerritChangeID(&published))
+}
+
+func TestAppendGerritChangeID(t *testing.T) {
+	for name, tc := range map[string]struct {
+		message string
+		want    string
+	}{
+		"subject only": {
+			message: "Fix the thing",
+			want:    "Fix the thing\n\nChange-Id: Iabc\n",
+		},
+		"subject and body": {
+			message: "Fix the thing\n\nIt was broken.\n",
+			want:    "Fix the thing\n\nIt was broken.\n\nChange-Id: Iabc\n",
+		},
+		"existing Change-Id": {
+			message: "Fix the thing\n\nChange-Id: Idef\n",
+			want:    "Fix the thing\n\nChange-Id: Idef\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, AppendGerritChangeID(tc.message, "Iabc"))
+		})
+	}
+}
+
+func newTestGerritSource(t *testing.T, ctx context.Context, cf *httpcli.Factory) *GerritSource {
+	t.Helper()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindGerrit,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.GerritConnection{
+			Url:      "https://gerrit.sgdev.org/",
+			Username: os.Getenv("GERRIT_USERNAME"),
+			Password: os.Getenv("GERRIT_PASSWORD"),
+		})),
+	}
+
+	src, err := NewGerritSource(ctx, svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
@@ -290,7 +290,9 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 		case *schema.GitHubConnection,
 			*schema.BitbucketServerConnection,
 			*schema.GitLabConnection,
-			*schema.BitbucketCloudConnection:
+			*schema.BitbucketCloudConnection,
+			*schema.AzureDevOpsConnection,
+			*schema.GerritConnection:
 			return e, nil
 		}
 	}
@@ -310,6 +312,10 @@ func buildChangesetSource(ctx context.Context, cf *httpcli.Factory, externalServ
 		return NewBitbucketServerSource(ctx, externalService, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(ctx, externalService, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, externalService, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(ctx, externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -359,6 +365,12 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeAzureDevOps:
+		return errors.New("require username/token to push commits to Azure DevOps")
+
+	case extsvc.TypeGerrit:
+		return errors.New("require username/password to push commits to Gerrit")
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -372,7 +384,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAzureDevOps, extsvc.TypeGerrit:
 		u.User = url.UserPassword(username, password)
 
 	default:
@@ -7,9 +7,13 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -407,6 +411,94 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeAzureDevOpsBuildState(t *testing.T) {
+	t.Parallel()
+
+	status := func(id int, name string, state azuredevops.PullRequestStatusState) *azuredevops.PullRequestBuildStatus {
+		return &azuredevops.PullRequestBuildStatus{
+			ID:      id,
+			State:   state,
+			Context: azuredevops.PullRequestStatusContext{Genre: "ci", Name: name},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		statuses []*azuredevops.PullRequestBuildStatus
+		want     btypes.ChangesetCheckState
+	}{
+		"no statuses": {
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		"single succeeded status": {
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status(1, "build", azuredevops.PullRequestBuildStatusStateSucceeded),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		"errored and succeeded statuses": {
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status(1, "build", azuredevops.PullRequestBuildStatusStateSucceeded),
+				status(2, "lint", azuredevops.PullRequestBuildStatusStateError),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		"newer status from the same source wins": {
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status(2, "build", azuredevops.PullRequestBuildStatusStateSucceeded),
+				status(1, "build", azuredevops.PullRequestBuildStatusStateFailed),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := computeAzureDevOpsBuildState(&adobatches.AnnotatedPullRequest{
+				PullRequest: &azuredevops.PullRequest{},
+				Statuses:    tc.statuses,
+			})
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	account := &gerrit.Account{ID: 1000000, Username: "ci-bot"}
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.ChangeLabel
+		want   btypes.ChangesetCheckState
+	}{
+		"no Verified label": {
+			labels: map[string]gerrit.ChangeLabel{gerrit.CodeReviewLabel: {}},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		"no votes yet": {
+			labels: map[string]gerrit.ChangeLabel{gerrit.VerifiedLabel: {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		"verified": {
+			labels: map[string]gerrit.ChangeLabel{gerrit.VerifiedLabel: {Approved: account}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"rejection takes precedence": {
+			labels: map[string]gerrit.ChangeLabel{gerrit.VerifiedLabel: {Approved: account, Rejected: account}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := computeGerritCheckState(&gerritbatches.AnnotatedChange{
+				Change: &gerrit.Change{Labels: tc.labels},
+			})
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
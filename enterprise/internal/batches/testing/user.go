@@ -9,6 +9,8 @@ import (
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/rbac/rbactest"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -47,6 +49,10 @@ var CreateTestUser = func() func(*testing.T, database.DB, bool) *types.User {
 			t.Fatalf("failed to create name: %s", err)
 		}
 
+		// Like on a real instance, all users are granted the permissions of
+		// batch changes.
+		rbactest.GrantToDefaultRole(t, db, rbac.BatchChangesNamespace)
+
 		return user
 	}
 }()
@@ -8,11 +8,15 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/go-diff/diff"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -420,6 +424,37 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *adobatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceRefName)
+		// Azure DevOps doesn't expose when a pull request was last updated,
+		// so the closed date is the best approximation we have.
+		if pr.ClosedDate != nil {
+			c.ExternalUpdatedAt = *pr.ClosedDate
+		} else {
+			c.ExternalUpdatedAt = pr.CreationDate
+		}
+
+		if pr.ForkSource != nil {
+			namespace, err := pr.ForkSource.Repository.Namespace()
+			if err != nil {
+				return errors.Wrap(err, "determining fork namespace")
+			}
+			c.ExternalForkNamespace = namespace
+		} else {
+			c.ExternalForkNamespace = ""
+		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = pr.ChangeID
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Gerrit changes are pushed to refs/for/<base branch> and don't have
+		// a branch of their own, so ExternalBranch keeps the head ref of the
+		// changeset spec that was set when publishing.
+		c.ExternalUpdatedAt = pr.Updated.Time
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +482,10 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +505,10 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreatedBy.UniqueName, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +536,15 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *adobatches.AnnotatedPullRequest:
+		// On Azure DevOps Services the unique name of a user is their e-mail
+		// address, but that's not guaranteed for Azure DevOps Server.
+		if strings.Contains(m.CreatedBy.UniqueName, "@") {
+			return m.CreatedBy.UniqueName, nil
+		}
+		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +563,10 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreationDate
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
 	default:
 		return time.Time{}
 	}
@@ -527,6 +583,10 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Description, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Body(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +632,10 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *adobatches.AnnotatedPullRequest:
+		return m.WebURL(), nil
+	case *gerritbatches.AnnotatedChange:
+		return m.WebURL(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -744,6 +808,58 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    status,
 			})
 		}
+
+	case *adobatches.AnnotatedPullRequest:
+		// As with Bitbucket Cloud, we create review events from the reviewers
+		// of the pull request, and check events from its statuses.
+		var kind ChangesetEventKind
+
+		for i := range m.Reviewers {
+			reviewer := &m.Reviewers[i]
+			if kind, err = ChangesetEventKindFor(reviewer); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				// Reviewers don't have an ID of their own, but a user can only
+				// review a given pull request once.
+				Key:      m.Repository.ID + ":" + strconv.Itoa(m.ID) + ":" + reviewer.ID,
+				Kind:     kind,
+				Metadata: reviewer,
+			})
+		}
+
+		for _, status := range m.Statuses {
+			if kind, err = ChangesetEventKindFor(status); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         status.Key(),
+				Kind:        kind,
+				Metadata:    status,
+			})
+		}
+
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit only exposes the current votes of each reviewer, so we
+		// create one review event per reviewer. CI results are tracked
+		// through the Verified label, which is computed from the change
+		// itself.
+		var kind ChangesetEventKind
+
+		for i := range m.Reviewers {
+			reviewer := &m.Reviewers[i]
+			if kind, err = ChangesetEventKindFor(reviewer); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         m.ChangeID + ":" + strconv.Itoa(int(reviewer.ID)),
+				Kind:        kind,
+				Metadata:    reviewer,
+			})
+		}
 	}
 	return events, nil
 }
@@ -761,6 +877,10 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.LastMergeSourceCommit.CommitID, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +898,11 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.SourceRefName, nil
+	case *gerritbatches.AnnotatedChange:
+		// The ref of the current patch set, e.g. refs/changes/45/12345/2.
+		return m.Revisions[m.CurrentRevision].Ref, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +921,13 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.LastMergeTargetCommit.CommitID, nil
+	case *gerritbatches.AnnotatedChange:
+		if commit := m.CurrentCommit(); commit != nil && len(commit.Parents) > 0 {
+			return commit.Parents[0].Commit, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,6 +945,10 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.TargetRefName, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -1135,6 +1271,37 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusCreated, nil
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated, nil
+
+	case *azuredevops.Reviewer:
+		switch e.Vote {
+		case azuredevops.PullRequestVoteApproved:
+			return ChangesetEventKindAzureDevOpsPullRequestApproved, nil
+		case azuredevops.PullRequestVoteApprovedWithSuggestions:
+			return ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions, nil
+		case azuredevops.PullRequestVoteWaitingForAuthor:
+			return ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor, nil
+		case azuredevops.PullRequestVoteRejected:
+			return ChangesetEventKindAzureDevOpsPullRequestRejected, nil
+		default:
+			return ChangesetEventKindAzureDevOpsPullRequestReviewed, nil
+		}
+	case *azuredevops.PullRequestBuildStatus:
+		return ChangesetEventKindAzureDevOpsPullRequestBuildStatus, nil
+
+	case *gerrit.Reviewer:
+		vote, _ := e.Vote(gerrit.CodeReviewLabel)
+		switch {
+		case vote >= 2:
+			return ChangesetEventKindGerritChangeApproved, nil
+		case vote == 1:
+			return ChangesetEventKindGerritChangeRecommended, nil
+		case vote == -1:
+			return ChangesetEventKindGerritChangeDisliked, nil
+		case vote <= -2:
+			return ChangesetEventKindGerritChangeRejected, nil
+		default:
+			return ChangesetEventKindGerritChangeReviewed, nil
+		}
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -1178,6 +1345,15 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 		case ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated:
 			return new(bitbucketcloud.RepoCommitStatusUpdatedEvent), nil
 		}
+	case strings.HasPrefix(string(k), "azuredevops"):
+		switch k {
+		case ChangesetEventKindAzureDevOpsPullRequestBuildStatus:
+			return new(azuredevops.PullRequestBuildStatus), nil
+		default:
+			return new(azuredevops.Reviewer), nil
+		}
+	case strings.HasPrefix(string(k), "gerrit"):
+		return new(gerrit.Reviewer), nil
 	case strings.HasPrefix(string(k), "bitbucketserver"):
 		switch k {
 		case ChangesetEventKindBitbucketServerCommitStatus:
@@ -3,13 +3,16 @@ package types
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -102,6 +105,23 @@ const (
 	ChangesetEventKindBitbucketCloudRepoCommitStatusCreated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_created"          // RepoCommitStatusCreatedEvent
 	ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_updated"          // RepoCommitStatusUpdatedEvent
 
+	// These changeset events are created as the result of regular syncs with
+	// Azure DevOps.
+	ChangesetEventKindAzureDevOpsPullRequestApproved                ChangesetEventKind = "azuredevops:approved"
+	ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions ChangesetEventKind = "azuredevops:approved_with_suggestions"
+	ChangesetEventKindAzureDevOpsPullRequestReviewed                ChangesetEventKind = "azuredevops:reviewed"
+	ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor        ChangesetEventKind = "azuredevops:waiting_for_author"
+	ChangesetEventKindAzureDevOpsPullRequestRejected                ChangesetEventKind = "azuredevops:rejected"
+	ChangesetEventKindAzureDevOpsPullRequestBuildStatus             ChangesetEventKind = "azuredevops:build_status"
+
+	// These changeset events are created as the result of regular syncs with
+	// Gerrit, based on the Code-Review votes of the reviewers of a change.
+	ChangesetEventKindGerritChangeApproved    ChangesetEventKind = "gerrit:change:approved"    // +2
+	ChangesetEventKindGerritChangeRecommended ChangesetEventKind = "gerrit:change:recommended" // +1
+	ChangesetEventKindGerritChangeReviewed    ChangesetEventKind = "gerrit:change:reviewed"    // 0 or no vote
+	ChangesetEventKindGerritChangeDisliked    ChangesetEventKind = "gerrit:change:disliked"    // -1
+	ChangesetEventKindGerritChangeRejected    ChangesetEventKind = "gerrit:change:rejected"    // -2
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -163,6 +183,12 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
 		return meta.ChangesRequest.User.UUID
 
+	case *azuredevops.Reviewer:
+		return meta.ID
+
+	case *gerrit.Reviewer:
+		return strconv.Itoa(int(meta.ID))
+
 	default:
 		return ""
 	}
@@ -174,14 +200,21 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindBitbucketServerApproved,
 		ChangesetEventKindGitLabApproved,
 		ChangesetEventKindBitbucketCloudApproved,
-		ChangesetEventKindBitbucketCloudPullRequestApproved:
+		ChangesetEventKindBitbucketCloudPullRequestApproved,
+		ChangesetEventKindAzureDevOpsPullRequestApproved,
+		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+		ChangesetEventKindGerritChangeApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
 	case ChangesetEventKindBitbucketServerReviewed,
 		ChangesetEventKindBitbucketCloudChangesRequested,
-		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated:
+		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
+		ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor,
+		ChangesetEventKindAzureDevOpsPullRequestRejected,
+		ChangesetEventKindGerritChangeDisliked,
+		ChangesetEventKindGerritChangeRejected:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -316,6 +349,11 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CommitStatus.CreatedOn
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		t = ev.CommitStatus.UpdatedOn
+	case *azuredevops.PullRequestBuildStatus:
+		t = ev.UpdatedDate
+		if t.IsZero() {
+			t = ev.CreationDate
+		}
 	}
 
 	return t
@@ -854,6 +892,18 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		o := o.Metadata.(*bitbucketcloud.RepoCommitStatusUpdatedEvent)
 		*e = *o
 
+	case *azuredevops.Reviewer:
+		o := o.Metadata.(*azuredevops.Reviewer)
+		*e = *o
+
+	case *azuredevops.PullRequestBuildStatus:
+		o := o.Metadata.(*azuredevops.PullRequestBuildStatus)
+		*e = *o
+
+	case *gerrit.Reviewer:
+		o := o.Metadata.(*gerrit.Reviewer)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
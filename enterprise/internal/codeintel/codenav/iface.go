@@ -9,7 +9,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 type UploadService interface {
@@ -17,11 +21,22 @@ type UploadService interface {
 	GetUploadIDsWithReferences(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, ignoreIDs []int, repositoryID int, commit string, limit int, offset int) (ids []int, recordsScanned int, totalCount int, err error)
 	GetDumpsByIDs(ctx context.Context, ids []int) (_ []types.Dump, err error)
 	InferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []types.Dump, err error)
+	GetDependencyGraphRepositoryNames(ctx context.Context, repositoryID, limit int) (_ []api.RepoName, err error)
 }
 
 type GitserverClient interface {
 	CommitsExist(ctx context.Context, commits []gitserver.RepositoryCommit) ([]bool, error)
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
+	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
+}
+
+type SymbolsClient interface {
+	Search(ctx context.Context, args search.SymbolsParameters) (result.Symbols, error)
+}
+
+type SearchClient interface {
+	Plan(ctx context.Context, version string, patternType *string, searchQuery string, searchMode search.Mode, protocol search.Protocol, settings *schema.Settings, sourcegraphDotComMode bool) (*search.Inputs, error)
+	Execute(ctx context.Context, stream streaming.Sender, inputs *search.Inputs) (*search.Alert, error)
 }
 
 type DBStore interface {
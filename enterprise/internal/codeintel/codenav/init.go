@@ -6,6 +6,9 @@ import (
 	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchclient "github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
 )
 
 func NewService(
@@ -17,6 +20,7 @@ func NewService(
 ) *Service {
 	store := store.New(scopedContext("store", observationCtx), db)
 	lsifStore := lsifstore.New(scopedContext("lsifstore", observationCtx), codeIntelDB)
+	searchClient := searchclient.NewSearchClient(observationCtx.Logger, db, search.Indexed(), search.SearcherURLs())
 
 	return newService(
 		observationCtx,
@@ -24,6 +28,8 @@ func NewService(
 		lsifStore,
 		uploadSvc,
 		gitserver,
+		symbols.DefaultClient,
+		searchClient,
 	)
 }
 
@@ -19,81 +19,807 @@ import (
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	authz "github.com/sourcegraph/sourcegraph/internal/authz"
 	database "github.com/sourcegraph/sourcegraph/internal/database"
+	search "github.com/sourcegraph/sourcegraph/internal/search"
+	result "github.com/sourcegraph/sourcegraph/internal/search/result"
+	streaming "github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	precise "github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	schema "github.com/sourcegraph/sourcegraph/schema"
 )
 
-// MockStore is a mock implementation of the Store interface (from the
-// package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/internal/store)
+// MockGitTreeTranslator is a mock implementation of the GitTreeTranslator
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
 // used for unit testing.
-type MockStore struct {
-	// GetUnsafeDBFunc is an instance of a mock function object controlling
-	// the behavior of the method GetUnsafeDB.
-	GetUnsafeDBFunc *StoreGetUnsafeDBFunc
+type MockGitTreeTranslator struct {
+	// GetTargetCommitPathFromSourcePathFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetTargetCommitPathFromSourcePath.
+	GetTargetCommitPathFromSourcePathFunc *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc
+	// GetTargetCommitPositionFromSourcePositionFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// GetTargetCommitPositionFromSourcePosition.
+	GetTargetCommitPositionFromSourcePositionFunc *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc
+	// GetTargetCommitRangeFromSourceRangeFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetTargetCommitRangeFromSourceRange.
+	GetTargetCommitRangeFromSourceRangeFunc *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc
 }
 
-// NewMockStore creates a new mock of the Store interface. All methods
-// return zero values for all results, unless overwritten.
-func NewMockStore() *MockStore {
-	return &MockStore{
-		GetUnsafeDBFunc: &StoreGetUnsafeDBFunc{
-			defaultHook: func() (r0 database.DB) {
+// NewMockGitTreeTranslator creates a new mock of the GitTreeTranslator
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitTreeTranslator() *MockGitTreeTranslator {
+	return &MockGitTreeTranslator{
+		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
+			defaultHook: func(context.Context, string, string, bool) (r0 string, r1 bool, r2 error) {
+				return
+			},
+		},
+		GetTargetCommitPositionFromSourcePositionFunc: &GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc{
+			defaultHook: func(context.Context, string, types.Position, bool) (r0 string, r1 types.Position, r2 bool, r3 error) {
+				return
+			},
+		},
+		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
+			defaultHook: func(context.Context, string, string, types.Range, bool) (r0 string, r1 types.Range, r2 bool, r3 error) {
 				return
 			},
 		},
 	}
 }
 
-// NewStrictMockStore creates a new mock of the Store interface. All methods
-// panic on invocation, unless overwritten.
-func NewStrictMockStore() *MockStore {
-	return &MockStore{
-		GetUnsafeDBFunc: &StoreGetUnsafeDBFunc{
-			defaultHook: func() database.DB {
-				panic("unexpected invocation of MockStore.GetUnsafeDB")
+// NewStrictMockGitTreeTranslator creates a new mock of the
+// GitTreeTranslator interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockGitTreeTranslator() *MockGitTreeTranslator {
+	return &MockGitTreeTranslator{
+		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
+			defaultHook: func(context.Context, string, string, bool) (string, bool, error) {
+				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitPathFromSourcePath")
+			},
+		},
+		GetTargetCommitPositionFromSourcePositionFunc: &GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc{
+			defaultHook: func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
+				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitPositionFromSourcePosition")
+			},
+		},
+		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
+			defaultHook: func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
+				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitRangeFromSourceRange")
 			},
 		},
 	}
 }
 
-// NewMockStoreFrom creates a new mock of the MockStore interface. All
-// methods delegate to the given implementation, unless overwritten.
-func NewMockStoreFrom(i store.Store) *MockStore {
-	return &MockStore{
-		GetUnsafeDBFunc: &StoreGetUnsafeDBFunc{
-			defaultHook: i.GetUnsafeDB,
+// NewMockGitTreeTranslatorFrom creates a new mock of the
+// MockGitTreeTranslator interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockGitTreeTranslatorFrom(i GitTreeTranslator) *MockGitTreeTranslator {
+	return &MockGitTreeTranslator{
+		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
+			defaultHook: i.GetTargetCommitPathFromSourcePath,
+		},
+		GetTargetCommitPositionFromSourcePositionFunc: &GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc{
+			defaultHook: i.GetTargetCommitPositionFromSourcePosition,
+		},
+		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
+			defaultHook: i.GetTargetCommitRangeFromSourceRange,
 		},
 	}
 }
 
-// StoreGetUnsafeDBFunc describes the behavior when the GetUnsafeDB method
-// of the parent MockStore instance is invoked.
-type StoreGetUnsafeDBFunc struct {
-	defaultHook func() database.DB
-	hooks       []func() database.DB
-	history     []StoreGetUnsafeDBFuncCall
+// GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc describes the
+// behavior when the GetTargetCommitPathFromSourcePath method of the parent
+// MockGitTreeTranslator instance is invoked.
+type GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc struct {
+	defaultHook func(context.Context, string, string, bool) (string, bool, error)
+	hooks       []func(context.Context, string, string, bool) (string, bool, error)
+	history     []GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTargetCommitPathFromSourcePath delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockGitTreeTranslator) GetTargetCommitPathFromSourcePath(v0 context.Context, v1 string, v2 string, v3 bool) (string, bool, error) {
+	r0, r1, r2 := m.GetTargetCommitPathFromSourcePathFunc.nextHook()(v0, v1, v2, v3)
+	m.GetTargetCommitPathFromSourcePathFunc.appendCall(GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTargetCommitPathFromSourcePath method of the parent
+// MockGitTreeTranslator instance is invoked and the hook queue is empty.
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) SetDefaultHook(hook func(context.Context, string, string, bool) (string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTargetCommitPathFromSourcePath method of the parent
+// MockGitTreeTranslator instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) PushHook(hook func(context.Context, string, string, bool) (string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string, bool) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, string, string, bool) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) nextHook() func(context.Context, string, string, bool) (string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall objects
+// describing the invocations of this function.
+func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) History() []GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall {
+	f.mutex.Lock()
+	history := make([]GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall is an object
+// that describes an invocation of method GetTargetCommitPathFromSourcePath
+// on an instance of MockGitTreeTranslator.
+type GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc describes
+// the behavior when the GetTargetCommitPositionFromSourcePosition method of
+// the parent MockGitTreeTranslator instance is invoked.
+type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc struct {
+	defaultHook func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)
+	hooks       []func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)
+	history     []GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTargetCommitPositionFromSourcePosition delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockGitTreeTranslator) GetTargetCommitPositionFromSourcePosition(v0 context.Context, v1 string, v2 types.Position, v3 bool) (string, types.Position, bool, error) {
+	r0, r1, r2, r3 := m.GetTargetCommitPositionFromSourcePositionFunc.nextHook()(v0, v1, v2, v3)
+	m.GetTargetCommitPositionFromSourcePositionFunc.appendCall(GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall{v0, v1, v2, v3, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTargetCommitPositionFromSourcePosition method of the parent
+// MockGitTreeTranslator instance is invoked and the hook queue is empty.
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) SetDefaultHook(hook func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTargetCommitPositionFromSourcePosition method of the parent
+// MockGitTreeTranslator instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) PushHook(hook func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) SetDefaultReturn(r0 string, r1 types.Position, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) PushReturn(r0 string, r1 types.Position, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) nextHook() func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall
+// objects describing the invocations of this function.
+func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) History() []GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall {
+	f.mutex.Lock()
+	history := make([]GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall is an
+// object that describes an invocation of method
+// GetTargetCommitPositionFromSourcePosition on an instance of
+// MockGitTreeTranslator.
+type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 types.Position
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 types.Position
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc describes the
+// behavior when the GetTargetCommitRangeFromSourceRange method of the
+// parent MockGitTreeTranslator instance is invoked.
+type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc struct {
+	defaultHook func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)
+	hooks       []func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)
+	history     []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTargetCommitRangeFromSourceRange delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockGitTreeTranslator) GetTargetCommitRangeFromSourceRange(v0 context.Context, v1 string, v2 string, v3 types.Range, v4 bool) (string, types.Range, bool, error) {
+	r0, r1, r2, r3 := m.GetTargetCommitRangeFromSourceRangeFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetTargetCommitRangeFromSourceRangeFunc.appendCall(GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance is invoked and the hook queue is empty.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultHook(hook func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushHook(hook func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultReturn(r0 string, r1 types.Range, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushReturn(r0 string, r1 types.Range, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) nextHook() func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall objects
+// describing the invocations of this function.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) History() []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall {
+	f.mutex.Lock()
+	history := make([]GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall is an object
+// that describes an invocation of method
+// GetTargetCommitRangeFromSourceRange on an instance of
+// MockGitTreeTranslator.
+type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 types.Range
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 types.Range
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// MockGitserverClient is a mock implementation of the GitserverClient
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
+// used for unit testing.
+type MockGitserverClient struct {
+	// CommitsExistFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitsExist.
+	CommitsExistFunc *GitserverClientCommitsExistFunc
+	// DiffPathFunc is an instance of a mock function object controlling the
+	// behavior of the method DiffPath.
+	DiffPathFunc *GitserverClientDiffPathFunc
+	// RawContentsFunc is an instance of a mock function object controlling
+	// the behavior of the method RawContents.
+	RawContentsFunc *GitserverClientRawContentsFunc
+}
+
+// NewMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitsExistFunc: &GitserverClientCommitsExistFunc{
+			defaultHook: func(context.Context, []gitserver.RepositoryCommit) (r0 []bool, r1 error) {
+				return
+			},
+		},
+		DiffPathFunc: &GitserverClientDiffPathFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []*diff.Hunk, r1 error) {
+				return
+			},
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) (r0 []byte, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitsExistFunc: &GitserverClientCommitsExistFunc{
+			defaultHook: func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
+				panic("unexpected invocation of MockGitserverClient.CommitsExist")
+			},
+		},
+		DiffPathFunc: &GitserverClientDiffPathFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+				panic("unexpected invocation of MockGitserverClient.DiffPath")
+			},
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) ([]byte, error) {
+				panic("unexpected invocation of MockGitserverClient.RawContents")
+			},
+		},
+	}
+}
+
+// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitsExistFunc: &GitserverClientCommitsExistFunc{
+			defaultHook: i.CommitsExist,
+		},
+		DiffPathFunc: &GitserverClientDiffPathFunc{
+			defaultHook: i.DiffPath,
+		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: i.RawContents,
+		},
+	}
+}
+
+// GitserverClientCommitsExistFunc describes the behavior when the
+// CommitsExist method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientCommitsExistFunc struct {
+	defaultHook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)
+	hooks       []func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)
+	history     []GitserverClientCommitsExistFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitsExist delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) CommitsExist(v0 context.Context, v1 []gitserver.RepositoryCommit) ([]bool, error) {
+	r0, r1 := m.CommitsExistFunc.nextHook()(v0, v1)
+	m.CommitsExistFunc.appendCall(GitserverClientCommitsExistFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitsExist method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientCommitsExistFunc) SetDefaultHook(hook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitsExist method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientCommitsExistFunc) PushHook(hook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientCommitsExistFunc) SetDefaultReturn(r0 []bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientCommitsExistFunc) PushReturn(r0 []bool, r1 error) {
+	f.PushHook(func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientCommitsExistFunc) nextHook() func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientCommitsExistFunc) appendCall(r0 GitserverClientCommitsExistFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientCommitsExistFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientCommitsExistFunc) History() []GitserverClientCommitsExistFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientCommitsExistFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientCommitsExistFuncCall is an object that describes an
+// invocation of method CommitsExist on an instance of MockGitserverClient.
+type GitserverClientCommitsExistFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []gitserver.RepositoryCommit
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientCommitsExistFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientCommitsExistFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientDiffPathFunc describes the behavior when the DiffPath
+// method of the parent MockGitserverClient instance is invoked.
+type GitserverClientDiffPathFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)
+	history     []GitserverClientDiffPathFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffPath delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverClient) DiffPath(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string) ([]*diff.Hunk, error) {
+	r0, r1 := m.DiffPathFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.DiffPathFunc.appendCall(GitserverClientDiffPathFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffPath method of
+// the parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientDiffPathFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffPath method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientDiffPathFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientDiffPathFunc) SetDefaultReturn(r0 []*diff.Hunk, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientDiffPathFunc) PushReturn(r0 []*diff.Hunk, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientDiffPathFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientDiffPathFunc) appendCall(r0 GitserverClientDiffPathFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientDiffPathFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientDiffPathFunc) History() []GitserverClientDiffPathFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientDiffPathFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientDiffPathFuncCall is an object that describes an invocation
+// of method DiffPath on an instance of MockGitserverClient.
+type GitserverClientDiffPathFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*diff.Hunk
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientDiffPathFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientRawContentsFunc describes the behavior when the
+// RawContents method of the parent MockGitserverClient instance is invoked.
+type GitserverClientRawContentsFunc struct {
+	defaultHook func(context.Context, int, string, string) ([]byte, error)
+	hooks       []func(context.Context, int, string, string) ([]byte, error)
+	history     []GitserverClientRawContentsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUnsafeDB delegates to the next hook function in the queue and stores
+// RawContents delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockStore) GetUnsafeDB() database.DB {
-	r0 := m.GetUnsafeDBFunc.nextHook()()
-	m.GetUnsafeDBFunc.appendCall(StoreGetUnsafeDBFuncCall{r0})
-	return r0
+func (m *MockGitserverClient) RawContents(v0 context.Context, v1 int, v2 string, v3 string) ([]byte, error) {
+	r0, r1 := m.RawContentsFunc.nextHook()(v0, v1, v2, v3)
+	m.RawContentsFunc.appendCall(GitserverClientRawContentsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetUnsafeDB method
-// of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreGetUnsafeDBFunc) SetDefaultHook(hook func() database.DB) {
+// SetDefaultHook sets function that is called when the RawContents method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientRawContentsFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]byte, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUnsafeDB method of the parent MockStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreGetUnsafeDBFunc) PushHook(hook func() database.DB) {
+// RawContents method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientRawContentsFunc) PushHook(hook func(context.Context, int, string, string) ([]byte, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -101,20 +827,20 @@ func (f *StoreGetUnsafeDBFunc) PushHook(hook func() database.DB) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetUnsafeDBFunc) SetDefaultReturn(r0 database.DB) {
-	f.SetDefaultHook(func() database.DB {
-		return r0
+func (f *GitserverClientRawContentsFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) ([]byte, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetUnsafeDBFunc) PushReturn(r0 database.DB) {
-	f.PushHook(func() database.DB {
-		return r0
+func (f *GitserverClientRawContentsFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) ([]byte, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreGetUnsafeDBFunc) nextHook() func() database.DB {
+func (f *GitserverClientRawContentsFunc) nextHook() func(context.Context, int, string, string) ([]byte, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -127,41 +853,56 @@ func (f *StoreGetUnsafeDBFunc) nextHook() func() database.DB {
 	return hook
 }
 
-func (f *StoreGetUnsafeDBFunc) appendCall(r0 StoreGetUnsafeDBFuncCall) {
+func (f *GitserverClientRawContentsFunc) appendCall(r0 GitserverClientRawContentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetUnsafeDBFuncCall objects describing
-// the invocations of this function.
-func (f *StoreGetUnsafeDBFunc) History() []StoreGetUnsafeDBFuncCall {
+// History returns a sequence of GitserverClientRawContentsFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientRawContentsFunc) History() []GitserverClientRawContentsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetUnsafeDBFuncCall, len(f.history))
+	history := make([]GitserverClientRawContentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetUnsafeDBFuncCall is an object that describes an invocation of
-// method GetUnsafeDB on an instance of MockStore.
-type StoreGetUnsafeDBFuncCall struct {
+// GitserverClientRawContentsFuncCall is an object that describes an
+// invocation of method RawContents on an instance of MockGitserverClient.
+type GitserverClientRawContentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 database.DB
+	Result0 []byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetUnsafeDBFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c GitserverClientRawContentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetUnsafeDBFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c GitserverClientRawContentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
 // MockLsifStore is a mock implementation of the LsifStore interface (from
@@ -1674,265 +2415,120 @@ type LsifStoreGetStencilFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 []types.Range
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c LsifStoreGetStencilFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c LsifStoreGetStencilFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
-// MockGitTreeTranslator is a mock implementation of the GitTreeTranslator
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
-// used for unit testing.
-type MockGitTreeTranslator struct {
-	// GetTargetCommitPathFromSourcePathFunc is an instance of a mock
-	// function object controlling the behavior of the method
-	// GetTargetCommitPathFromSourcePath.
-	GetTargetCommitPathFromSourcePathFunc *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc
-	// GetTargetCommitPositionFromSourcePositionFunc is an instance of a
-	// mock function object controlling the behavior of the method
-	// GetTargetCommitPositionFromSourcePosition.
-	GetTargetCommitPositionFromSourcePositionFunc *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc
-	// GetTargetCommitRangeFromSourceRangeFunc is an instance of a mock
-	// function object controlling the behavior of the method
-	// GetTargetCommitRangeFromSourceRange.
-	GetTargetCommitRangeFromSourceRangeFunc *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc
-}
-
-// NewMockGitTreeTranslator creates a new mock of the GitTreeTranslator
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockGitTreeTranslator() *MockGitTreeTranslator {
-	return &MockGitTreeTranslator{
-		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
-			defaultHook: func(context.Context, string, string, bool) (r0 string, r1 bool, r2 error) {
-				return
-			},
-		},
-		GetTargetCommitPositionFromSourcePositionFunc: &GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc{
-			defaultHook: func(context.Context, string, types.Position, bool) (r0 string, r1 types.Position, r2 bool, r3 error) {
-				return
-			},
-		},
-		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
-			defaultHook: func(context.Context, string, string, types.Range, bool) (r0 string, r1 types.Range, r2 bool, r3 error) {
-				return
-			},
-		},
-	}
-}
-
-// NewStrictMockGitTreeTranslator creates a new mock of the
-// GitTreeTranslator interface. All methods panic on invocation, unless
-// overwritten.
-func NewStrictMockGitTreeTranslator() *MockGitTreeTranslator {
-	return &MockGitTreeTranslator{
-		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
-			defaultHook: func(context.Context, string, string, bool) (string, bool, error) {
-				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitPathFromSourcePath")
-			},
-		},
-		GetTargetCommitPositionFromSourcePositionFunc: &GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc{
-			defaultHook: func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
-				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitPositionFromSourcePosition")
-			},
-		},
-		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
-			defaultHook: func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
-				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitRangeFromSourceRange")
-			},
-		},
-	}
-}
-
-// NewMockGitTreeTranslatorFrom creates a new mock of the
-// MockGitTreeTranslator interface. All methods delegate to the given
-// implementation, unless overwritten.
-func NewMockGitTreeTranslatorFrom(i GitTreeTranslator) *MockGitTreeTranslator {
-	return &MockGitTreeTranslator{
-		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
-			defaultHook: i.GetTargetCommitPathFromSourcePath,
-		},
-		GetTargetCommitPositionFromSourcePositionFunc: &GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc{
-			defaultHook: i.GetTargetCommitPositionFromSourcePosition,
-		},
-		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
-			defaultHook: i.GetTargetCommitRangeFromSourceRange,
-		},
-	}
-}
-
-// GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc describes the
-// behavior when the GetTargetCommitPathFromSourcePath method of the parent
-// MockGitTreeTranslator instance is invoked.
-type GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc struct {
-	defaultHook func(context.Context, string, string, bool) (string, bool, error)
-	hooks       []func(context.Context, string, string, bool) (string, bool, error)
-	history     []GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall
-	mutex       sync.Mutex
-}
-
-// GetTargetCommitPathFromSourcePath delegates to the next hook function in
-// the queue and stores the parameter and result values of this invocation.
-func (m *MockGitTreeTranslator) GetTargetCommitPathFromSourcePath(v0 context.Context, v1 string, v2 string, v3 bool) (string, bool, error) {
-	r0, r1, r2 := m.GetTargetCommitPathFromSourcePathFunc.nextHook()(v0, v1, v2, v3)
-	m.GetTargetCommitPathFromSourcePathFunc.appendCall(GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall{v0, v1, v2, v3, r0, r1, r2})
-	return r0, r1, r2
-}
-
-// SetDefaultHook sets function that is called when the
-// GetTargetCommitPathFromSourcePath method of the parent
-// MockGitTreeTranslator instance is invoked and the hook queue is empty.
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) SetDefaultHook(hook func(context.Context, string, string, bool) (string, bool, error)) {
-	f.defaultHook = hook
-}
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// GetTargetCommitPathFromSourcePath method of the parent
-// MockGitTreeTranslator instance invokes the hook at the front of the queue
-// and discards it. After the queue is empty, the default hook function is
-// invoked for any future action.
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) PushHook(hook func(context.Context, string, string, bool) (string, bool, error)) {
-	f.mutex.Lock()
-	f.hooks = append(f.hooks, hook)
-	f.mutex.Unlock()
-}
-
-// SetDefaultReturn calls SetDefaultHook with a function that returns the
-// given values.
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, string, string, bool) (string, bool, error) {
-		return r0, r1, r2
-	})
-}
-
-// PushReturn calls PushHook with a function that returns the given values.
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) PushReturn(r0 string, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, string, string, bool) (string, bool, error) {
-		return r0, r1, r2
-	})
-}
-
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) nextHook() func(context.Context, string, string, bool) (string, bool, error) {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if len(f.hooks) == 0 {
-		return f.defaultHook
-	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of
-// GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall objects
-// describing the invocations of this function.
-func (f *GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc) History() []GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall {
-	f.mutex.Lock()
-	history := make([]GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall is an object
-// that describes an invocation of method GetTargetCommitPathFromSourcePath
-// on an instance of MockGitTreeTranslator.
-type GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 string
+	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 bool
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 []types.Range
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c LsifStoreGetStencilFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitPathFromSourcePathFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c LsifStoreGetStencilFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc describes
-// the behavior when the GetTargetCommitPositionFromSourcePosition method of
-// the parent MockGitTreeTranslator instance is invoked.
-type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc struct {
-	defaultHook func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)
-	hooks       []func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)
-	history     []GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall
+// MockSearchClient is a mock implementation of the SearchClient interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
+// used for unit testing.
+type MockSearchClient struct {
+	// ExecuteFunc is an instance of a mock function object controlling the
+	// behavior of the method Execute.
+	ExecuteFunc *SearchClientExecuteFunc
+	// PlanFunc is an instance of a mock function object controlling the
+	// behavior of the method Plan.
+	PlanFunc *SearchClientPlanFunc
+}
+
+// NewMockSearchClient creates a new mock of the SearchClient interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockSearchClient() *MockSearchClient {
+	return &MockSearchClient{
+		ExecuteFunc: &SearchClientExecuteFunc{
+			defaultHook: func(context.Context, streaming.Sender, *search.Inputs) (r0 *search.Alert, r1 error) {
+				return
+			},
+		},
+		PlanFunc: &SearchClientPlanFunc{
+			defaultHook: func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (r0 *search.Inputs, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSearchClient creates a new mock of the SearchClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSearchClient() *MockSearchClient {
+	return &MockSearchClient{
+		ExecuteFunc: &SearchClientExecuteFunc{
+			defaultHook: func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error) {
+				panic("unexpected invocation of MockSearchClient.Execute")
+			},
+		},
+		PlanFunc: &SearchClientPlanFunc{
+			defaultHook: func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error) {
+				panic("unexpected invocation of MockSearchClient.Plan")
+			},
+		},
+	}
+}
+
+// NewMockSearchClientFrom creates a new mock of the MockSearchClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSearchClientFrom(i SearchClient) *MockSearchClient {
+	return &MockSearchClient{
+		ExecuteFunc: &SearchClientExecuteFunc{
+			defaultHook: i.Execute,
+		},
+		PlanFunc: &SearchClientPlanFunc{
+			defaultHook: i.Plan,
+		},
+	}
+}
+
+// SearchClientExecuteFunc describes the behavior when the Execute method of
+// the parent MockSearchClient instance is invoked.
+type SearchClientExecuteFunc struct {
+	defaultHook func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error)
+	hooks       []func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error)
+	history     []SearchClientExecuteFuncCall
 	mutex       sync.Mutex
 }
 
-// GetTargetCommitPositionFromSourcePosition delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockGitTreeTranslator) GetTargetCommitPositionFromSourcePosition(v0 context.Context, v1 string, v2 types.Position, v3 bool) (string, types.Position, bool, error) {
-	r0, r1, r2, r3 := m.GetTargetCommitPositionFromSourcePositionFunc.nextHook()(v0, v1, v2, v3)
-	m.GetTargetCommitPositionFromSourcePositionFunc.appendCall(GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall{v0, v1, v2, v3, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+// Execute delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchClient) Execute(v0 context.Context, v1 streaming.Sender, v2 *search.Inputs) (*search.Alert, error) {
+	r0, r1 := m.ExecuteFunc.nextHook()(v0, v1, v2)
+	m.ExecuteFunc.appendCall(SearchClientExecuteFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetTargetCommitPositionFromSourcePosition method of the parent
-// MockGitTreeTranslator instance is invoked and the hook queue is empty.
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) SetDefaultHook(hook func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)) {
+// SetDefaultHook sets function that is called when the Execute method of
+// the parent MockSearchClient instance is invoked and the hook queue is
+// empty.
+func (f *SearchClientExecuteFunc) SetDefaultHook(hook func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetTargetCommitPositionFromSourcePosition method of the parent
-// MockGitTreeTranslator instance invokes the hook at the front of the queue
-// and discards it. After the queue is empty, the default hook function is
-// invoked for any future action.
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) PushHook(hook func(context.Context, string, types.Position, bool) (string, types.Position, bool, error)) {
+// Execute method of the parent MockSearchClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SearchClientExecuteFunc) PushHook(hook func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1940,20 +2536,20 @@ func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) PushHoo
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) SetDefaultReturn(r0 string, r1 types.Position, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
-		return r0, r1, r2, r3
+func (f *SearchClientExecuteFunc) SetDefaultReturn(r0 *search.Alert, r1 error) {
+	f.SetDefaultHook(func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) PushReturn(r0 string, r1 types.Position, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
-		return r0, r1, r2, r3
+func (f *SearchClientExecuteFunc) PushReturn(r0 *search.Alert, r1 error) {
+	f.PushHook(func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error) {
+		return r0, r1
 	})
 }
 
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) nextHook() func(context.Context, string, types.Position, bool) (string, types.Position, bool, error) {
+func (f *SearchClientExecuteFunc) nextHook() func(context.Context, streaming.Sender, *search.Inputs) (*search.Alert, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1966,99 +2562,83 @@ func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) nextHoo
 	return hook
 }
 
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) {
+func (f *SearchClientExecuteFunc) appendCall(r0 SearchClientExecuteFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall
-// objects describing the invocations of this function.
-func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) History() []GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall {
+// History returns a sequence of SearchClientExecuteFuncCall objects
+// describing the invocations of this function.
+func (f *SearchClientExecuteFunc) History() []SearchClientExecuteFuncCall {
 	f.mutex.Lock()
-	history := make([]GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall, len(f.history))
+	history := make([]SearchClientExecuteFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall is an
-// object that describes an invocation of method
-// GetTargetCommitPositionFromSourcePosition on an instance of
-// MockGitTreeTranslator.
-type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall struct {
+// SearchClientExecuteFuncCall is an object that describes an invocation of
+// method Execute on an instance of MockSearchClient.
+type SearchClientExecuteFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
+	Arg1 streaming.Sender
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 types.Position
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 bool
+	Arg2 *search.Inputs
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 *search.Alert
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 types.Position
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 bool
-	// Result3 is the value of the 4th result returned from this method
-	// invocation.
-	Result3 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c SearchClientExecuteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+func (c SearchClientExecuteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc describes the
-// behavior when the GetTargetCommitRangeFromSourceRange method of the
-// parent MockGitTreeTranslator instance is invoked.
-type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc struct {
-	defaultHook func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)
-	hooks       []func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)
-	history     []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall
+// SearchClientPlanFunc describes the behavior when the Plan method of the
+// parent MockSearchClient instance is invoked.
+type SearchClientPlanFunc struct {
+	defaultHook func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error)
+	hooks       []func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error)
+	history     []SearchClientPlanFuncCall
 	mutex       sync.Mutex
 }
 
-// GetTargetCommitRangeFromSourceRange delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockGitTreeTranslator) GetTargetCommitRangeFromSourceRange(v0 context.Context, v1 string, v2 string, v3 types.Range, v4 bool) (string, types.Range, bool, error) {
-	r0, r1, r2, r3 := m.GetTargetCommitRangeFromSourceRangeFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.GetTargetCommitRangeFromSourceRangeFunc.appendCall(GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+// Plan delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchClient) Plan(v0 context.Context, v1 string, v2 *string, v3 string, v4 search.Mode, v5 search.Protocol, v6 *schema.Settings, v7 bool) (*search.Inputs, error) {
+	r0, r1 := m.PlanFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6, v7)
+	m.PlanFunc.appendCall(SearchClientPlanFuncCall{v0, v1, v2, v3, v4, v5, v6, v7, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// GetTargetCommitRangeFromSourceRange method of the parent
-// MockGitTreeTranslator instance is invoked and the hook queue is empty.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultHook(hook func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)) {
+// SetDefaultHook sets function that is called when the Plan method of the
+// parent MockSearchClient instance is invoked and the hook queue is empty.
+func (f *SearchClientPlanFunc) SetDefaultHook(hook func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetTargetCommitRangeFromSourceRange method of the parent
-// MockGitTreeTranslator instance invokes the hook at the front of the queue
-// and discards it. After the queue is empty, the default hook function is
-// invoked for any future action.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushHook(hook func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error)) {
+// Plan method of the parent MockSearchClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SearchClientPlanFunc) PushHook(hook func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2066,20 +2646,20 @@ func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushHook(hook
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultReturn(r0 string, r1 types.Range, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *SearchClientPlanFunc) SetDefaultReturn(r0 *search.Inputs, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushReturn(r0 string, r1 types.Range, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *SearchClientPlanFunc) PushReturn(r0 *search.Inputs, r1 error) {
+	f.PushHook(func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error) {
+		return r0, r1
 	})
 }
 
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) nextHook() func(context.Context, string, string, types.Range, bool) (string, types.Range, bool, error) {
+func (f *SearchClientPlanFunc) nextHook() func(context.Context, string, *string, string, search.Mode, search.Protocol, *schema.Settings, bool) (*search.Inputs, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2092,29 +2672,26 @@ func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) nextHook() fu
 	return hook
 }
 
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) {
+func (f *SearchClientPlanFunc) appendCall(r0 SearchClientPlanFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall objects
-// describing the invocations of this function.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) History() []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall {
+// History returns a sequence of SearchClientPlanFuncCall objects describing
+// the invocations of this function.
+func (f *SearchClientPlanFunc) History() []SearchClientPlanFuncCall {
 	f.mutex.Lock()
-	history := make([]GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall, len(f.history))
+	history := make([]SearchClientPlanFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall is an object
-// that describes an invocation of method
-// GetTargetCommitRangeFromSourceRange on an instance of
-// MockGitTreeTranslator.
-type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
+// SearchClientPlanFuncCall is an object that describes an invocation of
+// method Plan on an instance of MockSearchClient.
+type SearchClientPlanFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2123,131 +2700,114 @@ type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 *string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 types.Range
+	Arg3 string
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
-	Arg4 bool
-	// Result0 is the value of the 1st result returned from this method
+	Arg4 search.Mode
+	// Arg5 is the value of the 6th argument passed to this method
 	// invocation.
-	Result0 string
-	// Result1 is the value of the 2nd result returned from this method
+	Arg5 search.Protocol
+	// Arg6 is the value of the 7th argument passed to this method
 	// invocation.
-	Result1 types.Range
-	// Result2 is the value of the 3rd result returned from this method
+	Arg6 *schema.Settings
+	// Arg7 is the value of the 8th argument passed to this method
 	// invocation.
-	Result2 bool
-	// Result3 is the value of the 4th result returned from this method
+	Arg7 bool
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result3 error
+	Result0 *search.Inputs
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+func (c SearchClientPlanFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6, c.Arg7}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+func (c SearchClientPlanFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockGitserverClient is a mock implementation of the GitserverClient
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
+// MockStore is a mock implementation of the Store interface (from the
+// package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/internal/store)
 // used for unit testing.
-type MockGitserverClient struct {
-	// CommitsExistFunc is an instance of a mock function object controlling
-	// the behavior of the method CommitsExist.
-	CommitsExistFunc *GitserverClientCommitsExistFunc
-	// DiffPathFunc is an instance of a mock function object controlling the
-	// behavior of the method DiffPath.
-	DiffPathFunc *GitserverClientDiffPathFunc
+type MockStore struct {
+	// GetUnsafeDBFunc is an instance of a mock function object controlling
+	// the behavior of the method GetUnsafeDB.
+	GetUnsafeDBFunc *StoreGetUnsafeDBFunc
 }
 
-// NewMockGitserverClient creates a new mock of the GitserverClient
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockGitserverClient() *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitsExistFunc: &GitserverClientCommitsExistFunc{
-			defaultHook: func(context.Context, []gitserver.RepositoryCommit) (r0 []bool, r1 error) {
-				return
-			},
-		},
-		DiffPathFunc: &GitserverClientDiffPathFunc{
-			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []*diff.Hunk, r1 error) {
+// NewMockStore creates a new mock of the Store interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockStore() *MockStore {
+	return &MockStore{
+		GetUnsafeDBFunc: &StoreGetUnsafeDBFunc{
+			defaultHook: func() (r0 database.DB) {
 				return
 			},
 		},
 	}
 }
 
-// NewStrictMockGitserverClient creates a new mock of the GitserverClient
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockGitserverClient() *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitsExistFunc: &GitserverClientCommitsExistFunc{
-			defaultHook: func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
-				panic("unexpected invocation of MockGitserverClient.CommitsExist")
-			},
-		},
-		DiffPathFunc: &GitserverClientDiffPathFunc{
-			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
-				panic("unexpected invocation of MockGitserverClient.DiffPath")
+// NewStrictMockStore creates a new mock of the Store interface. All methods
+// panic on invocation, unless overwritten.
+func NewStrictMockStore() *MockStore {
+	return &MockStore{
+		GetUnsafeDBFunc: &StoreGetUnsafeDBFunc{
+			defaultHook: func() database.DB {
+				panic("unexpected invocation of MockStore.GetUnsafeDB")
 			},
 		},
 	}
 }
 
-// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitsExistFunc: &GitserverClientCommitsExistFunc{
-			defaultHook: i.CommitsExist,
-		},
-		DiffPathFunc: &GitserverClientDiffPathFunc{
-			defaultHook: i.DiffPath,
+// NewMockStoreFrom creates a new mock of the MockStore interface. All
+// methods delegate to the given implementation, unless overwritten.
+func NewMockStoreFrom(i store.Store) *MockStore {
+	return &MockStore{
+		GetUnsafeDBFunc: &StoreGetUnsafeDBFunc{
+			defaultHook: i.GetUnsafeDB,
 		},
 	}
 }
 
-// GitserverClientCommitsExistFunc describes the behavior when the
-// CommitsExist method of the parent MockGitserverClient instance is
-// invoked.
-type GitserverClientCommitsExistFunc struct {
-	defaultHook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)
-	hooks       []func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)
-	history     []GitserverClientCommitsExistFuncCall
+// StoreGetUnsafeDBFunc describes the behavior when the GetUnsafeDB method
+// of the parent MockStore instance is invoked.
+type StoreGetUnsafeDBFunc struct {
+	defaultHook func() database.DB
+	hooks       []func() database.DB
+	history     []StoreGetUnsafeDBFuncCall
 	mutex       sync.Mutex
 }
 
-// CommitsExist delegates to the next hook function in the queue and stores
+// GetUnsafeDB delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockGitserverClient) CommitsExist(v0 context.Context, v1 []gitserver.RepositoryCommit) ([]bool, error) {
-	r0, r1 := m.CommitsExistFunc.nextHook()(v0, v1)
-	m.CommitsExistFunc.appendCall(GitserverClientCommitsExistFuncCall{v0, v1, r0, r1})
-	return r0, r1
+func (m *MockStore) GetUnsafeDB() database.DB {
+	r0 := m.GetUnsafeDBFunc.nextHook()()
+	m.GetUnsafeDBFunc.appendCall(StoreGetUnsafeDBFuncCall{r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the CommitsExist method
-// of the parent MockGitserverClient instance is invoked and the hook queue
-// is empty.
-func (f *GitserverClientCommitsExistFunc) SetDefaultHook(hook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)) {
+// SetDefaultHook sets function that is called when the GetUnsafeDB method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetUnsafeDBFunc) SetDefaultHook(hook func() database.DB) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CommitsExist method of the parent MockGitserverClient instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *GitserverClientCommitsExistFunc) PushHook(hook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)) {
+// GetUnsafeDB method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetUnsafeDBFunc) PushHook(hook func() database.DB) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2255,20 +2815,20 @@ func (f *GitserverClientCommitsExistFunc) PushHook(hook func(context.Context, []
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *GitserverClientCommitsExistFunc) SetDefaultReturn(r0 []bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
-		return r0, r1
+func (f *StoreGetUnsafeDBFunc) SetDefaultReturn(r0 database.DB) {
+	f.SetDefaultHook(func() database.DB {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *GitserverClientCommitsExistFunc) PushReturn(r0 []bool, r1 error) {
-	f.PushHook(func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
-		return r0, r1
+func (f *StoreGetUnsafeDBFunc) PushReturn(r0 database.DB) {
+	f.PushHook(func() database.DB {
+		return r0
 	})
 }
 
-func (f *GitserverClientCommitsExistFunc) nextHook() func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
+func (f *StoreGetUnsafeDBFunc) nextHook() func() database.DB {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2281,81 +2841,116 @@ func (f *GitserverClientCommitsExistFunc) nextHook() func(context.Context, []git
 	return hook
 }
 
-func (f *GitserverClientCommitsExistFunc) appendCall(r0 GitserverClientCommitsExistFuncCall) {
+func (f *StoreGetUnsafeDBFunc) appendCall(r0 StoreGetUnsafeDBFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of GitserverClientCommitsExistFuncCall objects
-// describing the invocations of this function.
-func (f *GitserverClientCommitsExistFunc) History() []GitserverClientCommitsExistFuncCall {
+// History returns a sequence of StoreGetUnsafeDBFuncCall objects describing
+// the invocations of this function.
+func (f *StoreGetUnsafeDBFunc) History() []StoreGetUnsafeDBFuncCall {
 	f.mutex.Lock()
-	history := make([]GitserverClientCommitsExistFuncCall, len(f.history))
+	history := make([]StoreGetUnsafeDBFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitserverClientCommitsExistFuncCall is an object that describes an
-// invocation of method CommitsExist on an instance of MockGitserverClient.
-type GitserverClientCommitsExistFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 []gitserver.RepositoryCommit
+// StoreGetUnsafeDBFuncCall is an object that describes an invocation of
+// method GetUnsafeDB on an instance of MockStore.
+type StoreGetUnsafeDBFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []bool
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 database.DB
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitserverClientCommitsExistFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c StoreGetUnsafeDBFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitserverClientCommitsExistFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c StoreGetUnsafeDBFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// GitserverClientDiffPathFunc describes the behavior when the DiffPath
-// method of the parent MockGitserverClient instance is invoked.
-type GitserverClientDiffPathFunc struct {
-	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)
-	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)
-	history     []GitserverClientDiffPathFuncCall
+// MockSymbolsClient is a mock implementation of the SymbolsClient interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
+// used for unit testing.
+type MockSymbolsClient struct {
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *SymbolsClientSearchFunc
+}
+
+// NewMockSymbolsClient creates a new mock of the SymbolsClient interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockSymbolsClient() *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: func(context.Context, search.SymbolsParameters) (r0 []result.Symbol, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockSymbolsClient creates a new mock of the SymbolsClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSymbolsClient() *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: func(context.Context, search.SymbolsParameters) ([]result.Symbol, error) {
+				panic("unexpected invocation of MockSymbolsClient.Search")
+			},
+		},
+	}
+}
+
+// NewMockSymbolsClientFrom creates a new mock of the MockSymbolsClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSymbolsClientFrom(i SymbolsClient) *MockSymbolsClient {
+	return &MockSymbolsClient{
+		SearchFunc: &SymbolsClientSearchFunc{
+			defaultHook: i.Search,
+		},
+	}
+}
+
+// SymbolsClientSearchFunc describes the behavior when the Search method of
+// the parent MockSymbolsClient instance is invoked.
+type SymbolsClientSearchFunc struct {
+	defaultHook func(context.Context, search.SymbolsParameters) ([]result.Symbol, error)
+	hooks       []func(context.Context, search.SymbolsParameters) ([]result.Symbol, error)
+	history     []SymbolsClientSearchFuncCall
 	mutex       sync.Mutex
 }
 
-// DiffPath delegates to the next hook function in the queue and stores the
+// Search delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockGitserverClient) DiffPath(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string) ([]*diff.Hunk, error) {
-	r0, r1 := m.DiffPathFunc.nextHook()(v0, v1, v2, v3, v4, v5)
-	m.DiffPathFunc.appendCall(GitserverClientDiffPathFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+func (m *MockSymbolsClient) Search(v0 context.Context, v1 search.SymbolsParameters) ([]result.Symbol, error) {
+	r0, r1 := m.SearchFunc.nextHook()(v0, v1)
+	m.SearchFunc.appendCall(SymbolsClientSearchFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the DiffPath method of
-// the parent MockGitserverClient instance is invoked and the hook queue is
-// empty.
-func (f *GitserverClientDiffPathFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)) {
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockSymbolsClient instance is invoked and the hook queue is empty.
+func (f *SymbolsClientSearchFunc) SetDefaultHook(hook func(context.Context, search.SymbolsParameters) ([]result.Symbol, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DiffPath method of the parent MockGitserverClient instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *GitserverClientDiffPathFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)) {
+// Search method of the parent MockSymbolsClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SymbolsClientSearchFunc) PushHook(hook func(context.Context, search.SymbolsParameters) ([]result.Symbol, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2363,20 +2958,20 @@ func (f *GitserverClientDiffPathFunc) PushHook(hook func(context.Context, authz.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *GitserverClientDiffPathFunc) SetDefaultReturn(r0 []*diff.Hunk, r1 error) {
-	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+func (f *SymbolsClientSearchFunc) SetDefaultReturn(r0 []result.Symbol, r1 error) {
+	f.SetDefaultHook(func(context.Context, search.SymbolsParameters) ([]result.Symbol, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *GitserverClientDiffPathFunc) PushReturn(r0 []*diff.Hunk, r1 error) {
-	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+func (f *SymbolsClientSearchFunc) PushReturn(r0 []result.Symbol, r1 error) {
+	f.PushHook(func(context.Context, search.SymbolsParameters) ([]result.Symbol, error) {
 		return r0, r1
 	})
 }
 
-func (f *GitserverClientDiffPathFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+func (f *SymbolsClientSearchFunc) nextHook() func(context.Context, search.SymbolsParameters) ([]result.Symbol, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2389,47 +2984,35 @@ func (f *GitserverClientDiffPathFunc) nextHook() func(context.Context, authz.Sub
 	return hook
 }
 
-func (f *GitserverClientDiffPathFunc) appendCall(r0 GitserverClientDiffPathFuncCall) {
+func (f *SymbolsClientSearchFunc) appendCall(r0 SymbolsClientSearchFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of GitserverClientDiffPathFuncCall objects
+// History returns a sequence of SymbolsClientSearchFuncCall objects
 // describing the invocations of this function.
-func (f *GitserverClientDiffPathFunc) History() []GitserverClientDiffPathFuncCall {
+func (f *SymbolsClientSearchFunc) History() []SymbolsClientSearchFuncCall {
 	f.mutex.Lock()
-	history := make([]GitserverClientDiffPathFuncCall, len(f.history))
+	history := make([]SymbolsClientSearchFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitserverClientDiffPathFuncCall is an object that describes an invocation
-// of method DiffPath on an instance of MockGitserverClient.
-type GitserverClientDiffPathFuncCall struct {
+// SymbolsClientSearchFuncCall is an object that describes an invocation of
+// method Search on an instance of MockSymbolsClient.
+type SymbolsClientSearchFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 authz.SubRepoPermissionChecker
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 api.RepoName
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 string
-	// Arg5 is the value of the 6th argument passed to this method
-	// invocation.
-	Arg5 string
+	Arg1 search.SymbolsParameters
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*diff.Hunk
+	Result0 []result.Symbol
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2437,13 +3020,13 @@ type GitserverClientDiffPathFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitserverClientDiffPathFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+func (c SymbolsClientSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
+func (c SymbolsClientSearchFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -2452,6 +3035,10 @@ func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav)
 // used for unit testing.
 type MockUploadService struct {
+	// GetDependencyGraphRepositoryNamesFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetDependencyGraphRepositoryNames.
+	GetDependencyGraphRepositoryNamesFunc *UploadServiceGetDependencyGraphRepositoryNamesFunc
 	// GetDumpsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDumpsByIDs.
 	GetDumpsByIDsFunc *UploadServiceGetDumpsByIDsFunc
@@ -2472,6 +3059,11 @@ type MockUploadService struct {
 // All methods return zero values for all results, unless overwritten.
 func NewMockUploadService() *MockUploadService {
 	return &MockUploadService{
+		GetDependencyGraphRepositoryNamesFunc: &UploadServiceGetDependencyGraphRepositoryNamesFunc{
+			defaultHook: func(context.Context, int, int) (r0 []api.RepoName, r1 error) {
+				return
+			},
+		},
 		GetDumpsByIDsFunc: &UploadServiceGetDumpsByIDsFunc{
 			defaultHook: func(context.Context, []int) (r0 []types.Dump, r1 error) {
 				return
@@ -2499,6 +3091,11 @@ func NewMockUploadService() *MockUploadService {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockUploadService() *MockUploadService {
 	return &MockUploadService{
+		GetDependencyGraphRepositoryNamesFunc: &UploadServiceGetDependencyGraphRepositoryNamesFunc{
+			defaultHook: func(context.Context, int, int) ([]api.RepoName, error) {
+				panic("unexpected invocation of MockUploadService.GetDependencyGraphRepositoryNames")
+			},
+		},
 		GetDumpsByIDsFunc: &UploadServiceGetDumpsByIDsFunc{
 			defaultHook: func(context.Context, []int) ([]types.Dump, error) {
 				panic("unexpected invocation of MockUploadService.GetDumpsByIDs")
@@ -2527,6 +3124,9 @@ func NewStrictMockUploadService() *MockUploadService {
 // overwritten.
 func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 	return &MockUploadService{
+		GetDependencyGraphRepositoryNamesFunc: &UploadServiceGetDependencyGraphRepositoryNamesFunc{
+			defaultHook: i.GetDependencyGraphRepositoryNames,
+		},
 		GetDumpsByIDsFunc: &UploadServiceGetDumpsByIDsFunc{
 			defaultHook: i.GetDumpsByIDs,
 		},
@@ -2542,6 +3142,121 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 	}
 }
 
+// UploadServiceGetDependencyGraphRepositoryNamesFunc describes the behavior
+// when the GetDependencyGraphRepositoryNames method of the parent
+// MockUploadService instance is invoked.
+type UploadServiceGetDependencyGraphRepositoryNamesFunc struct {
+	defaultHook func(context.Context, int, int) ([]api.RepoName, error)
+	hooks       []func(context.Context, int, int) ([]api.RepoName, error)
+	history     []UploadServiceGetDependencyGraphRepositoryNamesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDependencyGraphRepositoryNames delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetDependencyGraphRepositoryNames(v0 context.Context, v1 int, v2 int) ([]api.RepoName, error) {
+	r0, r1 := m.GetDependencyGraphRepositoryNamesFunc.nextHook()(v0, v1, v2)
+	m.GetDependencyGraphRepositoryNamesFunc.appendCall(UploadServiceGetDependencyGraphRepositoryNamesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetDependencyGraphRepositoryNames method of the parent MockUploadService
+// instance is invoked and the hook queue is empty.
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) SetDefaultHook(hook func(context.Context, int, int) ([]api.RepoName, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDependencyGraphRepositoryNames method of the parent MockUploadService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) PushHook(hook func(context.Context, int, int) ([]api.RepoName, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) SetDefaultReturn(r0 []api.RepoName, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) PushReturn(r0 []api.RepoName, r1 error) {
+	f.PushHook(func(context.Context, int, int) ([]api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) nextHook() func(context.Context, int, int) ([]api.RepoName, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) appendCall(r0 UploadServiceGetDependencyGraphRepositoryNamesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadServiceGetDependencyGraphRepositoryNamesFuncCall objects describing
+// the invocations of this function.
+func (f *UploadServiceGetDependencyGraphRepositoryNamesFunc) History() []UploadServiceGetDependencyGraphRepositoryNamesFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetDependencyGraphRepositoryNamesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetDependencyGraphRepositoryNamesFuncCall is an object that
+// describes an invocation of method GetDependencyGraphRepositoryNames on an
+// instance of MockUploadService.
+type UploadServiceGetDependencyGraphRepositoryNamesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []api.RepoName
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetDependencyGraphRepositoryNamesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetDependencyGraphRepositoryNamesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadServiceGetDumpsByIDsFunc describes the behavior when the
 // GetDumpsByIDs method of the parent MockUploadService instance is invoked.
 type UploadServiceGetDumpsByIDsFunc struct {
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	sgTypes "github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -23,9 +24,10 @@ type RequestState struct {
 
 	authChecker authz.SubRepoPermissionChecker
 
-	RepositoryID int
-	Commit       string
-	Path         string
+	RepositoryID   int
+	RepositoryName api.RepoName
+	Commit         string
+	Path           string
 }
 
 func NewRequestState(
@@ -39,9 +41,10 @@ func NewRequestState(
 	hunkCache HunkCache,
 ) RequestState {
 	r := &RequestState{
-		RepositoryID: int(repo.ID),
-		Commit:       commit,
-		Path:         path,
+		RepositoryID:   int(repo.ID),
+		RepositoryName: repo.Name,
+		Commit:         commit,
+		Path:           path,
 	}
 	r.SetUploadsDataLoader(uploads)
 	r.SetAuthChecker(authChecker)
@@ -53,6 +56,9 @@ func NewRequestState(
 }
 
 func (r RequestState) GetCacheUploads() []types.Dump {
+	if r.dataLoader == nil {
+		return nil
+	}
 	return r.dataLoader.uploads
 }
 
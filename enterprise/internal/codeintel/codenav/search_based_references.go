@@ -0,0 +1,280 @@
+package codenav
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/grafana/regexp"
+	traceLog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maximumSearchBasedDependencyRepos is the maximum number of dependency and dependent
+// repositories that are searched for search-based references.
+const maximumSearchBasedDependencyRepos = 50
+
+// GetSearchBasedReferences returns the locations referencing the identifier at the given
+// position without consulting any precise code intelligence data. Definitions of the
+// identifier are read from the symbols index of the current repository, and references
+// are found by a word-boundary text search over the current repository and its dependencies
+// and dependents. If filter is non-empty, only locations whose path contains it are returned.
+// The results are ranked heuristically and are not paginated.
+func (s *Service) GetSearchBasedReferences(ctx context.Context, args shared.RequestArgs, repo api.RepoName, filter string) (_ []types.UploadLocation, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getSearchBasedReferences, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+			traceLog.String("filter", filter),
+		},
+	})
+	defer endObservation()
+
+	contents, err := s.gitserver.RawContents(ctx, args.RepositoryID, args.Commit, args.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.RawContents")
+	}
+
+	name := identifierAt(contents, args.Line, args.Character)
+	if name == "" {
+		return nil, nil
+	}
+	trace.Log(traceLog.String("name", name))
+
+	definitions, err := s.symbols.Search(ctx, search.SymbolsParameters{
+		Repo:            repo,
+		CommitID:        api.CommitID(args.Commit),
+		Query:           "^" + regexp.QuoteMeta(name) + "$",
+		IsRegExp:        true,
+		IsCaseSensitive: true,
+		First:           args.Limit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "symbols.Search")
+	}
+
+	language, _ := inventory.GetLanguageByFilename(args.Path)
+	repos, err := s.getSearchBasedReferenceRepos(ctx, args.RepositoryID, repo)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(traceLog.Int("numRepos", len(repos)))
+
+	matches, err := s.searchWordBoundary(ctx, name, language, filter, repos, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]searchBasedLocation, 0, len(definitions)+len(matches))
+	for _, symbol := range definitions {
+		candidates = append(candidates, searchBasedLocation{
+			location: types.UploadLocation{
+				Dump:         types.Dump{RepositoryID: args.RepositoryID, RepositoryName: string(repo), Commit: args.Commit},
+				Path:         symbol.Path,
+				TargetCommit: args.Commit,
+				TargetRange: types.Range{
+					Start: types.Position{Line: symbol.Line - 1, Character: symbol.Character},
+					End:   types.Position{Line: symbol.Line - 1, Character: symbol.Character + len(symbol.Name)},
+				},
+			},
+			definition: true,
+		})
+	}
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		dump := types.Dump{RepositoryID: int(fm.Repo.ID), RepositoryName: string(fm.Repo.Name), Commit: string(fm.CommitID)}
+		for _, chunk := range fm.ChunkMatches {
+			for _, rn := range chunk.Ranges {
+				candidates = append(candidates, searchBasedLocation{
+					location: types.UploadLocation{
+						Dump:         dump,
+						Path:         fm.Path,
+						TargetCommit: string(fm.CommitID),
+						TargetRange: types.Range{
+							Start: types.Position{Line: rn.Start.Line, Character: rn.Start.Column},
+							End:   types.Position{Line: rn.End.Line, Character: rn.End.Column},
+						},
+					},
+				})
+			}
+		}
+	}
+
+	locations := rankSearchBasedLocations(candidates, args.RepositoryID, args.Path)
+	if filter != "" {
+		filtered := locations[:0]
+		for _, location := range locations {
+			if strings.Contains(location.Path, filter) {
+				filtered = append(filtered, location)
+			}
+		}
+		locations = filtered
+	}
+	if args.Limit > 0 && len(locations) > args.Limit {
+		locations = locations[:args.Limit]
+	}
+	trace.Log(traceLog.Int("numLocations", len(locations)))
+
+	return locations, nil
+}
+
+// getSearchBasedReferenceRepos returns the given repository followed by the repositories
+// adjacent to it in the package graph of precise code intelligence uploads: the repositories
+// providing packages it depends on, then the repositories depending on packages it provides.
+func (s *Service) getSearchBasedReferenceRepos(ctx context.Context, repositoryID int, repo api.RepoName) ([]api.RepoName, error) {
+	graphRepos, err := s.uploadSvc.GetDependencyGraphRepositoryNames(ctx, repositoryID, maximumSearchBasedDependencyRepos)
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadSvc.GetDependencyGraphRepositoryNames")
+	}
+
+	return append([]api.RepoName{repo}, graphRepos...), nil
+}
+
+// searchWordBoundary searches the given repositories for whole-word, case-sensitive
+// occurrences of name in files of the given language whose path contains filter.
+func (s *Service) searchWordBoundary(ctx context.Context, name, language, filter string, repos []api.RepoName, limit int) (result.Matches, error) {
+	patterns := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		patterns = append(patterns, regexp.QuoteMeta(string(repo)))
+	}
+
+	query := fmt.Sprintf(`repo:^(%s)$ type:file case:yes patterntype:regexp`, strings.Join(patterns, "|"))
+	if language != "" {
+		query += fmt.Sprintf(" lang:%q", language)
+	}
+	if filter != "" {
+		query += fmt.Sprintf(" file:%q", regexp.QuoteMeta(filter))
+	}
+	if limit > 0 {
+		query += fmt.Sprintf(" count:%d", limit)
+	}
+	query += fmt.Sprintf(` \b%s\b`, regexp.QuoteMeta(name))
+
+	inputs, err := s.searchClient.Plan(ctx, "V3", nil, query, search.Precise, search.Streaming, &schema.Settings{}, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return nil, errors.Wrap(err, "searchClient.Plan")
+	}
+
+	agg := streaming.NewAggregatingStream()
+	if _, err := s.searchClient.Execute(ctx, agg, inputs); err != nil {
+		return nil, errors.Wrap(err, "searchClient.Execute")
+	}
+
+	return agg.Results, nil
+}
+
+// searchBasedLocation is a candidate location found by search-based code navigation.
+type searchBasedLocation struct {
+	location   types.UploadLocation
+	definition bool
+}
+
+// rankSearchBasedLocations de-duplicates the given candidates and orders them so that
+// the most likely relevant locations come first: definitions precede references, and
+// locations in the source repository, then in the source directory, precede all others.
+// Locations in test files are ranked last within each of these groups.
+func rankSearchBasedLocations(candidates []searchBasedLocation, repositoryID int, sourcePath string) []types.UploadLocation {
+	type key struct {
+		repositoryID int
+		path         string
+		start        types.Position
+	}
+
+	seen := make(map[key]struct{}, len(candidates))
+	deduped := candidates[:0]
+	for _, candidate := range candidates {
+		k := key{candidate.location.Dump.RepositoryID, candidate.location.Path, candidate.location.TargetRange.Start}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		deduped = append(deduped, candidate)
+	}
+
+	rank := func(candidate searchBasedLocation) []bool {
+		sameRepo := candidate.location.Dump.RepositoryID == repositoryID
+		return []bool{
+			!candidate.definition,
+			!sameRepo,
+			!sameRepo || path.Dir(candidate.location.Path) != path.Dir(sourcePath),
+			isTestPath(candidate.location.Path),
+		}
+	}
+
+	sort.SliceStable(deduped, func(i, j int) bool {
+		ri, rj := rank(deduped[i]), rank(deduped[j])
+		for k := range ri {
+			if ri[k] != rj[k] {
+				return !ri[k]
+			}
+		}
+		return false
+	})
+
+	locations := make([]types.UploadLocation, 0, len(deduped))
+	for _, candidate := range deduped {
+		locations = append(locations, candidate.location)
+	}
+	return locations
+}
+
+var testPathPattern = regexp.MustCompile(`(^|/)(tests?|__tests__|spec)/|[._-](test|spec)\.[^/]+$|(^|/)test_[^/]+$`)
+
+// isTestPath returns true if the given path looks like a test file.
+func isTestPath(path string) bool {
+	return testPathPattern.MatchString(path)
+}
+
+// identifierAt returns the identifier enclosing the given zero-based line and
+// character of the given file contents, or an empty string if the position
+// does not fall on an identifier.
+func identifierAt(contents []byte, line, character int) string {
+	lines := bytes.Split(contents, []byte("\n"))
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+
+	runes := []rune(string(lines[line]))
+	if character < 0 || character >= len(runes) || !isIdentifierRune(runes[character]) {
+		return ""
+	}
+
+	start, end := character, character+1
+	for start > 0 && isIdentifierRune(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isIdentifierRune(runes[end]) {
+		end++
+	}
+
+	if unicode.IsDigit(runes[start]) {
+		return ""
+	}
+	return string(runes[start:end])
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
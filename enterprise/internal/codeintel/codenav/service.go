@@ -23,12 +23,14 @@ import (
 )
 
 type Service struct {
-	store      store.Store
-	lsifstore  lsifstore.LsifStore
-	gitserver  GitserverClient
-	uploadSvc  UploadService
-	operations *operations
-	logger     log.Logger
+	store        store.Store
+	lsifstore    lsifstore.LsifStore
+	gitserver    GitserverClient
+	uploadSvc    UploadService
+	symbols      SymbolsClient
+	searchClient SearchClient
+	operations   *operations
+	logger       log.Logger
 }
 
 func newService(
@@ -37,14 +39,18 @@ func newService(
 	lsifstore lsifstore.LsifStore,
 	uploadSvc UploadService,
 	gitserver GitserverClient,
+	symbols SymbolsClient,
+	searchClient SearchClient,
 ) *Service {
 	return &Service{
-		store:      store,
-		lsifstore:  lsifstore,
-		gitserver:  gitserver,
-		uploadSvc:  uploadSvc,
-		operations: newOperations(observationCtx),
-		logger:     log.Scoped("codenav", ""),
+		store:        store,
+		lsifstore:    lsifstore,
+		gitserver:    gitserver,
+		uploadSvc:    uploadSvc,
+		symbols:      symbols,
+		searchClient: searchClient,
+		operations:   newOperations(observationCtx),
+		logger:       log.Scoped("codenav", ""),
 	}
 }
 
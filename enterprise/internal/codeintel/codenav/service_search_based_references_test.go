@@ -0,0 +1,170 @@
+package codenav
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSearchBasedReferences(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockSearchClient := NewMockSearchClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, mockSymbolsClient, mockSearchClient)
+
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte("package s1\n\nfunc main() {\n\tdoThing(42)\n}\n"), nil)
+	mockUploadSvc.GetDependencyGraphRepositoryNamesFunc.SetDefaultReturn([]api.RepoName{"go/github.com/sourcegraph/dep"}, nil)
+	mockSymbolsClient.SearchFunc.SetDefaultReturn(result.Symbols{
+		{Name: "doThing", Path: "s1/thing.go", Line: 8, Character: 5},
+	}, nil)
+
+	var searchQuery string
+	mockSearchClient.PlanFunc.SetDefaultHook(func(_ context.Context, _ string, _ *string, query string, _ search.Mode, _ search.Protocol, _ *schema.Settings, _ bool) (*search.Inputs, error) {
+		searchQuery = query
+		return &search.Inputs{}, nil
+	})
+	mockSearchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, stream streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		fileMatch := func(repoID api.RepoID, repoName api.RepoName, path string, line, column int) *result.FileMatch {
+			return &result.FileMatch{
+				File: result.File{Repo: sgtypes.MinimalRepo{ID: repoID, Name: repoName}, CommitID: "cafebabe", Path: path},
+				ChunkMatches: result.ChunkMatches{{
+					Ranges: result.Ranges{{
+						Start: result.Location{Line: line, Column: column},
+						End:   result.Location{Line: line, Column: column + len("doThing")},
+					}},
+				}},
+			}
+		}
+
+		stream.Send(streaming.SearchEvent{Results: result.Matches{
+			fileMatch(43, "go/github.com/sourcegraph/dep", "dep.go", 3, 1),
+			fileMatch(42, "github.com/sourcegraph/s1", "s2/other_test.go", 5, 1),
+			fileMatch(42, "github.com/sourcegraph/s1", "s2/other.go", 6, 1),
+			fileMatch(42, "github.com/sourcegraph/s1", "s1/main.go", 3, 1),
+			fileMatch(42, "github.com/sourcegraph/s1", "s1/thing.go", 7, 5),
+		}})
+		return nil, nil
+	})
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         3,
+		Character:    3,
+		Limit:        50,
+	}
+	locations, err := svc.GetSearchBasedReferences(context.Background(), mockRequest, "github.com/sourcegraph/s1", "")
+	if err != nil {
+		t.Fatalf("unexpected error querying search-based references: %s", err)
+	}
+
+	if history := mockUploadSvc.GetDependencyGraphRepositoryNamesFunc.History(); len(history) != 1 || history[0].Arg1 != 42 {
+		t.Errorf("expected the dependency graph of repository 42 to be read, got %v", history)
+	}
+
+	for _, expected := range []string{`repo:^(github\.com/sourcegraph/s1|go/github\.com/sourcegraph/dep)$`, `lang:"Go"`, `case:yes`, `\bdoThing\b`} {
+		if !strings.Contains(searchQuery, expected) {
+			t.Errorf("expected search query %q to contain %q", searchQuery, expected)
+		}
+	}
+
+	location := func(repositoryID int, repositoryName, commit, path string, line, character int) types.UploadLocation {
+		return types.UploadLocation{
+			Dump:         types.Dump{RepositoryID: repositoryID, RepositoryName: repositoryName, Commit: commit},
+			Path:         path,
+			TargetCommit: commit,
+			TargetRange: types.Range{
+				Start: types.Position{Line: line, Character: character},
+				End:   types.Position{Line: line, Character: character + len("doThing")},
+			},
+		}
+	}
+	expectedLocations := []types.UploadLocation{
+		location(42, "github.com/sourcegraph/s1", mockCommit, "s1/thing.go", 7, 5),
+		location(42, "github.com/sourcegraph/s1", "cafebabe", "s1/main.go", 3, 1),
+		location(42, "github.com/sourcegraph/s1", "cafebabe", "s2/other.go", 6, 1),
+		location(42, "github.com/sourcegraph/s1", "cafebabe", "s2/other_test.go", 5, 1),
+		location(43, "go/github.com/sourcegraph/dep", "cafebabe", "dep.go", 3, 1),
+	}
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	// The filter must be applied before the results are truncated to the limit
+	mockRequest.Limit = 1
+	locations, err = svc.GetSearchBasedReferences(context.Background(), mockRequest, "github.com/sourcegraph/s1", "s2/")
+	if err != nil {
+		t.Fatalf("unexpected error querying search-based references: %s", err)
+	}
+	if !strings.Contains(searchQuery, `file:"s2/"`) {
+		t.Errorf("expected search query %q to filter by file", searchQuery)
+	}
+	if diff := cmp.Diff(expectedLocations[2:3], locations); diff != "" {
+		t.Errorf("unexpected filtered locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedReferencesNoIdentifier(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockSymbolsClient := NewMockSymbolsClient()
+	mockSearchClient := NewMockSearchClient()
+	svc := newService(&observation.TestContext, NewMockStore(), NewMockLsifStore(), NewMockUploadService(), mockGitserverClient, mockSymbolsClient, mockSearchClient)
+
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte("package s1\n\n// 42 + 1\n"), nil)
+
+	locations, err := svc.GetSearchBasedReferences(context.Background(), shared.RequestArgs{Path: mockPath, Line: 2, Character: 4}, "github.com/sourcegraph/s1", "")
+	if err != nil {
+		t.Fatalf("unexpected error querying search-based references: %s", err)
+	}
+	if len(locations) != 0 {
+		t.Errorf("expected no locations, got %v", locations)
+	}
+	if len(mockSymbolsClient.SearchFunc.History()) != 0 || len(mockSearchClient.PlanFunc.History()) != 0 {
+		t.Errorf("expected no searches without an identifier")
+	}
+}
+
+func TestIdentifierAt(t *testing.T) {
+	contents := []byte("func main() {\n\tfmt.Println(café_2, $el)\n}\n")
+
+	testCases := []struct {
+		line, character int
+		expected        string
+	}{
+		{0, 0, "func"},
+		{0, 7, "main"},
+		{0, 9, ""},
+		{1, 1, "fmt"},
+		{1, 4, ""},
+		{1, 8, "Println"},
+		{1, 16, "café_2"},
+		{1, 22, "$el"},
+		{3, 0, ""},
+		{7, 0, ""},
+	}
+
+	for _, testCase := range testCases {
+		if actual := identifierAt(contents, testCase.line, testCase.character); actual != testCase.expected {
+			t.Errorf("unexpected identifier at %d:%d. want=%q have=%q", testCase.line, testCase.character, testCase.expected, actual)
+		}
+	}
+}
@@ -154,6 +154,8 @@ func (r *gitBlobLSIFDataResolver) Definitions(ctx context.Context, args *resolve
 const DefaultReferencesPageSize = 100
 
 // References returns the list of source locations that reference the symbol at the given position.
+// If there is no precise upload for this file, the references are found by search-based heuristics
+// instead and the returned connection is marked as imprecise.
 func (r *gitBlobLSIFDataResolver) References(ctx context.Context, args *resolverstubs.LSIFPagedQueryPositionArgs) (_ resolverstubs.LocationConnectionResolver, err error) {
 	limit := derefInt32(args.First, DefaultReferencesPageSize)
 	if limit <= 0 {
@@ -169,6 +171,20 @@ func (r *gitBlobLSIFDataResolver) References(ctx context.Context, args *resolver
 	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.references, time.Second, getObservationArgs(requestArgs))
 	defer endObservation()
 
+	if len(r.requestState.GetCacheUploads()) == 0 {
+		var filter string
+		if args.Filter != nil {
+			filter = *args.Filter
+		}
+
+		refs, err := r.codeNavSvc.GetSearchBasedReferences(ctx, requestArgs, r.requestState.RepositoryName, filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "svc.GetSearchBasedReferences")
+		}
+
+		return NewImpreciseLocationConnectionResolver(refs, r.locationResolver), nil
+	}
+
 	// Decode cursor given from previous response or create a new one with default values.
 	// We use the cursor state track offsets with the result set and cache initial data that
 	// is used to resolve each page. This cursor will be modified in-place to become the
@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -100,6 +101,7 @@ func TestReferences(t *testing.T) {
 		Commit:       "deadbeef1",
 		Path:         "/src/main",
 	}
+	mockRequestState.SetUploadsDataLoader([]types.Dump{{ID: 42}})
 	mockOperations := newOperations(&observation.TestContext)
 
 	resolver := NewGitBlobLSIFDataResolver(
@@ -157,6 +159,7 @@ func TestReferencesDefaultLimit(t *testing.T) {
 		Commit:       "deadbeef1",
 		Path:         "/src/main",
 	}
+	mockRequestState.SetUploadsDataLoader([]types.Dump{{ID: 42}})
 	mockOperations := newOperations(&observation.TestContext)
 
 	resolver := NewGitBlobLSIFDataResolver(
@@ -225,6 +228,69 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestReferencesSearchBasedFallback(t *testing.T) {
+	mockAutoIndexingSvc := NewMockAutoIndexingService()
+	mockAutoIndexingSvc.GetUnsafeDBFunc.SetDefaultReturn(database.NewMockDB())
+	mockUploadsService := NewMockUploadsService()
+	mockPolicyService := NewMockPolicyService()
+	mockCodeNavService := NewMockCodeNavService()
+	mockCodeNavService.GetSearchBasedReferencesFunc.SetDefaultReturn([]types.UploadLocation{{Path: "cmd/main.go"}}, nil)
+	mockRequestState := codenav.RequestState{
+		RepositoryID:   1,
+		RepositoryName: "github.com/sourcegraph/sourcegraph",
+		Commit:         "deadbeef1",
+		Path:           "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := NewGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		mockAutoIndexingSvc,
+		mockUploadsService,
+		mockPolicyService,
+		mockRequestState,
+		observation.NewErrorCollector(),
+		mockOperations,
+	)
+
+	filter := "cmd/"
+	args := &resolverstubs.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: resolverstubs.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		Filter: &filter,
+	}
+
+	connection, err := resolver.References(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !connection.Imprecise() {
+		t.Errorf("expected search-based references to be imprecise")
+	}
+
+	if len(mockCodeNavService.GetReferencesFunc.History()) != 0 {
+		t.Fatalf("unexpected precise references call without uploads")
+	}
+	if len(mockCodeNavService.GetSearchBasedReferencesFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockCodeNavService.GetSearchBasedReferencesFunc.History()))
+	}
+	call := mockCodeNavService.GetSearchBasedReferencesFunc.History()[0]
+	if call.Arg1.Line != 10 || call.Arg1.Character != 15 {
+		t.Errorf("unexpected position. want=%d:%d have=%d:%d", 10, 15, call.Arg1.Line, call.Arg1.Character)
+	}
+	if call.Arg1.Limit != DefaultReferencesPageSize {
+		t.Errorf("unexpected limit. want=%d have=%d", DefaultReferencesPageSize, call.Arg1.Limit)
+	}
+	if call.Arg2 != "github.com/sourcegraph/sourcegraph" {
+		t.Errorf("unexpected repo. want=%s have=%s", "github.com/sourcegraph/sourcegraph", call.Arg2)
+	}
+	if call.Arg3 != filter {
+		t.Errorf("unexpected filter. want=%s have=%s", filter, call.Arg3)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockAutoIndexingSvc := NewMockAutoIndexingService()
 	mockUploadsService := NewMockUploadsService()
@@ -26,6 +26,7 @@ type CodeNavService interface {
 	GetDiagnostics(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState) (diagnosticsAtUploads []shared.DiagnosticAtUpload, _ int, err error)
 	GetRanges(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState, startLine, endLine int) (adjustedRanges []shared.AdjustedCodeIntelligenceRange, err error)
 	GetStencil(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState) (adjustedRanges []types.Range, err error)
+	GetSearchBasedReferences(ctx context.Context, args shared.RequestArgs, repo api.RepoName, filter string) (_ []types.UploadLocation, err error)
 
 	// Uploads Service
 	GetDumpsByIDs(ctx context.Context, ids []int) (_ []types.Dump, err error)
@@ -37,6 +38,7 @@ type CodeNavService interface {
 type GitserverClient interface {
 	CommitsExist(ctx context.Context, commits []gitserver.RepositoryCommit) ([]bool, error)
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
+	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
 }
 
 type AutoIndexingService interface {
@@ -12,6 +12,7 @@ type locationConnectionResolver struct {
 	locations        []types.UploadLocation
 	cursor           *string
 	locationResolver *sharedresolvers.CachedLocationResolver
+	imprecise        bool
 }
 
 func NewLocationConnectionResolver(locations []types.UploadLocation, cursor *string, locationResolver *sharedresolvers.CachedLocationResolver) resolverstubs.LocationConnectionResolver {
@@ -22,6 +23,16 @@ func NewLocationConnectionResolver(locations []types.UploadLocation, cursor *str
 	}
 }
 
+// NewImpreciseLocationConnectionResolver returns a connection of locations that were
+// found by search-based heuristics rather than from precise code intelligence data.
+func NewImpreciseLocationConnectionResolver(locations []types.UploadLocation, locationResolver *sharedresolvers.CachedLocationResolver) resolverstubs.LocationConnectionResolver {
+	return &locationConnectionResolver{
+		locations:        locations,
+		locationResolver: locationResolver,
+		imprecise:        true,
+	}
+}
+
 func (r *locationConnectionResolver) Nodes(ctx context.Context) ([]resolverstubs.LocationResolver, error) {
 	return resolveLocations(ctx, r.locationResolver, r.locations)
 }
@@ -29,3 +40,7 @@ func (r *locationConnectionResolver) Nodes(ctx context.Context) ([]resolverstubs
 func (r *locationConnectionResolver) PageInfo(ctx context.Context) (resolverstubs.PageInfo, error) {
 	return EncodeCursor(r.cursor), nil
 }
+
+func (r *locationConnectionResolver) Imprecise() bool {
+	return r.imprecise
+}
@@ -857,6 +857,9 @@ type MockCodeNavService struct {
 	// GetReferencesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetReferences.
 	GetReferencesFunc *CodeNavServiceGetReferencesFunc
+	// GetSearchBasedReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSearchBasedReferences.
+	GetSearchBasedReferencesFunc *CodeNavServiceGetSearchBasedReferencesFunc
 	// GetStencilFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStencil.
 	GetStencilFunc *CodeNavServiceGetStencilFunc
@@ -909,6 +912,11 @@ func NewMockCodeNavService() *MockCodeNavService {
 				return
 			},
 		},
+		GetSearchBasedReferencesFunc: &CodeNavServiceGetSearchBasedReferencesFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, api.RepoName, string) (r0 []types.UploadLocation, r1 error) {
+				return
+			},
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState) (r0 []types.Range, r1 error) {
 				return
@@ -966,6 +974,11 @@ func NewStrictMockCodeNavService() *MockCodeNavService {
 				panic("unexpected invocation of MockCodeNavService.GetReferences")
 			},
 		},
+		GetSearchBasedReferencesFunc: &CodeNavServiceGetSearchBasedReferencesFunc{
+			defaultHook: func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error) {
+				panic("unexpected invocation of MockCodeNavService.GetSearchBasedReferences")
+			},
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: func(context.Context, shared1.RequestArgs, codenav.RequestState) ([]types.Range, error) {
 				panic("unexpected invocation of MockCodeNavService.GetStencil")
@@ -1008,6 +1021,9 @@ func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
 		GetReferencesFunc: &CodeNavServiceGetReferencesFunc{
 			defaultHook: i.GetReferences,
 		},
+		GetSearchBasedReferencesFunc: &CodeNavServiceGetSearchBasedReferencesFunc{
+			defaultHook: i.GetSearchBasedReferences,
+		},
 		GetStencilFunc: &CodeNavServiceGetStencilFunc{
 			defaultHook: i.GetStencil,
 		},
@@ -1949,6 +1965,124 @@ func (c CodeNavServiceGetReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// CodeNavServiceGetSearchBasedReferencesFunc describes the behavior when
+// the GetSearchBasedReferences method of the parent MockCodeNavService
+// instance is invoked.
+type CodeNavServiceGetSearchBasedReferencesFunc struct {
+	defaultHook func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error)
+	hooks       []func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error)
+	history     []CodeNavServiceGetSearchBasedReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSearchBasedReferences delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetSearchBasedReferences(v0 context.Context, v1 shared1.RequestArgs, v2 api.RepoName, v3 string) ([]types.UploadLocation, error) {
+	r0, r1 := m.GetSearchBasedReferencesFunc.nextHook()(v0, v1, v2, v3)
+	m.GetSearchBasedReferencesFunc.appendCall(CodeNavServiceGetSearchBasedReferencesFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetSearchBasedReferences method of the parent MockCodeNavService instance
+// is invoked and the hook queue is empty.
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) SetDefaultHook(hook func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSearchBasedReferences method of the parent MockCodeNavService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) PushHook(hook func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) SetDefaultReturn(r0 []types.UploadLocation, r1 error) {
+	f.SetDefaultHook(func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) PushReturn(r0 []types.UploadLocation, r1 error) {
+	f.PushHook(func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) nextHook() func(context.Context, shared1.RequestArgs, api.RepoName, string) ([]types.UploadLocation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) appendCall(r0 CodeNavServiceGetSearchBasedReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeNavServiceGetSearchBasedReferencesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeNavServiceGetSearchBasedReferencesFunc) History() []CodeNavServiceGetSearchBasedReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetSearchBasedReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetSearchBasedReferencesFuncCall is an object that
+// describes an invocation of method GetSearchBasedReferences on an instance
+// of MockCodeNavService.
+type CodeNavServiceGetSearchBasedReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.RequestArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []types.UploadLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetSearchBasedReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetSearchBasedReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeNavServiceGetStencilFunc describes the behavior when the GetStencil
 // method of the parent MockCodeNavService instance is invoked.
 type CodeNavServiceGetStencilFunc struct {
@@ -2170,6 +2304,9 @@ type MockGitserverClient struct {
 	// DiffPathFunc is an instance of a mock function object controlling the
 	// behavior of the method DiffPath.
 	DiffPathFunc *GitserverClientDiffPathFunc
+	// RawContentsFunc is an instance of a mock function object controlling
+	// the behavior of the method RawContents.
+	RawContentsFunc *GitserverClientRawContentsFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -2187,6 +2324,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return
 			},
 		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) (r0 []byte, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -2204,6 +2346,11 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.DiffPath")
 			},
 		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: func(context.Context, int, string, string) ([]byte, error) {
+				panic("unexpected invocation of MockGitserverClient.RawContents")
+			},
+		},
 	}
 }
 
@@ -2218,6 +2365,9 @@ func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 		DiffPathFunc: &GitserverClientDiffPathFunc{
 			defaultHook: i.DiffPath,
 		},
+		RawContentsFunc: &GitserverClientRawContentsFunc{
+			defaultHook: i.RawContents,
+		},
 	}
 }
 
@@ -2450,6 +2600,120 @@ func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientRawContentsFunc describes the behavior when the
+// RawContents method of the parent MockGitserverClient instance is invoked.
+type GitserverClientRawContentsFunc struct {
+	defaultHook func(context.Context, int, string, string) ([]byte, error)
+	hooks       []func(context.Context, int, string, string) ([]byte, error)
+	history     []GitserverClientRawContentsFuncCall
+	mutex       sync.Mutex
+}
+
+// RawContents delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) RawContents(v0 context.Context, v1 int, v2 string, v3 string) ([]byte, error) {
+	r0, r1 := m.RawContentsFunc.nextHook()(v0, v1, v2, v3)
+	m.RawContentsFunc.appendCall(GitserverClientRawContentsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RawContents method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientRawContentsFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]byte, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RawContents method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientRawContentsFunc) PushHook(hook func(context.Context, int, string, string) ([]byte, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientRawContentsFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientRawContentsFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRawContentsFunc) nextHook() func(context.Context, int, string, string) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRawContentsFunc) appendCall(r0 GitserverClientRawContentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRawContentsFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientRawContentsFunc) History() []GitserverClientRawContentsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRawContentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRawContentsFuncCall is an object that describes an
+// invocation of method RawContents on an instance of MockGitserverClient.
+type GitserverClientRawContentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRawContentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRawContentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockPolicyService is a mock implementation of the PolicyService interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/transport/graphql)
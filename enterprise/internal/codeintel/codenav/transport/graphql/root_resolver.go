@@ -48,17 +48,23 @@ func (r *rootResolver) GitBlobLSIFData(ctx context.Context, args *resolverstubs.
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
 	uploads, err := r.svc.GetClosestDumpsForBlob(ctx, int(args.Repo.ID), string(args.Commit), args.Path, args.ExactPath, args.ToolName)
-	if err != nil || len(uploads) == 0 {
+	if err != nil {
 		return nil, err
 	}
 
 	if len(uploads) == 0 {
 		// If we're on sourcegraph.com and it's a rust package repo, index it on-demand
 		if envvar.SourcegraphDotComMode() && strings.HasPrefix(string(args.Repo.Name), "crates/") {
-			err = r.autoindexingSvc.QueueRepoRev(ctx, int(args.Repo.ID), string(args.Commit))
+			if err := r.autoindexingSvc.QueueRepoRev(ctx, int(args.Repo.ID), string(args.Commit)); err != nil {
+				return nil, err
+			}
 		}
 
-		return nil, err
+		// Files without a precise upload still answer references with search-based
+		// results (see gitBlobLSIFDataResolver.References). Trees have nothing to offer.
+		if !args.ExactPath {
+			return nil, nil
+		}
 	}
 
 	reqState := codenav.NewRequestState(uploads, authz.DefaultSubRepoPermsChecker, r.gitserver, args.Repo, string(args.Commit), args.Path, r.maximumIndexesPerMonikerSearch, r.hunkCache)
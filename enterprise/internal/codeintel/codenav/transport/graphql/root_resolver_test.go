@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGitBlobLSIFDataWithoutUploads(t *testing.T) {
+	mockAutoIndexingSvc := NewMockAutoIndexingService()
+	mockAutoIndexingSvc.GetUnsafeDBFunc.SetDefaultReturn(database.NewMockDB())
+
+	resolver, err := NewRootResolver(&observation.TestContext, NewMockCodeNavService(), mockAutoIndexingSvc, NewMockUploadsService(), NewMockPolicyService(), NewMockGitserverClient(), 0, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	args := &resolverstubs.GitBlobLSIFDataArgs{
+		Repo:      &sgtypes.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+		Commit:    "deadbeef1",
+		Path:      "/src/main",
+		ExactPath: true,
+	}
+	blobResolver, err := resolver.GitBlobLSIFData(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if blobResolver == nil {
+		t.Fatalf("expected a resolver answering search-based references for a blob without uploads")
+	}
+	if name := blobResolver.(*gitBlobLSIFDataResolver).requestState.RepositoryName; name != "github.com/sourcegraph/sourcegraph" {
+		t.Errorf("unexpected repository name. want=%s have=%s", "github.com/sourcegraph/sourcegraph", name)
+	}
+
+	args.Path = "/src"
+	args.ExactPath = false
+	treeResolver, err := resolver.GitBlobLSIFData(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if treeResolver != nil {
+		t.Errorf("expected no resolver for a tree without uploads")
+	}
+}
@@ -114,50 +114,18 @@ ORDER BY r.name
 `
 
 func (s *store) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (map[string][2]float64, bool, error) {
-	pathRanksWithPrecision := map[string][2]float64{}
-	scanner := func(s dbutil.Scanner) (bool, error) {
-		var (
-			precision  float64
-			serialized string
-		)
-		if err := s.Scan(&precision, &serialized); err != nil {
-			return false, err
-		}
-
-		pathRanks := map[string]float64{}
-		if err := json.Unmarshal([]byte(serialized), &pathRanks); err != nil {
-			return false, err
-		}
-
-		for path, newRank := range pathRanks {
-			if oldRank, ok := pathRanksWithPrecision[path]; ok && oldRank[0] <= precision {
-				continue
-			}
-
-			pathRanksWithPrecision[path] = [2]float64{precision, newRank}
-		}
-
-		return true, nil
+	pathRanks, err := database.PathRanksWith(s.db).GetDocumentRanks(ctx, repoName, nil)
+	if err != nil {
+		return nil, false, err
 	}
 
-	if err := basestore.NewCallbackScanner(scanner)(s.db.Query(ctx, sqlf.Sprintf(getDocumentRanksQuery, repoName))); err != nil {
-		return nil, false, err
+	pathRanksWithPrecision := make(map[string][2]float64, len(pathRanks))
+	for path, rank := range pathRanks {
+		pathRanksWithPrecision[path] = [2]float64{rank.Precision, rank.Rank}
 	}
 	return pathRanksWithPrecision, true, nil
 }
 
-const getDocumentRanksQuery = `
-SELECT
-	precision,
-	payload
-FROM codeintel_path_ranks pr
-JOIN repo r ON r.id = pr.repository_id
-WHERE
-	r.name = %s AND
-	r.deleted_at IS NULL AND
-	r.blocked IS NULL
-`
-
 func (s *store) SetDocumentRanks(ctx context.Context, repoName api.RepoName, precision float64, ranks map[string]float64) error {
 	serialized, err := json.Marshal(ranks)
 	if err != nil {
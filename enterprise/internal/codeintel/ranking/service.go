@@ -3,7 +3,6 @@ package ranking
 import (
 	"context"
 	"sort"
-	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -15,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -116,15 +116,7 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 	}
 	if ok {
 		for path, rank := range documentRanks {
-			ranks[path] = []float64{
-				rank[0],                             // precision level (0, 1]
-				1 - boolRank(isPathGenerated(path)), // rank generated paths lower
-				1 - boolRank(isPathVendored(path)),  // rank vendored paths lower
-				1 - boolRank(isPathTest(path)),      // rank test paths lower
-				squashRange(rank[1]),                // global document rank
-				1,                                   // name length
-				1,                                   // lexicographic order in repo
-			}
+			ranks[path] = result.PreciseDocumentRanks(path, rank[0], rank[1])
 		}
 	}
 
@@ -139,15 +131,7 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 			continue
 		}
 
-		ranks[path] = []float64{
-			0,                                     // imprecise
-			1 - boolRank(isPathGenerated(path)),   // rank generated paths lower
-			1 - boolRank(isPathVendored(path)),    // rank vendored paths lower
-			1 - boolRank(isPathTest(path)),        // rank test paths lower
-			0,                                     // no global document rank
-			1.0 - squashRange(float64(len(path))), // name length (prefer short names)
-			1.0 - float64(i)/float64(len(paths)),  // lexicographic order in repo
-		}
+		ranks[path] = result.ImpreciseDocumentRanks(path, 1.0-float64(i)/float64(len(paths)))
 	}
 
 	return ranks, nil
@@ -161,29 +145,6 @@ func (s *Service) UpdatedAfter(ctx context.Context, t time.Time) ([]api.RepoName
 	return s.store.UpdatedAfter(ctx, t)
 }
 
-func isPathGenerated(path string) bool {
-	return strings.HasSuffix(path, "min.js") || strings.HasSuffix(path, "js.map")
-}
-
-func isPathVendored(path string) bool {
-	return strings.Contains(path, "vendor/") || strings.Contains(path, "node_modules/")
-}
-
-var testPattern = lazyregexp.New("test")
-
-func isPathTest(path string) bool {
-	return testPattern.MatchString(path)
-}
-
-// Converts a boolean to a [0, 1] rank (where true is ordered before false).
-func boolRank(v bool) float64 {
-	if v {
-		return 1.0
-	}
-
-	return 0.0
-}
-
 // squashRange maps a value in the range [0, inf) to a value in the range
 // [0, 1) monotonically (i.e., (a < b) <-> (squashRange(a) < squashRange(b))).
 func squashRange(j float64) float64 {
@@ -71,6 +71,8 @@ type operations struct {
 	updatePackageReferences *observation.Operation
 	referencesForUpload     *observation.Operation
 
+	getDependencyGraphRepositoryNames *observation.Operation
+
 	// Audit logs
 	deleteOldAuditLogs *observation.Operation
 
@@ -163,6 +165,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		updatePackageReferences: op("UpdatePackageReferences"),
 		referencesForUpload:     op("ReferencesForUpload"),
 
+		getDependencyGraphRepositoryNames: op("GetDependencyGraphRepositoryNames"),
+
 		// Audit logs
 		deleteOldAuditLogs: op("DeleteOldAuditLogs"),
 
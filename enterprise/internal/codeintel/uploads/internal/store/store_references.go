@@ -7,6 +7,8 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
@@ -101,3 +103,82 @@ FROM lsif_references r
 WHERE dump_id = %s
 ORDER BY r.scheme, r.manager, r.name, r.version
 `
+
+// GetDependencyGraphRepositoryNames returns the names of the repositories adjacent to the given
+// repository in the package graph of completed uploads. Repositories providing a package referenced
+// by an upload of the given repository (its dependencies) are returned before the repositories with
+// an upload referencing a package provided by the given repository (its dependents).
+func (s *store) GetDependencyGraphRepositoryNames(ctx context.Context, repositoryID, limit int) (_ []api.RepoName, err error) {
+	ctx, _, endObservation := s.operations.getDependencyGraphRepositoryNames.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	names, err := basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(
+		getDependencyGraphRepositoryNamesQuery,
+		repositoryID,
+		repositoryID,
+		repositoryID,
+		limit,
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	repoNames := make([]api.RepoName, 0, len(names))
+	for _, name := range names {
+		repoNames = append(repoNames, api.RepoName(name))
+	}
+
+	return repoNames, nil
+}
+
+const getDependencyGraphRepositoryNamesQuery = `
+WITH
+dependencies AS (
+	SELECT pu.repository_id
+	FROM lsif_uploads u
+	JOIN lsif_references r ON r.dump_id = u.id
+	JOIN lsif_packages p ON
+		p.scheme = r.scheme AND
+		p.manager = r.manager AND
+		p.name = r.name AND
+		p.version = r.version
+	JOIN lsif_uploads pu ON pu.id = p.dump_id
+	WHERE
+		u.repository_id = %s AND
+		u.state = 'completed' AND
+		pu.state = 'completed'
+),
+dependents AS (
+	SELECT ru.repository_id
+	FROM lsif_uploads u
+	JOIN lsif_packages p ON p.dump_id = u.id
+	JOIN lsif_references r ON
+		r.scheme = p.scheme AND
+		r.manager = p.manager AND
+		r.name = p.name AND
+		r.version = p.version
+	JOIN lsif_uploads ru ON ru.id = r.dump_id
+	WHERE
+		u.repository_id = %s AND
+		u.state = 'completed' AND
+		ru.state = 'completed'
+),
+candidates AS (
+	SELECT repository_id, 0 AS rank FROM dependencies
+	UNION ALL
+	SELECT repository_id, 1 AS rank FROM dependents
+)
+SELECT repo.name
+FROM candidates c
+JOIN repo ON repo.id = c.repository_id
+WHERE
+	repo.id != %s AND
+	repo.deleted_at IS NULL AND
+	repo.blocked IS NULL
+GROUP BY repo.name
+ORDER BY MIN(c.rank), repo.name
+LIMIT %s
+`
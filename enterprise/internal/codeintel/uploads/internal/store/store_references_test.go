@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
@@ -88,3 +89,50 @@ func TestReferencesForUpload(t *testing.T) {
 		t.Errorf("unexpected filters (-want +got):\n%s", diff)
 	}
 }
+
+func TestGetDependencyGraphRepositoryNames(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertUploads(t, db,
+		types.Upload{ID: 1, RepositoryID: 50, RepositoryName: "r50"},
+		types.Upload{ID: 2, RepositoryID: 51, RepositoryName: "r51"},
+		types.Upload{ID: 3, RepositoryID: 52, RepositoryName: "r52"},
+		types.Upload{ID: 4, RepositoryID: 53, RepositoryName: "r53"},
+		types.Upload{ID: 5, RepositoryID: 54, RepositoryName: "r54", State: "errored"},
+		types.Upload{ID: 6, RepositoryID: 55, RepositoryName: "r55"},
+	)
+
+	insertPackages(t, store, []shared.Package{
+		{DumpID: 1, Scheme: "gomod", Name: "r50", Version: "v1"},
+		{DumpID: 2, Scheme: "gomod", Name: "r51", Version: "v1"},
+		{DumpID: 4, Scheme: "gomod", Name: "r53", Version: "v1"},
+		{DumpID: 6, Scheme: "gomod", Name: "r55", Version: "v1"},
+	})
+	insertPackageReferences(t, store, []shared.PackageReference{
+		// r50 depends on r51 and on a version of r55 that isn't indexed
+		{Package: shared.Package{DumpID: 1, Scheme: "gomod", Name: "r51", Version: "v1"}},
+		{Package: shared.Package{DumpID: 1, Scheme: "gomod", Name: "r55", Version: "v2"}},
+		// r52 and r53 depend on r50, as does an upload of r54 that failed to process
+		{Package: shared.Package{DumpID: 3, Scheme: "gomod", Name: "r50", Version: "v1"}},
+		{Package: shared.Package{DumpID: 4, Scheme: "gomod", Name: "r50", Version: "v1"}},
+		{Package: shared.Package{DumpID: 5, Scheme: "gomod", Name: "r50", Version: "v1"}},
+	})
+
+	names, err := store.GetDependencyGraphRepositoryNames(context.Background(), 50, 10)
+	if err != nil {
+		t.Fatalf("unexpected error getting dependency graph: %s", err)
+	}
+	if diff := cmp.Diff([]api.RepoName{"r51", "r52", "r53"}, names); diff != "" {
+		t.Errorf("unexpected repository names (-want +got):\n%s", diff)
+	}
+
+	names, err = store.GetDependencyGraphRepositoryNames(context.Background(), 50, 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting dependency graph: %s", err)
+	}
+	if diff := cmp.Diff([]api.RepoName{"r51", "r52"}, names); diff != "" {
+		t.Errorf("unexpected repository names (-want +got):\n%s", diff)
+	}
+}
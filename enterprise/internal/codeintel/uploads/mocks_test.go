@@ -85,6 +85,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetCommitsVisibleToUpload.
 	GetCommitsVisibleToUploadFunc *StoreGetCommitsVisibleToUploadFunc
+	// GetDependencyGraphRepositoryNamesFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetDependencyGraphRepositoryNames.
+	GetDependencyGraphRepositoryNamesFunc *StoreGetDependencyGraphRepositoryNamesFunc
 	// GetDirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDirtyRepositories.
 	GetDirtyRepositoriesFunc *StoreGetDirtyRepositoriesFunc
@@ -301,6 +305,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetDependencyGraphRepositoryNamesFunc: &StoreGetDependencyGraphRepositoryNamesFunc{
+			defaultHook: func(context.Context, int, int) (r0 []api.RepoName, r1 error) {
+				return
+			},
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
 				return
@@ -588,6 +597,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetCommitsVisibleToUpload")
 			},
 		},
+		GetDependencyGraphRepositoryNamesFunc: &StoreGetDependencyGraphRepositoryNamesFunc{
+			defaultHook: func(context.Context, int, int) ([]api.RepoName, error) {
+				panic("unexpected invocation of MockStore.GetDependencyGraphRepositoryNames")
+			},
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (map[int]int, error) {
 				panic("unexpected invocation of MockStore.GetDirtyRepositories")
@@ -847,6 +861,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetCommitsVisibleToUploadFunc: &StoreGetCommitsVisibleToUploadFunc{
 			defaultHook: i.GetCommitsVisibleToUpload,
 		},
+		GetDependencyGraphRepositoryNamesFunc: &StoreGetDependencyGraphRepositoryNamesFunc{
+			defaultHook: i.GetDependencyGraphRepositoryNames,
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: i.GetDirtyRepositories,
 		},
@@ -2550,6 +2567,121 @@ func (c StoreGetCommitsVisibleToUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetDependencyGraphRepositoryNamesFunc describes the behavior when
+// the GetDependencyGraphRepositoryNames method of the parent MockStore
+// instance is invoked.
+type StoreGetDependencyGraphRepositoryNamesFunc struct {
+	defaultHook func(context.Context, int, int) ([]api.RepoName, error)
+	hooks       []func(context.Context, int, int) ([]api.RepoName, error)
+	history     []StoreGetDependencyGraphRepositoryNamesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDependencyGraphRepositoryNames delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetDependencyGraphRepositoryNames(v0 context.Context, v1 int, v2 int) ([]api.RepoName, error) {
+	r0, r1 := m.GetDependencyGraphRepositoryNamesFunc.nextHook()(v0, v1, v2)
+	m.GetDependencyGraphRepositoryNamesFunc.appendCall(StoreGetDependencyGraphRepositoryNamesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetDependencyGraphRepositoryNames method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) SetDefaultHook(hook func(context.Context, int, int) ([]api.RepoName, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDependencyGraphRepositoryNames method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) PushHook(hook func(context.Context, int, int) ([]api.RepoName, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) SetDefaultReturn(r0 []api.RepoName, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) ([]api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) PushReturn(r0 []api.RepoName, r1 error) {
+	f.PushHook(func(context.Context, int, int) ([]api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) nextHook() func(context.Context, int, int) ([]api.RepoName, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) appendCall(r0 StoreGetDependencyGraphRepositoryNamesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetDependencyGraphRepositoryNamesFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetDependencyGraphRepositoryNamesFunc) History() []StoreGetDependencyGraphRepositoryNamesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDependencyGraphRepositoryNamesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDependencyGraphRepositoryNamesFuncCall is an object that
+// describes an invocation of method GetDependencyGraphRepositoryNames on an
+// instance of MockStore.
+type StoreGetDependencyGraphRepositoryNamesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []api.RepoName
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDependencyGraphRepositoryNamesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDependencyGraphRepositoryNamesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetDirtyRepositoriesFunc describes the behavior when the
 // GetDirtyRepositories method of the parent MockStore instance is invoked.
 type StoreGetDirtyRepositoriesFunc struct {
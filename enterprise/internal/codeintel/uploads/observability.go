@@ -52,7 +52,8 @@ type operations struct {
 	getDumpsByIDs                      *observation.Operation
 
 	// References
-	referencesForUpload *observation.Operation
+	referencesForUpload               *observation.Operation
+	getDependencyGraphRepositoryNames *observation.Operation
 
 	// Audit Logs
 	getAuditLogsForUpload *observation.Operation
@@ -165,7 +166,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getDumpsByIDs:                      op("GetDumpsByIDs"),
 
 		// References
-		referencesForUpload: op("ReferencesForUpload"),
+		referencesForUpload:               op("ReferencesForUpload"),
+		getDependencyGraphRepositoryNames: op("GetDependencyGraphRepositoryNames"),
 
 		// Audit Logs
 		getAuditLogsForUpload: op("GetAuditLogsForUpload"),
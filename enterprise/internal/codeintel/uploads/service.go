@@ -321,6 +321,15 @@ func (s *Service) ReferencesForUpload(ctx context.Context, uploadID int) (_ shar
 	return s.store.ReferencesForUpload(ctx, uploadID)
 }
 
+func (s *Service) GetDependencyGraphRepositoryNames(ctx context.Context, repositoryID, limit int) (_ []api.RepoName, err error) {
+	ctx, _, endObservation := s.operations.getDependencyGraphRepositoryNames.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{log.Int("repositoryID", repositoryID), log.Int("limit", limit)},
+	})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetDependencyGraphRepositoryNames(ctx, repositoryID, limit)
+}
+
 func (s *Service) GetAuditLogsForUpload(ctx context.Context, uploadID int) (_ []types.UploadLog, err error) {
 	ctx, _, endObservation := s.operations.getAuditLogsForUpload.With(ctx, &err, observation.Args{
 		LogFields: []log.Field{log.Int("uploadID", uploadID)},
@@ -12,6 +12,7 @@ func NewBackgroundJobs(observationCtx *observation.Context, db edb.EnterpriseDB)
 	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("BackgroundJobs", "code monitors background jobs"), observationCtx)
 
 	codeMonitorsStore := db.CodeMonitors()
+	savedSearchRunsStore := db.SavedSearchRuns()
 
 	triggerMetrics := newMetricsForTriggerQueries(observationCtx)
 	actionMetrics := newActionMetrics(observationCtx)
@@ -26,6 +27,10 @@ func NewBackgroundJobs(observationCtx *observation.Context, db edb.EnterpriseDB)
 		newTriggerQueryResetter(ctx, scopedContext("TriggerQueryResetter", observationCtx), codeMonitorsStore, triggerMetrics),
 		newActionRunner(ctx, scopedContext("ActionRunner", observationCtx), codeMonitorsStore, actionMetrics),
 		newActionJobResetter(ctx, scopedContext("ActionJobResetter", observationCtx), codeMonitorsStore, actionMetrics),
+		newSavedSearchRunEnqueuer(ctx, savedSearchRunsStore),
+		newSavedSearchRunsLogDeleter(ctx, savedSearchRunsStore),
+		newSavedSearchRunner(ctx, scopedContext("SavedSearchRunner", observationCtx), db, triggerMetrics),
+		newSavedSearchRunResetter(ctx, scopedContext("SavedSearchRunResetter", observationCtx), savedSearchRunsStore, triggerMetrics),
 	}
 }
 
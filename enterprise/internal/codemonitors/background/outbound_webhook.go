@@ -0,0 +1,63 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const CodeMonitorTriggered = "code_monitor:triggered"
+
+func init() {
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         CodeMonitorTriggered,
+		Description: "sent when the query of a code monitor has new results",
+	})
+}
+
+// monitorTrigger is a code monitor query run that yielded new results.
+type monitorTrigger struct {
+	Monitor     *edb.Monitor
+	Query       string
+	ResultCount int
+}
+
+// codeMonitorTrigger represents a triggered code monitor in a webhook payload.
+type codeMonitorTrigger struct {
+	ID          graphql.ID `json:"id"`
+	Description string     `json:"description"`
+	Owner       graphql.ID `json:"owner_user_id"`
+	URL         string     `json:"url"`
+	Query       string     `json:"query"`
+	ResultCount int        `json:"result_count"`
+}
+
+func marshalMonitorTrigger(ctx context.Context, _ basestore.ShareableStore, trigger monitorTrigger) ([]byte, error) {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting external Sourcegraph URL")
+	}
+
+	payload := codeMonitorTrigger{
+		ID:          relay.MarshalID(MonitorKind, trigger.Monitor.ID),
+		Description: trigger.Monitor.Description,
+		Owner:       relay.MarshalID("User", trigger.Monitor.UserID),
+		URL:         getCodeMonitorURL(externalURL, trigger.Monitor.ID, "code-monitor-outbound-webhook"),
+		Query:       trigger.Query,
+		ResultCount: trigger.ResultCount,
+	}
+
+	return json.Marshal(&payload)
+}
+
+func enqueueMonitorTrigger(ctx context.Context, logger log.Logger, db basestore.ShareableStore, trigger monitorTrigger) {
+	outbound.Enqueue(ctx, logger, db, CodeMonitorTriggered, marshalMonitorTrigger, trigger)
+}
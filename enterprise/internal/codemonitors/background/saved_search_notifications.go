@@ -0,0 +1,236 @@
+package background
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	utmSourceSavedSearchEmail        = "saved-search-email"
+	utmSourceSavedSearchSlackWebhook = "saved-search-slack-webhook"
+	utmSourceSavedSearchWebhook      = "saved-search-webhook"
+
+	// maxNotifiedSavedSearchResults is the maximum number of added and of
+	// removed results listed in a notification.
+	maxNotifiedSavedSearchResults = 5
+)
+
+// savedSearchNotificationArgs is the set of arguments needed to notify a
+// subscriber of the changed results of a scheduled saved search.
+type savedSearchNotificationArgs struct {
+	SavedSearchDescription string
+	ExternalURL            *url.URL
+	Query                  string
+	Added                  []database.SavedSearchResult
+	Removed                []database.SavedSearchResult
+	IncludeResults         bool
+}
+
+func notifySavedSearchSubscriber(ctx context.Context, db database.DB, sub *database.SavedSearchSubscription, args savedSearchNotificationArgs) error {
+	args.IncludeResults = sub.IncludeResults
+
+	switch {
+	case sub.UserID != nil:
+		return sendEmail(ctx, db, *sub.UserID, savedSearchEmailTemplates, newTemplateDataForSavedSearchResults(args))
+	case sub.SlackWebhookURL != nil:
+		return postSlackWebhook(ctx, httpcli.ExternalDoer, *sub.SlackWebhookURL, savedSearchSlackPayload(args))
+	case sub.WebhookURL != nil:
+		return postWebhook(ctx, httpcli.ExternalDoer, *sub.WebhookURL, generateSavedSearchWebhookPayload(args))
+	default:
+		return errors.New("subscription must be one of type email, webhook, or slack webhook")
+	}
+}
+
+var (
+	//go:embed saved_search_email_template.html.tmpl
+	savedSearchHTMLTemplate string
+
+	//go:embed saved_search_email_template.txt.tmpl
+	savedSearchTextTemplate string
+)
+
+var savedSearchEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph saved search {{.Description}} has {{.AddedCount}} new and {{.RemovedCount}} removed {{.ResultPluralized}}`,
+	Text:    savedSearchTextTemplate,
+	HTML:    savedSearchHTMLTemplate,
+})
+
+type TemplateDataSavedSearchResults struct {
+	Description           string
+	SearchURL             string
+	IncludeResults        bool
+	AddedCount            int
+	RemovedCount          int
+	ResultPluralized      string
+	AddedResults          []*DisplaySavedSearchResult
+	RemovedResults        []*DisplaySavedSearchResult
+	TruncatedAddedCount   int
+	TruncatedRemovedCount int
+}
+
+type DisplaySavedSearchResult struct {
+	Label   string
+	URL     string
+	Preview string
+}
+
+func newTemplateDataForSavedSearchResults(args savedSearchNotificationArgs) *TemplateDataSavedSearchResults {
+	added, truncatedAdded := toDisplaySavedSearchResults(args.ExternalURL, args.Added)
+	removed, truncatedRemoved := toDisplaySavedSearchResults(args.ExternalURL, args.Removed)
+	return &TemplateDataSavedSearchResults{
+		Description:           args.SavedSearchDescription,
+		SearchURL:             getSearchURL(args.ExternalURL, args.Query, utmSourceSavedSearchEmail),
+		IncludeResults:        args.IncludeResults,
+		AddedCount:            len(args.Added),
+		RemovedCount:          len(args.Removed),
+		ResultPluralized:      pluralize("result", len(args.Added)+len(args.Removed)),
+		AddedResults:          added,
+		RemovedResults:        removed,
+		TruncatedAddedCount:   truncatedAdded,
+		TruncatedRemovedCount: truncatedRemoved,
+	}
+}
+
+func toDisplaySavedSearchResults(externalURL *url.URL, results []database.SavedSearchResult) (_ []*DisplaySavedSearchResult, truncatedCount int) {
+	if len(results) > maxNotifiedSavedSearchResults {
+		truncatedCount = len(results) - maxNotifiedSavedSearchResults
+		results = results[:maxNotifiedSavedSearchResults]
+	}
+	out := make([]*DisplaySavedSearchResult, len(results))
+	for i, r := range results {
+		out[i] = &DisplaySavedSearchResult{
+			Label:   savedSearchResultLabel(r),
+			URL:     savedSearchResultURL(externalURL, r),
+			Preview: truncateString(r.Preview, 10),
+		}
+	}
+	return out, truncatedCount
+}
+
+func savedSearchResultLabel(r database.SavedSearchResult) string {
+	switch {
+	case r.Commit != "":
+		commit := r.Commit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		return fmt.Sprintf("%s@%s", r.Repo, commit)
+	case r.Path != "":
+		return fmt.Sprintf("%s/%s", r.Repo, r.Path)
+	default:
+		return r.Repo
+	}
+}
+
+func savedSearchResultURL(externalURL *url.URL, r database.SavedSearchResult) string {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return externalURL.String()
+	}
+	return externalURL.ResolveReference(u).String()
+}
+
+func savedSearchSlackPayload(args savedSearchNotificationArgs) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"Sourcegraph saved search *%s* has *%d* new and *%d* removed %s.",
+			args.SavedSearchDescription,
+			len(args.Added),
+			len(args.Removed),
+			pluralize("result", len(args.Added)+len(args.Removed)),
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, section := range []struct {
+			title   string
+			results []database.SavedSearchResult
+		}{
+			{"New", args.Added},
+			{"Removed", args.Removed},
+		} {
+			results, truncatedCount := toDisplaySavedSearchResults(args.ExternalURL, section.results)
+			for _, r := range results {
+				blocks = append(blocks, newMarkdownSection(fmt.Sprintf("%s: <%s|%s>", section.title, r.URL, r.Label)))
+				if r.Preview != "" {
+					blocks = append(blocks, newMarkdownSection(formatCodeBlock(r.Preview)))
+				}
+			}
+			if truncatedCount > 0 {
+				blocks = append(blocks, newMarkdownSection(fmt.Sprintf("...and %d more %s %s.", truncatedCount, strings.ToLower(section.title), pluralize("result", truncatedCount))))
+			}
+		}
+	}
+
+	blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+		"<%s|View results>",
+		getSearchURL(args.ExternalURL, args.Query, utmSourceSavedSearchSlackWebhook),
+	)))
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+type savedSearchWebhookPayload struct {
+	SavedSearchDescription string                     `json:"savedSearchDescription"`
+	SearchURL              string                     `json:"searchURL"`
+	Query                  string                     `json:"query"`
+	AddedCount             int                        `json:"addedCount"`
+	RemovedCount           int                        `json:"removedCount"`
+	Added                  []savedSearchWebhookResult `json:"added,omitempty"`
+	Removed                []savedSearchWebhookResult `json:"removed,omitempty"`
+}
+
+type savedSearchWebhookResult struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Path       string `json:"path,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	Preview    string `json:"preview,omitempty"`
+	URL        string `json:"url"`
+}
+
+func generateSavedSearchWebhookPayload(args savedSearchNotificationArgs) savedSearchWebhookPayload {
+	p := savedSearchWebhookPayload{
+		SavedSearchDescription: args.SavedSearchDescription,
+		SearchURL:              getSearchURL(args.ExternalURL, args.Query, utmSourceSavedSearchWebhook),
+		Query:                  args.Query,
+		AddedCount:             len(args.Added),
+		RemovedCount:           len(args.Removed),
+	}
+
+	if args.IncludeResults {
+		p.Added = generateSavedSearchWebhookResults(args.ExternalURL, args.Added)
+		p.Removed = generateSavedSearchWebhookResults(args.ExternalURL, args.Removed)
+	}
+
+	return p
+}
+
+func generateSavedSearchWebhookResults(externalURL *url.URL, in []database.SavedSearchResult) []savedSearchWebhookResult {
+	out := make([]savedSearchWebhookResult, len(in))
+	for i, r := range in {
+		out[i] = savedSearchWebhookResult{
+			Type:       r.Type,
+			Repository: r.Repo,
+			Path:       r.Path,
+			Commit:     r.Commit,
+			Preview:    r.Preview,
+			URL:        savedSearchResultURL(externalURL, r),
+		}
+	}
+	return out
+}
@@ -0,0 +1,305 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func newSavedSearchRunEnqueuer(ctx context.Context, store database.SavedSearchRunStore) goroutine.BackgroundRoutine {
+	enqueue := goroutine.HandlerFunc(
+		func(ctx context.Context) error {
+			_, err := store.EnqueueScheduledRuns(ctx)
+			return err
+		})
+	return goroutine.NewPeriodicGoroutine(
+		ctx, "saved_searches.run_enqueuer", "enqueues runs of scheduled saved searches",
+		1*time.Minute, enqueue,
+	)
+}
+
+func newSavedSearchRunsLogDeleter(ctx context.Context, store database.SavedSearchRunStore) goroutine.BackgroundRoutine {
+	deleteLogs := goroutine.HandlerFunc(
+		func(ctx context.Context) error {
+			return store.DeleteOldRuns(ctx, eventRetentionInDays)
+		})
+	return goroutine.NewPeriodicGoroutine(ctx, "saved_searches.runs_log_deleter", "deletes old runs of scheduled saved searches", 60*time.Minute, deleteLogs)
+}
+
+func newSavedSearchRunner(ctx context.Context, observationCtx *observation.Context, db edb.EnterpriseDB, metrics codeMonitorsMetrics) *workerutil.Worker[*database.SavedSearchRun] {
+	options := workerutil.WorkerOptions{
+		Name:                 "saved_search_runs_worker",
+		Description:          "runs scheduled saved searches and notifies their subscribers of changed results",
+		NumHandlers:          2,
+		Interval:             5 * time.Second,
+		HeartbeatInterval:    15 * time.Second,
+		Metrics:              metrics.workerMetrics,
+		MaximumRuntimePerJob: 5 * time.Minute,
+	}
+
+	store := createDBWorkerStoreForSavedSearchRuns(observationCtx, db)
+
+	return dbworker.NewWorker[*database.SavedSearchRun](ctx, store, &savedSearchRunner{db: db}, options)
+}
+
+func newSavedSearchRunResetter(_ context.Context, observationCtx *observation.Context, s basestore.ShareableStore, metrics codeMonitorsMetrics) *dbworker.Resetter[*database.SavedSearchRun] {
+	workerStore := createDBWorkerStoreForSavedSearchRuns(observationCtx, s)
+
+	options := dbworker.ResetterOptions{
+		Name:     "saved_search_runs_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(observationCtx.Logger, workerStore, options)
+}
+
+func createDBWorkerStoreForSavedSearchRuns(observationCtx *observation.Context, s basestore.ShareableStore) dbworkerstore.Store[*database.SavedSearchRun] {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("savedSearchRuns.dbworker.Store", ""), observationCtx)
+
+	return dbworkerstore.New(observationCtx, s.Handle(), dbworkerstore.Options[*database.SavedSearchRun]{
+		Name:              "saved_search_runs_worker_store",
+		TableName:         "saved_search_runs",
+		ColumnExpressions: database.SavedSearchRunColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(database.ScanSavedSearchRun),
+		StalledMaxAge:     60 * time.Second,
+		RetryAfter:        10 * time.Second,
+		MaxNumRetries:     3,
+		OrderByExpression: sqlf.Sprintf("id"),
+	})
+}
+
+type savedSearchRunner struct {
+	db edb.EnterpriseDB
+}
+
+func (r *savedSearchRunner) Handle(ctx context.Context, logger log.Logger, run *database.SavedSearchRun) (err error) {
+	defer func() {
+		if err != nil {
+			logger.Error("savedSearchRunner.Handle", log.Error(err))
+		}
+	}()
+
+	ss, err := r.db.SavedSearches().GetByID(ctx, run.SavedSearchID)
+	if err != nil {
+		return errors.Wrap(err, "GetByID")
+	}
+	if ss.Config.UserID == nil {
+		return errcode.MakeNonRetryable(errors.New("only saved searches owned by a user can be scheduled"))
+	}
+
+	// SECURITY: set the actor to the user that owns the saved search. The
+	// search must only return results the owner is allowed to see.
+	ctx = actor.WithActor(ctx, actor.FromUser(*ss.Config.UserID))
+	ctx = featureflag.WithFlags(ctx, r.db.FeatureFlags())
+
+	settings, err := codemonitors.Settings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "query settings")
+	}
+
+	// A search that hits its result limit fails the run rather than being
+	// diffed, as its truncated results would be reported as changes.
+	matches, err := codemonitors.SearchAll(ctx, logger, r.db, ss.Config.Query, settings)
+	if err != nil {
+		return errors.Wrap(err, "execute search")
+	}
+	results := toSavedSearchResults(matches)
+
+	s := r.db.SavedSearchRuns()
+	previous, err := s.GetPreviousCompletedRun(ctx, run.SavedSearchID, run.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetPreviousCompletedRun")
+	}
+
+	added, removed := diffSavedSearchRun(previous, ss.Config.Query, results)
+
+	if err := s.UpdateRunResults(ctx, run.ID, ss.Config.Query, results, added, removed); err != nil {
+		return errors.Wrap(err, "UpdateRunResults")
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	subs, err := s.ListSubscriptions(ctx, run.SavedSearchID)
+	if err != nil {
+		return errors.Wrap(err, "ListSubscriptions")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := savedSearchNotificationArgs{
+		SavedSearchDescription: ss.Config.Description,
+		ExternalURL:            externalURL,
+		Query:                  ss.Config.Query,
+		Added:                  added,
+		Removed:                removed,
+	}
+
+	// Failing to notify a subscriber must not fail the run: retrying it would
+	// notify the other subscribers twice.
+	for _, sub := range subs {
+		if err := notifySavedSearchSubscriber(ctx, r.db, sub, args); err != nil {
+			logger.Warn("failed to notify saved search subscriber", log.Int32("subscription", sub.ID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// diffSavedSearchRun returns the results of a run of query which were added
+// and removed since the previous completed run. The first run and the first
+// run after the query was edited only record the baseline result set,
+// otherwise every subscriber would be notified of all the results as added
+// ones, and of the results of the old query as removed ones.
+func diffSavedSearchRun(previous *database.SavedSearchRun, query string, results []database.SavedSearchResult) (added, removed []database.SavedSearchResult) {
+	if previous == nil || previous.QueryString == nil || *previous.QueryString != query {
+		return nil, nil
+	}
+	return diffSavedSearchResults(previous.Results, results)
+}
+
+// toSavedSearchResults converts search matches to the results stored for a
+// scheduled saved search run. Content matches are split by matched line, so
+// that a new match in a file that already had matches is reported as added.
+func toSavedSearchResults(matches result.Matches) []database.SavedSearchResult {
+	var results []database.SavedSearchResult
+	seen := make(map[string]struct{})
+	add := func(r database.SavedSearchResult) {
+		if _, ok := seen[r.Key]; ok {
+			return
+		}
+		seen[r.Key] = struct{}{}
+		results = append(results, r)
+	}
+
+	for _, match := range matches {
+		repo := string(match.RepoName().Name)
+		switch m := match.(type) {
+		case *result.FileMatch:
+			fileURL := m.File.URL()
+			for _, sym := range m.Symbols {
+				add(database.SavedSearchResult{
+					Key:     savedSearchResultKey("symbol", repo, m.Path, sym.Symbol.Name, sym.Symbol.Kind),
+					Type:    "symbol",
+					Repo:    repo,
+					Path:    m.Path,
+					Preview: sym.Symbol.Name,
+					URL:     sym.URL().String(),
+				})
+			}
+			for _, chunk := range m.ChunkMatches {
+				lines := strings.Split(chunk.Content, "\n")
+				for _, rr := range chunk.Ranges {
+					for line := rr.Start.Line; line <= rr.End.Line; line++ {
+						i := line - chunk.ContentStart.Line
+						if i < 0 || i >= len(lines) {
+							continue
+						}
+						content := strings.TrimSpace(lines[i])
+						u := *fileURL
+						u.RawQuery = fmt.Sprintf("L%d", line+1)
+						add(database.SavedSearchResult{
+							Key:     savedSearchResultKey("content", repo, m.Path, content),
+							Type:    "content",
+							Repo:    repo,
+							Path:    m.Path,
+							Preview: content,
+							URL:     u.String(),
+						})
+					}
+				}
+			}
+			if len(m.Symbols) == 0 && len(m.ChunkMatches) == 0 {
+				add(database.SavedSearchResult{
+					Key:  savedSearchResultKey("path", repo, m.Path),
+					Type: "path",
+					Repo: repo,
+					Path: m.Path,
+					URL:  fileURL.String(),
+				})
+			}
+		case *result.RepoMatch:
+			add(database.SavedSearchResult{
+				Key:  savedSearchResultKey("repo", repo),
+				Type: "repo",
+				Repo: repo,
+				URL:  m.URL().String(),
+			})
+		case *result.CommitMatch:
+			resultType := "commit"
+			if m.DiffPreview != nil {
+				resultType = "diff"
+			}
+			add(database.SavedSearchResult{
+				Key:     savedSearchResultKey(resultType, repo, string(m.Commit.ID)),
+				Type:    resultType,
+				Repo:    repo,
+				Commit:  string(m.Commit.ID),
+				Preview: m.Commit.Message.Subject(),
+				URL:     m.URL().String(),
+			})
+		case *result.OwnerMatch:
+			add(database.SavedSearchResult{
+				Key:     savedSearchResultKey("owner", repo, m.Identifier()),
+				Type:    "owner",
+				Repo:    repo,
+				Preview: m.Identifier(),
+				URL:     m.URL().String(),
+			})
+		}
+	}
+	return results
+}
+
+func savedSearchResultKey(parts ...string) string {
+	return strings.Join(parts, "\x1f")
+}
+
+// diffSavedSearchResults returns the results that are in next but not in
+// previous, and the results that are in previous but not in next.
+func diffSavedSearchResults(previous, next []database.SavedSearchResult) (added, removed []database.SavedSearchResult) {
+	previousKeys := make(map[string]struct{}, len(previous))
+	for _, r := range previous {
+		previousKeys[r.Key] = struct{}{}
+	}
+	nextKeys := make(map[string]struct{}, len(next))
+	for _, r := range next {
+		nextKeys[r.Key] = struct{}{}
+		if _, ok := previousKeys[r.Key]; !ok {
+			added = append(added, r)
+		}
+	}
+	for _, r := range previous {
+		if _, ok := nextKeys[r.Key]; !ok {
+			removed = append(removed, r)
+		}
+	}
+	return added, removed
+}
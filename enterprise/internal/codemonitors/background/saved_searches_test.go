@@ -0,0 +1,205 @@
+package background
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestToSavedSearchResults(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	matches := result.Matches{
+		&result.FileMatch{
+			File: result.File{Repo: repo, Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {\n\tfmt.Println(\"hello\")\n",
+				ContentStart: result.Location{Line: 9},
+				Ranges: result.Ranges{
+					{Start: result.Location{Line: 9}, End: result.Location{Line: 9}},
+					{Start: result.Location{Line: 10}, End: result.Location{Line: 10}},
+				},
+			}},
+		},
+		// The same line matched twice is a single result.
+		&result.FileMatch{
+			File: result.File{Repo: repo, Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {\n",
+				ContentStart: result.Location{Line: 9},
+				Ranges:       result.Ranges{{Start: result.Location{Line: 9}, End: result.Location{Line: 9}}},
+			}},
+		},
+		&result.FileMatch{File: result.File{Repo: repo, Path: "README.md"}},
+		&result.RepoMatch{ID: repo.ID, Name: repo.Name},
+		&result.CommitMatch{
+			Repo: repo,
+			Commit: gitdomain.Commit{
+				ID:      api.CommitID("0123456789abcdef0123456789abcdef01234567"),
+				Message: "Fix the build\n\nIt was broken.",
+			},
+		},
+	}
+
+	want := []database.SavedSearchResult{
+		{
+			Key:     "content\x1fgithub.com/sourcegraph/sourcegraph\x1fmain.go\x1ffunc main() {",
+			Type:    "content",
+			Repo:    "github.com/sourcegraph/sourcegraph",
+			Path:    "main.go",
+			Preview: "func main() {",
+			URL:     "/github.com/sourcegraph/sourcegraph/-/blob/main.go?L10",
+		},
+		{
+			Key:     "content\x1fgithub.com/sourcegraph/sourcegraph\x1fmain.go\x1ffmt.Println(\"hello\")",
+			Type:    "content",
+			Repo:    "github.com/sourcegraph/sourcegraph",
+			Path:    "main.go",
+			Preview: "fmt.Println(\"hello\")",
+			URL:     "/github.com/sourcegraph/sourcegraph/-/blob/main.go?L11",
+		},
+		{
+			Key:  "path\x1fgithub.com/sourcegraph/sourcegraph\x1fREADME.md",
+			Type: "path",
+			Repo: "github.com/sourcegraph/sourcegraph",
+			Path: "README.md",
+			URL:  "/github.com/sourcegraph/sourcegraph/-/blob/README.md",
+		},
+		{
+			Key:  "repo\x1fgithub.com/sourcegraph/sourcegraph",
+			Type: "repo",
+			Repo: "github.com/sourcegraph/sourcegraph",
+			URL:  "/github.com/sourcegraph/sourcegraph",
+		},
+		{
+			Key:     "commit\x1fgithub.com/sourcegraph/sourcegraph\x1f0123456789abcdef0123456789abcdef01234567",
+			Type:    "commit",
+			Repo:    "github.com/sourcegraph/sourcegraph",
+			Commit:  "0123456789abcdef0123456789abcdef01234567",
+			Preview: "Fix the build",
+			URL:     "/github.com/sourcegraph/sourcegraph/-/commit/0123456789abcdef0123456789abcdef01234567",
+		},
+	}
+
+	require.Equal(t, want, toSavedSearchResults(matches))
+}
+
+func TestDiffSavedSearchResults(t *testing.T) {
+	r := func(key string) database.SavedSearchResult {
+		return database.SavedSearchResult{Key: key}
+	}
+
+	added, removed := diffSavedSearchResults(
+		[]database.SavedSearchResult{r("a"), r("b"), r("c")},
+		[]database.SavedSearchResult{r("b"), r("d"), r("c"), r("e")},
+	)
+	require.Equal(t, []database.SavedSearchResult{r("d"), r("e")}, added)
+	require.Equal(t, []database.SavedSearchResult{r("a")}, removed)
+
+	added, removed = diffSavedSearchResults(nil, nil)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestDiffSavedSearchRun(t *testing.T) {
+	r := func(key string) database.SavedSearchResult {
+		return database.SavedSearchResult{Key: key}
+	}
+	query := "TODO"
+	previous := &database.SavedSearchRun{QueryString: &query, Results: []database.SavedSearchResult{r("a"), r("b")}}
+	results := []database.SavedSearchResult{r("b"), r("c")}
+
+	t.Run("first run", func(t *testing.T) {
+		added, removed := diffSavedSearchRun(nil, "TODO", results)
+		require.Empty(t, added)
+		require.Empty(t, removed)
+	})
+
+	t.Run("same query", func(t *testing.T) {
+		added, removed := diffSavedSearchRun(previous, "TODO", results)
+		require.Equal(t, []database.SavedSearchResult{r("c")}, added)
+		require.Equal(t, []database.SavedSearchResult{r("a")}, removed)
+	})
+
+	t.Run("edited query", func(t *testing.T) {
+		added, removed := diffSavedSearchRun(previous, "FIXME", results)
+		require.Empty(t, added)
+		require.Empty(t, removed)
+	})
+}
+
+func TestSavedSearchNotifications(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	args := savedSearchNotificationArgs{
+		SavedSearchDescription: "TODOs",
+		ExternalURL:            eu,
+		Query:                  "TODO patternType:literal",
+		Added: []database.SavedSearchResult{
+			{Key: "a", Type: "content", Repo: "github.com/a/b", Path: "x.go", Preview: "// TODO", URL: "/github.com/a/b/-/blob/x.go?L3"},
+			{Key: "b", Type: "commit", Repo: "github.com/a/b", Commit: "0123456789abcdef", Preview: "Add TODO", URL: "/github.com/a/b/-/commit/0123456789abcdef"},
+		},
+		Removed: []database.SavedSearchResult{
+			{Key: "c", Type: "repo", Repo: "github.com/c/d", URL: "/github.com/c/d"},
+		},
+	}
+
+	t.Run("webhook payload without results", func(t *testing.T) {
+		require.Equal(t, savedSearchWebhookPayload{
+			SavedSearchDescription: "TODOs",
+			SearchURL:              "https://sourcegraph.com/search?q=TODO+patternType%3Aliteral&utm_source=saved-search-webhook",
+			Query:                  "TODO patternType:literal",
+			AddedCount:             2,
+			RemovedCount:           1,
+		}, generateSavedSearchWebhookPayload(args))
+	})
+
+	t.Run("webhook payload with results", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.IncludeResults = true
+
+		p := generateSavedSearchWebhookPayload(argsCopy)
+		require.Equal(t, []savedSearchWebhookResult{
+			{Type: "content", Repository: "github.com/a/b", Path: "x.go", Preview: "// TODO", URL: "https://sourcegraph.com/github.com/a/b/-/blob/x.go?L3"},
+			{Type: "commit", Repository: "github.com/a/b", Commit: "0123456789abcdef", Preview: "Add TODO", URL: "https://sourcegraph.com/github.com/a/b/-/commit/0123456789abcdef"},
+		}, p.Added)
+		require.Equal(t, []savedSearchWebhookResult{
+			{Type: "repo", Repository: "github.com/c/d", URL: "https://sourcegraph.com/github.com/c/d"},
+		}, p.Removed)
+	})
+
+	t.Run("email template data", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.IncludeResults = true
+
+		data := newTemplateDataForSavedSearchResults(argsCopy)
+		require.Equal(t, 2, data.AddedCount)
+		require.Equal(t, 1, data.RemovedCount)
+		require.Equal(t, "results", data.ResultPluralized)
+		require.Equal(t, []*DisplaySavedSearchResult{
+			{Label: "github.com/a/b/x.go", URL: "https://sourcegraph.com/github.com/a/b/-/blob/x.go?L3", Preview: "// TODO"},
+			{Label: "github.com/a/b@0123456", URL: "https://sourcegraph.com/github.com/a/b/-/commit/0123456789abcdef", Preview: "Add TODO"},
+		}, data.AddedResults)
+		require.Equal(t, []*DisplaySavedSearchResult{
+			{Label: "github.com/c/d", URL: "https://sourcegraph.com/github.com/c/d"},
+		}, data.RemovedResults)
+	})
+
+	t.Run("slack payload", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.IncludeResults = true
+
+		msg := savedSearchSlackPayload(argsCopy)
+		// Summary, two added results with previews, one removed result
+		// without a preview and the link to the results.
+		require.Len(t, msg.Blocks.BlockSet, 7)
+	})
+}
@@ -211,6 +211,12 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
 		}
+
+		enqueueMonitorTrigger(ctx, logger, s, monitorTrigger{
+			Monitor:     m,
+			Query:       query,
+			ResultCount: len(results),
+		})
 	}
 	return nil
 }
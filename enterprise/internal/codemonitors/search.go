@@ -184,6 +184,37 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 	return results, nil
 }
 
+// SearchAll runs the query and returns all its matches. Unlike Search, it
+// accepts queries of any type, such as the queries of scheduled saved searches.
+// It returns a non-retryable error if the search hits its result limit, since
+// the truncated set of matches can differ between runs without any change to
+// the searched code.
+func SearchAll(ctx context.Context, logger log.Logger, db database.DB, query string, settings *schema.Settings) (result.Matches, error) {
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+
+	agg := streaming.NewAggregatingStream()
+	if _, err := searchClient.Execute(ctx, agg, inputs); err != nil {
+		return nil, err
+	}
+	if agg.Stats.IsLimitHit {
+		return nil, errcode.MakeNonRetryable(errors.Errorf("search hit the result limit of %d, add a count: to the query that covers all of its results", inputs.MaxResults()))
+	}
+	return agg.Results, nil
+}
+
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
 // be searched from the beginning.
@@ -6816,6 +6816,12 @@ type MockEnterpriseDB struct {
 	// AccessTokensFunc is an instance of a mock function object controlling
 	// the behavior of the method AccessTokens.
 	AccessTokensFunc *EnterpriseDBAccessTokensFunc
+	// AssignedOwnersFunc is an instance of a mock function object
+	// controlling the behavior of the method AssignedOwners.
+	AssignedOwnersFunc *EnterpriseDBAssignedOwnersFunc
+	// AuditLogsFunc is an instance of a mock function object controlling
+	// the behavior of the method AuditLogs.
+	AuditLogsFunc *EnterpriseDBAuditLogsFunc
 	// AuthzFunc is an instance of a mock function object controlling the
 	// behavior of the method Authz.
 	AuthzFunc *EnterpriseDBAuthzFunc
@@ -6889,6 +6895,9 @@ type MockEnterpriseDB struct {
 	// OutboundWebhooksFunc is an instance of a mock function object
 	// controlling the behavior of the method OutboundWebhooks.
 	OutboundWebhooksFunc *EnterpriseDBOutboundWebhooksFunc
+	// PathRanksFunc is an instance of a mock function object controlling
+	// the behavior of the method PathRanks.
+	PathRanksFunc *EnterpriseDBPathRanksFunc
 	// PermissionSyncJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method PermissionSyncJobs.
 	PermissionSyncJobsFunc *EnterpriseDBPermissionSyncJobsFunc
@@ -6913,6 +6922,9 @@ type MockEnterpriseDB struct {
 	// RepoStatisticsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoStatistics.
 	RepoStatisticsFunc *EnterpriseDBRepoStatisticsFunc
+	// RepoUpdateScheduleFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoUpdateSchedule.
+	RepoUpdateScheduleFunc *EnterpriseDBRepoUpdateScheduleFunc
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *EnterpriseDBReposFunc
@@ -6922,12 +6934,18 @@ type MockEnterpriseDB struct {
 	// RolesFunc is an instance of a mock function object controlling the
 	// behavior of the method Roles.
 	RolesFunc *EnterpriseDBRolesFunc
+	// SavedSearchRunsFunc is an instance of a mock function object
+	// controlling the behavior of the method SavedSearchRuns.
+	SavedSearchRunsFunc *EnterpriseDBSavedSearchRunsFunc
 	// SavedSearchesFunc is an instance of a mock function object
 	// controlling the behavior of the method SavedSearches.
 	SavedSearchesFunc *EnterpriseDBSavedSearchesFunc
 	// SearchContextsFunc is an instance of a mock function object
 	// controlling the behavior of the method SearchContexts.
 	SearchContextsFunc *EnterpriseDBSearchContextsFunc
+	// SearchExportJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchExportJobs.
+	SearchExportJobsFunc *EnterpriseDBSearchExportJobsFunc
 	// SecurityEventLogsFunc is an instance of a mock function object
 	// controlling the behavior of the method SecurityEventLogs.
 	SecurityEventLogsFunc *EnterpriseDBSecurityEventLogsFunc
@@ -6978,6 +6996,16 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		AssignedOwnersFunc: &EnterpriseDBAssignedOwnersFunc{
+			defaultHook: func() (r0 database.AssignedOwnersStore) {
+				return
+			},
+		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: func() (r0 database.AuditLogStore) {
+				return
+			},
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: func() (r0 database.AuthzStore) {
 				return
@@ -7098,6 +7126,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		PathRanksFunc: &EnterpriseDBPathRanksFunc{
+			defaultHook: func() (r0 database.PathRankStore) {
+				return
+			},
+		},
 		PermissionSyncJobsFunc: &EnterpriseDBPermissionSyncJobsFunc{
 			defaultHook: func() (r0 database.PermissionSyncJobStore) {
 				return
@@ -7138,6 +7171,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		RepoUpdateScheduleFunc: &EnterpriseDBRepoUpdateScheduleFunc{
+			defaultHook: func() (r0 database.RepoUpdateScheduleStore) {
+				return
+			},
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: func() (r0 database.RepoStore) {
 				return
@@ -7153,6 +7191,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		SavedSearchRunsFunc: &EnterpriseDBSavedSearchRunsFunc{
+			defaultHook: func() (r0 database.SavedSearchRunStore) {
+				return
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() (r0 database.SavedSearchStore) {
 				return
@@ -7163,6 +7206,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		SearchExportJobsFunc: &EnterpriseDBSearchExportJobsFunc{
+			defaultHook: func() (r0 database.SearchExportJobStore) {
+				return
+			},
+		},
 		SecurityEventLogsFunc: &EnterpriseDBSecurityEventLogsFunc{
 			defaultHook: func() (r0 database.SecurityEventLogsStore) {
 				return
@@ -7240,6 +7288,16 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.AccessTokens")
 			},
 		},
+		AssignedOwnersFunc: &EnterpriseDBAssignedOwnersFunc{
+			defaultHook: func() database.AssignedOwnersStore {
+				panic("unexpected invocation of MockEnterpriseDB.AssignedOwners")
+			},
+		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: func() database.AuditLogStore {
+				panic("unexpected invocation of MockEnterpriseDB.AuditLogs")
+			},
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: func() database.AuthzStore {
 				panic("unexpected invocation of MockEnterpriseDB.Authz")
@@ -7360,6 +7418,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.OutboundWebhooks")
 			},
 		},
+		PathRanksFunc: &EnterpriseDBPathRanksFunc{
+			defaultHook: func() database.PathRankStore {
+				panic("unexpected invocation of MockEnterpriseDB.PathRanks")
+			},
+		},
 		PermissionSyncJobsFunc: &EnterpriseDBPermissionSyncJobsFunc{
 			defaultHook: func() database.PermissionSyncJobStore {
 				panic("unexpected invocation of MockEnterpriseDB.PermissionSyncJobs")
@@ -7400,6 +7463,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.RepoStatistics")
 			},
 		},
+		RepoUpdateScheduleFunc: &EnterpriseDBRepoUpdateScheduleFunc{
+			defaultHook: func() database.RepoUpdateScheduleStore {
+				panic("unexpected invocation of MockEnterpriseDB.RepoUpdateSchedule")
+			},
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: func() database.RepoStore {
 				panic("unexpected invocation of MockEnterpriseDB.Repos")
@@ -7415,6 +7483,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.Roles")
 			},
 		},
+		SavedSearchRunsFunc: &EnterpriseDBSavedSearchRunsFunc{
+			defaultHook: func() database.SavedSearchRunStore {
+				panic("unexpected invocation of MockEnterpriseDB.SavedSearchRuns")
+			},
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: func() database.SavedSearchStore {
 				panic("unexpected invocation of MockEnterpriseDB.SavedSearches")
@@ -7425,6 +7498,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.SearchContexts")
 			},
 		},
+		SearchExportJobsFunc: &EnterpriseDBSearchExportJobsFunc{
+			defaultHook: func() database.SearchExportJobStore {
+				panic("unexpected invocation of MockEnterpriseDB.SearchExportJobs")
+			},
+		},
 		SecurityEventLogsFunc: &EnterpriseDBSecurityEventLogsFunc{
 			defaultHook: func() database.SecurityEventLogsStore {
 				panic("unexpected invocation of MockEnterpriseDB.SecurityEventLogs")
@@ -7501,6 +7579,12 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		AccessTokensFunc: &EnterpriseDBAccessTokensFunc{
 			defaultHook: i.AccessTokens,
 		},
+		AssignedOwnersFunc: &EnterpriseDBAssignedOwnersFunc{
+			defaultHook: i.AssignedOwners,
+		},
+		AuditLogsFunc: &EnterpriseDBAuditLogsFunc{
+			defaultHook: i.AuditLogs,
+		},
 		AuthzFunc: &EnterpriseDBAuthzFunc{
 			defaultHook: i.Authz,
 		},
@@ -7573,6 +7657,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		OutboundWebhooksFunc: &EnterpriseDBOutboundWebhooksFunc{
 			defaultHook: i.OutboundWebhooks,
 		},
+		PathRanksFunc: &EnterpriseDBPathRanksFunc{
+			defaultHook: i.PathRanks,
+		},
 		PermissionSyncJobsFunc: &EnterpriseDBPermissionSyncJobsFunc{
 			defaultHook: i.PermissionSyncJobs,
 		},
@@ -7597,6 +7684,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		RepoStatisticsFunc: &EnterpriseDBRepoStatisticsFunc{
 			defaultHook: i.RepoStatistics,
 		},
+		RepoUpdateScheduleFunc: &EnterpriseDBRepoUpdateScheduleFunc{
+			defaultHook: i.RepoUpdateSchedule,
+		},
 		ReposFunc: &EnterpriseDBReposFunc{
 			defaultHook: i.Repos,
 		},
@@ -7606,12 +7696,18 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		RolesFunc: &EnterpriseDBRolesFunc{
 			defaultHook: i.Roles,
 		},
+		SavedSearchRunsFunc: &EnterpriseDBSavedSearchRunsFunc{
+			defaultHook: i.SavedSearchRuns,
+		},
 		SavedSearchesFunc: &EnterpriseDBSavedSearchesFunc{
 			defaultHook: i.SavedSearches,
 		},
 		SearchContextsFunc: &EnterpriseDBSearchContextsFunc{
 			defaultHook: i.SearchContexts,
 		},
+		SearchExportJobsFunc: &EnterpriseDBSearchExportJobsFunc{
+			defaultHook: i.SearchExportJobs,
+		},
 		SecurityEventLogsFunc: &EnterpriseDBSecurityEventLogsFunc{
 			defaultHook: i.SecurityEventLogs,
 		},
@@ -7753,6 +7849,204 @@ func (c EnterpriseDBAccessTokensFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBAssignedOwnersFunc describes the behavior when the
+// AssignedOwners method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBAssignedOwnersFunc struct {
+	defaultHook func() database.AssignedOwnersStore
+	hooks       []func() database.AssignedOwnersStore
+	history     []EnterpriseDBAssignedOwnersFuncCall
+	mutex       sync.Mutex
+}
+
+// AssignedOwners delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) AssignedOwners() database.AssignedOwnersStore {
+	r0 := m.AssignedOwnersFunc.nextHook()()
+	m.AssignedOwnersFunc.appendCall(EnterpriseDBAssignedOwnersFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AssignedOwners
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBAssignedOwnersFunc) SetDefaultHook(hook func() database.AssignedOwnersStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AssignedOwners method of the parent MockEnterpriseDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EnterpriseDBAssignedOwnersFunc) PushHook(hook func() database.AssignedOwnersStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBAssignedOwnersFunc) SetDefaultReturn(r0 database.AssignedOwnersStore) {
+	f.SetDefaultHook(func() database.AssignedOwnersStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBAssignedOwnersFunc) PushReturn(r0 database.AssignedOwnersStore) {
+	f.PushHook(func() database.AssignedOwnersStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBAssignedOwnersFunc) nextHook() func() database.AssignedOwnersStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBAssignedOwnersFunc) appendCall(r0 EnterpriseDBAssignedOwnersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBAssignedOwnersFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBAssignedOwnersFunc) History() []EnterpriseDBAssignedOwnersFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBAssignedOwnersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBAssignedOwnersFuncCall is an object that describes an
+// invocation of method AssignedOwners on an instance of MockEnterpriseDB.
+type EnterpriseDBAssignedOwnersFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.AssignedOwnersStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBAssignedOwnersFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBAssignedOwnersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EnterpriseDBAuditLogsFunc describes the behavior when the AuditLogs
+// method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBAuditLogsFunc struct {
+	defaultHook func() database.AuditLogStore
+	hooks       []func() database.AuditLogStore
+	history     []EnterpriseDBAuditLogsFuncCall
+	mutex       sync.Mutex
+}
+
+// AuditLogs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) AuditLogs() database.AuditLogStore {
+	r0 := m.AuditLogsFunc.nextHook()()
+	m.AuditLogsFunc.appendCall(EnterpriseDBAuditLogsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AuditLogs method of
+// the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBAuditLogsFunc) SetDefaultHook(hook func() database.AuditLogStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AuditLogs method of the parent MockEnterpriseDB instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBAuditLogsFunc) PushHook(hook func() database.AuditLogStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBAuditLogsFunc) SetDefaultReturn(r0 database.AuditLogStore) {
+	f.SetDefaultHook(func() database.AuditLogStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBAuditLogsFunc) PushReturn(r0 database.AuditLogStore) {
+	f.PushHook(func() database.AuditLogStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBAuditLogsFunc) nextHook() func() database.AuditLogStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBAuditLogsFunc) appendCall(r0 EnterpriseDBAuditLogsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBAuditLogsFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBAuditLogsFunc) History() []EnterpriseDBAuditLogsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBAuditLogsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBAuditLogsFuncCall is an object that describes an invocation
+// of method AuditLogs on an instance of MockEnterpriseDB.
+type EnterpriseDBAuditLogsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.AuditLogStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBAuditLogsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBAuditLogsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBAuthzFunc describes the behavior when the Authz method of the
 // parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBAuthzFunc struct {
@@ -10178,6 +10472,105 @@ func (c EnterpriseDBOutboundWebhooksFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBPathRanksFunc describes the behavior when the PathRanks
+// method of the parent MockEnterpriseDB instance is invoked.
+type EnterpriseDBPathRanksFunc struct {
+	defaultHook func() database.PathRankStore
+	hooks       []func() database.PathRankStore
+	history     []EnterpriseDBPathRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// PathRanks delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnterpriseDB) PathRanks() database.PathRankStore {
+	r0 := m.PathRanksFunc.nextHook()()
+	m.PathRanksFunc.appendCall(EnterpriseDBPathRanksFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the PathRanks method of
+// the parent MockEnterpriseDB instance is invoked and the hook queue is
+// empty.
+func (f *EnterpriseDBPathRanksFunc) SetDefaultHook(hook func() database.PathRankStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PathRanks method of the parent MockEnterpriseDB instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *EnterpriseDBPathRanksFunc) PushHook(hook func() database.PathRankStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBPathRanksFunc) SetDefaultReturn(r0 database.PathRankStore) {
+	f.SetDefaultHook(func() database.PathRankStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBPathRanksFunc) PushReturn(r0 database.PathRankStore) {
+	f.PushHook(func() database.PathRankStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBPathRanksFunc) nextHook() func() database.PathRankStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBPathRanksFunc) appendCall(r0 EnterpriseDBPathRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBPathRanksFunc) History() []EnterpriseDBPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBPathRanksFuncCall is an object that describes an invocation
+// of method PathRanks on an instance of MockEnterpriseDB.
+type EnterpriseDBPathRanksFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.PathRankStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBPermissionSyncJobsFunc describes the behavior when the
 // PermissionSyncJobs method of the parent MockEnterpriseDB instance is
 // invoked.
@@ -11007,6 +11400,107 @@ func (c EnterpriseDBRepoStatisticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBRepoUpdateScheduleFunc describes the behavior when the
+// RepoUpdateSchedule method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBRepoUpdateScheduleFunc struct {
+	defaultHook func() database.RepoUpdateScheduleStore
+	hooks       []func() database.RepoUpdateScheduleStore
+	history     []EnterpriseDBRepoUpdateScheduleFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoUpdateSchedule delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) RepoUpdateSchedule() database.RepoUpdateScheduleStore {
+	r0 := m.RepoUpdateScheduleFunc.nextHook()()
+	m.RepoUpdateScheduleFunc.appendCall(EnterpriseDBRepoUpdateScheduleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoUpdateSchedule
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) SetDefaultHook(hook func() database.RepoUpdateScheduleStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoUpdateSchedule method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) PushHook(hook func() database.RepoUpdateScheduleStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) SetDefaultReturn(r0 database.RepoUpdateScheduleStore) {
+	f.SetDefaultHook(func() database.RepoUpdateScheduleStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) PushReturn(r0 database.RepoUpdateScheduleStore) {
+	f.PushHook(func() database.RepoUpdateScheduleStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBRepoUpdateScheduleFunc) nextHook() func() database.RepoUpdateScheduleStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBRepoUpdateScheduleFunc) appendCall(r0 EnterpriseDBRepoUpdateScheduleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBRepoUpdateScheduleFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBRepoUpdateScheduleFunc) History() []EnterpriseDBRepoUpdateScheduleFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBRepoUpdateScheduleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBRepoUpdateScheduleFuncCall is an object that describes an
+// invocation of method RepoUpdateSchedule on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBRepoUpdateScheduleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RepoUpdateScheduleStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBRepoUpdateScheduleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBRepoUpdateScheduleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBReposFunc describes the behavior when the Repos method of the
 // parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBReposFunc struct {
@@ -11303,6 +11797,106 @@ func (c EnterpriseDBRolesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBSavedSearchRunsFunc describes the behavior when the
+// SavedSearchRuns method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBSavedSearchRunsFunc struct {
+	defaultHook func() database.SavedSearchRunStore
+	hooks       []func() database.SavedSearchRunStore
+	history     []EnterpriseDBSavedSearchRunsFuncCall
+	mutex       sync.Mutex
+}
+
+// SavedSearchRuns delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) SavedSearchRuns() database.SavedSearchRunStore {
+	r0 := m.SavedSearchRunsFunc.nextHook()()
+	m.SavedSearchRunsFunc.appendCall(EnterpriseDBSavedSearchRunsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SavedSearchRuns
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBSavedSearchRunsFunc) SetDefaultHook(hook func() database.SavedSearchRunStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SavedSearchRuns method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBSavedSearchRunsFunc) PushHook(hook func() database.SavedSearchRunStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBSavedSearchRunsFunc) SetDefaultReturn(r0 database.SavedSearchRunStore) {
+	f.SetDefaultHook(func() database.SavedSearchRunStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBSavedSearchRunsFunc) PushReturn(r0 database.SavedSearchRunStore) {
+	f.PushHook(func() database.SavedSearchRunStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBSavedSearchRunsFunc) nextHook() func() database.SavedSearchRunStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBSavedSearchRunsFunc) appendCall(r0 EnterpriseDBSavedSearchRunsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBSavedSearchRunsFuncCall objects
+// describing the invocations of this function.
+func (f *EnterpriseDBSavedSearchRunsFunc) History() []EnterpriseDBSavedSearchRunsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBSavedSearchRunsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBSavedSearchRunsFuncCall is an object that describes an
+// invocation of method SavedSearchRuns on an instance of MockEnterpriseDB.
+type EnterpriseDBSavedSearchRunsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.SavedSearchRunStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBSavedSearchRunsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBSavedSearchRunsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSavedSearchesFunc describes the behavior when the
 // SavedSearches method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBSavedSearchesFunc struct {
@@ -11501,6 +12095,106 @@ func (c EnterpriseDBSearchContextsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBSearchExportJobsFunc describes the behavior when the
+// SearchExportJobs method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBSearchExportJobsFunc struct {
+	defaultHook func() database.SearchExportJobStore
+	hooks       []func() database.SearchExportJobStore
+	history     []EnterpriseDBSearchExportJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchExportJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) SearchExportJobs() database.SearchExportJobStore {
+	r0 := m.SearchExportJobsFunc.nextHook()()
+	m.SearchExportJobsFunc.appendCall(EnterpriseDBSearchExportJobsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SearchExportJobs
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBSearchExportJobsFunc) SetDefaultHook(hook func() database.SearchExportJobStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchExportJobs method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBSearchExportJobsFunc) PushHook(hook func() database.SearchExportJobStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBSearchExportJobsFunc) SetDefaultReturn(r0 database.SearchExportJobStore) {
+	f.SetDefaultHook(func() database.SearchExportJobStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBSearchExportJobsFunc) PushReturn(r0 database.SearchExportJobStore) {
+	f.PushHook(func() database.SearchExportJobStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBSearchExportJobsFunc) nextHook() func() database.SearchExportJobStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBSearchExportJobsFunc) appendCall(r0 EnterpriseDBSearchExportJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBSearchExportJobsFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBSearchExportJobsFunc) History() []EnterpriseDBSearchExportJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBSearchExportJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBSearchExportJobsFuncCall is an object that describes an
+// invocation of method SearchExportJobs on an instance of MockEnterpriseDB.
+type EnterpriseDBSearchExportJobsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.SearchExportJobStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBSearchExportJobsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBSearchExportJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBSecurityEventLogsFunc describes the behavior when the
 // SecurityEventLogs method of the parent MockEnterpriseDB instance is
 // invoked.
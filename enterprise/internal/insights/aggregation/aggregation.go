@@ -2,16 +2,18 @@ package aggregation
 
 import (
 	"context"
+	"path"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
@@ -52,11 +54,11 @@ func countRepo(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
-func countLang(r result.Match) map[MatchKey]int {
+func countLang(r result.Match) (map[MatchKey]int, error) {
 	var lang string
 	switch match := r.(type) {
 	case *result.FileMatch:
-		lang, _ = enry.GetLanguageByExtension(match.Path)
+		lang, _ = inventory.GetLanguageByFilename(match.Path)
 	default:
 	}
 	if lang != "" {
@@ -64,9 +66,96 @@ func countLang(r result.Match) map[MatchKey]int {
 			RepoID: int32(r.RepoName().ID),
 			Repo:   string(r.RepoName().Name),
 			Group:  lang,
-		}: r.ResultCount()}
+		}: r.ResultCount()}, nil
+	}
+	return nil, nil
+}
+
+func countFileExtension(r result.Match) (map[MatchKey]int, error) {
+	var ext string
+	switch match := r.(type) {
+	case *result.FileMatch:
+		ext = strings.ToLower(path.Ext(match.Path))
+	default:
+	}
+	// A dot on its own is not an extension, eg. "file." or "README.".
+	if len(ext) > 1 {
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  ext,
+		}: r.ResultCount()}, nil
+	}
+	return nil, nil
+}
+
+const (
+	// commitWeekLayout is the format of the groups when aggregating by commit
+	// week. Each group is the Monday the week starts on.
+	commitWeekLayout = "2006-01-02"
+	// commitMonthLayout is the format of the groups when aggregating by commit
+	// month.
+	commitMonthLayout = "2006-01"
+)
+
+func countCommitDateFunc(mode types.SearchAggregationMode) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		var date time.Time
+		switch match := r.(type) {
+		case *result.CommitMatch:
+			// Prefer the committer date as that is what after: and before:
+			// filter on when drilling down into a group.
+			if match.Commit.Committer != nil && !match.Commit.Committer.Date.IsZero() {
+				date = match.Commit.Committer.Date
+			} else {
+				date = match.Commit.Author.Date
+			}
+		default:
+		}
+		if date.IsZero() {
+			return nil, nil
+		}
+
+		date = date.UTC()
+		var group string
+		switch mode {
+		case types.COMMIT_WEEK_AGGREGATION_MODE:
+			// time.Weekday starts on Sunday, but weeks start on Monday.
+			daysSinceMonday := (int(date.Weekday()) + 6) % 7
+			group = date.AddDate(0, 0, -daysSinceMonday).Format(commitWeekLayout)
+		case types.COMMIT_MONTH_AGGREGATION_MODE:
+			group = date.Format(commitMonthLayout)
+		default:
+			return nil, errors.Newf("unsupported commit date aggregation mode: %s", mode)
+		}
+
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  group,
+		}: r.ResultCount()}, nil
+	}
+}
+
+// CommitDateRange returns the range of commit dates covered by a group of an
+// aggregation by commit date. The range includes after and excludes before.
+func CommitDateRange(mode types.SearchAggregationMode, group string) (after, before time.Time, err error) {
+	switch mode {
+	case types.COMMIT_WEEK_AGGREGATION_MODE:
+		after, err = time.Parse(commitWeekLayout, group)
+		if err != nil {
+			return after, before, errors.Wrap(err, "parsing commit week")
+		}
+		return after, after.AddDate(0, 0, 7), nil
+	case types.COMMIT_MONTH_AGGREGATION_MODE:
+		after, err = time.Parse(commitMonthLayout, group)
+		if err != nil {
+			return after, before, errors.Wrap(err, "parsing commit month")
+		}
+		return after, after.AddDate(0, 1, 0), nil
+	default:
+		return after, before, errors.Newf("unsupported commit date aggregation mode: %s", mode)
 	}
-	return nil
 }
 
 func countPath(r result.Match) (map[MatchKey]int, error) {
@@ -168,9 +257,13 @@ func matchContent(event result.Match) []string {
 
 func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
-		types.REPO_AGGREGATION_MODE:   countRepo,
-		types.PATH_AGGREGATION_MODE:   countPath,
-		types.AUTHOR_AGGREGATION_MODE: countAuthor,
+		types.REPO_AGGREGATION_MODE:           countRepo,
+		types.PATH_AGGREGATION_MODE:           countPath,
+		types.AUTHOR_AGGREGATION_MODE:         countAuthor,
+		types.LANGUAGE_AGGREGATION_MODE:       countLang,
+		types.FILE_EXTENSION_AGGREGATION_MODE: countFileExtension,
+		types.COMMIT_WEEK_AGGREGATION_MODE:    countCommitDateFunc(types.COMMIT_WEEK_AGGREGATION_MODE),
+		types.COMMIT_MONTH_AGGREGATION_MODE:   countCommitDateFunc(types.COMMIT_MONTH_AGGREGATION_MODE),
 	}
 
 	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
@@ -94,8 +94,8 @@ func commitMatch(repo, author string, date time.Time, repoID, numRanges int32, c
 
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
-			Author:    gitdomain.Signature{Name: author},
-			Committer: &gitdomain.Signature{},
+			Author:    gitdomain.Signature{Name: author, Date: date},
+			Committer: &gitdomain.Signature{Date: date},
 			Message:   gitdomain.Message(content),
 		},
 		Repo: internaltypes.MinimalRepo{Name: api.RepoName(repo), ID: api.RepoID(repoID)},
@@ -407,6 +407,154 @@ func TestPathAggregation(t *testing.T) {
 	}
 }
 
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.LANGUAGE_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{repoMatch("myRepo", 1)},
+			},
+			autogold.Want("No language for repo match", map[string]int{}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{commitMatch("repoA", "Author A", sampleDate, 1, 2, "a")},
+			},
+			autogold.Want("No language for commit match", map[string]int{}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "main.go", 1, "a", "b"),
+					contentMatch("myRepo", "web/index.ts", 1, "a"),
+					symbolMatch("myRepo", "cmd/server.go", 1, "a"),
+					pathMatch("myRepo", "LICENSE", 1),
+				},
+			},
+			autogold.Want("counts by language", map[string]int{"Go": 3, "TypeScript": 1}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestFileExtensionAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.FILE_EXTENSION_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.FILE_EXTENSION_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{diffMatch("myRepo", "author-a", 1)},
+			},
+			autogold.Want("No extension for diff match", map[string]int{}),
+		},
+		{
+			types.FILE_EXTENSION_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "main.go", 1, "a", "b"),
+					pathMatch("myRepo", "docs/README.MD", 1),
+					pathMatch("myRepo", "docs/index.md", 1),
+					pathMatch("myRepo", "Makefile", 1),
+					pathMatch("myRepo", ".github/CODEOWNERS", 1),
+				},
+			},
+			autogold.Want("counts by extension", map[string]int{".go": 2, ".md": 2}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestCommitDateAggregation(t *testing.T) {
+	// sampleDate is a Friday.
+	sunday := time.Date(2022, time.April, 3, 23, 0, 0, 0, time.UTC)
+	monday := time.Date(2022, time.April, 4, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{types.COMMIT_WEEK_AGGREGATION_MODE, streaming.SearchEvent{}, autogold.Want("No results", map[string]int{})},
+		{
+			types.COMMIT_WEEK_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{contentMatch("myRepo", "file.go", 1, "a", "b")},
+			},
+			autogold.Want("No date for content match", map[string]int{}),
+		},
+		{
+			types.COMMIT_WEEK_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", sunday, 1, 2, "a"),
+					commitMatch("repoB", "Author B", monday, 2, 2, "a"),
+				},
+			},
+			autogold.Want("counts by week", map[string]int{"2022-03-28": 4, "2022-04-04": 2}),
+		},
+		{
+			types.COMMIT_MONTH_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate.AddDate(0, 0, -1), 1, 2, "a"),
+					commitMatch("repoA", "Author B", sampleDate, 1, 2, "a"),
+					commitMatch("repoB", "Author B", monday, 2, 2, "a"),
+				},
+			},
+			autogold.Want("counts by month", map[string]int{"2022-03": 2, "2022-04": 4}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestCommitDateRange(t *testing.T) {
+	after, before, err := CommitDateRange(types.COMMIT_MONTH_AGGREGATION_MODE, "2022-12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("month range", [2]string{"2022-12-01", "2023-01-01"}).Equal(t, [2]string{after.Format("2006-01-02"), before.Format("2006-01-02")})
+
+	if _, _, err := CommitDateRange(types.COMMIT_WEEK_AGGREGATION_MODE, "2022-12"); err == nil {
+		t.Fatal("expected error parsing month as week")
+	}
+}
+
 func TestCaptureGroupAggregation(t *testing.T) {
 	longCaptureGroup := "111111111|222222222|333333333|444444444|555555555|666666666|777777777|888888888|999999999|000000000|"
 	testCases := []struct {
@@ -357,13 +357,42 @@ func IsSingleRepoQuery(query BasicQuery) (bool, error) {
 }
 
 func AddAuthorFilter(query BasicQuery, author string) (BasicQuery, error) {
+	return addCommitDiffParameters(query, searchquery.Parameter{
+		Field:      searchquery.FieldAuthor,
+		Value:      buildFilterText(author),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
+// AddCommitDateFilter restricts a commit or diff search to commits made on or
+// after after and before before.
+func AddCommitDateFilter(query BasicQuery, after, before time.Time) (BasicQuery, error) {
+	return addCommitDiffParameters(query, searchquery.Parameter{
+		Field:      searchquery.FieldAfter,
+		Value:      after.Format(commitDateLayout),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	}, searchquery.Parameter{
+		Field:      searchquery.FieldBefore,
+		Value:      before.Format(commitDateLayout),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
+const commitDateLayout = "2006-01-02"
+
+// addCommitDiffParameters appends the parameters to the steps of the query
+// plan that search commits or diffs. Other steps are left unchanged.
+func addCommitDiffParameters(query BasicQuery, parameters ...searchquery.Parameter) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
 	}
 
 	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
-		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+len(parameters))
 		isCommitDiffType := false
 		for _, parameter := range basic.Parameters {
 			modified = append(modified, parameter)
@@ -372,18 +401,12 @@ func AddAuthorFilter(query BasicQuery, author string) (BasicQuery, error) {
 			}
 		}
 		if !isCommitDiffType {
-			// we can't modify this plan to accept an author so return the original input
+			// we can't modify this plan to filter on commits so return the original input
 			return basic
 		}
-		modified = append(modified, searchquery.Parameter{
-			Field:      searchquery.FieldAuthor,
-			Value:      buildFilterText(author),
-			Negated:    false,
-			Annotation: searchquery.Annotation{},
-		})
+		modified = append(modified, parameters...)
 		return basic.MapParameters(modified)
 	})
-
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
 }
 
@@ -395,6 +418,32 @@ func AddFileFilter(query BasicQuery, file string) (BasicQuery, error) {
 	return addFilterSimple(query, searchquery.FieldFile, file)
 }
 
+// AddLangFilter adds a lang: filter for the language, as returned by
+// inventory.GetLanguageByFilename.
+func AddLangFilter(query BasicQuery, lang string) (BasicQuery, error) {
+	parameter := searchquery.Parameter{
+		Field:      searchquery.FieldLang,
+		Value:      strings.ToLower(lang),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	}
+	if strings.Contains(lang, " ") {
+		parameter.Annotation.Labels = searchquery.Quoted
+	}
+	return addParameters(query, parameter)
+}
+
+// AddFileExtensionFilter adds a file: filter matching files with the
+// extension, eg. ".go".
+func AddFileExtensionFilter(query BasicQuery, extension string) (BasicQuery, error) {
+	return addParameters(query, searchquery.Parameter{
+		Field:      searchquery.FieldFile,
+		Value:      regexp.QuoteMeta(extension) + "$",
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
@@ -404,20 +453,25 @@ func buildFilterText(raw string) string {
 }
 
 func AddFilter(query BasicQuery, field, value string, negated bool) (BasicQuery, error) {
+	return addParameters(query, searchquery.Parameter{
+		Field:      field,
+		Value:      buildFilterText(value),
+		Negated:    negated,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
+// addParameters appends the parameters to every step of the query plan.
+func addParameters(query BasicQuery, parameters ...searchquery.Parameter) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
 	}
 
 	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
-		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+len(parameters))
 		modified = append(modified, basic.Parameters...)
-		modified = append(modified, searchquery.Parameter{
-			Field:      field,
-			Value:      buildFilterText(value),
-			Negated:    negated,
-			Annotation: searchquery.Annotation{},
-		})
+		modified = append(modified, parameters...)
 		return basic.MapParameters(modified)
 	})
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
@@ -535,6 +535,38 @@ func Test_addAuthorFilter(t *testing.T) {
 	}
 }
 
+func Test_addCommitDateFilter(t *testing.T) {
+	after := time.Date(2022, time.March, 28, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, 0, 7)
+
+	tests := []struct {
+		input string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery repo:myrepo type:commit",
+			want:  autogold.Want("commit search", BasicQuery("repo:myrepo type:commit after:2022-03-28 before:2022-04-04 myquery")),
+		},
+		{
+			input: "myquery repo:myrepo type:repo",
+			want:  autogold.Want("repo search - should return input", BasicQuery("repo:myrepo type:repo myquery")),
+		},
+		{
+			input: "(myquery repo:myrepo type:repo) or (type:diff repo:asdf findme)",
+			want:  autogold.Want("compound query where one side is diff and one side is repo", BasicQuery("(repo:myrepo type:repo myquery OR type:diff repo:asdf after:2022-03-28 before:2022-04-04 findme)")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddCommitDateFilter(BasicQuery(test.input), after, before)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.want.Equal(t, got)
+		})
+	}
+}
+
 func Test_addRepoFilter(t *testing.T) {
 	tests := []struct {
 		input string
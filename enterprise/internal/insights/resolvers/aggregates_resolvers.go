@@ -35,6 +35,9 @@ const (
 const invalidQueryMsg = "Grouping is disabled because the search query is not valid."
 const fileUnsupportedFieldValueFmt = `Grouping by file is not available for searches with "%s:%s".`
 const authNotCommitDiffMsg = "Grouping by author is only available for diff and commit searches."
+const langUnsupportedFieldValueFmt = `Grouping by language is not available for searches with "%s:%s".`
+const extUnsupportedFieldValueFmt = `Grouping by file extension is not available for searches with "%s:%s".`
+const commitDateNotCommitDiffMsg = "Grouping by commit date is only available for diff and commit searches."
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
 const cgUnsupportedSelectFmt = `Grouping by capture group is not available for searches with "%s:%s".`
@@ -364,10 +367,14 @@ func getNotAvailableReason(query, patternType string, mode types.SearchAggregati
 
 func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 	checkByMode := map[types.SearchAggregationMode]canAggregateBy{
-		types.REPO_AGGREGATION_MODE:          canAggregateByRepo,
-		types.PATH_AGGREGATION_MODE:          canAggregateByPath,
-		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
-		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
+		types.REPO_AGGREGATION_MODE:           canAggregateByRepo,
+		types.PATH_AGGREGATION_MODE:           canAggregateByPath,
+		types.AUTHOR_AGGREGATION_MODE:         canAggregateByAuthor,
+		types.CAPTURE_GROUP_AGGREGATION_MODE:  canAggregateByCaptureGroup,
+		types.LANGUAGE_AGGREGATION_MODE:       canAggregateByLanguage,
+		types.FILE_EXTENSION_AGGREGATION_MODE: canAggregateByFileExtension,
+		types.COMMIT_WEEK_AGGREGATION_MODE:    canAggregateByCommitDate,
+		types.COMMIT_MONTH_AGGREGATION_MODE:   canAggregateByCommitDate,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -393,6 +400,21 @@ func canAggregateByRepo(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, fileUnsupportedFieldValueFmt)
+}
+
+func canAggregateByLanguage(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, langUnsupportedFieldValueFmt)
+}
+
+func canAggregateByFileExtension(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, extUnsupportedFieldValueFmt)
+}
+
+// canAggregateByFile checks whether a query returns file results that can be
+// grouped by a property of the file. unsupportedFmt is used to explain which
+// field value prevents grouping.
+func canAggregateByFile(searchQuery, patternType, unsupportedFmt string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -403,7 +425,7 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 	for _, parameter := range parameters {
 		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
 			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
-				reason := fmt.Sprintf(fileUnsupportedFieldValueFmt,
+				reason := fmt.Sprintf(unsupportedFmt,
 					parameter.Field, parameter.Value)
 				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 			}
@@ -413,6 +435,17 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, authNotCommitDiffMsg)
+}
+
+func canAggregateByCommitDate(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByCommit(searchQuery, patternType, commitDateNotCommitDiffMsg)
+}
+
+// canAggregateByCommit checks whether a query returns commit or diff results
+// that can be grouped by a property of the commit. notCommitDiffMsg is the
+// reason given for any other query.
+func canAggregateByCommit(searchQuery, patternType, notCommitDiffMsg string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -427,7 +460,7 @@ func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableR
 			}
 		}
 	}
-	return false, &notAvailableReason{reason: authNotCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+	return false, &notAvailableReason{reason: notCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 }
 
 func canAggregateByCaptureGroup(searchQuery, patternType string) (bool, *notAvailableReason, error) {
@@ -585,6 +618,18 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.LANGUAGE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddLangFilter
+	case types.FILE_EXTENSION_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddFileExtensionFilter
+	case types.COMMIT_WEEK_AGGREGATION_MODE, types.COMMIT_MONTH_AGGREGATION_MODE:
+		modifierFunc = func(basicQuery querybuilder.BasicQuery, s string) (querybuilder.BasicQuery, error) {
+			after, before, err := aggregation.CommitDateRange(mode, s)
+			if err != nil {
+				return "", err
+			}
+			return querybuilder.AddCommitDateFilter(basicQuery, after, before)
+		}
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
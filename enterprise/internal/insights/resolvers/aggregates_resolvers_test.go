@@ -189,6 +189,90 @@ func Test_canAggregateByAuthor(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByLanguage(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(langUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "insights type:diff",
+			reason:       fmt.Sprintf(langUnsupportedFieldValueFmt, "type", "diff"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByLanguage,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByFileExtension(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with type:commit parameter",
+			query:        "insights type:commit",
+			reason:       fmt.Sprintf(extUnsupportedFieldValueFmt, "type", "commit"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByFileExtension,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByCommitDate(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "cannot aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			reason:       commitDateNotCommitDiffMsg,
+			canAggregate: false,
+		},
+		{
+			name:         "can aggregate for query with type:commit parameter",
+			query:        "type:commit fix",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with type:diff parameter",
+			query:        "type:diff fix",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for invalid query",
+			query:        "type:diff fork:leo",
+			reason:       invalidQueryMsg,
+			canAggregate: false,
+			err:          errors.Newf("ParseQuery"),
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByCommitDate,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByCaptureGroup(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -456,6 +540,41 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.PATH_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Want("lang_no_whitespace", "lang:go findme"),
+			query:       "findme",
+			drilldown:   "Go",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("lang_with_whitespace", `lang:"protocol buffer" findme`),
+			query:       "findme",
+			drilldown:   "Protocol Buffer",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("file_extension", `file:\.go$ findme`),
+			query:       "findme",
+			drilldown:   ".go",
+			patternType: "standard",
+			mode:        types.FILE_EXTENSION_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("commit_week", "type:commit after:2022-03-28 before:2022-04-04 findme"),
+			query:       "findme type:commit",
+			drilldown:   "2022-03-28",
+			patternType: "standard",
+			mode:        types.COMMIT_WEEK_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("commit_month", "type:diff after:2022-12-01 before:2023-01-01 findme"),
+			query:       "findme type:diff",
+			drilldown:   "2022-12",
+			patternType: "standard",
+			mode:        types.COMMIT_MONTH_AGGREGATION_MODE,
+		},
 		{
 			want:        autogold.Want("capturegroup_with_whitespace", "case:yes /fin(?:d m)e/"),
 			query:       "/fin(.*)e/",
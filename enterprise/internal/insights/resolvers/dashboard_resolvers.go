@@ -59,6 +59,11 @@ func (d *dashboardConnectionResolver) compute(ctx context.Context) ([]*types.Das
 			d.err = errors.Wrap(err, "getUserPermissions")
 			return
 		}
+		args.UserID, args.OrgID, err = filterReadableNamespaces(ctx, d.postgresDB, args.UserID, args.OrgID)
+		if err != nil {
+			d.err = err
+			return
+		}
 
 		if d.args.ID != nil {
 			id, err := unmarshalDashboardID(*d.args.ID)
@@ -251,6 +256,9 @@ func (r *Resolver) CreateInsightsDashboard(ctx context.Context, args *graphqlbac
 	if len(dashboardGrants) == 0 {
 		return nil, errors.New("dashboard must be created with at least one grant")
 	}
+	if err := checkDashboardWriteAccess(ctx, r.postgresDB, dashboardGrants); err != nil {
+		return nil, err
+	}
 
 	userIds, orgIds, err := getUserPermissions(ctx, database.NewDBWith(r.logger, r.workerBaseStore).Orgs())
 	if err != nil {
@@ -298,6 +306,14 @@ func (r *Resolver) UpdateInsightsDashboard(ctx context.Context, args *graphqlbac
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: The user must be granted the permission for the namespaces the dashboard is
+	// currently shared with, and for the ones it will be shared with.
+	if err := r.checkDashboardWriteAccess(ctx, r.dashboardStore, int(dashboardID.Arg)); err != nil {
+		return nil, err
+	}
+	if err := checkDashboardWriteAccess(ctx, r.postgresDB, dashboardGrants); err != nil {
+		return nil, err
+	}
 
 	dashboard, err := r.dashboardStore.UpdateDashboard(ctx, store.UpdateDashboardArgs{
 		ID:     int(dashboardID.Arg),
@@ -367,6 +383,20 @@ func hasPermissionForGrants(dashboardGrants []store.DashboardGrant, userIds []in
 	return true
 }
 
+// checkDashboardWriteAccess returns an error if the current user is not granted the permission
+// to modify the dashboard with the given ID, based on the namespaces it is shared with.
+func (r *Resolver) checkDashboardWriteAccess(ctx context.Context, dashboardStore *store.DBDashboardStore, dashboardID int) error {
+	grants, err := dashboardStore.GetDashboardGrants(ctx, dashboardID)
+	if err != nil {
+		return errors.Wrap(err, "GetDashboardGrants")
+	}
+	dashboardGrants := make([]store.DashboardGrant, 0, len(grants))
+	for _, grant := range grants {
+		dashboardGrants = append(dashboardGrants, *grant)
+	}
+	return checkDashboardWriteAccess(ctx, r.postgresDB, dashboardGrants)
+}
+
 func (r *Resolver) DeleteInsightsDashboard(ctx context.Context, args *graphqlbackend.DeleteInsightsDashboardArgs) (*graphqlbackend.EmptyResponse, error) {
 	emptyResponse := &graphqlbackend.EmptyResponse{}
 
@@ -393,6 +423,9 @@ func (r *Resolver) DeleteInsightsDashboard(ctx context.Context, args *graphqlbac
 	if err != nil {
 		return nil, err
 	}
+	if err := r.checkDashboardWriteAccess(ctx, r.dashboardStore, int(dashboardID.Arg)); err != nil {
+		return nil, err
+	}
 
 	err = r.dashboardStore.DeleteDashboard(ctx, int(dashboardID.Arg))
 	if err != nil {
@@ -434,6 +467,9 @@ func (r *Resolver) AddInsightViewToDashboard(ctx context.Context, args *graphqlb
 	if err != nil {
 		return nil, err
 	}
+	if err := r.checkDashboardWriteAccess(ctx, tx, int(dashboardID.Arg)); err != nil {
+		return nil, err
+	}
 	err = txValidator.validateUserAccessForView(ctx, viewID)
 	if err != nil {
 		return nil, err
@@ -495,6 +531,9 @@ func (r *Resolver) RemoveInsightViewFromDashboard(ctx context.Context, args *gra
 	if err != nil {
 		return nil, err
 	}
+	if err := r.checkDashboardWriteAccess(ctx, tx, int(dashboardID.Arg)); err != nil {
+		return nil, err
+	}
 
 	err = tx.RemoveViewsFromDashboard(ctx, int(dashboardID.Arg), []string{viewID})
 	if err != nil {
@@ -1,11 +1,16 @@
 package resolvers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 
 	"github.com/graph-gophers/graphql-go/relay"
 )
@@ -116,3 +121,47 @@ func TestHasPermissionForGrants(t *testing.T) {
 		}
 	})
 }
+
+func TestDashboardOrgPermissions(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	permissions := database.NewMockPermissionStore()
+	// The user is only granted the permissions within org 2.
+	permissions.ListForUserFunc.SetDefaultReturn([]*types.GrantedPermission{
+		{Namespace: rbac.CodeInsightsNamespace, Action: rbac.ReadAction, OrgID: 2},
+		{Namespace: rbac.CodeInsightsNamespace, Action: rbac.WriteAction, OrgID: 2},
+	}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	t.Run("write", func(t *testing.T) {
+		if err := checkDashboardWriteAccess(ctx, db, []store.DashboardGrant{store.OrgDashboardGrant(2)}); err != nil {
+			t.Errorf("expected access to dashboard granted to org 2, got %s", err)
+		}
+		for _, grants := range [][]store.DashboardGrant{
+			{store.OrgDashboardGrant(3)},
+			{store.OrgDashboardGrant(2), store.GlobalDashboardGrant()},
+			{store.UserDashboardGrant(1)},
+		} {
+			if err := checkDashboardWriteAccess(ctx, db, grants); err == nil {
+				t.Errorf("expected no access to dashboard with grants %v", grants)
+			}
+		}
+	})
+
+	t.Run("read", func(t *testing.T) {
+		userIds, orgIds, err := filterReadableNamespaces(ctx, db, []int{1}, []int{2, 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int(nil), userIds); diff != "" {
+			t.Errorf("unexpected user ids (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]int{2}, orgIds); diff != "" {
+			t.Errorf("unexpected org ids (-want +got):\n%s", diff)
+		}
+	})
+}
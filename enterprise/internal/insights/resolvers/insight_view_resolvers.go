@@ -443,6 +443,10 @@ func (l *lineChartDataSeriesPresentationResolver) Color(ctx context.Context) (st
 }
 
 func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.CreateLineChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := checkInsightsWriteAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	// Validation
 	// Needs at least 1 series
 	if len(args.Input.DataSeries) == 0 {
@@ -534,6 +538,10 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 }
 
 func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graphqlbackend.UpdateLineChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := checkInsightsWriteAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	if len(args.Input.DataSeries) == 0 {
 		return nil, errors.New("At least one data series is required to update an insight view")
 	}
@@ -741,6 +749,10 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 }
 
 func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend.SaveInsightAsNewViewArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := checkInsightsWriteAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	uid := actor.FromContext(ctx).UID
 	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
 
@@ -832,6 +844,10 @@ func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend
 }
 
 func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphqlbackend.CreatePieChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := checkInsightsWriteAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	insightTx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -913,6 +929,10 @@ func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphq
 }
 
 func (r *Resolver) UpdatePieChartSearchInsight(ctx context.Context, args *graphqlbackend.UpdatePieChartSearchInsightArgs) (_ graphqlbackend.InsightViewPayloadResolver, err error) {
+	if err := checkInsightsWriteAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	tx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -1053,6 +1073,10 @@ func (r *insightDataSeriesDefinitionUnionResolver) ToSearchInsightDataSeriesDefi
 }
 
 func (r *Resolver) InsightViews(ctx context.Context, args *graphqlbackend.InsightViewQueryArgs) (graphqlbackend.InsightViewConnectionResolver, error) {
+	if err := checkInsightsReadAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	return &InsightViewQueryConnectionResolver{
 		baseInsightResolver: r.baseInsightResolver,
 		args:                args,
@@ -1388,6 +1412,10 @@ func seriesFound(existingSeries types.InsightViewSeries, inputSeries []graphqlba
 }
 
 func (r *Resolver) DeleteInsightView(ctx context.Context, args *graphqlbackend.DeleteInsightViewArgs) (*graphqlbackend.EmptyResponse, error) {
+	if err := checkInsightsWriteAccess(ctx, r.postgresDB); err != nil {
+		return nil, err
+	}
+
 	var viewId string
 	err := relay.UnmarshalSpec(args.Id, &viewId)
 	if err != nil {
@@ -19,8 +19,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/rbac"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 var (
@@ -92,6 +94,15 @@ func newWithClock(db edb.InsightsDB, postgres database.DB, clock func() time.Tim
 }
 
 func (r *Resolver) InsightsDashboards(ctx context.Context, args *graphqlbackend.InsightsDashboardsArgs) (graphqlbackend.InsightsDashboardConnectionResolver, error) {
+	// 🚨 SECURITY: Dashboards shared with an org only require the permission within the org,
+	// so the dashboards of the namespaces that the user is not granted the permission for are
+	// filtered out when computing the connection.
+	if actor.FromContext(ctx).IsAuthenticated() {
+		if err := rbac.CheckCurrentUserHasPermissionInAnyOrg(ctx, r.postgresDB, rbac.CodeInsightsNamespace, rbac.ReadAction); err != nil {
+			return nil, err
+		}
+	}
+
 	return &dashboardConnectionResolver{
 		baseInsightResolver: r.baseInsightResolver,
 		orgStore:            r.postgresDB.Orgs(),
@@ -99,6 +110,74 @@ func (r *Resolver) InsightsDashboards(ctx context.Context, args *graphqlbackend.
 	}, nil
 }
 
+// 🚨 SECURITY: checkInsightsWriteAccess returns an error if the current user is not granted
+// the permission to create or modify insights and dashboards.
+func checkInsightsWriteAccess(ctx context.Context, db database.DB) error {
+	return rbac.CheckCurrentUserHasPermission(ctx, db, rbac.CodeInsightsNamespace, rbac.WriteAction)
+}
+
+// 🚨 SECURITY: checkInsightsReadAccess returns an error if the current user is signed in and
+// not granted the permission to view insights. Anonymous users are restricted by the grants of
+// insights and dashboards instead.
+func checkInsightsReadAccess(ctx context.Context, db database.DB) error {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return nil
+	}
+	return rbac.CheckCurrentUserHasPermission(ctx, db, rbac.CodeInsightsNamespace, rbac.ReadAction)
+}
+
+// 🚨 SECURITY: checkDashboardWriteAccess returns an error if the current user is not granted
+// the permission to modify a dashboard with the given grants. Grants to an org require the
+// permission within that org, grants to users and global grants require it globally.
+func checkDashboardWriteAccess(ctx context.Context, db database.DB, grants []store.DashboardGrant) error {
+	for _, grant := range grants {
+		var orgID int32
+		if grant.OrgID != nil {
+			orgID = int32(*grant.OrgID)
+		}
+		if err := rbac.CheckCurrentUserHasOrgPermission(ctx, db, orgID, rbac.CodeInsightsNamespace, rbac.WriteAction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 🚨 SECURITY: filterReadableNamespaces drops the user and the orgs whose dashboards the
+// current user is not granted the permission to view, either globally or within the org.
+func filterReadableNamespaces(ctx context.Context, db database.DB, userIds []int, orgIds []int) ([]int, []int, error) {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return userIds, orgIds, nil
+	}
+
+	canRead := func(orgID int32) (bool, error) {
+		err := rbac.CheckCurrentUserHasOrgPermission(ctx, db, orgID, rbac.CodeInsightsNamespace, rbac.ReadAction)
+		if err == nil {
+			return true, nil
+		}
+		var notAuthorized *rbac.ErrNotAuthorized
+		if errors.As(err, &notAuthorized) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	ok, err := canRead(0)
+	if err != nil || ok {
+		return userIds, orgIds, err
+	}
+	readableOrgIds := make([]int, 0, len(orgIds))
+	for _, orgID := range orgIds {
+		ok, err := canRead(int32(orgID))
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			readableOrgIds = append(readableOrgIds, orgID)
+		}
+	}
+	return nil, readableOrgIds, nil
+}
+
 // 🚨 SECURITY
 // only add users / orgs if the user is non-anonymous. This will restrict anonymous users to only see
 // dashboards with a global grant.
@@ -207,13 +207,26 @@ type RecordingTime struct {
 type SearchAggregationMode string
 
 const (
-	REPO_AGGREGATION_MODE          SearchAggregationMode = "REPO"
-	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
-	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
-	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	REPO_AGGREGATION_MODE           SearchAggregationMode = "REPO"
+	PATH_AGGREGATION_MODE           SearchAggregationMode = "PATH"
+	AUTHOR_AGGREGATION_MODE         SearchAggregationMode = "AUTHOR"
+	CAPTURE_GROUP_AGGREGATION_MODE  SearchAggregationMode = "CAPTURE_GROUP"
+	LANGUAGE_AGGREGATION_MODE       SearchAggregationMode = "LANGUAGE"
+	FILE_EXTENSION_AGGREGATION_MODE SearchAggregationMode = "FILE_EXTENSION"
+	COMMIT_WEEK_AGGREGATION_MODE    SearchAggregationMode = "COMMIT_WEEK"
+	COMMIT_MONTH_AGGREGATION_MODE   SearchAggregationMode = "COMMIT_MONTH"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{
+	REPO_AGGREGATION_MODE,
+	PATH_AGGREGATION_MODE,
+	AUTHOR_AGGREGATION_MODE,
+	CAPTURE_GROUP_AGGREGATION_MODE,
+	LANGUAGE_AGGREGATION_MODE,
+	FILE_EXTENSION_AGGREGATION_MODE,
+	COMMIT_WEEK_AGGREGATION_MODE,
+	COMMIT_MONTH_AGGREGATION_MODE,
+}
 
 type AggregationNotAvailableReasonType string
 
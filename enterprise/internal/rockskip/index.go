@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/amit7itz/goset"
 	"github.com/inconshreveable/log15"
 	pg "github.com/lib/pq"
+	"github.com/sourcegraph/go-ctags"
 	"k8s.io/utils/lru"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
@@ -134,12 +136,12 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 			}
 		}
 
-		symbolsFromDeletedFiles := map[string]*goset.Set[string]{}
+		symbolsFromDeletedFiles := map[string]fileSymbols{}
 		{
 			// Fill from the cache.
 			for _, path := range deletedPaths {
 				if symbols, ok := pathSymbolsCache.Get(path); ok {
-					symbolsFromDeletedFiles[path] = symbols.(*goset.Set[string])
+					symbolsFromDeletedFiles[path] = symbols.(fileSymbols)
 				}
 			}
 
@@ -161,22 +163,19 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 			}
 		}
 
-		symbolsFromAddedFiles := map[string]*goset.Set[string]{}
+		symbolsFromAddedFiles := map[string]fileSymbols{}
 		{
 			tasklog.Start("ArchiveEach")
 			err = archiveEach(ctx, s.fetcher, repo, entry.Commit, addedPaths, func(path string, contents []byte) error {
 				defer tasklog.Continue("ArchiveEach")
 
 				tasklog.Start("parse")
-				symbols, err := parser.Parse(path, contents)
+				symbols, err := parseSymbols(parser, path, contents)
 				if err != nil {
 					return errors.Wrap(err, "parse")
 				}
 
-				symbolsFromAddedFiles[path] = goset.NewSet[string]()
-				for _, symbol := range symbols {
-					symbolsFromAddedFiles[path].Add(symbol.Name)
-				}
+				symbolsFromAddedFiles[path] = newFileSymbols(symbols)
 
 				// Cache the symbols we just parsed.
 				pathSymbolsCache.Add(path, symbolsFromAddedFiles[path])
@@ -191,53 +190,48 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 		}
 
 		// Compute the symmetric difference of symbols between the added and deleted paths.
-		deletedSymbols := map[string]*goset.Set[string]{}
-		addedSymbols := map[string]*goset.Set[string]{}
+		// Symbols that are in both but moved are deleted at their old position and added at
+		// their new one, so that earlier commits keep the old position.
+		deletedSymbols := map[string][]Symbol{}
+		addedSymbols := map[string][]Symbol{}
 		for _, pathStatus := range entry.PathStatuses {
 			deleted := symbolsFromDeletedFiles[pathStatus.Path]
-			if deleted == nil {
-				deleted = goset.NewSet[string]()
-			}
 			added := symbolsFromAddedFiles[pathStatus.Path]
-			if added == nil {
-				added = goset.NewSet[string]()
-			}
 			switch pathStatus.Status {
 			case gitdomain.DeletedAMD:
-				deletedSymbols[pathStatus.Path] = deleted
+				deletedSymbols[pathStatus.Path], _ = diffFileSymbols(deleted, nil)
 			case gitdomain.AddedAMD:
-				addedSymbols[pathStatus.Path] = added
+				_, addedSymbols[pathStatus.Path] = diffFileSymbols(nil, added)
 			case gitdomain.ModifiedAMD:
-				deletedSymbols[pathStatus.Path] = deleted.Difference(added)
-				addedSymbols[pathStatus.Path] = added.Difference(deleted)
+				deletedSymbols[pathStatus.Path], addedSymbols[pathStatus.Path] = diffFileSymbols(deleted, added)
+			}
+		}
+
+		getSymbolId := func(path string, symbol Symbol) (int, bool, error) {
+			if id, ok := symbolCache.Get(pathSymbol{path: path, symbol: symbol.key()}); ok {
+				return id.(int), true, nil
 			}
+			tasklog.Start("GetSymbol")
+			return GetSymbol(ctx, tx, repoId, path, symbol.key(), hops)
 		}
 
 		for path, symbols := range deletedSymbols {
-			for _, symbol := range symbols.Items() {
-				id := 0
-				id_, ok := symbolCache.Get(pathSymbol{path: path, symbol: symbol})
-				if ok {
-					id = id_.(int)
-				} else {
-					tasklog.Start("GetSymbol")
-					found := false
-					id, found, err = GetSymbol(ctx, tx, repoId, path, symbol, hops)
-					if err != nil {
-						return errors.Wrap(err, "GetSymbol")
-					}
-					if !found {
-						// We did not find the symbol that (supposedly) has been deleted, so ignore the
-						// deletion. This will probably lead to extra symbols in search results.
-						//
-						// The last time this happened, it was caused by impurity in ctags where the
-						// result of parsing a file was affected by previously parsed files and not fully
-						// determined by the file itself:
-						//
-						// https://github.com/universal-ctags/ctags/pull/3300
-						log15.Error("Could not find symbol that was supposedly deleted", "repo", repo, "commit", commit, "path", path, "symbol", symbol)
-						continue
-					}
+			for _, symbol := range symbols {
+				id, found, err := getSymbolId(path, symbol)
+				if err != nil {
+					return errors.Wrap(err, "GetSymbol")
+				}
+				if !found {
+					// We did not find the symbol that (supposedly) has been deleted, so ignore the
+					// deletion. This will probably lead to extra symbols in search results.
+					//
+					// The last time this happened, it was caused by impurity in ctags where the
+					// result of parsing a file was affected by previously parsed files and not fully
+					// determined by the file itself:
+					//
+					// https://github.com/universal-ctags/ctags/pull/3300
+					log15.Error("Could not find symbol that was supposedly deleted", "repo", repo, "commit", commit, "path", path, "symbol", symbol.Name)
+					continue
 				}
 
 				tasklog.Start("UpdateSymbolHops")
@@ -280,11 +274,11 @@ func (s *Service) Index(ctx context.Context, repo, givenCommit string) (err erro
 	return nil
 }
 
-func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoId, commit int, symbolCache *lru.Cache, symbols map[string]*goset.Set[string]) error {
+func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoId, commit int, symbolCache *lru.Cache, symbols map[string][]Symbol) error {
 	callback := func(inserter *batch.Inserter) error {
 		for path, pathSymbols := range symbols {
-			for _, symbol := range pathSymbols.Items() {
-				if err := inserter.Insert(ctx, pg.Array([]int{commit}), pg.Array([]int{}), repoId, path, symbol); err != nil {
+			for _, symbol := range pathSymbols {
+				if err := inserter.Insert(ctx, pg.Array([]int{commit}), pg.Array([]int{}), repoId, path, symbol.Name, symbol.Kind, symbol.Line, symbol.Character, symbol.Parent, symbol.ParentKind); err != nil {
 					return err
 				}
 			}
@@ -295,9 +289,9 @@ func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoI
 
 	returningScanner := func(rows dbutil.Scanner) error {
 		var path string
-		var symbol string
+		var symbol symbolKey
 		var id int
-		if err := rows.Scan(&path, &symbol, &id); err != nil {
+		if err := rows.Scan(&path, &symbol.Name, &symbol.Kind, &symbol.Parent, &symbol.ParentKind, &id); err != nil {
 			return err
 		}
 		symbolCache.Add(pathSymbol{path: path, symbol: symbol}, id)
@@ -309,9 +303,9 @@ func BatchInsertSymbols(ctx context.Context, tasklog *TaskLog, tx *sql.Tx, repoI
 		tx,
 		"rockskip_symbols",
 		batch.MaxNumPostgresParameters,
-		[]string{"added", "deleted", "repo_id", "path", "name"},
+		[]string{"added", "deleted", "repo_id", "path", "name", "kind", "line", "character", "parent", "parent_kind"},
 		"",
-		[]string{"path", "name", "id"},
+		[]string{"path", "name", "kind", "parent", "parent_kind", "id"},
 		returningScanner,
 		callback,
 	)
@@ -329,5 +323,43 @@ type indexRequest struct {
 
 type pathSymbol struct {
 	path   string
-	symbol string
+	symbol symbolKey
+}
+
+// parseSymbols parses the symbols in the given file, computing their
+// positions the same way as the SQLite-backed symbols service.
+func parseSymbols(parser ctags.Parser, path string, contents []byte) ([]Symbol, error) {
+	entries, err := parser.Parse(path, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(contents), "\n")
+
+	symbols := make([]Symbol, 0, len(entries))
+	for _, entry := range entries {
+		// ⚠️ Careful, ctags lines are 1-indexed!
+		line := entry.Line - 1
+		if line < 0 || line >= len(lines) {
+			log15.Warn("ctags returned an invalid line number", "path", path, "line", entry.Line, "len(lines)", len(lines), "symbol", entry.Name)
+			continue
+		}
+
+		character := strings.Index(lines[line], entry.Name)
+		if character == -1 {
+			// Could not find the symbol in the line. ctags doesn't always return the right line.
+			character = 0
+		}
+
+		symbols = append(symbols, Symbol{
+			Name:       entry.Name,
+			Kind:       entry.Kind,
+			Line:       line,
+			Character:  character,
+			Parent:     entry.Parent,
+			ParentKind: entry.ParentKind,
+		})
+	}
+
+	return symbols, nil
 }
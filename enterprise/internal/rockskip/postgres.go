@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"fmt"
 
-	"github.com/amit7itz/goset"
 	pg "github.com/lib/pq"
 	"github.com/segmentio/fasthash/fnv1"
 
@@ -60,7 +59,7 @@ func InsertCommit(ctx context.Context, db dbutil.DB, repoId int, commitHash stri
 	return id, errors.Wrap(err, "InsertCommit")
 }
 
-func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, name string, hops []CommitId) (id int, found bool, err error) {
+func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, symbol symbolKey, hops []CommitId) (id int, found bool, err error) {
 	err = db.QueryRowContext(ctx, `
 		SELECT id
 		FROM rockskip_symbols
@@ -68,9 +67,12 @@ func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, name
 			repo_id = $1 AND
 			path = $2 AND
 			name = $3 AND
-		    $4 && added AND
-			NOT $4 && deleted
-	`, repoId, path, name, pg.Array(hops)).Scan(&id)
+			kind = $4 AND
+			parent = $5 AND
+			parent_kind = $6 AND
+		    $7 && added AND
+			NOT $7 && deleted
+	`, repoId, path, symbol.Name, symbol.Kind, symbol.Parent, symbol.ParentKind, pg.Array(hops)).Scan(&id)
 	if err == sql.ErrNoRows {
 		return 0, false, nil
 	} else if err != nil {
@@ -79,12 +81,12 @@ func GetSymbol(ctx context.Context, db dbutil.DB, repoId int, path string, name
 	return id, true, nil
 }
 
-func GetSymbolsInFiles(ctx context.Context, db dbutil.DB, repoId int, paths []string, hops []CommitId) (map[string]*goset.Set[string], error) {
-	pathToSymbols := map[string]*goset.Set[string]{}
+func GetSymbolsInFiles(ctx context.Context, db dbutil.DB, repoId int, paths []string, hops []CommitId) (map[string]fileSymbols, error) {
+	pathToSymbols := map[string]fileSymbols{}
 
 	for _, chunk := range chunksOf(paths, 1000) {
 		rows, err := db.QueryContext(ctx, `
-			SELECT name, path
+			SELECT name, kind, line, character, parent, parent_kind, path
 			FROM rockskip_symbols
 			WHERE
 				repo_id = $1 AND
@@ -96,15 +98,15 @@ func GetSymbolsInFiles(ctx context.Context, db dbutil.DB, repoId int, paths []st
 			return nil, errors.Newf("GetSymbolsInFiles: %s", err)
 		}
 		for rows.Next() {
-			var name string
+			var symbol Symbol
 			var path string
-			if err := rows.Scan(&name, &path); err != nil {
+			if err := rows.Scan(&symbol.Name, &symbol.Kind, &symbol.Line, &symbol.Character, &symbol.Parent, &symbol.ParentKind, &path); err != nil {
 				return nil, errors.Newf("GetSymbolsInFiles: %s", err)
 			}
 			if pathToSymbols[path] == nil {
-				pathToSymbols[path] = goset.NewSet[string]()
+				pathToSymbols[path] = fileSymbols{}
 			}
-			pathToSymbols[path].Add(name)
+			pathToSymbols[path][symbol.key()] = symbol
 		}
 		err = rows.Close()
 		if err != nil {
@@ -125,12 +127,12 @@ func UpdateSymbolHops(ctx context.Context, db dbutil.DB, id int, status StatusAD
 	return errors.Wrap(err, "UpdateSymbolHops")
 }
 
-func InsertSymbol(ctx context.Context, db dbutil.DB, hop CommitId, repoId int, path string, name string) (id int, err error) {
+func InsertSymbol(ctx context.Context, db dbutil.DB, hop CommitId, repoId int, path string, symbol Symbol) (id int, err error) {
 	err = db.QueryRowContext(ctx, `
-		INSERT INTO rockskip_symbols (added, deleted, repo_id, path, name)
-		                      VALUES ($1   , $2     , $3     , $4  , $5  )
+		INSERT INTO rockskip_symbols (added, deleted, repo_id, path, name, kind, line, character, parent, parent_kind)
+		                      VALUES ($1   , $2     , $3     , $4  , $5  , $6  , $7  , $8       , $9    , $10        )
 		RETURNING id
-	`, pg.Array([]int{hop}), pg.Array([]int{}), repoId, path, name).Scan(&id)
+	`, pg.Array([]int{hop}), pg.Array([]int{}), repoId, path, symbol.Name, symbol.Kind, symbol.Line, symbol.Character, symbol.Parent, symbol.ParentKind).Scan(&id)
 	return id, errors.Wrap(err, "InsertSymbol")
 }
 
@@ -277,7 +279,7 @@ func PrintInternals(ctx context.Context, db dbutil.DB) error {
 	fmt.Println()
 
 	rows, err = db.QueryContext(ctx, `
-		SELECT id, path, name, added, deleted
+		SELECT id, path, name, kind, line, added, deleted
 		FROM rockskip_symbols
 		ORDER BY id ASC
 	`)
@@ -289,12 +291,14 @@ func PrintInternals(ctx context.Context, db dbutil.DB) error {
 		var id int
 		var path string
 		var name string
+		var kind string
+		var line int
 		var added, deleted []int64
-		err = rows.Scan(&id, &path, &name, pg.Array(&added), pg.Array(&deleted))
+		err = rows.Scan(&id, &path, &name, &kind, &line, pg.Array(&added), pg.Array(&deleted))
 		if err != nil {
 			return errors.Wrap(err, "PrintInternals: Scan")
 		}
-		fmt.Printf("  id %d path %-10s symbol %s\n", id, path, name)
+		fmt.Printf("  id %d path %-10s symbol %s kind %s line %d\n", id, path, name, kind, line)
 		for _, a := range added {
 			hash, _, _, _, err := GetCommitById(ctx, db, int(a))
 			if err != nil {
@@ -8,8 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/amit7itz/goset"
-	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
 	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
@@ -139,34 +137,6 @@ func (s *Service) Search(ctx context.Context, args search.SymbolsParameters) (_
 	return symbols, nil
 }
 
-func mkIsMatch(args search.SymbolsParameters) (func(string) bool, error) {
-	if !args.IsRegExp {
-		if args.IsCaseSensitive {
-			return func(symbol string) bool { return strings.Contains(symbol, args.Query) }, nil
-		} else {
-			return func(symbol string) bool {
-				return strings.Contains(strings.ToLower(symbol), strings.ToLower(args.Query))
-			}, nil
-		}
-	}
-
-	expr := args.Query
-	if !args.IsCaseSensitive {
-		expr = "(?i)" + expr
-	}
-
-	regex, err := regexp.Compile(expr)
-	if err != nil {
-		return nil, err
-	}
-
-	if args.IsCaseSensitive {
-		return func(symbol string) bool { return regex.MatchString(symbol) }, nil
-	} else {
-		return func(symbol string) bool { return regex.MatchString(strings.ToLower(symbol)) }, nil
-	}
-}
-
 func (s *Service) emitIndexRequest(rc repoCommit) (chan struct{}, error) {
 	key := fmt.Sprintf("%s@%s", rc.repo, rc.commit)
 
@@ -225,7 +195,7 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 
 	threadStatus.Tasklog.Start("run query")
 	q := sqlf.Sprintf(`
-		SELECT path
+		SELECT path, name, kind, line, character, parent, parent_kind
 		FROM rockskip_symbols
 		WHERE
 			%s && singleton_integer(repo_id)
@@ -249,24 +219,56 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 	}
 	defer rows.Close()
 
-	isMatch, err := mkIsMatch(args)
+	symbols := []result.Symbol{}
+	for rows.Next() {
+		var symbol result.Symbol
+		err = rows.Scan(&symbol.Path, &symbol.Name, &symbol.Kind, &symbol.Line, &symbol.Character, &symbol.Parent, &symbol.ParentKind)
+		if err != nil {
+			return nil, errors.Wrap(err, "Search: Scan")
+		}
+		symbols = append(symbols, symbol)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "Search: rows")
+	}
+
+	symbols, err = s.fillLegacySymbols(ctx, args, symbols, limit, threadStatus)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(err, "fillLegacySymbols")
 	}
 
-	paths := goset.NewSet[string]()
-	for rows.Next() {
-		var path string
-		err = rows.Scan(&path)
+	if s.logQueries {
+		err = logQuery(ctx, db, args, q, duration, len(symbols))
 		if err != nil {
-			return nil, errors.Wrap(err, "Search: Scan")
+			return nil, errors.Wrap(err, "logQuery")
 		}
-		paths.Add(path)
 	}
 
-	stopErr := errors.New("stop iterating")
+	return symbols, nil
+}
 
-	symbols := []result.Symbol{}
+// fillLegacySymbols replaces the symbols that were indexed before Rockskip
+// stored the kind, position and scope of symbols by the symbols with the same
+// name parsed from their files. Legacy symbols are replaced in the index as the
+// files that contain them change.
+func (s *Service) fillLegacySymbols(ctx context.Context, args search.SymbolsParameters, symbols []result.Symbol, limit int, threadStatus *ThreadStatus) ([]result.Symbol, error) {
+	legacyNames := map[string]map[string]struct{}{}
+	filled := symbols[:0]
+	for _, symbol := range symbols {
+		// ctags reports a kind for every symbol, so only legacy symbols have
+		// an empty kind.
+		if symbol.Kind != "" {
+			filled = append(filled, symbol)
+			continue
+		}
+		if legacyNames[symbol.Path] == nil {
+			legacyNames[symbol.Path] = map[string]struct{}{}
+		}
+		legacyNames[symbol.Path][symbol.Name] = struct{}{}
+	}
+	if len(legacyNames) == 0 {
+		return symbols, nil
+	}
 
 	parser, err := s.createParser()
 	if err != nil {
@@ -274,61 +276,41 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 	}
 	defer parser.Close()
 
+	paths := make([]string, 0, len(legacyNames))
+	for path := range legacyNames {
+		paths = append(paths, path)
+	}
+
 	threadStatus.Tasklog.Start("ArchiveEach")
-	err = archiveEach(ctx, s.fetcher, string(args.Repo), string(args.CommitID), paths.Items(), func(path string, contents []byte) error {
+	err = archiveEach(ctx, s.fetcher, string(args.Repo), string(args.CommitID), paths, func(path string, contents []byte) error {
 		defer threadStatus.Tasklog.Continue("ArchiveEach")
 
 		threadStatus.Tasklog.Start("parse")
-		allSymbols, err := parser.Parse(path, contents)
+		parsed, err := parseSymbols(parser, path, contents)
 		if err != nil {
 			return err
 		}
-
-		lines := strings.Split(string(contents), "\n")
-
-		for _, symbol := range allSymbols {
-			if isMatch(symbol.Name) {
-				if symbol.Line < 1 || symbol.Line > len(lines) {
-					log15.Warn("ctags returned an invalid line number", "path", path, "line", symbol.Line, "len(lines)", len(lines), "symbol", symbol.Name)
-					continue
-				}
-
-				character := strings.Index(lines[symbol.Line-1], symbol.Name)
-				if character == -1 {
-					// Could not find the symbol in the line. ctags doesn't always return the right line.
-					character = 0
-				}
-
-				symbols = append(symbols, result.Symbol{
-					Name:      symbol.Name,
-					Path:      path,
-					Line:      symbol.Line - 1,
-					Character: character,
-					Kind:      symbol.Kind,
-					Parent:    symbol.Parent,
-				})
-
-				if len(symbols) >= limit {
-					return stopErr
-				}
+		for _, symbol := range parsed {
+			if _, ok := legacyNames[path][symbol.Name]; !ok || len(filled) >= limit {
+				continue
 			}
+			filled = append(filled, result.Symbol{
+				Name:       symbol.Name,
+				Path:       path,
+				Line:       symbol.Line,
+				Character:  symbol.Character,
+				Kind:       symbol.Kind,
+				Parent:     symbol.Parent,
+				ParentKind: symbol.ParentKind,
+			})
 		}
-
 		return nil
 	})
-
-	if err != nil && err != stopErr {
+	if err != nil {
 		return nil, err
 	}
 
-	if s.logQueries {
-		err = logQuery(ctx, db, args, q, duration, len(symbols))
-		if err != nil {
-			return nil, errors.Wrap(err, "logQuery")
-		}
-	}
-
-	return symbols, nil
+	return filled, nil
 }
 
 func logQuery(ctx context.Context, db database.DB, args search.SymbolsParameters, q *sqlf.Query, duration time.Duration, symbols int) error {
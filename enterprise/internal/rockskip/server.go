@@ -15,11 +15,63 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
+// Symbol is a symbol as stored in the rockskip_symbols table. A symbol is
+// identified within its file by its key, its position is an attribute that is
+// updated when the symbol moves.
 type Symbol struct {
-	Name   string `json:"name"`
-	Parent string `json:"parent"`
-	Kind   string `json:"kind"`
-	Line   int    `json:"line"`
+	Name       string `json:"name"`
+	Parent     string `json:"parent"`
+	ParentKind string `json:"parentKind"`
+	Kind       string `json:"kind"`
+	Line       int    `json:"line"`      // 0-based
+	Character  int    `json:"character"` // 0-based
+}
+
+// symbolKey identifies a symbol within a file. The position of a symbol is not
+// part of its identity, so that edits that only move symbols around don't
+// replace them.
+type symbolKey struct {
+	Name       string
+	Kind       string
+	Parent     string
+	ParentKind string
+}
+
+func (s Symbol) key() symbolKey {
+	return symbolKey{Name: s.Name, Kind: s.Kind, Parent: s.Parent, ParentKind: s.ParentKind}
+}
+
+// fileSymbols are the symbols in a file by their key. Symbols with the same
+// key in a file are only stored once.
+type fileSymbols map[symbolKey]Symbol
+
+func newFileSymbols(symbols []Symbol) fileSymbols {
+	fs := make(fileSymbols, len(symbols))
+	for _, symbol := range symbols {
+		if _, ok := fs[symbol.key()]; !ok {
+			fs[symbol.key()] = symbol
+		}
+	}
+	return fs
+}
+
+// diffFileSymbols compares the symbols of a file before and after it was
+// modified. It returns the symbols that were deleted and the ones that were
+// added. A symbol that moved to another position is both deleted at its old
+// position and added at its new one, so that earlier commits keep the old
+// position.
+func diffFileSymbols(before, after fileSymbols) (deleted, added []Symbol) {
+	for key, symbol := range before {
+		if new, ok := after[key]; !ok || new.Line != symbol.Line || new.Character != symbol.Character {
+			deleted = append(deleted, symbol)
+		}
+	}
+	for key, symbol := range after {
+		if old, ok := before[key]; !ok || old.Line != symbol.Line || old.Character != symbol.Character {
+			added = append(added, symbol)
+		}
+	}
+	return deleted, added
 }
 
 const NULL CommitId = 0
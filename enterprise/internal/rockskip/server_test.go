@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/sourcegraph/go-ctags"
 	"github.com/sourcegraph/log/logtest"
 
@@ -26,7 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-// mockParser converts each line to a symbol.
+// mockParser converts each line to a function symbol, scoped to the file.
 type mockParser struct{}
 
 func (mockParser) Parse(path string, bytes []byte) ([]*ctags.Entry, error) {
@@ -37,7 +38,7 @@ func (mockParser) Parse(path string, bytes []byte) ([]*ctags.Entry, error) {
 			continue
 		}
 
-		symbols = append(symbols, &ctags.Entry{Name: line, Line: lineNumber + 1})
+		symbols = append(symbols, &ctags.Entry{Name: line, Line: lineNumber + 1, Kind: "function", Parent: path, ParentKind: "file"})
 	}
 
 	return symbols, nil
@@ -94,7 +95,7 @@ func TestIndex(t *testing.T) {
 		fatalIfError(err, "simpleParse")
 		state[filename] = []string{}
 		for _, symbol := range symbols {
-			state[filename] = append(state[filename], symbol.Name)
+			state[filename] = append(state[filename], fmt.Sprintf("%s %s %s.%s L%d:0", symbol.Name, symbol.Kind, symbol.ParentKind, symbol.Parent, symbol.Line-1))
 		}
 	}
 
@@ -117,9 +118,8 @@ func TestIndex(t *testing.T) {
 	service, err := NewService(db, git, newMockRepositoryFetcher(git), createParser, 1, 1, false, 1, 1, 1, false)
 	fatalIfError(err, "NewService")
 
-	verifyBlobs := func() {
+	verifyBlobs := func(commit string, want map[string][]string) {
 		repo := "somerepo"
-		commit := getHead()
 		args := search.SymbolsParameters{Repo: api.RepoName(repo), CommitID: api.CommitID(commit), Query: ""}
 		symbols, err := service.Search(context.Background(), args)
 		fatalIfError(err, "Search")
@@ -134,7 +134,7 @@ func TestIndex(t *testing.T) {
 			gotPaths = append(gotPaths, path)
 		}
 		wantPaths := []string{}
-		for path := range state {
+		for path := range want {
 			wantPaths = append(wantPaths, path)
 		}
 		sort.Strings(gotPaths)
@@ -149,12 +149,12 @@ func TestIndex(t *testing.T) {
 
 		gotPathToSymbols := map[string][]string{}
 		for _, blob := range symbols {
-			gotPathToSymbols[blob.Path] = append(gotPathToSymbols[blob.Path], blob.Name)
+			gotPathToSymbols[blob.Path] = append(gotPathToSymbols[blob.Path], fmt.Sprintf("%s %s %s.%s L%d:%d", blob.Name, blob.Kind, blob.ParentKind, blob.Parent, blob.Line, blob.Character))
 		}
 
 		// Make sure the symbols match.
 		for path, gotSymbols := range gotPathToSymbols {
-			wantSymbols := state[path]
+			wantSymbols := want[path]
 			sort.Strings(gotSymbols)
 			sort.Strings(wantSymbols)
 			if diff := cmp.Diff(gotSymbols, wantSymbols); diff != "" {
@@ -167,9 +167,22 @@ func TestIndex(t *testing.T) {
 		}
 	}
 
+	type snapshot struct {
+		commit string
+		state  map[string][]string
+	}
+	var history []snapshot
+
 	commit := func(message string) {
 		gitRun("commit", "--allow-empty", "-m", message)
-		verifyBlobs()
+		head := getHead()
+		verifyBlobs(head, state)
+
+		snap := map[string][]string{}
+		for path, symbols := range state {
+			snap[path] = append([]string{}, symbols...)
+		}
+		history = append(history, snapshot{commit: head, state: snap})
 	}
 
 	add("a.txt", "sym1\n")
@@ -184,12 +197,87 @@ func TestIndex(t *testing.T) {
 	add("a.txt", "sym1\nsym2")
 	commit("add a symbol to a.txt")
 
+	add("c.txt", "sym0\nsym1\nsym2")
+	commit("move the symbols of c.txt to other lines")
+
 	commit("empty")
 
 	rm("a.txt")
 	commit("rm a.txt")
+
+	// Indexing later commits must not change the symbols of earlier ones, such
+	// as the positions of the symbols of c.txt before they moved.
+	for _, snap := range history {
+		verifyBlobs(snap.commit, snap.state)
+	}
 }
 
+func TestParseSymbols(t *testing.T) {
+	contents := []byte("package main\n\nfunc  main() {}\n")
+	parser := staticParser{
+		{Name: "main", Line: 3, Kind: "function", Parent: "main", ParentKind: "package"},
+		// Invalid line numbers are skipped.
+		{Name: "bogus", Line: 10, Kind: "function"},
+		// Symbols missing from their line start at the first character.
+		{Name: "missing", Line: 1, Kind: "variable"},
+	}
+
+	symbols, err := parseSymbols(parser, "main.go", contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Symbol{
+		{Name: "main", Kind: "function", Line: 2, Character: 6, Parent: "main", ParentKind: "package"},
+		{Name: "missing", Kind: "variable", Line: 0, Character: 0},
+	}
+	if diff := cmp.Diff(want, symbols); diff != "" {
+		t.Fatalf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffFileSymbols(t *testing.T) {
+	before := newFileSymbols([]Symbol{
+		{Name: "kept", Kind: "function", Line: 1},
+		{Name: "moved", Kind: "function", Line: 2, Character: 4},
+		{Name: "removed", Kind: "function", Line: 3},
+		{Name: "rescoped", Kind: "method", Parent: "A", ParentKind: "class", Line: 4},
+	})
+	after := newFileSymbols([]Symbol{
+		{Name: "kept", Kind: "function", Line: 1},
+		{Name: "moved", Kind: "function", Line: 5, Character: 4},
+		{Name: "rescoped", Kind: "method", Parent: "B", ParentKind: "class", Line: 4},
+		{Name: "new", Kind: "variable", Line: 6},
+		// Only the first symbol with the same key is kept.
+		{Name: "new", Kind: "variable", Line: 7},
+	})
+
+	deleted, added := diffFileSymbols(before, after)
+
+	byName := func(a, b Symbol) bool { return a.Name+a.Parent < b.Name+b.Parent }
+	if diff := cmp.Diff([]Symbol{
+		{Name: "moved", Kind: "function", Line: 2, Character: 4},
+		{Name: "removed", Kind: "function", Line: 3},
+		{Name: "rescoped", Kind: "method", Parent: "A", ParentKind: "class", Line: 4},
+	}, deleted, cmpopts.SortSlices(byName)); diff != "" {
+		t.Errorf("unexpected deleted symbols (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]Symbol{
+		{Name: "moved", Kind: "function", Line: 5, Character: 4},
+		{Name: "new", Kind: "variable", Line: 6},
+		{Name: "rescoped", Kind: "method", Parent: "B", ParentKind: "class", Line: 4},
+	}, added, cmpopts.SortSlices(byName)); diff != "" {
+		t.Errorf("unexpected added symbols (-want +got):\n%s", diff)
+	}
+}
+
+// staticParser returns the same entries for every file.
+type staticParser []*ctags.Entry
+
+func (p staticParser) Parse(string, []byte) ([]*ctags.Entry, error) { return p, nil }
+
+func (staticParser) Close() {}
+
 type SubprocessGit struct {
 	gitDir        string
 	catFileCmd    *exec.Cmd
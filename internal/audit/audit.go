@@ -2,7 +2,9 @@ package audit
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sourcegraph/log"
@@ -16,6 +18,7 @@ import (
 // Log creates an INFO log statement that will be a part of the audit log.
 // The audit log records comply with the following design: an actor takes an action on an entity within a context.
 // Refer to Record struct to see details about individual components.
+// The record is also handed to the Sink registered with SetSink, if any.
 func Log(ctx context.Context, logger log.Logger, record Record) {
 	act := actor.FromContext(ctx)
 
@@ -41,6 +44,22 @@ func Log(ctx context.Context, logger log.Logger, record Record) {
 	loggerFunc := getLoggerFuncWithSeverity(logger, siteConfig)
 	// message string looks like: #{record.Action} (sampling immunity token: #{auditId})
 	loggerFunc(fmt.Sprintf("%s (sampling immunity token: %s)", record.Action, auditId), fields...)
+
+	recordFields, err := marshalFields(record.Fields)
+	if err != nil {
+		logger.Warn("failed to marshal audit log fields", log.String("auditId", auditId), log.Error(err))
+		recordFields = json.RawMessage("{}")
+	}
+	writeToSink(&Entry{
+		AuditID:      auditId,
+		Entity:       record.Entity,
+		Action:       record.Action,
+		ActorUID:     actorId(act),
+		IP:           ip(client),
+		ForwardedFor: forwardedFor(client),
+		Fields:       recordFields,
+		Timestamp:    time.Now(),
+	})
 }
 
 func actorId(act *actor.Actor) string {
@@ -101,6 +120,21 @@ func IsEnabled(cfg schema.SiteConfiguration, setting AuditLogSetting) bool {
 	return false
 }
 
+// DefaultRetention is how long audit log records are kept in the database
+// unless configured otherwise.
+const DefaultRetention = 90 * 24 * time.Hour
+
+// Retention returns how long audit log records are kept in the database. It
+// falls back to DefaultRetention if the configured value is missing or invalid.
+func Retention(cfg schema.SiteConfiguration) time.Duration {
+	if auditCfg := getAuditCfg(cfg); auditCfg != nil && auditCfg.Retention != "" {
+		if retention, err := time.ParseDuration(auditCfg.Retention); err == nil && retention > 0 {
+			return retention
+		}
+	}
+	return DefaultRetention
+}
+
 // getLoggerFuncWithSeverity returns a specific logger function (logger.Info, logger.Warn, etc.), a the severity is configurable.
 func getLoggerFuncWithSeverity(logger log.Logger, cfg schema.SiteConfiguration) func(string, ...log.Field) {
 	if auditCfg := getAuditCfg(cfg); auditCfg != nil {
@@ -3,6 +3,7 @@ package audit
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/log/logtest"
@@ -197,6 +198,23 @@ func TestIsEnabled(t *testing.T) {
 	}
 }
 
+func TestRetention(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg  schema.SiteConfiguration
+		want time.Duration
+	}{
+		"no config":       {cfg: schema.SiteConfiguration{}, want: DefaultRetention},
+		"empty retention": {cfg: schema.SiteConfiguration{Log: &schema.Log{AuditLog: &schema.AuditLog{}}}, want: DefaultRetention},
+		"invalid":         {cfg: schema.SiteConfiguration{Log: &schema.Log{AuditLog: &schema.AuditLog{Retention: "forever"}}}, want: DefaultRetention},
+		"negative":        {cfg: schema.SiteConfiguration{Log: &schema.Log{AuditLog: &schema.AuditLog{Retention: "-1h"}}}, want: DefaultRetention},
+		"valid":           {cfg: schema.SiteConfiguration{Log: &schema.Log{AuditLog: &schema.AuditLog{Retention: "720h"}}}, want: 720 * time.Hour},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Retention(tc.cfg))
+		})
+	}
+}
+
 func TestSwitchingSeverityLevel(t *testing.T) {
 	useAuditLogLevel("INFO")
 	defer conf.Mock(nil)
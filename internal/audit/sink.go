@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry is an audit log record as it is handed to a Sink.
+type Entry struct {
+	// AuditID is the sampling immunity token of the record.
+	AuditID  string
+	Entity   string
+	Action   string
+	ActorUID string
+	IP       string
+	// ForwardedFor is the value of the X-Forwarded-For header of the request.
+	ForwardedFor string
+	// Fields holds the additional context of the record as a JSON object.
+	Fields    json.RawMessage
+	Timestamp time.Time
+}
+
+// Sink receives every record that is written to the audit log, in addition to
+// the log output.
+type Sink interface {
+	Write(entry *Entry)
+}
+
+var (
+	sinkMu sync.RWMutex
+	sink   Sink
+)
+
+// SetSink registers the Sink that receives all subsequent audit log records.
+// Passing nil unregisters the current Sink.
+func SetSink(s Sink) {
+	sinkMu.Lock()
+	defer sinkMu.Unlock()
+	sink = s
+}
+
+func writeToSink(entry *Entry) {
+	sinkMu.RLock()
+	defer sinkMu.RUnlock()
+	if sink != nil {
+		sink.Write(entry)
+	}
+}
+
+// marshalFields encodes the given log fields as a JSON object.
+func marshalFields(fields []log.Field) (json.RawMessage, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return json.Marshal(enc.Fields)
+}
+
+const (
+	bufferedSinkCapacity      = 1000
+	bufferedSinkBatchSize     = 100
+	bufferedSinkFlushInterval = time.Second
+)
+
+// BufferedSink is a Sink that writes entries in batches from a background
+// routine, so that audit.Log never waits on the underlying storage. Entries are
+// dropped when the buffer is full.
+type BufferedSink struct {
+	logger log.Logger
+	write  func(context.Context, ...*Entry) error
+
+	entries chan *Entry
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewBufferedSink returns a BufferedSink that persists entries with the given
+// write function. The returned sink must be started to write any entries.
+func NewBufferedSink(logger log.Logger, write func(context.Context, ...*Entry) error) *BufferedSink {
+	return &BufferedSink{
+		logger:  logger,
+		write:   write,
+		entries: make(chan *Entry, bufferedSinkCapacity),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (s *BufferedSink) Write(entry *Entry) {
+	select {
+	case s.entries <- entry:
+	default:
+		s.logger.Warn("audit log buffer is full, dropping entry", log.String("auditId", entry.AuditID))
+	}
+}
+
+// Start writes buffered entries until Stop is called.
+func (s *BufferedSink) Start() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(bufferedSinkFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Entry, 0, bufferedSinkBatchSize)
+	for {
+		select {
+		case entry := <-s.entries:
+			batch = append(batch, entry)
+			if len(batch) >= bufferedSinkBatchSize {
+				batch = s.flush(batch)
+			}
+		case <-ticker.C:
+			batch = s.flush(batch)
+		case <-s.stop:
+			for {
+				select {
+				case entry := <-s.entries:
+					batch = append(batch, entry)
+				default:
+					s.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stop writes the remaining buffered entries and waits for Start to return.
+func (s *BufferedSink) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *BufferedSink) flush(batch []*Entry) []*Entry {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := s.write(context.Background(), batch...); err != nil {
+		s.logger.Error("failed to write audit log entries", log.Int("count", len(batch)), log.Error(err))
+	}
+	return batch[:0]
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+type recordingSink struct {
+	entries []*Entry
+}
+
+func (s *recordingSink) Write(entry *Entry) {
+	s.entries = append(s.entries, entry)
+}
+
+func TestLogWritesToSink(t *testing.T) {
+	sink := &recordingSink{}
+	SetSink(sink)
+	t.Cleanup(func() { SetSink(nil) })
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	ctx = requestclient.WithClient(ctx, &requestclient.Client{IP: "192.168.0.1", ForwardedFor: "10.0.0.1"})
+
+	logger, exportLogs := logtest.Captured(t)
+	Log(ctx, logger, Record{
+		Entity: "test entity",
+		Action: "test audit action",
+		Fields: []log.Field{
+			log.String("additional", "stuff"),
+			log.Object("nested", log.Int("count", 3)),
+		},
+	})
+
+	require.Len(t, sink.entries, 1)
+	entry := sink.entries[0]
+
+	logs := exportLogs()
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0].Message, entry.AuditID)
+
+	assert.Equal(t, "test entity", entry.Entity)
+	assert.Equal(t, "test audit action", entry.Action)
+	assert.Equal(t, "1", entry.ActorUID)
+	assert.Equal(t, "192.168.0.1", entry.IP)
+	assert.Equal(t, "10.0.0.1", entry.ForwardedFor)
+	assert.JSONEq(t, `{"additional": "stuff", "nested": {"count": 3}}`, string(entry.Fields))
+	assert.False(t, entry.Timestamp.IsZero())
+}
+
+func TestBufferedSink(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		written []string
+	)
+	sink := NewBufferedSink(logtest.Scoped(t), func(_ context.Context, entries ...*Entry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, e := range entries {
+			written = append(written, e.AuditID)
+		}
+		return nil
+	})
+
+	go sink.Start()
+	sink.Write(&Entry{AuditID: "a"})
+	sink.Write(&Entry{AuditID: "b"})
+
+	// Stop flushes the entries that are still buffered.
+	sink.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"a", "b"}, written)
+}
@@ -3,6 +3,7 @@ package permssync
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -60,3 +61,16 @@ func TestSchedulePermsSync_RepoPermsTest(t *testing.T) {
 	assert.Equal(t, ReasonManualRepoSync, permsSyncStore.CreateRepoSyncJobFunc.History()[0].Arg2.Reason)
 	assert.Equal(t, int32(0), permsSyncStore.CreateRepoSyncJobFunc.History()[0].Arg2.TriggeredByUserID)
 }
+
+func TestMarshalCompletedSync(t *testing.T) {
+	ctx := context.Background()
+	completedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	have, err := MarshalCompletedSync(ctx, nil, CompletedSync{UserID: 1, CompletedAt: completedAt})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"user_id":"VXNlcjox","repository_id":null,"completed_at":"2023-01-02T03:04:05Z"}`, string(have))
+
+	have, err = MarshalCompletedSync(ctx, nil, CompletedSync{RepoID: 1, CompletedAt: completedAt})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"user_id":null,"repository_id":"UmVwb3NpdG9yeTox","completed_at":"2023-01-02T03:04:05Z"}`, string(have))
+}
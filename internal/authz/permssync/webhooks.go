@@ -0,0 +1,59 @@
+package permssync
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+)
+
+const PermissionsSyncCompleted = "permissions:sync_completed"
+
+func init() {
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         PermissionsSyncCompleted,
+		Description: "sent when the permissions of a user or repository have been synced from the code host",
+	})
+}
+
+// CompletedSync describes a successful permissions sync of either a user or a
+// repository.
+type CompletedSync struct {
+	UserID      int32
+	RepoID      api.RepoID
+	CompletedAt time.Time
+}
+
+// completedSync represents a completed permissions sync in a webhook payload.
+type completedSync struct {
+	UserID       *graphql.ID `json:"user_id"`
+	RepositoryID *graphql.ID `json:"repository_id"`
+	CompletedAt  time.Time   `json:"completed_at"`
+}
+
+func MarshalCompletedSync(_ context.Context, _ basestore.ShareableStore, sync CompletedSync) ([]byte, error) {
+	payload := completedSync{CompletedAt: sync.CompletedAt}
+	if sync.UserID != 0 {
+		id := relay.MarshalID("User", sync.UserID)
+		payload.UserID = &id
+	}
+	if sync.RepoID != 0 {
+		id := relay.MarshalID("Repository", sync.RepoID)
+		payload.RepositoryID = &id
+	}
+
+	return json.Marshal(&payload)
+}
+
+// EnqueueCompletedSync enqueues an outbound webhook for the given completed
+// permissions sync.
+func EnqueueCompletedSync(ctx context.Context, logger log.Logger, db basestore.ShareableStore, sync CompletedSync) {
+	outbound.Enqueue(ctx, logger, db, PermissionsSyncCompleted, MarshalCompletedSync, sync)
+}
@@ -228,6 +228,7 @@ type CodeIntelligenceRangeConnectionResolver interface {
 type LocationConnectionResolver interface {
 	Nodes(ctx context.Context) ([]LocationResolver, error)
 	PageInfo(ctx context.Context) (PageInfo, error)
+	Imprecise() bool
 }
 
 type LSIFDiagnosticsArgs struct {
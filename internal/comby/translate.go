@@ -190,3 +190,22 @@ func StructuralPatToRegexpQuery(pattern string, shortcircuit bool) string {
 	}
 	return "(?:" + strings.Join(pieces, ")(?:.|\\s)*?(?:") + ")"
 }
+
+// StructuralPatToLiterals returns the literal fragments of a comby pattern
+// that every match must contain verbatim. Literals are split on whitespace,
+// since comby matches whitespace in the pattern against any amount of
+// whitespace in a document. Holes, including regular expression holes,
+// contribute no fragments. The result is suitable for prefiltering
+// candidate files with a substring index before running comby.
+//
+// Example:
+// "ParseInt(:[args]) if err != nil" -> ["ParseInt(", ")", "if", "err", "!=", "nil"]
+func StructuralPatToLiterals(pattern string) []string {
+	var literals []string
+	for _, term := range parseTemplate([]byte(pattern)) {
+		if v, ok := term.(Literal); ok {
+			literals = append(literals, strings.Fields(v.String())...)
+		}
+	}
+	return literals
+}
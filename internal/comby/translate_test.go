@@ -119,3 +119,51 @@ func TestStructuralPatToRegexpQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuralPatToLiterals(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Pattern string
+		Want    []string
+	}{
+		{
+			Name:    "Just a hole",
+			Pattern: ":[1]",
+			Want:    nil,
+		},
+		{
+			Name:    "Substring between holes",
+			Pattern: ":[1] substring :[2]",
+			Want:    []string{"substring"},
+		},
+		{
+			Name:    "Whitespace splits literals",
+			Pattern: "ParseInt(:[args]) if err != nil",
+			Want:    []string{"ParseInt(", ")", "if", "err", "!=", "nil"},
+		},
+		{
+			Name: "Newlines split literals",
+			Pattern: `foo(:[x],
+	bar)`,
+			Want: []string{"foo(", ",", "bar)"},
+		},
+		{
+			Name:    "Regex holes contribute no literals",
+			Pattern: `:[x~[yo]] done`,
+			Want:    []string{"done"},
+		},
+		{
+			Name:    "Array-like preserved",
+			Pattern: `[:[x]]`,
+			Want:    []string{"[", "]"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			got := StructuralPatToLiterals(tt.Pattern)
+			if diff := cmp.Diff(tt.Want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// AssignedOwnerSummary is an owner explicitly assigned to a file or directory
+// of a repository.
+type AssignedOwnerSummary struct {
+	OwnerUserID int32
+	RepoID      api.RepoID
+	// FilePath is the path of the file or directory relative to the
+	// repository root. It is empty for the whole repository.
+	FilePath          string
+	WhoAssignedUserID int32
+	AssignedAt        time.Time
+}
+
+// AssignedOwnersStore stores the owners explicitly assigned to files and
+// directories of repositories.
+type AssignedOwnersStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) AssignedOwnersStore
+
+	// Insert assigns the user as an owner of the path in the repository.
+	// Assigning the same owner twice is a no-op.
+	Insert(ctx context.Context, ownerUserID int32, repoID api.RepoID, filePath string, whoAssignedUserID int32) error
+
+	// ListForPath returns the owners assigned to the path in the repository,
+	// or to any of its parent directories, ordered from the most specific
+	// path to the least specific one.
+	ListForPath(ctx context.Context, repoID api.RepoID, filePath string) ([]*AssignedOwnerSummary, error)
+
+	// ListForRepo returns all the owners assigned within the repository.
+	ListForRepo(ctx context.Context, repoID api.RepoID) ([]*AssignedOwnerSummary, error)
+
+	// Delete removes the user as an assigned owner of the path in the
+	// repository.
+	Delete(ctx context.Context, ownerUserID int32, repoID api.RepoID, filePath string) error
+}
+
+type assignedOwnersStore struct {
+	*basestore.Store
+}
+
+var _ AssignedOwnersStore = (*assignedOwnersStore)(nil)
+
+// AssignedOwnersStoreWith instantiates and returns a new AssignedOwnersStore
+// using the other store handle.
+func AssignedOwnersStoreWith(other basestore.ShareableStore) AssignedOwnersStore {
+	return &assignedOwnersStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *assignedOwnersStore) With(other basestore.ShareableStore) AssignedOwnersStore {
+	return &assignedOwnersStore{Store: s.Store.With(other)}
+}
+
+const insertAssignedOwnerFmtstr = `
+INSERT INTO assigned_owners (owner_user_id, repo_id, file_path, who_assigned_user_id)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT (repo_id, file_path, owner_user_id) DO NOTHING
+`
+
+func (s *assignedOwnersStore) Insert(ctx context.Context, ownerUserID int32, repoID api.RepoID, filePath string, whoAssignedUserID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		insertAssignedOwnerFmtstr,
+		ownerUserID,
+		repoID,
+		normalizeOwnedPath(filePath),
+		dbutil.NullInt32Column(whoAssignedUserID),
+	))
+}
+
+const listAssignedOwnersForPathFmtstr = `
+SELECT owner_user_id, repo_id, file_path, who_assigned_user_id, assigned_at
+FROM assigned_owners
+WHERE repo_id = %s AND file_path = ANY(%s)
+ORDER BY length(file_path) DESC, assigned_at, owner_user_id
+`
+
+func (s *assignedOwnersStore) ListForPath(ctx context.Context, repoID api.RepoID, filePath string) ([]*AssignedOwnerSummary, error) {
+	return scanAssignedOwners(s.Query(ctx, sqlf.Sprintf(
+		listAssignedOwnersForPathFmtstr,
+		repoID,
+		pq.Array(ownedPathAncestors(filePath)),
+	)))
+}
+
+const listAssignedOwnersForRepoFmtstr = `
+SELECT owner_user_id, repo_id, file_path, who_assigned_user_id, assigned_at
+FROM assigned_owners
+WHERE repo_id = %s
+ORDER BY file_path, assigned_at, owner_user_id
+`
+
+func (s *assignedOwnersStore) ListForRepo(ctx context.Context, repoID api.RepoID) ([]*AssignedOwnerSummary, error) {
+	return scanAssignedOwners(s.Query(ctx, sqlf.Sprintf(listAssignedOwnersForRepoFmtstr, repoID)))
+}
+
+const deleteAssignedOwnerFmtstr = `
+DELETE FROM assigned_owners
+WHERE owner_user_id = %s AND repo_id = %s AND file_path = %s
+`
+
+func (s *assignedOwnersStore) Delete(ctx context.Context, ownerUserID int32, repoID api.RepoID, filePath string) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAssignedOwnerFmtstr, ownerUserID, repoID, normalizeOwnedPath(filePath)))
+}
+
+var scanAssignedOwners = basestore.NewSliceScanner(func(scanner dbutil.Scanner) (*AssignedOwnerSummary, error) {
+	var s AssignedOwnerSummary
+	err := scanner.Scan(
+		&s.OwnerUserID,
+		&s.RepoID,
+		&s.FilePath,
+		&dbutil.NullInt32{N: &s.WhoAssignedUserID},
+		&s.AssignedAt,
+	)
+	return &s, err
+})
+
+// normalizeOwnedPath strips leading and trailing slashes, so that `/docs/`
+// and `docs` refer to the same directory.
+func normalizeOwnedPath(filePath string) string {
+	return strings.Trim(filePath, "/")
+}
+
+// ownedPathAncestors returns the path along with all its parent directories,
+// including the repository root denoted by an empty path.
+func ownedPathAncestors(filePath string) []string {
+	filePath = normalizeOwnedPath(filePath)
+	ancestors := []string{""}
+	if filePath == "" {
+		return ancestors
+	}
+	parts := strings.Split(filePath, "/")
+	for i := range parts {
+		ancestors = append(ancestors, strings.Join(parts[:i+1], "/"))
+	}
+	return ancestors
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAssignedOwners(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	alice, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	bob, err := db.Users().Create(ctx, NewUser{Username: "bob"})
+	require.NoError(t, err)
+
+	err = db.Repos().Create(ctx, &types.Repo{Name: "github.com/sourcegraph/sourcegraph"})
+	require.NoError(t, err)
+	repo, err := db.Repos().GetByName(ctx, "github.com/sourcegraph/sourcegraph")
+	require.NoError(t, err)
+
+	store := db.AssignedOwners()
+	require.NoError(t, store.Insert(ctx, alice.ID, repo.ID, "", bob.ID))
+	require.NoError(t, store.Insert(ctx, bob.ID, repo.ID, "/internal/own/", alice.ID))
+	require.NoError(t, store.Insert(ctx, alice.ID, repo.ID, "internal/own/service.go", 0))
+	// Inserting the same owner twice is a no-op.
+	require.NoError(t, store.Insert(ctx, bob.ID, repo.ID, "internal/own", alice.ID))
+	// Unrelated paths are not returned.
+	require.NoError(t, store.Insert(ctx, bob.ID, repo.ID, "internal/owner", alice.ID))
+
+	paths := func(owners []*AssignedOwnerSummary) []string {
+		var res []string
+		for _, o := range owners {
+			res = append(res, o.FilePath)
+		}
+		return res
+	}
+
+	t.Run("ListForPath", func(t *testing.T) {
+		owners, err := store.ListForPath(ctx, repo.ID, "internal/own/service.go")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"internal/own/service.go", "internal/own", ""}, paths(owners))
+		assert.Equal(t, alice.ID, owners[0].OwnerUserID)
+		assert.Zero(t, owners[0].WhoAssignedUserID)
+		assert.Equal(t, bob.ID, owners[1].OwnerUserID)
+		assert.Equal(t, alice.ID, owners[1].WhoAssignedUserID)
+	})
+
+	t.Run("ListForRepo", func(t *testing.T) {
+		owners, err := store.ListForRepo(ctx, repo.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"", "internal/own", "internal/own/service.go", "internal/owner"}, paths(owners))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, bob.ID, repo.ID, "internal/own/"))
+		owners, err := store.ListForPath(ctx, repo.ID, "internal/own/service.go")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"internal/own/service.go", ""}, paths(owners))
+	})
+}
+
+func TestOwnedPathAncestors(t *testing.T) {
+	assert.Equal(t, []string{""}, ownedPathAncestors(""))
+	assert.Equal(t, []string{""}, ownedPathAncestors("/"))
+	assert.Equal(t, []string{"", "a", "a/b", "a/b/c.go"}, ownedPathAncestors("/a/b/c.go"))
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// AuditLogStore persists the records written with audit.Log, so that they can
+// be queried and exported by site admins.
+type AuditLogStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) AuditLogStore
+	Transact(context.Context) (AuditLogStore, error)
+
+	// Insert persists the given audit log entries.
+	Insert(ctx context.Context, entries ...*audit.Entry) error
+
+	// List returns the audit log records matching the given options, newest
+	// first.
+	List(ctx context.Context, opts AuditLogListOpts) ([]*AuditLog, error)
+
+	// Count returns the number of audit log records matching the given
+	// options, ignoring pagination.
+	Count(ctx context.Context, opts AuditLogListOpts) (int, error)
+
+	// DeleteStale removes all audit log records older than the given
+	// retention.
+	DeleteStale(ctx context.Context, retention time.Duration) error
+}
+
+// AuditLog is a persisted audit log record.
+type AuditLog struct {
+	ID int64
+	audit.Entry
+}
+
+// AuditLogListOpts specifies the options for listing audit log records.
+type AuditLogListOpts struct {
+	// ActorUID only returns records of the given actor, if set.
+	ActorUID string
+	// Entity only returns records of the given entity, if set.
+	Entity string
+	// Since and Until bound the time range of the returned records, if set.
+	// Since is inclusive, Until is exclusive.
+	Since time.Time
+	Until time.Time
+	// BeforeID only returns records with a lower ID, if set. It allows paging
+	// through all records without the cost of large offsets.
+	BeforeID int64
+
+	*LimitOffset
+}
+
+func (opts AuditLogListOpts) sqlConds() *sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if opts.ActorUID != "" {
+		preds = append(preds, sqlf.Sprintf("actor_uid = %s", opts.ActorUID))
+	}
+	if opts.Entity != "" {
+		preds = append(preds, sqlf.Sprintf("entity = %s", opts.Entity))
+	}
+	if !opts.Since.IsZero() {
+		preds = append(preds, sqlf.Sprintf("created_at >= %s", opts.Since))
+	}
+	if !opts.Until.IsZero() {
+		preds = append(preds, sqlf.Sprintf("created_at < %s", opts.Until))
+	}
+	if opts.BeforeID != 0 {
+		preds = append(preds, sqlf.Sprintf("id < %s", opts.BeforeID))
+	}
+
+	return sqlf.Join(preds, "\n AND ")
+}
+
+var _ AuditLogStore = (*auditLogStore)(nil)
+
+// auditLogStore is responsible for data stored in the audit_logs table.
+type auditLogStore struct {
+	*basestore.Store
+}
+
+// AuditLogsWith instantiates and returns a new AuditLogStore using the other
+// store handle.
+func AuditLogsWith(other basestore.ShareableStore) AuditLogStore {
+	return &auditLogStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *auditLogStore) With(other basestore.ShareableStore) AuditLogStore {
+	return &auditLogStore{Store: s.Store.With(other)}
+}
+
+func (s *auditLogStore) Transact(ctx context.Context) (AuditLogStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &auditLogStore{Store: txBase}, err
+}
+
+var auditLogInsertColumns = []string{
+	"audit_id",
+	"entity",
+	"action",
+	"actor_uid",
+	"ip",
+	"forwarded_for",
+	"fields",
+	"created_at",
+}
+
+func (s *auditLogStore) Insert(ctx context.Context, entries ...*audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	inserter := batch.NewInserter(ctx, s.Handle(), "audit_logs", batch.MaxNumPostgresParameters, auditLogInsertColumns...)
+	for _, e := range entries {
+		fields := e.Fields
+		if len(fields) == 0 {
+			fields = []byte("{}")
+		}
+		createdAt := e.Timestamp
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		if err := inserter.Insert(
+			ctx,
+			e.AuditID,
+			e.Entity,
+			e.Action,
+			e.ActorUID,
+			e.IP,
+			e.ForwardedFor,
+			string(fields),
+			createdAt,
+		); err != nil {
+			return err
+		}
+	}
+	return inserter.Flush(ctx)
+}
+
+func (s *auditLogStore) List(ctx context.Context, opts AuditLogListOpts) ([]*AuditLog, error) {
+	q := sqlf.Sprintf(listAuditLogsQuery, opts.sqlConds(), opts.LimitOffset.SQL())
+	return scanAuditLogs(s.Query(ctx, q))
+}
+
+const listAuditLogsQuery = `
+-- source: internal/database/audit_logs.go:auditLogStore.List
+SELECT
+	id,
+	audit_id,
+	entity,
+	action,
+	actor_uid,
+	ip,
+	forwarded_for,
+	fields,
+	created_at
+FROM audit_logs
+WHERE %s
+ORDER BY id DESC
+%s
+`
+
+func (s *auditLogStore) Count(ctx context.Context, opts AuditLogListOpts) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countAuditLogsQuery, opts.sqlConds())))
+	return count, err
+}
+
+const countAuditLogsQuery = `
+-- source: internal/database/audit_logs.go:auditLogStore.Count
+SELECT COUNT(*) FROM audit_logs WHERE %s
+`
+
+func (s *auditLogStore) DeleteStale(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+	return s.Exec(ctx, sqlf.Sprintf(deleteStaleAuditLogsQuery, before))
+}
+
+const deleteStaleAuditLogsQuery = `
+-- source: internal/database/audit_logs.go:auditLogStore.DeleteStale
+DELETE FROM audit_logs WHERE created_at < %s
+`
+
+var scanAuditLogs = basestore.NewSliceScanner(func(sc dbutil.Scanner) (*AuditLog, error) {
+	var l AuditLog
+	if err := sc.Scan(
+		&l.ID,
+		&l.AuditID,
+		&l.Entity,
+		&l.Action,
+		&l.ActorUID,
+		&l.IP,
+		&l.ForwardedFor,
+		&l.Fields,
+		&l.Timestamp,
+	); err != nil {
+		return nil, err
+	}
+	return &l, nil
+})
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestAuditLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.AuditLogs()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, store.Insert(ctx,
+		&audit.Entry{AuditID: "1", Entity: "security events", Action: "SignInSucceeded", ActorUID: "1", IP: "127.0.0.1", Timestamp: now.Add(-3 * time.Hour)},
+		&audit.Entry{AuditID: "2", Entity: "graphql", Action: "request", ActorUID: "1", Fields: json.RawMessage(`{"query":"{ currentUser { id } }"}`), Timestamp: now.Add(-2 * time.Hour)},
+		&audit.Entry{AuditID: "3", Entity: "graphql", Action: "request", ActorUID: "2", Timestamp: now.Add(-time.Hour)},
+		&audit.Entry{AuditID: "4", Entity: "gitserver", Action: "access", ActorUID: "unknown", Timestamp: now},
+	))
+
+	auditIDs := func(logs []*AuditLog) []string {
+		ids := make([]string, 0, len(logs))
+		for _, l := range logs {
+			ids = append(ids, l.AuditID)
+		}
+		return ids
+	}
+
+	t.Run("List", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts AuditLogListOpts
+			want []string
+		}{
+			"all":      {opts: AuditLogListOpts{}, want: []string{"4", "3", "2", "1"}},
+			"actor":    {opts: AuditLogListOpts{ActorUID: "1"}, want: []string{"2", "1"}},
+			"entity":   {opts: AuditLogListOpts{Entity: "graphql"}, want: []string{"3", "2"}},
+			"since":    {opts: AuditLogListOpts{Since: now.Add(-time.Hour)}, want: []string{"4", "3"}},
+			"until":    {opts: AuditLogListOpts{Until: now.Add(-time.Hour)}, want: []string{"2", "1"}},
+			"limit":    {opts: AuditLogListOpts{LimitOffset: &LimitOffset{Limit: 2, Offset: 1}}, want: []string{"3", "2"}},
+			"combined": {opts: AuditLogListOpts{ActorUID: "1", Entity: "graphql"}, want: []string{"2"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				logs, err := store.List(ctx, tc.opts)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, auditIDs(logs))
+
+				count, err := store.Count(ctx, AuditLogListOpts{ActorUID: tc.opts.ActorUID, Entity: tc.opts.Entity, Since: tc.opts.Since, Until: tc.opts.Until})
+				require.NoError(t, err)
+				if tc.opts.LimitOffset == nil {
+					assert.Equal(t, len(tc.want), count)
+				}
+			})
+		}
+
+		t.Run("BeforeID", func(t *testing.T) {
+			logs, err := store.List(ctx, AuditLogListOpts{LimitOffset: &LimitOffset{Limit: 2}})
+			require.NoError(t, err)
+			require.Len(t, logs, 2)
+
+			logs, err = store.List(ctx, AuditLogListOpts{BeforeID: logs[1].ID})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"2", "1"}, auditIDs(logs))
+		})
+
+		t.Run("fields", func(t *testing.T) {
+			logs, err := store.List(ctx, AuditLogListOpts{ActorUID: "1", Entity: "graphql"})
+			require.NoError(t, err)
+			require.Len(t, logs, 1)
+			assert.JSONEq(t, `{"query":"{ currentUser { id } }"}`, string(logs[0].Fields))
+			assert.Equal(t, now.Add(-2*time.Hour), logs[0].Timestamp.UTC())
+		})
+	})
+
+	t.Run("DeleteStale", func(t *testing.T) {
+		require.NoError(t, store.DeleteStale(ctx, 90*time.Minute))
+
+		logs, err := store.List(ctx, AuditLogListOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"4", "3"}, auditIDs(logs))
+	})
+}
@@ -21,6 +21,8 @@ type DB interface {
 	basestore.ShareableStore
 
 	AccessTokens() AccessTokenStore
+	AssignedOwners() AssignedOwnersStore
+	AuditLogs() AuditLogStore
 	Authz() AuthzStore
 	BitbucketProjectPermissions() BitbucketProjectPermissionsStore
 	Conf() ConfStore
@@ -39,15 +41,19 @@ type DB interface {
 	OutboundWebhooks(encryption.Key) OutboundWebhookStore
 	OutboundWebhookJobs(encryption.Key) OutboundWebhookJobStore
 	OutboundWebhookLogs(encryption.Key) OutboundWebhookLogStore
+	PathRanks() PathRankStore
 	Permissions() PermissionStore
 	PermissionSyncJobs() PermissionSyncJobStore
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
 	RepoKVPs() RepoKVPStore
+	RepoUpdateSchedule() RepoUpdateScheduleStore
 	RolePermissions() RolePermissionStore
 	Roles() RoleStore
 	SavedSearches() SavedSearchStore
+	SavedSearchRuns() SavedSearchRunStore
 	SearchContexts() SearchContextsStore
+	SearchExportJobs() SearchExportJobStore
 	Settings() SettingsStore
 	TemporarySettings() TemporarySettingsStore
 	UserCredentials(encryption.Key) UserCredentialsStore
@@ -112,6 +118,14 @@ func (d *db) AccessTokens() AccessTokenStore {
 	return AccessTokensWith(d.Store, d.logger.Scoped("AccessTokenStore", ""))
 }
 
+func (d *db) AssignedOwners() AssignedOwnersStore {
+	return AssignedOwnersStoreWith(d.Store)
+}
+
+func (d *db) AuditLogs() AuditLogStore {
+	return AuditLogsWith(d.Store)
+}
+
 func (d *db) BitbucketProjectPermissions() BitbucketProjectPermissionsStore {
 	return BitbucketProjectPermissionsStoreWith(d.Store)
 }
@@ -184,6 +198,10 @@ func (d *db) OutboundWebhookLogs(key encryption.Key) OutboundWebhookLogStore {
 	return OutboundWebhookLogsWith(d.Store, key)
 }
 
+func (d *db) PathRanks() PathRankStore {
+	return PathRanksWith(d.Store)
+}
+
 func (d *db) Permissions() PermissionStore {
 	return PermissionsWith(d.Store)
 }
@@ -204,6 +222,10 @@ func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
 
+func (d *db) RepoUpdateSchedule() RepoUpdateScheduleStore {
+	return RepoUpdateScheduleWith(d.Store)
+}
+
 func (d *db) RolePermissions() RolePermissionStore {
 	return RolePermissionsWith(d.Store)
 }
@@ -216,10 +238,18 @@ func (d *db) SavedSearches() SavedSearchStore {
 	return SavedSearchesWith(d.Store)
 }
 
+func (d *db) SavedSearchRuns() SavedSearchRunStore {
+	return SavedSearchRunsWith(d.Store)
+}
+
 func (d *db) SearchContexts() SearchContextsStore {
 	return SearchContextsWith(d.logger, d.Store)
 }
 
+func (d *db) SearchExportJobs() SearchExportJobStore {
+	return SearchExportJobsWith(d.Store)
+}
+
 func (d *db) Settings() SettingsStore {
 	return SettingsWith(d.Store)
 }
@@ -37,6 +37,9 @@ type GitserverRepoStore interface {
 	// LogCorruption sets the corrupted at value and logs the corruption reason. Reason will be truncated if it exceeds
 	// MaxReasonSizeInMB
 	LogCorruption(ctx context.Context, name api.RepoName, reason string, shardID string) error
+	// LogMaintenance logs a run of a scheduled maintenance task. Only the 50 most recent runs are kept. The error
+	// of the log will be truncated if it exceeds MaxReasonSizeInMB.
+	LogMaintenance(ctx context.Context, name api.RepoName, log types.RepoMaintenanceLog) error
 	// SetCloneStatus will attempt to update ONLY the clone status of a
 	// GitServerRepo. If a matching row does not yet exist a new one will be created.
 	// If the status value hasn't changed, the row will not be updated.
@@ -320,7 +323,8 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.maintenance_logs
 FROM gitserver_repos gr
 JOIN repo ON gr.repo_id = repo.id
 WHERE %s
@@ -352,7 +356,8 @@ SELECT
 	repo_size_bytes,
 	updated_at,
 	corrupted_at,
-	corruption_logs
+	corruption_logs,
+	maintenance_logs
 FROM gitserver_repos
 WHERE repo_id = %s
 `
@@ -381,7 +386,8 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.maintenance_logs
 FROM gitserver_repos gr
 JOIN repo r ON r.id = gr.repo_id
 WHERE r.name = %s
@@ -404,7 +410,8 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.maintenance_logs
 FROM gitserver_repos gr
 JOIN repo r on r.id = gr.repo_id
 WHERE r.name = ANY (%s)
@@ -432,7 +439,7 @@ func (s *gitserverRepoStore) GetByNames(ctx context.Context, names ...api.RepoNa
 
 func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoName, error) {
 	var gr types.GitserverRepo
-	var rawLogs []byte
+	var rawLogs, rawMaintenanceLogs []byte
 	var cloneStatus string
 	var repoName api.RepoName
 	err := scanner.Scan(
@@ -447,6 +454,7 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 		&gr.UpdatedAt,
 		&dbutil.NullTime{Time: &gr.CorruptedAt},
 		&rawLogs,
+		&rawMaintenanceLogs,
 	)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "scanning GitserverRepo")
@@ -457,6 +465,10 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 	if err != nil {
 		return nil, repoName, errors.Wrap(err, "unmarshal of corruption_logs failed")
 	}
+	err = json.Unmarshal(rawMaintenanceLogs, &gr.MaintenanceLogs)
+	if err != nil {
+		return nil, repoName, errors.Wrap(err, "unmarshal of maintenance_logs failed")
+	}
 	return &gr, repoName, nil
 }
 
@@ -562,6 +574,38 @@ AND
 	return nil
 }
 
+func (s *gitserverRepoStore) LogMaintenance(ctx context.Context, name api.RepoName, log types.RepoMaintenanceLog) error {
+	// trim the error so that we don't store huge logs, like we do for corruption reasons
+	if len(log.Error) > MaxReasonSizeInMB {
+		log.Error = log.Error[:MaxReasonSizeInMB]
+	}
+	log.Error = sanitizeToUTF8(log.Error)
+
+	rawLog, err := json.Marshal(log)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal maintenance_logs")
+	}
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos as gtr
+SET
+	-- prepend the json and then ensure we only keep 50 items in the resulting json array
+	maintenance_logs = (SELECT jsonb_path_query_array(%s||gtr.maintenance_logs, '$[0 to 49]')),
+	updated_at = NOW()
+WHERE
+	repo_id = (SELECT id FROM repo WHERE name = %s)`, rawLog, name))
+	if err != nil {
+		return errors.Wrapf(err, "logging repo maintenance")
+	}
+
+	if nrows, err := res.RowsAffected(); err != nil {
+		return errors.Wrapf(err, "getting rows affected")
+	} else if nrows != 1 {
+		return errors.New("repo not found")
+	}
+	return nil
+}
+
 // GitserverFetchData is the metadata associated with a fetch operation on
 // gitserver.
 type GitserverFetchData struct {
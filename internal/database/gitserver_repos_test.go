@@ -375,7 +375,7 @@ func TestGitserverReposGetByID(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 }
@@ -401,7 +401,7 @@ func TestGitserverReposGetByName(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 }
@@ -441,7 +441,7 @@ func TestGitserverReposGetByNames(t *testing.T) {
 		sort.Slice(haveRepos, func(i, j int) bool {
 			return haveRepos[i].RepoID < haveRepos[j].RepoID
 		})
-		if diff := cmp.Diff(gitserverRepos[:i+1], haveRepos, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+		if diff := cmp.Diff(gitserverRepos[:i+1], haveRepos, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 			t.Fatal(diff)
 		}
 	}
@@ -473,7 +473,7 @@ func TestSetCloneStatus(t *testing.T) {
 
 	gitserverRepo.CloneStatus = types.CloneStatusCloned
 	gitserverRepo.ShardID = shardID
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -500,7 +500,7 @@ func TestSetCloneStatus(t *testing.T) {
 		ShardID:     shardID,
 		CloneStatus: types.CloneStatusCloned,
 	}
-	if diff := cmp.Diff(gitserverRepo2, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "LastFetched", "LastChanged", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo2, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "LastFetched", "LastChanged", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -700,7 +700,7 @@ func TestSetLastError(t *testing.T) {
 	}
 
 	gitserverRepo.LastError = "oops"
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -717,7 +717,7 @@ func TestSetLastError(t *testing.T) {
 	}
 
 	gitserverRepo.LastError = emptyErr
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -777,7 +777,7 @@ func TestSetRepoSize(t *testing.T) {
 	gitserverRepo.RepoSizeBytes = 200
 	// If we have size, we can assume it's cloned
 	gitserverRepo.CloneStatus = types.CloneStatusCloned
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -808,7 +808,7 @@ func TestSetRepoSize(t *testing.T) {
 		// If we have size, we can assume it's cloned
 		CloneStatus: types.CloneStatusCloned,
 	}
-	if diff := cmp.Diff(gitserverRepo2, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "LastFetched", "LastChanged", "CloneStatus", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo2, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "LastFetched", "LastChanged", "CloneStatus", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -825,6 +825,66 @@ func TestSetRepoSize(t *testing.T) {
 	}
 }
 
+func TestLogMaintenance(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{
+		Name:          "github.com/sourcegraph/repo1",
+		RepoSizeBytes: 100,
+		CloneStatus:   types.CloneStatusCloned,
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 0; i < 60; i++ {
+		log := types.RepoMaintenanceLog{
+			Task:       "commit-graph",
+			StartedAt:  now.Add(time.Duration(i) * time.Minute),
+			FinishedAt: now.Add(time.Duration(i)*time.Minute + time.Second),
+			NextRunAt:  now.Add(time.Duration(i)*time.Minute + time.Hour),
+		}
+		if i == 59 {
+			log.Task = "geometric-repack"
+			log.Error = "fatal: something went wrong"
+		}
+		if err := db.GitserverRepos().LogMaintenance(ctx, repo.Name, log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fromDB, err := db.GitserverRepos().GetByID(ctx, repo.ID)
+	if err != nil {
+		t.Fatalf("failed to get repo by id: %s", err)
+	}
+
+	// We only keep the 50 most recent entries, most recent first.
+	if len(fromDB.MaintenanceLogs) != 50 {
+		t.Fatalf("Wanted 50 maintenance log entries, got %d entries", len(fromDB.MaintenanceLogs))
+	}
+	want := types.RepoMaintenanceLog{
+		Task:       "geometric-repack",
+		StartedAt:  now.Add(59 * time.Minute),
+		FinishedAt: now.Add(59*time.Minute + time.Second),
+		Error:      "fatal: something went wrong",
+		NextRunAt:  now.Add(59*time.Minute + time.Hour),
+	}
+	if diff := cmp.Diff(want, fromDB.MaintenanceLogs[0]); diff != "" {
+		t.Fatalf("unexpected most recent maintenance log (-want +got):\n%s", diff)
+	}
+	if got := fromDB.MaintenanceLogs[49].StartedAt; !got.Equal(now.Add(10 * time.Minute)) {
+		t.Fatalf("unexpected oldest maintenance log start time %s", got)
+	}
+
+	if err := db.GitserverRepos().LogMaintenance(ctx, "github.com/sourcegraph/missing", types.RepoMaintenanceLog{Task: "commit-graph"}); err == nil {
+		t.Fatal("expected error for missing repo")
+	}
+}
+
 func TestGitserverRepo_Update(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -850,7 +910,7 @@ func TestGitserverRepo_Update(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -871,7 +931,7 @@ func TestGitserverRepo_Update(t *testing.T) {
 	// Set LastError to the expected error string but without the null character, because we expect
 	// our code to work and strip it before writing to the DB.
 	gitserverRepo.LastError = "Oops"
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -884,7 +944,7 @@ func TestGitserverRepo_Update(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 }
@@ -923,7 +983,7 @@ func TestGitserverRepoUpdateMany(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if diff := cmp.Diff(gitserverRepo1, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+		if diff := cmp.Diff(gitserverRepo1, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 			t.Fatal(diff)
 		}
 	})
@@ -932,7 +992,7 @@ func TestGitserverRepoUpdateMany(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if diff := cmp.Diff(gitserverRepo2, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+		if diff := cmp.Diff(gitserverRepo2, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 			t.Fatal(diff)
 		}
 	})
@@ -980,7 +1040,7 @@ func TestGitserverRepoListReposWithoutSize(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -1005,7 +1065,7 @@ func TestGitserverRepoListReposWithoutSize(t *testing.T) {
 	}
 
 	// Check that nothing except UpdatedAt and RepoSizeBytes has been changed
-	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "RepoSizeBytes", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(gitserverRepo, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "RepoSizeBytes", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 }
@@ -1061,7 +1121,7 @@ func TestGitserverUpdateRepoSizes(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		if diff := cmp.Diff(repo, reloaded, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs")); diff != "" {
+		if diff := cmp.Diff(repo, reloaded, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 			t.Fatal(diff)
 		}
 		// Separately make sure UpdatedAt has changed, though
@@ -1132,7 +1192,7 @@ func createTestRepo(ctx context.Context, t *testing.T, db DB, payload *createTes
 		CloneStatus:    types.CloneStatusNotCloned,
 		CorruptionLogs: []types.RepoCorruptionLog{},
 	}
-	if diff := cmp.Diff(want, gitserverRepo, cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "UpdatedAt", "CorruptionLogs")); diff != "" {
+	if diff := cmp.Diff(want, gitserverRepo, cmpopts.IgnoreFields(types.GitserverRepo{}, "LastFetched", "LastChanged", "UpdatedAt", "CorruptionLogs", "MaintenanceLogs")); diff != "" {
 		t.Fatal(diff)
 	}
 
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// PathRankStore provides read access to the document ranks computed by code
+// intelligence ranking and stored in the codeintel_path_ranks table. It is the
+// single reader of that table, shared by the ranking service and the searcher.
+type PathRankStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) PathRankStore
+
+	// GetDocumentRanks returns the stored global rank of the given paths within
+	// the given repository along with the precision at which it was computed.
+	// If paths is nil, the ranks of all paths of the repository are returned.
+	// Paths without a stored rank are absent from the returned map.
+	GetDocumentRanks(ctx context.Context, repoName api.RepoName, paths []string) (map[string]PathRank, error)
+}
+
+// PathRank is the stored global rank of a single path.
+type PathRank struct {
+	// Precision is the precision level of the rank in (0, 1].
+	Precision float64
+	// Rank is the unnormalized global rank of the path, in [0, inf).
+	Rank float64
+}
+
+var _ PathRankStore = (*pathRankStore)(nil)
+
+type pathRankStore struct {
+	*basestore.Store
+}
+
+// PathRanksWith instantiates and returns a new PathRankStore using the other
+// store handle.
+func PathRanksWith(other basestore.ShareableStore) PathRankStore {
+	return &pathRankStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *pathRankStore) With(other basestore.ShareableStore) PathRankStore {
+	return &pathRankStore{Store: s.Store.With(other)}
+}
+
+func (s *pathRankStore) GetDocumentRanks(ctx context.Context, repoName api.RepoName, paths []string) (map[string]PathRank, error) {
+	entries := sqlf.Sprintf("jsonb_each_text(pr.payload)")
+	if paths != nil {
+		// Look up only the requested keys rather than expanding the whole payload.
+		entries = sqlf.Sprintf("(SELECT k, pr.payload->>k FROM unnest(%s::text[]) k WHERE pr.payload ? k)", pq.Array(paths))
+	}
+
+	pathRanks := map[string]PathRank{}
+	scanner := func(sc dbutil.Scanner) (bool, error) {
+		var (
+			path string
+			rank PathRank
+		)
+		if err := sc.Scan(&path, &rank.Precision, &rank.Rank); err != nil {
+			return false, err
+		}
+
+		// A path may be ranked at several precisions; keep the rank with
+		// the lowest precision.
+		if old, ok := pathRanks[path]; ok && old.Precision <= rank.Precision {
+			return true, nil
+		}
+		pathRanks[path] = rank
+
+		return true, nil
+	}
+
+	if err := basestore.NewCallbackScanner(scanner)(s.Query(ctx, sqlf.Sprintf(getPathRanksQuery, entries, repoName))); err != nil {
+		return nil, err
+	}
+	return pathRanks, nil
+}
+
+const getPathRanksQuery = `
+SELECT
+	p.key,
+	pr.precision,
+	p.value::float8
+FROM codeintel_path_ranks pr
+JOIN repo r ON r.id = pr.repository_id
+CROSS JOIN LATERAL %s AS p(key, value)
+WHERE
+	r.name = %s AND
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL
+`
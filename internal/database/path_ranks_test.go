@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestPathRanks_GetDocumentRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo1"})
+	for _, q := range []string{
+		`INSERT INTO codeintel_path_ranks (repository_id, precision, payload) VALUES ($1, 1, '{"a.go": 3, "b.go": 1}'::jsonb)`,
+		`INSERT INTO codeintel_path_ranks (repository_id, precision, graph_key, payload) VALUES ($1, 0.5, 'k', '{"b.go": 2, "c.go": 4}'::jsonb)`,
+	} {
+		if _, err := db.Handle().ExecContext(ctx, q, repo.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ranks, err := db.PathRanks().GetDocumentRanks(ctx, "repo1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]PathRank{
+		"a.go": {Precision: 1, Rank: 3},
+		"b.go": {Precision: 0.5, Rank: 2},
+		"c.go": {Precision: 0.5, Rank: 4},
+	}, ranks); diff != "" {
+		t.Errorf("unexpected ranks (-want +got):\n%s", diff)
+	}
+
+	ranks, err = db.PathRanks().GetDocumentRanks(ctx, "repo1", []string{"a.go", "missing.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]PathRank{"a.go": {Precision: 1, Rank: 3}}, ranks); diff != "" {
+		t.Errorf("unexpected ranks (-want +got):\n%s", diff)
+	}
+}
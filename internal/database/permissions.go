@@ -43,6 +43,11 @@ type PermissionStore interface {
 	GetByID(ctx context.Context, opts GetPermissionOpts) (*types.Permission, error)
 	// List returns all the permissions in the database.
 	List(ctx context.Context) ([]*types.Permission, error)
+	// ListForUser returns all the permissions granted to the user with the
+	// given ID, either through the default role or through the roles assigned
+	// to them. Permissions of organization-scoped roles are only returned for
+	// organizations the user is a member of.
+	ListForUser(ctx context.Context, userID int32) ([]*types.GrantedPermission, error)
 }
 
 type CreatePermissionOpts struct {
@@ -267,3 +272,59 @@ func (p *permissionStore) List(ctx context.Context) ([]*types.Permission, error)
 
 	return permissions, rows.Err()
 }
+
+const permissionListForUserQueryFmtStr = `
+SELECT DISTINCT permissions.namespace, permissions.action, 0 AS org_id
+FROM permissions
+JOIN role_permissions ON role_permissions.permission_id = permissions.id
+JOIN roles ON roles.id = role_permissions.role_id
+WHERE roles.name = %s AND roles.deleted_at IS NULL
+
+UNION
+
+SELECT DISTINCT permissions.namespace, permissions.action, COALESCE(user_roles.org_id, 0) AS org_id
+FROM permissions
+JOIN role_permissions ON role_permissions.permission_id = permissions.id
+JOIN roles ON roles.id = role_permissions.role_id
+JOIN user_roles ON user_roles.role_id = roles.id
+JOIN users ON users.id = user_roles.user_id
+WHERE
+	user_roles.user_id = %s
+	AND roles.deleted_at IS NULL
+	AND users.deleted_at IS NULL
+	AND (
+		user_roles.org_id IS NULL
+		OR EXISTS (
+			SELECT 1 FROM org_members
+			JOIN orgs ON orgs.id = org_members.org_id
+			WHERE
+				org_members.org_id = user_roles.org_id
+				AND org_members.user_id = user_roles.user_id
+				AND orgs.deleted_at IS NULL
+		)
+	)
+ORDER BY namespace, action, org_id
+`
+
+func (p *permissionStore) ListForUser(ctx context.Context, userID int32) ([]*types.GrantedPermission, error) {
+	if userID == 0 {
+		return nil, errors.New("missing user id")
+	}
+
+	rows, err := p.Query(ctx, sqlf.Sprintf(permissionListForUserQueryFmtStr, types.DefaultRoleName, userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "error running query")
+	}
+
+	var permissions []*types.GrantedPermission
+	defer rows.Close()
+	for rows.Next() {
+		var perm types.GrantedPermission
+		if err := rows.Scan(&perm.Namespace, &perm.Action, &perm.OrgID); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &perm)
+	}
+
+	return permissions, rows.Err()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestPermissionGetByID(t *testing.T) {
@@ -85,6 +86,68 @@ func TestPermissionList(t *testing.T) {
 	assert.Len(t, ps, totalPerms)
 }
 
+func TestPermissionListForUser(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := db.Permissions()
+
+	user := createTestUserForUserRole(ctx, "u1@example.com", "u1", t, db)
+
+	read := createTestPermissionForRolePermission(ctx, "BATCHCHANGES", "READ", t, db)
+	write := createTestPermissionForRolePermission(ctx, "BATCHCHANGES", "WRITE", t, db)
+	monitors := createTestPermissionForRolePermission(ctx, "CODEMONITORS", "WRITE", t, db)
+	insights := createTestPermissionForRolePermission(ctx, "CODEINSIGHTS", "WRITE", t, db)
+
+	grant := func(role *types.Role, perm *types.Permission) {
+		t.Helper()
+		_, err := db.RolePermissions().Create(ctx, CreateRolePermissionOpts{RoleID: role.ID, PermissionID: perm.ID})
+		assert.NoError(t, err)
+	}
+
+	defaultRole, err := db.Roles().Create(ctx, types.DefaultRoleName, true)
+	assert.NoError(t, err)
+	grant(defaultRole, read)
+
+	writers := createTestRoleForRolePermission(ctx, "WRITERS", t, db)
+	grant(writers, write)
+	grant(writers, monitors)
+	grant(writers, insights)
+
+	member, err := db.Orgs().Create(ctx, "member", nil)
+	assert.NoError(t, err)
+	_, err = db.OrgMembers().Create(ctx, member.ID, user.ID)
+	assert.NoError(t, err)
+	other, err := db.Orgs().Create(ctx, "other", nil)
+	assert.NoError(t, err)
+
+	perms, err := store.ListForUser(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*types.GrantedPermission{{Namespace: "BATCHCHANGES", Action: "READ"}}, perms)
+
+	for _, orgID := range []int32{member.ID, other.ID} {
+		_, err = db.UserRoles().Create(ctx, CreateUserRoleOpts{UserID: user.ID, RoleID: writers.ID, OrgID: orgID})
+		assert.NoError(t, err)
+	}
+
+	// Only the assignment in the org the user is a member of is effective.
+	perms, err = store.ListForUser(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*types.GrantedPermission{
+		{Namespace: "BATCHCHANGES", Action: "READ"},
+		{Namespace: "BATCHCHANGES", Action: "WRITE", OrgID: member.ID},
+		{Namespace: "CODEINSIGHTS", Action: "WRITE", OrgID: member.ID},
+		{Namespace: "CODEMONITORS", Action: "WRITE", OrgID: member.ID},
+	}, perms)
+
+	_, err = db.UserRoles().Create(ctx, CreateUserRoleOpts{UserID: user.ID, RoleID: writers.ID})
+	assert.NoError(t, err)
+
+	perms, err = store.ListForUser(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Contains(t, perms, &types.GrantedPermission{Namespace: "BATCHCHANGES", Action: "WRITE"})
+}
+
 func TestPermissionDelete(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
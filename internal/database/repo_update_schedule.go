@@ -0,0 +1,285 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoUpdateScheduleStore persists the schedule of the repo-updater
+// UpdateScheduler, so that backoff intervals and due times survive restarts.
+type RepoUpdateScheduleStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) RepoUpdateScheduleStore
+	Transact(context.Context) (RepoUpdateScheduleStore, error)
+
+	// Upsert inserts or updates the schedule of the given repositories.
+	// Schedules of repositories that no longer exist are ignored.
+	Upsert(ctx context.Context, schedules ...*RepoUpdateSchedule) error
+
+	// Delete removes the given repositories from the schedule.
+	Delete(ctx context.Context, ids ...api.RepoID) error
+
+	// List returns the schedule of all repositories that are neither deleted
+	// nor blocked, ordered by the time they are due.
+	List(ctx context.Context) ([]*RepoUpdateSchedule, error)
+
+	// GetByRepoID returns the schedule of the given repository. If the
+	// repository is not scheduled, a RepoUpdateScheduleNotFoundErr is
+	// returned.
+	GetByRepoID(ctx context.Context, id api.RepoID) (*RepoUpdateSchedule, error)
+}
+
+// RepoUpdateSchedule is the persisted update schedule of a single repository.
+type RepoUpdateSchedule struct {
+	RepoID api.RepoID
+	// RepoName is only set when reading schedules.
+	RepoName api.RepoName
+
+	Interval    time.Duration
+	DueAt       time.Time
+	LastFetched time.Time
+	// Priority is the priority of the repository in the update queue, or
+	// nil if it isn't queued.
+	Priority *int
+
+	UpdatedAt time.Time
+
+	// Index and Total are the position of the repository in the schedule
+	// and the number of scheduled repositories. They are only set by
+	// GetByRepoID.
+	Index int
+	Total int
+}
+
+type RepoUpdateScheduleNotFoundErr struct {
+	RepoID api.RepoID
+}
+
+func (e *RepoUpdateScheduleNotFoundErr) Error() string {
+	return fmt.Sprintf("repo update schedule not found: repo_id=%d", e.RepoID)
+}
+
+func (e *RepoUpdateScheduleNotFoundErr) NotFound() bool {
+	return true
+}
+
+var _ RepoUpdateScheduleStore = (*repoUpdateScheduleStore)(nil)
+
+// repoUpdateScheduleStore is responsible for data stored in the
+// repo_update_schedule table.
+type repoUpdateScheduleStore struct {
+	*basestore.Store
+}
+
+// RepoUpdateScheduleWith instantiates and returns a new
+// RepoUpdateScheduleStore using the other store handle.
+func RepoUpdateScheduleWith(other basestore.ShareableStore) RepoUpdateScheduleStore {
+	return &repoUpdateScheduleStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoUpdateScheduleStore) With(other basestore.ShareableStore) RepoUpdateScheduleStore {
+	return &repoUpdateScheduleStore{Store: s.Store.With(other)}
+}
+
+func (s *repoUpdateScheduleStore) Transact(ctx context.Context) (RepoUpdateScheduleStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &repoUpdateScheduleStore{Store: txBase}, err
+}
+
+func (s *repoUpdateScheduleStore) Upsert(ctx context.Context, schedules ...*RepoUpdateSchedule) (err error) {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(upsertRepoUpdateScheduleCreateTempTableQuery)); err != nil {
+		return err
+	}
+
+	inserter := batch.NewInserter(ctx, tx.Handle(), "temp_repo_update_schedule", batch.MaxNumPostgresParameters, repoUpdateScheduleTempTableColumns...)
+	for _, sched := range schedules {
+		if err := inserter.Insert(
+			ctx,
+			sched.RepoID,
+			int(sched.Interval/time.Second),
+			sched.DueAt,
+			dbutil.NullTimeColumn(sched.LastFetched),
+			sched.Priority,
+		); err != nil {
+			return err
+		}
+	}
+	if err := inserter.Flush(ctx); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(upsertRepoUpdateScheduleQuery))
+}
+
+var repoUpdateScheduleTempTableColumns = []string{
+	"repo_id",
+	"interval_seconds",
+	"due_at",
+	"last_fetched_at",
+	"priority",
+}
+
+const upsertRepoUpdateScheduleCreateTempTableQuery = `
+CREATE TEMPORARY TABLE temp_repo_update_schedule (
+	repo_id          integer NOT NULL,
+	interval_seconds integer NOT NULL,
+	due_at           timestamp with time zone NOT NULL,
+	last_fetched_at  timestamp with time zone,
+	priority         integer
+) ON COMMIT DROP
+`
+
+const upsertRepoUpdateScheduleQuery = `
+-- source: internal/database/repo_update_schedule.go:repoUpdateScheduleStore.Upsert
+INSERT INTO repo_update_schedule (repo_id, interval_seconds, due_at, last_fetched_at, priority, updated_at)
+SELECT
+	source.repo_id,
+	source.interval_seconds,
+	source.due_at,
+	source.last_fetched_at,
+	source.priority,
+	now()
+FROM temp_repo_update_schedule source
+JOIN repo ON repo.id = source.repo_id
+ON CONFLICT (repo_id) DO UPDATE SET
+	interval_seconds = EXCLUDED.interval_seconds,
+	due_at           = EXCLUDED.due_at,
+	last_fetched_at  = COALESCE(EXCLUDED.last_fetched_at, repo_update_schedule.last_fetched_at),
+	priority         = EXCLUDED.priority,
+	updated_at       = EXCLUDED.updated_at
+`
+
+func (s *repoUpdateScheduleStore) Delete(ctx context.Context, ids ...api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	q := sqlf.Sprintf(deleteRepoUpdateScheduleQuery, pq.Array(ids))
+	return s.Exec(ctx, q)
+}
+
+const deleteRepoUpdateScheduleQuery = `
+-- source: internal/database/repo_update_schedule.go:repoUpdateScheduleStore.Delete
+DELETE FROM repo_update_schedule WHERE repo_id = ANY(%s)
+`
+
+func (s *repoUpdateScheduleStore) List(ctx context.Context) ([]*RepoUpdateSchedule, error) {
+	return scanRepoUpdateSchedules(s.Query(ctx, sqlf.Sprintf(listRepoUpdateScheduleQuery)))
+}
+
+const listRepoUpdateScheduleQuery = `
+-- source: internal/database/repo_update_schedule.go:repoUpdateScheduleStore.List
+SELECT
+	s.repo_id,
+	repo.name,
+	s.interval_seconds,
+	s.due_at,
+	s.last_fetched_at,
+	s.priority,
+	s.updated_at
+FROM repo_update_schedule s
+JOIN repo ON repo.id = s.repo_id
+WHERE
+	repo.deleted_at IS NULL
+AND
+	repo.blocked IS NULL
+ORDER BY s.due_at, s.repo_id
+`
+
+var scanRepoUpdateSchedules = basestore.NewSliceScanner(func(sc dbutil.Scanner) (*RepoUpdateSchedule, error) {
+	return scanRepoUpdateSchedule(sc)
+})
+
+func scanRepoUpdateSchedule(sc dbutil.Scanner, extra ...any) (*RepoUpdateSchedule, error) {
+	var (
+		sched    RepoUpdateSchedule
+		interval int
+		priority sql.NullInt32
+	)
+
+	dest := []any{
+		&sched.RepoID,
+		&sched.RepoName,
+		&interval,
+		&sched.DueAt,
+		&dbutil.NullTime{Time: &sched.LastFetched},
+		&priority,
+		&sched.UpdatedAt,
+	}
+	if err := sc.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	sched.Interval = time.Duration(interval) * time.Second
+	if priority.Valid {
+		p := int(priority.Int32)
+		sched.Priority = &p
+	}
+
+	return &sched, nil
+}
+
+func (s *repoUpdateScheduleStore) GetByRepoID(ctx context.Context, id api.RepoID) (*RepoUpdateSchedule, error) {
+	var index, total int
+	sched, err := scanRepoUpdateSchedule(s.QueryRow(ctx, sqlf.Sprintf(getRepoUpdateScheduleQuery, id)), &index, &total)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &RepoUpdateScheduleNotFoundErr{RepoID: id}
+		}
+		return nil, err
+	}
+
+	sched.Index = index
+	sched.Total = total
+	return sched, nil
+}
+
+const getRepoUpdateScheduleQuery = `
+-- source: internal/database/repo_update_schedule.go:repoUpdateScheduleStore.GetByRepoID
+WITH scheduled AS (
+	SELECT
+		s.*,
+		repo.name,
+		ROW_NUMBER() OVER (ORDER BY s.due_at, s.repo_id) - 1 AS index,
+		COUNT(*) OVER () AS total
+	FROM repo_update_schedule s
+	JOIN repo ON repo.id = s.repo_id
+	WHERE
+		repo.deleted_at IS NULL
+	AND
+		repo.blocked IS NULL
+)
+SELECT
+	repo_id,
+	name,
+	interval_seconds,
+	due_at,
+	last_fetched_at,
+	priority,
+	updated_at,
+	index,
+	total
+FROM scheduled
+WHERE repo_id = %s
+`
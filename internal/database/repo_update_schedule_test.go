@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoUpdateSchedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.RepoUpdateSchedule()
+
+	repos := types.Repos{
+		{Name: "github.com/sourcegraph/a"},
+		{Name: "github.com/sourcegraph/b"},
+		{Name: "github.com/sourcegraph/c"},
+	}
+	require.NoError(t, db.Repos().Create(ctx, repos...))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	high := 1
+
+	require.NoError(t, store.Upsert(ctx,
+		&RepoUpdateSchedule{RepoID: repos[0].ID, Interval: time.Hour, DueAt: now.Add(time.Hour)},
+		&RepoUpdateSchedule{RepoID: repos[1].ID, Interval: time.Minute, DueAt: now.Add(time.Minute), LastFetched: now, Priority: &high},
+		&RepoUpdateSchedule{RepoID: repos[2].ID, Interval: 2 * time.Hour, DueAt: now.Add(2 * time.Hour)},
+		// Repos that don't exist are ignored.
+		&RepoUpdateSchedule{RepoID: 1000, Interval: time.Hour, DueAt: now},
+	))
+
+	t.Run("List", func(t *testing.T) {
+		schedules, err := store.List(ctx)
+		require.NoError(t, err)
+
+		have := make([]api.RepoID, 0, len(schedules))
+		for _, s := range schedules {
+			have = append(have, s.RepoID)
+		}
+		require.Equal(t, []api.RepoID{repos[1].ID, repos[0].ID, repos[2].ID}, have)
+
+		require.Equal(t, repos[1].Name, schedules[0].RepoName)
+		require.Equal(t, time.Minute, schedules[0].Interval)
+		require.Equal(t, now.Add(time.Minute), schedules[0].DueAt.UTC())
+		require.Equal(t, now, schedules[0].LastFetched.UTC())
+		require.Equal(t, &high, schedules[0].Priority)
+
+		require.True(t, schedules[1].LastFetched.IsZero())
+		require.Nil(t, schedules[1].Priority)
+	})
+
+	t.Run("GetByRepoID", func(t *testing.T) {
+		sched, err := store.GetByRepoID(ctx, repos[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, time.Hour, sched.Interval)
+		require.Equal(t, 1, sched.Index)
+		require.Equal(t, 3, sched.Total)
+
+		_, err = store.GetByRepoID(ctx, 1000)
+		require.True(t, errcode.IsNotFound(err))
+	})
+
+	t.Run("Upsert updates existing schedules", func(t *testing.T) {
+		// A zero LastFetched doesn't overwrite the previous value.
+		require.NoError(t, store.Upsert(ctx, &RepoUpdateSchedule{RepoID: repos[1].ID, Interval: 3 * time.Hour, DueAt: now.Add(3 * time.Hour)}))
+
+		sched, err := store.GetByRepoID(ctx, repos[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, 3*time.Hour, sched.Interval)
+		require.Equal(t, now, sched.LastFetched.UTC())
+		require.Nil(t, sched.Priority)
+		require.Equal(t, 2, sched.Index)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, repos[0].ID, repos[2].ID))
+
+		schedules, err := store.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		require.Equal(t, repos[1].ID, schedules[0].RepoID)
+	})
+
+	t.Run("soft-deleted repos are excluded", func(t *testing.T) {
+		require.NoError(t, db.Repos().Delete(ctx, repos[1].ID))
+
+		schedules, err := store.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, schedules)
+	})
+}
@@ -0,0 +1,437 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SavedSearchSchedule is the schedule on which a saved search is run to
+// detect added and removed results.
+type SavedSearchSchedule struct {
+	SavedSearchID   int32
+	IntervalMinutes int32
+	NextRunAt       time.Time
+}
+
+// SavedSearchResult is a single result of a scheduled saved search run, as
+// stored between runs.
+type SavedSearchResult struct {
+	// Key identifies the result across runs. Two results with the same key
+	// are considered to be the same result.
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	Repo string `json:"repo"`
+	Path string `json:"path,omitempty"`
+	// Commit is the commit hash of commit and diff results.
+	Commit string `json:"commit,omitempty"`
+	// Preview is a short human-readable excerpt of the result, such as the
+	// matched line or the commit message subject.
+	Preview string `json:"preview,omitempty"`
+	// URL is the URL of the result, relative to the Sourcegraph external URL.
+	URL string `json:"url"`
+}
+
+// SavedSearchRun is a single run of a scheduled saved search.
+type SavedSearchRun struct {
+	ID              int
+	SavedSearchID   int32
+	QueryString     *string
+	Results         []SavedSearchResult
+	AddedResults    []SavedSearchResult
+	RemovedResults  []SavedSearchResult
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt time.Time
+	ExecutionLogs   []workerutil.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+}
+
+func (r *SavedSearchRun) RecordID() int { return r.ID }
+
+// SavedSearchSubscription is a recipient notified when the results of a
+// scheduled saved search change. Exactly one of UserID, SlackWebhookURL and
+// WebhookURL is set.
+type SavedSearchSubscription struct {
+	ID              int32
+	SavedSearchID   int32
+	UserID          *int32
+	SlackWebhookURL *string
+	WebhookURL      *string
+	IncludeResults  bool
+	CreatedAt       time.Time
+}
+
+// SavedSearchRunStore stores the schedules, runs and subscriptions of
+// scheduled saved searches.
+type SavedSearchRunStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) SavedSearchRunStore
+	Transact(context.Context) (SavedSearchRunStore, error)
+	Done(error) error
+
+	// GetSchedule returns the schedule of the saved search, or nil if the
+	// saved search is not scheduled.
+	GetSchedule(ctx context.Context, savedSearchID int32) (*SavedSearchSchedule, error)
+
+	// SetSchedule schedules the saved search to run every intervalMinutes
+	// minutes, starting now.
+	SetSchedule(ctx context.Context, savedSearchID, intervalMinutes int32) (*SavedSearchSchedule, error)
+
+	// DeleteSchedule stops running the saved search on a schedule. The
+	// previous runs and the subscriptions are kept.
+	DeleteSchedule(ctx context.Context, savedSearchID int32) error
+
+	// EnqueueScheduledRuns queues a run for each user-owned saved search whose
+	// schedule is due and which has no run queued or in progress, and moves
+	// the schedules to their next run.
+	EnqueueScheduledRuns(ctx context.Context) ([]*SavedSearchRun, error)
+
+	// ListRuns returns the most recent runs of the saved search, newest first.
+	ListRuns(ctx context.Context, savedSearchID int32, limit int) ([]*SavedSearchRun, error)
+
+	// GetPreviousCompletedRun returns the most recent completed run of the
+	// saved search which was queued before the given run, or nil if there is
+	// none.
+	GetPreviousCompletedRun(ctx context.Context, savedSearchID int32, runID int) (*SavedSearchRun, error)
+
+	// UpdateRunResults records the query that was run along with its results
+	// and how they differ from the previous completed run.
+	UpdateRunResults(ctx context.Context, runID int, queryString string, results, added, removed []SavedSearchResult) error
+
+	// DeleteOldRuns deletes the finished runs older than the retention
+	// period, except for the latest completed run of every saved search,
+	// which subsequent runs are compared to.
+	DeleteOldRuns(ctx context.Context, retentionInDays int) error
+
+	CreateSubscription(ctx context.Context, sub *SavedSearchSubscription) (*SavedSearchSubscription, error)
+	GetSubscription(ctx context.Context, id int32) (*SavedSearchSubscription, error)
+	DeleteSubscription(ctx context.Context, id int32) error
+	ListSubscriptions(ctx context.Context, savedSearchID int32) ([]*SavedSearchSubscription, error)
+}
+
+type savedSearchRunStore struct {
+	*basestore.Store
+}
+
+var _ SavedSearchRunStore = (*savedSearchRunStore)(nil)
+
+// SavedSearchRunsWith instantiates and returns a new SavedSearchRunStore
+// using the other store handle.
+func SavedSearchRunsWith(other basestore.ShareableStore) SavedSearchRunStore {
+	return &savedSearchRunStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *savedSearchRunStore) With(other basestore.ShareableStore) SavedSearchRunStore {
+	return &savedSearchRunStore{Store: s.Store.With(other)}
+}
+
+func (s *savedSearchRunStore) Transact(ctx context.Context) (SavedSearchRunStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &savedSearchRunStore{Store: txBase}, err
+}
+
+func (s *savedSearchRunStore) Done(err error) error {
+	return s.Store.Done(err)
+}
+
+const getSavedSearchScheduleFmtstr = `
+SELECT saved_search_id, interval_minutes, next_run_at
+FROM saved_search_schedules
+WHERE saved_search_id = %s
+`
+
+func (s *savedSearchRunStore) GetSchedule(ctx context.Context, savedSearchID int32) (*SavedSearchSchedule, error) {
+	schedule, err := scanSavedSearchSchedule(s.QueryRow(ctx, sqlf.Sprintf(getSavedSearchScheduleFmtstr, savedSearchID)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return schedule, err
+}
+
+const setSavedSearchScheduleFmtstr = `
+INSERT INTO saved_search_schedules (saved_search_id, interval_minutes)
+VALUES (%s, %s)
+ON CONFLICT (saved_search_id) DO UPDATE
+SET
+	interval_minutes = EXCLUDED.interval_minutes,
+	next_run_at = now()
+RETURNING saved_search_id, interval_minutes, next_run_at
+`
+
+func (s *savedSearchRunStore) SetSchedule(ctx context.Context, savedSearchID, intervalMinutes int32) (*SavedSearchSchedule, error) {
+	return scanSavedSearchSchedule(s.QueryRow(ctx, sqlf.Sprintf(setSavedSearchScheduleFmtstr, savedSearchID, intervalMinutes)))
+}
+
+func (s *savedSearchRunStore) DeleteSchedule(ctx context.Context, savedSearchID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_schedules WHERE saved_search_id = %s`, savedSearchID))
+}
+
+func scanSavedSearchSchedule(sc dbutil.Scanner) (*SavedSearchSchedule, error) {
+	var schedule SavedSearchSchedule
+	if err := sc.Scan(&schedule.SavedSearchID, &schedule.IntervalMinutes, &schedule.NextRunAt); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+const enqueueScheduledSavedSearchRunsFmtstr = `
+WITH due AS (
+	SELECT sch.saved_search_id
+	FROM saved_search_schedules sch
+	JOIN saved_searches ss ON ss.id = sch.saved_search_id
+	WHERE sch.next_run_at <= now()
+	AND ss.user_id IS NOT NULL
+	AND NOT EXISTS (
+		SELECT 1 FROM saved_search_runs r
+		WHERE r.saved_search_id = sch.saved_search_id
+		AND r.state IN ('queued', 'processing')
+	)
+	FOR UPDATE OF sch SKIP LOCKED
+),
+rescheduled AS (
+	UPDATE saved_search_schedules sch
+	SET next_run_at = now() + (sch.interval_minutes * interval '1 minute')
+	FROM due
+	WHERE sch.saved_search_id = due.saved_search_id
+)
+INSERT INTO saved_search_runs (saved_search_id)
+SELECT saved_search_id FROM due
+RETURNING %s
+`
+
+func (s *savedSearchRunStore) EnqueueScheduledRuns(ctx context.Context) ([]*SavedSearchRun, error) {
+	return scanSavedSearchRuns(s.Query(ctx, sqlf.Sprintf(enqueueScheduledSavedSearchRunsFmtstr, sqlf.Join(SavedSearchRunColumns, ", "))))
+}
+
+const listSavedSearchRunsFmtstr = `
+SELECT %s
+FROM saved_search_runs
+WHERE saved_search_id = %s
+ORDER BY id DESC
+LIMIT %s
+`
+
+func (s *savedSearchRunStore) ListRuns(ctx context.Context, savedSearchID int32, limit int) ([]*SavedSearchRun, error) {
+	return scanSavedSearchRuns(s.Query(ctx, sqlf.Sprintf(listSavedSearchRunsFmtstr, sqlf.Join(SavedSearchRunColumns, ", "), savedSearchID, limit)))
+}
+
+const getPreviousCompletedSavedSearchRunFmtstr = `
+SELECT %s
+FROM saved_search_runs
+WHERE saved_search_id = %s AND id < %s AND state = 'completed'
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (s *savedSearchRunStore) GetPreviousCompletedRun(ctx context.Context, savedSearchID int32, runID int) (*SavedSearchRun, error) {
+	run, err := ScanSavedSearchRun(s.QueryRow(ctx, sqlf.Sprintf(getPreviousCompletedSavedSearchRunFmtstr, sqlf.Join(SavedSearchRunColumns, ", "), savedSearchID, runID)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return run, err
+}
+
+const updateSavedSearchRunResultsFmtstr = `
+UPDATE saved_search_runs
+SET query_string = %s, results = %s, added_results = %s, removed_results = %s
+WHERE id = %s
+`
+
+func (s *savedSearchRunStore) UpdateRunResults(ctx context.Context, runID int, queryString string, results, added, removed []SavedSearchResult) error {
+	var raw [3][]byte
+	for i, rs := range [][]SavedSearchResult{results, added, removed} {
+		if rs == nil {
+			rs = []SavedSearchResult{}
+		}
+		b, err := json.Marshal(rs)
+		if err != nil {
+			return err
+		}
+		raw[i] = b
+	}
+	return s.Exec(ctx, sqlf.Sprintf(updateSavedSearchRunResultsFmtstr, queryString, raw[0], raw[1], raw[2], runID))
+}
+
+const deleteOldSavedSearchRunsFmtstr = `
+DELETE FROM saved_search_runs r
+WHERE r.finished_at < now() - (%s * interval '1 day')
+AND r.id <> COALESCE((
+	SELECT MAX(latest.id) FROM saved_search_runs latest
+	WHERE latest.saved_search_id = r.saved_search_id AND latest.state = 'completed'
+), 0)
+`
+
+func (s *savedSearchRunStore) DeleteOldRuns(ctx context.Context, retentionInDays int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteOldSavedSearchRunsFmtstr, retentionInDays))
+}
+
+// SavedSearchRunColumns are the columns scanned by ScanSavedSearchRun.
+var SavedSearchRunColumns = []*sqlf.Query{
+	sqlf.Sprintf("saved_search_runs.id"),
+	sqlf.Sprintf("saved_search_runs.saved_search_id"),
+	sqlf.Sprintf("saved_search_runs.query_string"),
+	sqlf.Sprintf("saved_search_runs.results"),
+	sqlf.Sprintf("saved_search_runs.added_results"),
+	sqlf.Sprintf("saved_search_runs.removed_results"),
+	sqlf.Sprintf("saved_search_runs.state"),
+	sqlf.Sprintf("saved_search_runs.failure_message"),
+	sqlf.Sprintf("saved_search_runs.queued_at"),
+	sqlf.Sprintf("saved_search_runs.started_at"),
+	sqlf.Sprintf("saved_search_runs.finished_at"),
+	sqlf.Sprintf("saved_search_runs.process_after"),
+	sqlf.Sprintf("saved_search_runs.num_resets"),
+	sqlf.Sprintf("saved_search_runs.num_failures"),
+	sqlf.Sprintf("saved_search_runs.last_heartbeat_at"),
+	sqlf.Sprintf("saved_search_runs.execution_logs"),
+	sqlf.Sprintf("saved_search_runs.worker_hostname"),
+	sqlf.Sprintf("saved_search_runs.cancel"),
+}
+
+func ScanSavedSearchRun(sc dbutil.Scanner) (*SavedSearchRun, error) {
+	var (
+		run                     SavedSearchRun
+		results, added, removed []byte
+		executionLogs           []dbworkerstore.ExecutionLogEntry
+	)
+	if err := sc.Scan(
+		&run.ID,
+		&run.SavedSearchID,
+		&run.QueryString,
+		&results,
+		&added,
+		&removed,
+		&run.State,
+		&run.FailureMessage,
+		&run.QueuedAt,
+		&run.StartedAt,
+		&run.FinishedAt,
+		&run.ProcessAfter,
+		&run.NumResets,
+		&run.NumFailures,
+		&dbutil.NullTime{Time: &run.LastHeartbeatAt},
+		pq.Array(&executionLogs),
+		&run.WorkerHostname,
+		&run.Cancel,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, r := range []struct {
+		raw []byte
+		dst *[]SavedSearchResult
+	}{
+		{results, &run.Results},
+		{added, &run.AddedResults},
+		{removed, &run.RemovedResults},
+	} {
+		if len(r.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(r.raw, r.dst); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range executionLogs {
+		run.ExecutionLogs = append(run.ExecutionLogs, workerutil.ExecutionLogEntry(entry))
+	}
+	return &run, nil
+}
+
+var scanSavedSearchRuns = basestore.NewSliceScanner(ScanSavedSearchRun)
+
+const savedSearchSubscriptionColumns = `id, saved_search_id, user_id, slack_webhook_url, webhook_url, include_results, created_at`
+
+const createSavedSearchSubscriptionFmtstr = `
+INSERT INTO saved_search_subscriptions (saved_search_id, user_id, slack_webhook_url, webhook_url, include_results)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING ` + savedSearchSubscriptionColumns
+
+func (s *savedSearchRunStore) CreateSubscription(ctx context.Context, sub *SavedSearchSubscription) (*SavedSearchSubscription, error) {
+	return scanSavedSearchSubscription(s.QueryRow(ctx, sqlf.Sprintf(
+		createSavedSearchSubscriptionFmtstr,
+		sub.SavedSearchID,
+		sub.UserID,
+		sub.SlackWebhookURL,
+		sub.WebhookURL,
+		sub.IncludeResults,
+	)))
+}
+
+const getSavedSearchSubscriptionFmtstr = `
+SELECT ` + savedSearchSubscriptionColumns + `
+FROM saved_search_subscriptions
+WHERE id = %s
+`
+
+func (s *savedSearchRunStore) GetSubscription(ctx context.Context, id int32) (*SavedSearchSubscription, error) {
+	sub, err := scanSavedSearchSubscription(s.QueryRow(ctx, sqlf.Sprintf(getSavedSearchSubscriptionFmtstr, id)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &errSavedSearchSubscriptionNotFound{id: id}
+	}
+	return sub, err
+}
+
+func (s *savedSearchRunStore) DeleteSubscription(ctx context.Context, id int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM saved_search_subscriptions WHERE id = %s`, id))
+}
+
+const listSavedSearchSubscriptionsFmtstr = `
+SELECT ` + savedSearchSubscriptionColumns + `
+FROM saved_search_subscriptions
+WHERE saved_search_id = %s
+ORDER BY id
+`
+
+func (s *savedSearchRunStore) ListSubscriptions(ctx context.Context, savedSearchID int32) ([]*SavedSearchSubscription, error) {
+	return scanSavedSearchSubscriptions(s.Query(ctx, sqlf.Sprintf(listSavedSearchSubscriptionsFmtstr, savedSearchID)))
+}
+
+func scanSavedSearchSubscription(sc dbutil.Scanner) (*SavedSearchSubscription, error) {
+	var sub SavedSearchSubscription
+	if err := sc.Scan(
+		&sub.ID,
+		&sub.SavedSearchID,
+		&sub.UserID,
+		&sub.SlackWebhookURL,
+		&sub.WebhookURL,
+		&sub.IncludeResults,
+		&sub.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+var scanSavedSearchSubscriptions = basestore.NewSliceScanner(scanSavedSearchSubscription)
+
+type errSavedSearchSubscriptionNotFound struct {
+	id int32
+}
+
+func (e *errSavedSearchSubscriptionNotFound) Error() string {
+	return fmt.Sprintf("saved search subscription %d not found", e.id)
+}
+
+func (e *errSavedSearchSubscriptionNotFound) NotFound() bool {
+	return true
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSavedSearchRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "alice"})
+	require.NoError(t, err)
+	org, err := db.Orgs().Create(ctx, "acme", nil)
+	require.NoError(t, err)
+
+	userSearch, err := db.SavedSearches().Create(ctx, &types.SavedSearch{Description: "mine", Query: "TODO patternType:literal", UserID: &user.ID})
+	require.NoError(t, err)
+	orgSearch, err := db.SavedSearches().Create(ctx, &types.SavedSearch{Description: "ours", Query: "FIXME patternType:literal", OrgID: &org.ID})
+	require.NoError(t, err)
+
+	store := db.SavedSearchRuns()
+
+	schedule, err := store.GetSchedule(ctx, userSearch.ID)
+	require.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	schedule, err = store.SetSchedule(ctx, userSearch.ID, 60)
+	require.NoError(t, err)
+	assert.Equal(t, int32(60), schedule.IntervalMinutes)
+	_, err = store.SetSchedule(ctx, orgSearch.ID, 60)
+	require.NoError(t, err)
+
+	// Only the user-owned saved search is enqueued, and only once while its
+	// run is queued.
+	runs, err := store.EnqueueScheduledRuns(ctx)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, userSearch.ID, runs[0].SavedSearchID)
+	assert.Equal(t, "queued", runs[0].State)
+
+	require.NoError(t, basestore.NewWithHandle(db.Handle()).Exec(ctx, sqlf.Sprintf("UPDATE saved_search_schedules SET next_run_at = now()")))
+	runs, err = store.EnqueueScheduledRuns(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	schedule, err = store.GetSchedule(ctx, userSearch.ID)
+	require.NoError(t, err)
+	assert.True(t, schedule.NextRunAt.After(time.Now()))
+
+	t.Run("results", func(t *testing.T) {
+		listed, err := store.ListRuns(ctx, userSearch.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		first := listed[0]
+
+		results := []SavedSearchResult{{Key: "a", Type: "repo", Repo: "github.com/a/b", URL: "/github.com/a/b"}}
+		require.NoError(t, store.UpdateRunResults(ctx, first.ID, "TODO patternType:literal", results, nil, nil))
+		require.NoError(t, basestore.NewWithHandle(db.Handle()).Exec(ctx, sqlf.Sprintf("UPDATE saved_search_runs SET state = 'completed', finished_at = now() WHERE id = %s", first.ID)))
+
+		previous, err := store.GetPreviousCompletedRun(ctx, userSearch.ID, first.ID)
+		require.NoError(t, err)
+		assert.Nil(t, previous)
+
+		previous, err = store.GetPreviousCompletedRun(ctx, userSearch.ID, first.ID+1)
+		require.NoError(t, err)
+		require.NotNil(t, previous)
+		assert.Equal(t, results, previous.Results)
+		assert.Empty(t, previous.AddedResults)
+
+		// The latest completed run is never deleted, since the next run is
+		// compared to it.
+		require.NoError(t, store.DeleteOldRuns(ctx, -1))
+		listed, err = store.ListRuns(ctx, userSearch.ID, 10)
+		require.NoError(t, err)
+		assert.Len(t, listed, 1)
+	})
+
+	t.Run("subscriptions", func(t *testing.T) {
+		url := "https://example.com/hook"
+		sub, err := store.CreateSubscription(ctx, &SavedSearchSubscription{SavedSearchID: userSearch.ID, WebhookURL: &url, IncludeResults: true})
+		require.NoError(t, err)
+		_, err = store.CreateSubscription(ctx, &SavedSearchSubscription{SavedSearchID: userSearch.ID, UserID: &user.ID})
+		require.NoError(t, err)
+
+		// A subscription must have exactly one target.
+		_, err = store.CreateSubscription(ctx, &SavedSearchSubscription{SavedSearchID: userSearch.ID, UserID: &user.ID, WebhookURL: &url})
+		require.Error(t, err)
+
+		got, err := store.GetSubscription(ctx, sub.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sub, got)
+
+		subs, err := store.ListSubscriptions(ctx, userSearch.ID)
+		require.NoError(t, err)
+		assert.Len(t, subs, 2)
+
+		require.NoError(t, store.DeleteSubscription(ctx, sub.ID))
+		_, err = store.GetSubscription(ctx, sub.ID)
+		require.Error(t, err)
+	})
+
+	require.NoError(t, store.DeleteSchedule(ctx, userSearch.ID))
+	schedule, err = store.GetSchedule(ctx, userSearch.ID)
+	require.NoError(t, err)
+	assert.Nil(t, schedule)
+}
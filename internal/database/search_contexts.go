@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -37,10 +38,15 @@ type SearchContextsStore interface {
 	GetSearchContextRepositoryRevisions(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
 	ListSearchContexts(context.Context, ListSearchContextsPageOptions, ListSearchContextsOptions) ([]*types.SearchContext, error)
 	GetAllQueries(context.Context) ([]string, error)
+	GetAllQueryRevisionsForRepos(context.Context, []api.RepoID) (map[api.RepoID][]string, error)
+	GetAllUnevaluatedQueries(context.Context) ([]string, error)
 	SetSearchContextRepositoryRevisions(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
 	Transact(context.Context) (SearchContextsStore, error)
 	UpdateSearchContextWithRepositoryRevisions(context.Context, *types.SearchContext, []*types.SearchContextRepositoryRevisions) (*types.SearchContext, error)
 	SetUserDefaultSearchContextID(ctx context.Context, userID int32, searchContextID int64) error
+	ListSearchContextsWithStaleQueryRepositories(ctx context.Context, evaluatedBefore time.Time, limit int) ([]*types.SearchContext, error)
+	GetSearchContextQueryRepositoryRevisions(context.Context, int64) ([]*types.SearchContextRepositoryRevisions, error)
+	SetSearchContextQueryRepositoryRevisions(context.Context, int64, []*types.SearchContextRepositoryRevisions) error
 	GetDefaultSearchContextForCurrentUser(ctx context.Context) (*types.SearchContext, error)
 	CreateSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
 	DeleteSearchContextStarForUser(ctx context.Context, userID int32, searchContextID int64) error
@@ -91,6 +97,7 @@ const searchContextQueryFmtStr = `
 		NULL as namespace_org_id,
 		TIMESTAMP WITH TIME ZONE 'epoch' as updated_at, -- Timestamp is not used for global context, but we need to return something.
 		NULL as query,
+		NULL as query_repos_updated_at,
 		NULL as namespace_name,
 		NULL as namespace_username,
 		NULL as namespace_org_name,
@@ -107,6 +114,7 @@ const searchContextQueryFmtStr = `
 		sc.namespace_org_id as namespace_org_id,
 		sc.updated_at as updated_at,
 		sc.query as query,
+		sc.query_repos_updated_at as query_repos_updated_at,
 		COALESCE(u.username, o.name) as namespace_name,
 		u.username as namespace_username,
 		o.name as namespace_org_name,
@@ -132,6 +140,7 @@ SELECT
 	namespace_org_id,
 	updated_at,
 	query,
+	query_repos_updated_at,
 	namespace_username,
 	namespace_org_name,
 	user_default,
@@ -386,6 +395,8 @@ SET
 	description = %s,
 	public = %s,
 	query = %s,
+	-- Re-evaluate the repositories of the query if it changed
+	query_repos_updated_at = CASE WHEN query IS DISTINCT FROM %s THEN NULL ELSE query_repos_updated_at END,
 	updated_at = now()
 WHERE id = %d
 `
@@ -407,9 +418,94 @@ func (s *searchContextsStore) UpdateSearchContextWithRepositoryRevisions(ctx con
 	if err != nil {
 		return nil, err
 	}
+
+	if updatedSearchContext.QueryReposUpdatedAt.IsZero() {
+		// The query changed, so the repositories it resolved to are stale.
+		err = tx.Exec(ctx, sqlf.Sprintf("DELETE FROM search_context_query_repos WHERE search_context_id = %d", updatedSearchContext.ID))
+		if err != nil {
+			return nil, err
+		}
+	}
 	return updatedSearchContext, nil
 }
 
+const listSearchContextsWithStaleQueryRepositoriesFmtStr = `
+SELECT id, query, namespace_user_id, namespace_org_id
+FROM search_contexts
+WHERE
+	query IS NOT NULL
+	AND (query_repos_updated_at IS NULL OR query_repos_updated_at < %s)
+ORDER BY query_repos_updated_at NULLS FIRST, id
+LIMIT %s
+`
+
+// ListSearchContextsWithStaleQueryRepositories returns query-based search contexts
+// whose repositories have not been evaluated since evaluatedBefore, least recently
+// evaluated first. Only the ID, query and namespace of the contexts are set.
+func (s *searchContextsStore) ListSearchContextsWithStaleQueryRepositories(ctx context.Context, evaluatedBefore time.Time, limit int) (_ []*types.SearchContext, err error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("ListSearchContextsWithStaleQueryRepositories can only be accessed by an internal actor")
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listSearchContextsWithStaleQueryRepositoriesFmtStr, evaluatedBefore, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var out []*types.SearchContext
+	for rows.Next() {
+		sc := &types.SearchContext{}
+		if err := rows.Scan(
+			&sc.ID,
+			&sc.Query,
+			&dbutil.NullInt32{N: &sc.NamespaceUserID},
+			&dbutil.NullInt32{N: &sc.NamespaceOrgID},
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, sc)
+	}
+	return out, nil
+}
+
+// SetSearchContextQueryRepositoryRevisions replaces the repositories and revisions
+// that the query of the search context resolves to and marks them as evaluated now.
+func (s *searchContextsStore) SetSearchContextQueryRepositoryRevisions(ctx context.Context, searchContextID int64, repositoryRevisions []*types.SearchContextRepositoryRevisions) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	err = tx.Exec(ctx, sqlf.Sprintf("DELETE FROM search_context_query_repos WHERE search_context_id = %d", searchContextID))
+	if err != nil {
+		return err
+	}
+
+	values := []*sqlf.Query{}
+	for _, repoRev := range repositoryRevisions {
+		for _, revision := range repoRev.Revisions {
+			values = append(values, sqlf.Sprintf(
+				"(%s, %s, %s)",
+				searchContextID, repoRev.Repo.ID, revision,
+			))
+		}
+	}
+
+	if len(values) > 0 {
+		err = tx.Exec(ctx, sqlf.Sprintf(
+			"INSERT INTO search_context_query_repos (search_context_id, repo_id, revision) VALUES %s ON CONFLICT DO NOTHING",
+			sqlf.Join(values, ","),
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf("UPDATE search_contexts SET query_repos_updated_at = now() WHERE id = %d", searchContextID))
+}
+
 func (s *searchContextsStore) SetSearchContextRepositoryRevisions(ctx context.Context, searchContextID int64, repositoryRevisions []*types.SearchContextRepositoryRevisions) (err error) {
 	tx, err := s.Transact(ctx)
 	if err != nil {
@@ -470,6 +566,7 @@ func updateSearchContext(ctx context.Context, s SearchContextsStore, searchConte
 		searchContext.Description,
 		searchContext.Public,
 		dbutil.NullStringColumn(searchContext.Query),
+		dbutil.NullStringColumn(searchContext.Query),
 		searchContext.ID,
 	)
 	_, err := s.Handle().ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
@@ -508,6 +605,7 @@ func scanSearchContexts(rows *sql.Rows) ([]*types.SearchContext, error) {
 			&dbutil.NullInt32{N: &sc.NamespaceOrgID},
 			&sc.UpdatedAt,
 			&dbutil.NullString{S: &sc.Query},
+			&dbutil.NullTime{Time: &sc.QueryReposUpdatedAt},
 			&dbutil.NullString{S: &sc.NamespaceUserName},
 			&dbutil.NullString{S: &sc.NamespaceOrgName},
 			&sc.Default,
@@ -527,7 +625,7 @@ SELECT
 	sc.revision,
 	r.name
 FROM
-	search_context_repos sc
+	%s sc
 JOIN
 	(
 		SELECT
@@ -545,6 +643,16 @@ WHERE sc.search_context_id = %d
 `
 
 func (s *searchContextsStore) GetSearchContextRepositoryRevisions(ctx context.Context, searchContextID int64) ([]*types.SearchContextRepositoryRevisions, error) {
+	return s.getRepositoryRevisions(ctx, sqlf.Sprintf("search_context_repos"), searchContextID)
+}
+
+// GetSearchContextQueryRepositoryRevisions returns the repositories and revisions
+// that the query of the search context resolved to when it was last evaluated.
+func (s *searchContextsStore) GetSearchContextQueryRepositoryRevisions(ctx context.Context, searchContextID int64) ([]*types.SearchContextRepositoryRevisions, error) {
+	return s.getRepositoryRevisions(ctx, sqlf.Sprintf("search_context_query_repos"), searchContextID)
+}
+
+func (s *searchContextsStore) getRepositoryRevisions(ctx context.Context, table *sqlf.Query, searchContextID int64) (_ []*types.SearchContextRepositoryRevisions, err error) {
 	authzConds, err := AuthzQueryConds(ctx, NewDBWith(s.logger, s))
 	if err != nil {
 		return nil, err
@@ -552,6 +660,7 @@ func (s *searchContextsStore) GetSearchContextRepositoryRevisions(ctx context.Co
 
 	rows, err := s.Query(ctx, sqlf.Sprintf(
 		getSearchContextRepositoryRevisionsFmtStr,
+		table,
 		authzConds,
 		searchContextID,
 	))
@@ -606,19 +715,44 @@ ORDER BY
 	scr.revision
 `
 
+var getAllQueryRevisionsForReposFmtStr = `
+SELECT DISTINCT
+	scqr.repo_id,
+	scqr.revision
+FROM
+	search_context_query_repos scqr
+WHERE
+	scqr.repo_id = ANY (%s)
+ORDER BY
+	scqr.revision
+`
+
 // GetAllRevisionsForRepos returns the list of revisions that are used in search
 // contexts for each given repo ID.
 func (s *searchContextsStore) GetAllRevisionsForRepos(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
 	if a := actor.FromContext(ctx); !a.IsInternal() {
 		return nil, errors.New("GetAllRevisionsForRepos can only be accessed by an internal actor")
 	}
+	return s.getAllRevisionsForRepos(ctx, getAllRevisionsForReposFmtStr, repoIDs)
+}
 
+// GetAllQueryRevisionsForRepos returns the list of revisions that the queries of
+// query-based search contexts resolved to for each given repo ID, when they were
+// last evaluated.
+func (s *searchContextsStore) GetAllQueryRevisionsForRepos(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("GetAllQueryRevisionsForRepos can only be accessed by an internal actor")
+	}
+	return s.getAllRevisionsForRepos(ctx, getAllQueryRevisionsForReposFmtStr, repoIDs)
+}
+
+func (s *searchContextsStore) getAllRevisionsForRepos(ctx context.Context, fmtStr string, repoIDs []api.RepoID) (_ map[api.RepoID][]string, err error) {
 	if len(repoIDs) == 0 {
 		return map[api.RepoID][]string{}, nil
 	}
 
 	q := sqlf.Sprintf(
-		getAllRevisionsForReposFmtStr,
+		fmtStr,
 		pq.Array(repoIDs),
 	)
 
@@ -653,6 +787,19 @@ func (s *searchContextsStore) GetAllQueries(ctx context.Context) (qs []string, _
 	return qs, s.QueryRow(ctx, q).Scan(pq.Array(&qs))
 }
 
+// GetAllUnevaluatedQueries returns the queries of the query-based search contexts
+// whose repositories have not been evaluated since they were created or their
+// query last changed.
+func (s *searchContextsStore) GetAllUnevaluatedQueries(ctx context.Context) (qs []string, _ error) {
+	if a := actor.FromContext(ctx); !a.IsInternal() {
+		return nil, errors.New("GetAllUnevaluatedQueries can only be accessed by an internal actor")
+	}
+
+	q := sqlf.Sprintf(`SELECT array_agg(query) FROM search_contexts WHERE query IS NOT NULL AND query_repos_updated_at IS NULL`)
+
+	return qs, s.QueryRow(ctx, q).Scan(pq.Array(&qs))
+}
+
 // 🚨 SECURITY: The caller must ensure that the actor is the user setting the context as their default.
 func (s *searchContextsStore) SetUserDefaultSearchContextID(ctx context.Context, userID int32, searchContextID int64) error {
 	if searchContextID == 0 {
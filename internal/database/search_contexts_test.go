@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -427,6 +428,115 @@ func TestSearchContexts_CreateAndSetRepositoryRevisions(t *testing.T) {
 	}
 }
 
+func TestSearchContexts_QueryRepositoryRevisions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	t.Parallel()
+	ctx := actor.WithInternalActor(context.Background())
+	sc := db.SearchContexts()
+	r := db.Repos()
+
+	err := r.Create(ctx, &types.Repo{Name: "testA", URI: "https://example.com/a"}, &types.Repo{Name: "testB", URI: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	repoA, err := r.GetByName(ctx, "testA")
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	repoB, err := r.GetByName(ctx, "testB")
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	queryContext, err := sc.CreateSearchContextWithRepositoryRevisions(ctx, &types.SearchContext{Name: "query", Public: true, Query: "repo:test"}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	_, err = sc.CreateSearchContextWithRepositoryRevisions(ctx, &types.SearchContext{Name: "repos", Public: true}, []*types.SearchContextRepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: repoA.ID, Name: repoA.Name}, Revisions: []string{"HEAD"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	// Only the never evaluated query-based context is stale.
+	stale, err := sc.ListSearchContextsWithStaleQueryRepositories(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if len(stale) != 1 || stale[0].ID != queryContext.ID || stale[0].Query != "repo:test" {
+		t.Fatalf("unexpected stale search contexts %+v", stale)
+	}
+	unevaluated, err := sc.GetAllUnevaluatedQueries(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if !reflect.DeepEqual([]string{"repo:test"}, unevaluated) {
+		t.Fatalf("unexpected unevaluated queries %v", unevaluated)
+	}
+
+	queryRepositoryRevisions := []*types.SearchContextRepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: repoA.ID, Name: repoA.Name}, Revisions: []string{"HEAD"}},
+		{Repo: types.MinimalRepo{ID: repoB.ID, Name: repoB.Name}, Revisions: []string{"HEAD", "main"}},
+	}
+	if err := sc.SetSearchContextQueryRepositoryRevisions(ctx, queryContext.ID, queryRepositoryRevisions); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	gotRepositoryRevisions, err := sc.GetSearchContextQueryRepositoryRevisions(ctx, queryContext.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if !reflect.DeepEqual(queryRepositoryRevisions, gotRepositoryRevisions) {
+		t.Fatalf("wanted %v query repository revisions, got %v", queryRepositoryRevisions, gotRepositoryRevisions)
+	}
+
+	revs, err := sc.GetAllQueryRevisionsForRepos(ctx, []api.RepoID{repoA.ID, repoB.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	wantRevs := map[api.RepoID][]string{repoA.ID: {"HEAD"}, repoB.ID: {"HEAD", "main"}}
+	if !reflect.DeepEqual(wantRevs, revs) {
+		t.Fatalf("wanted %v query revisions, got %v", wantRevs, revs)
+	}
+	unevaluated, err = sc.GetAllUnevaluatedQueries(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if len(unevaluated) != 0 {
+		t.Fatalf("expected no unevaluated queries, got %v", unevaluated)
+	}
+
+	got, err := sc.GetSearchContext(ctx, GetSearchContextOptions{Name: "query"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if got.QueryReposUpdatedAt.IsZero() {
+		t.Fatal("expected query repositories to be marked as evaluated")
+	}
+
+	stale, err = sc.ListSearchContextsWithStaleQueryRepositories(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if len(stale) != 0 {
+		t.Fatalf("expected no stale search contexts, got %+v", stale)
+	}
+
+	// Changing the query invalidates the evaluated repositories.
+	got.Query = "repo:testA"
+	if _, err := sc.UpdateSearchContextWithRepositoryRevisions(ctx, got, nil); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	gotRepositoryRevisions, err = sc.GetSearchContextQueryRepositoryRevisions(ctx, queryContext.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if len(gotRepositoryRevisions) != 0 {
+		t.Fatalf("expected query repository revisions to be reset, got %v", gotRepositoryRevisions)
+	}
+}
+
 func TestSearchContexts_Permissions(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := NewDB(logger, dbtest.NewDB(logger, t))
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// SearchExportJobStore stores the background jobs exporting search results.
+type SearchExportJobStore interface {
+	basestore.ShareableStore
+	With(basestore.ShareableStore) SearchExportJobStore
+
+	// Create enqueues an export of the results of the query by the user.
+	Create(ctx context.Context, userID int32, query, format string) (*types.SearchExportJob, error)
+	// GetByID returns the export job with the given ID.
+	GetByID(ctx context.Context, id int64) (*types.SearchExportJob, error)
+	// MarkExported records the key under which the results of the job were
+	// uploaded and the number of exported rows.
+	MarkExported(ctx context.Context, id int64, objectKey string, resultCount int) error
+	// DeleteFinishedBefore deletes the jobs that finished before the given
+	// time. Their exports are expired separately in the upload store.
+	DeleteFinishedBefore(ctx context.Context, before time.Time) error
+}
+
+type SearchExportJobNotFoundErr struct{ id int64 }
+
+func (err SearchExportJobNotFoundErr) Error() string {
+	return fmt.Sprintf("search export job not found: %v", err.id)
+}
+
+func (SearchExportJobNotFoundErr) NotFound() bool { return true }
+
+type searchExportJobStore struct {
+	*basestore.Store
+}
+
+func SearchExportJobsWith(other basestore.ShareableStore) SearchExportJobStore {
+	return &searchExportJobStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *searchExportJobStore) With(other basestore.ShareableStore) SearchExportJobStore {
+	return &searchExportJobStore{Store: s.Store.With(other)}
+}
+
+func (s *searchExportJobStore) Create(ctx context.Context, userID int32, query, format string) (*types.SearchExportJob, error) {
+	q := sqlf.Sprintf(
+		searchExportJobCreateQueryFmtstr,
+		userID,
+		query,
+		format,
+		sqlf.Join(SearchExportJobColumns, ","),
+	)
+
+	return ScanSearchExportJob(s.QueryRow(ctx, q))
+}
+
+func (s *searchExportJobStore) GetByID(ctx context.Context, id int64) (*types.SearchExportJob, error) {
+	q := sqlf.Sprintf(
+		searchExportJobGetByIDQueryFmtstr,
+		sqlf.Join(SearchExportJobColumns, ","),
+		id,
+	)
+
+	job, err := ScanSearchExportJob(s.QueryRow(ctx, q))
+	if err == sql.ErrNoRows {
+		return nil, SearchExportJobNotFoundErr{id}
+	}
+	return job, err
+}
+
+func (s *searchExportJobStore) MarkExported(ctx context.Context, id int64, objectKey string, resultCount int) error {
+	return s.Exec(ctx, sqlf.Sprintf(searchExportJobMarkExportedQueryFmtstr, objectKey, resultCount, id))
+}
+
+func (s *searchExportJobStore) DeleteFinishedBefore(ctx context.Context, before time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(searchExportJobDeleteFinishedBeforeQueryFmtstr, before))
+}
+
+// ScanSearchExportJob scans a search export job selected with
+// SearchExportJobColumns.
+func ScanSearchExportJob(sc dbutil.Scanner) (*types.SearchExportJob, error) {
+	var (
+		job           types.SearchExportJob
+		executionLogs []store.ExecutionLogEntry
+	)
+
+	if err := sc.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.Query,
+		&job.Format,
+		&job.CreatedAt,
+		&job.ResultCount,
+		&job.ObjectKey,
+		&job.State,
+		&job.FailureMessage,
+		&job.QueuedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+		&dbutil.NullTime{Time: &job.LastHeartbeatAt},
+		pq.Array(&executionLogs),
+		&job.WorkerHostname,
+		&job.Cancel,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range executionLogs {
+		job.ExecutionLogs = append(job.ExecutionLogs, workerutil.ExecutionLogEntry(entry))
+	}
+
+	return &job, nil
+}
+
+var SearchExportJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("query"),
+	sqlf.Sprintf("format"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("result_count"),
+	sqlf.Sprintf("object_key"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("queued_at"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("last_heartbeat_at"),
+	sqlf.Sprintf("execution_logs"),
+	sqlf.Sprintf("worker_hostname"),
+	sqlf.Sprintf("cancel"),
+}
+
+const searchExportJobCreateQueryFmtstr = `
+-- source: internal/database/search_export_jobs.go:Create
+INSERT INTO
+	search_export_jobs (
+		user_id,
+		query,
+		format
+	)
+VALUES (%s, %s, %s)
+RETURNING %s
+`
+
+const searchExportJobGetByIDQueryFmtstr = `
+-- source: internal/database/search_export_jobs.go:GetByID
+SELECT
+	%s
+FROM
+	search_export_jobs
+WHERE
+	id = %s
+`
+
+const searchExportJobMarkExportedQueryFmtstr = `
+-- source: internal/database/search_export_jobs.go:MarkExported
+UPDATE
+	search_export_jobs
+SET
+	object_key = %s,
+	result_count = %s
+WHERE
+	id = %s
+`
+
+const searchExportJobDeleteFinishedBeforeQueryFmtstr = `
+-- source: internal/database/search_export_jobs.go:DeleteFinishedBefore
+DELETE FROM
+	search_export_jobs
+WHERE
+	finished_at < %s
+`
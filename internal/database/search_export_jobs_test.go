@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestSearchExportJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u"})
+	require.NoError(t, err)
+
+	store := db.SearchExportJobs()
+
+	job, err := store.Create(ctx, user.ID, "foo count:all", "csv")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, job.UserID)
+	assert.Equal(t, "foo count:all", job.Query)
+	assert.Equal(t, "csv", job.Format)
+	assert.Equal(t, "queued", job.State)
+	assert.Nil(t, job.ObjectKey)
+
+	_, err = store.GetByID(ctx, job.ID+1)
+	assert.True(t, errcode.IsNotFound(err))
+
+	require.NoError(t, store.MarkExported(ctx, job.ID, "search-exports/1.csv", 3))
+	job, err = store.GetByID(ctx, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, job.ObjectKey)
+	assert.Equal(t, "search-exports/1.csv", *job.ObjectKey)
+	assert.Equal(t, 3, job.ResultCount)
+
+	unfinished, err := store.Create(ctx, user.ID, "bar", "jsonl")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, "UPDATE search_export_jobs SET state = 'completed', finished_at = NOW() - INTERVAL '2 days' WHERE id = $1", job.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteFinishedBefore(ctx, time.Now().Add(-24*time.Hour)))
+
+	_, err = store.GetByID(ctx, job.ID)
+	assert.True(t, errcode.IsNotFound(err))
+	_, err = store.GetByID(ctx, unfinished.ID)
+	assert.NoError(t, err)
+}
@@ -16,17 +16,22 @@ import (
 var userRoleInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("user_id"),
 	sqlf.Sprintf("role_id"),
+	sqlf.Sprintf("org_id"),
 }
 
 var userRoleColumns = []*sqlf.Query{
 	sqlf.Sprintf("user_roles.user_id"),
 	sqlf.Sprintf("user_roles.role_id"),
+	sqlf.Sprintf("user_roles.org_id"),
 	sqlf.Sprintf("user_roles.created_at"),
 }
 
 type UserRoleOpts struct {
 	UserID int32
 	RoleID int32
+	// OrgID scopes the user role to an organization. A zero value refers to a
+	// global user role.
+	OrgID int32
 }
 
 type (
@@ -77,6 +82,7 @@ const userRoleCreateQueryFmtStr = `
 INSERT INTO
 	user_roles (%s)
 VALUES (
+	%s,
 	%s,
 	%s
 )
@@ -97,6 +103,7 @@ func (r *userRoleStore) Create(ctx context.Context, opts CreateUserRoleOpts) (*t
 		sqlf.Join(userRoleInsertColumns, ", "),
 		opts.UserID,
 		opts.RoleID,
+		dbutil.NullInt32Column(opts.OrgID),
 		sqlf.Join(userRoleColumns, ", "),
 	)
 
@@ -136,7 +143,7 @@ func (r *userRoleStore) Delete(ctx context.Context, opts DeleteUserRoleOpts) err
 
 	q := sqlf.Sprintf(
 		deleteUserRoleQueryFmtStr,
-		sqlf.Sprintf("user_id = %s AND role_id = %s", opts.UserID, opts.RoleID),
+		sqlf.Sprintf("user_id = %s AND role_id = %s AND COALESCE(org_id, 0) = %s", opts.UserID, opts.RoleID, opts.OrgID),
 	)
 
 	result, err := r.ExecResult(ctx, q)
@@ -211,7 +218,7 @@ func (r *userRoleStore) GetByRoleIDAndUserID(ctx context.Context, opts GetUserRo
 		getUserRoleQueryFmtStr,
 		sqlf.Join(userRoleColumns, ", "),
 		sqlf.Sprintf("INNER JOIN users ON user_roles.user_id = users.id"),
-		sqlf.Sprintf("users.deleted_at IS NULL AND user_roles.role_id = %s AND user_roles.user_id = %s", opts.RoleID, opts.UserID),
+		sqlf.Sprintf("users.deleted_at IS NULL AND user_roles.role_id = %s AND user_roles.user_id = %s AND COALESCE(user_roles.org_id, 0) = %s", opts.RoleID, opts.UserID, opts.OrgID),
 	)
 
 	ur, err := scanUserRole(r.QueryRow(ctx, q))
@@ -230,6 +237,7 @@ func scanUserRole(sc dbutil.Scanner) (*types.UserRole, error) {
 	if err := sc.Scan(
 		&rm.UserID,
 		&rm.RoleID,
+		&dbutil.NullInt32{N: &rm.OrgID},
 		&rm.CreatedAt,
 	); err != nil {
 		return nil, err
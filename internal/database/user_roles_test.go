@@ -49,6 +49,50 @@ func TestUserRoleCreate(t *testing.T) {
 		assert.NotNil(t, ur)
 		assert.Equal(t, ur.RoleID, role.ID)
 		assert.Equal(t, ur.UserID, user.ID)
+		assert.Zero(t, ur.OrgID)
+	})
+
+	t.Run("scoped to an org", func(t *testing.T) {
+		org, err := db.Orgs().Create(ctx, "acme", nil)
+		assert.NoError(t, err)
+
+		ur, err := store.Create(ctx, CreateUserRoleOpts{
+			RoleID: role.ID,
+			UserID: user.ID,
+			OrgID:  org.ID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ur.OrgID, org.ID)
+
+		// The same role can't be assigned twice in the same org.
+		_, err = store.Create(ctx, CreateUserRoleOpts{
+			RoleID: role.ID,
+			UserID: user.ID,
+			OrgID:  org.ID,
+		})
+		assert.Error(t, err)
+
+		ur, err = store.GetByRoleIDAndUserID(ctx, GetUserRoleOpts{
+			RoleID: role.ID,
+			UserID: user.ID,
+			OrgID:  org.ID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ur.OrgID, org.ID)
+
+		err = store.Delete(ctx, DeleteUserRoleOpts{
+			RoleID: role.ID,
+			UserID: user.ID,
+			OrgID:  org.ID,
+		})
+		assert.NoError(t, err)
+
+		// The global assignment is unaffected.
+		_, err = store.GetByRoleIDAndUserID(ctx, GetUserRoleOpts{
+			RoleID: role.ID,
+			UserID: user.ID,
+		})
+		assert.NoError(t, err)
 	})
 }
 
@@ -0,0 +1,27 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// AccountData stores information of an Azure DevOps account.
+type AccountData struct {
+	// Descriptors maps an organization to the subject descriptor of the
+	// account in that organization. Azure DevOps assigns every user a
+	// different descriptor per organization.
+	Descriptors map[string]string `json:"descriptors"`
+	Email       string            `json:"email"`
+	DisplayName string            `json:"displayName"`
+}
+
+// GetExternalAccountData extracts account data for the external account.
+func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (*AccountData, error) {
+	if data.Data == nil {
+		return nil, nil
+	}
+
+	return encryption.DecryptJSON[AccountData](ctx, data.Data)
+}
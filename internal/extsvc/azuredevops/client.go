@@ -0,0 +1,216 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/oauthutil"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// apiVersion is the version of the Azure DevOps REST API used by this client.
+	// Both Azure DevOps Services and Azure DevOps Server 2022 support it.
+	apiVersion = "7.0"
+
+	// previewAPIVersion is used for the Graph and Profile APIs, which are still
+	// only available as previews.
+	previewAPIVersion = "7.0-preview.1"
+
+	// AzureDevOpsAPIURL is the base URL of Azure DevOps Services.
+	AzureDevOpsAPIURL = "https://dev.azure.com/"
+
+	// VisualStudioAppURL is the URL of the Visual Studio profile API, used to
+	// find out which user an authenticator belongs to on Azure DevOps Services.
+	VisualStudioAppURL = "https://app.vssps.visualstudio.com/"
+)
+
+// The metric generated here will be named as "src_azuredevops_requests_total".
+var requestCounter = metrics.NewRequestMeter("azuredevops", "Total number of requests sent to the Azure DevOps API.")
+
+// Client access an Azure DevOps code host via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API.
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Azure DevOps.
+	URL *url.URL
+
+	// auth is the authentication method used when accessing the server.
+	// auth.BasicAuth (username and personal access token) and
+	// auth.OAuthBearerToken are supported.
+	auth auth.Authenticator
+
+	// rateLimit is the self-imposed rate limiter (since Azure DevOps does not
+	// have a concept of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Azure DevOps API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The API path of the request mostly maps to the type of API request
+		// we are making, e.g. "git" or "graph".
+		parts := strings.Split(u.Path, "/")
+		for i, p := range parts {
+			if p == "_apis" && i+1 < len(parts) {
+				return parts[i+1]
+			}
+		}
+		return ""
+	})
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        extsvc.NormalizeBaseURL(u),
+		auth: &auth.BasicAuth{
+			Username: config.Username,
+			Password: config.Token,
+		},
+		rateLimit: ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// Authenticator returns the authenticator used by this client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTPClient, and RateLimiter as the current Client, except authenticated with
+// the given authenticator instance.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	switch a.(type) {
+	case *auth.BasicAuth, *auth.BasicAuthWithSSH, *auth.OAuthBearerToken, *auth.OAuthBearerTokenWithSSH:
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Azure DevOps clients: %T", a)
+	}
+
+	return &Client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		auth:       a,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
+// IsAzureDevOpsServices returns true if the client is configured to talk to the
+// hosted Azure DevOps Services offering, rather than to an Azure DevOps Server.
+func (c *Client) IsAzureDevOpsServices() bool {
+	return c.URL.String() == AzureDevOpsAPIURL
+}
+
+// graphURL returns the base URL of the Graph and Identities APIs for the given
+// organization. On Azure DevOps Services those live on a separate host.
+func (c *Client) graphURL(org string) *url.URL {
+	if c.IsAzureDevOpsServices() {
+		return &url.URL{Scheme: "https", Host: "vssps.dev.azure.com", Path: "/" + org + "/"}
+	}
+	return c.URL.ResolveReference(&url.URL{Path: org + "/"})
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+
+	queryParams := req.URL.Query()
+	if !queryParams.Has("api-version") {
+		queryParams.Set("api-version", apiVersion)
+		req.URL.RawQuery = queryParams.Encode()
+	}
+
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := oauthutil.DoRequest(ctx, nil, c.httpClient, req, c.auth)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps redirects unauthenticated requests to a sign in page
+	// rather than responding with a 401, so we have to treat non-JSON
+	// responses as authentication failures.
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return nil, &httpError{
+			URL:        req.URL,
+			StatusCode: http.StatusUnauthorized,
+			Body:       bs,
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, &httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		}
+	}
+
+	if result == nil {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(bs, result)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// HTTPErrorCode returns the HTTP status code of err, if err is an error
+// returned by an Azure DevOps API call, and 0 otherwise.
+func HTTPErrorCode(err error) int {
+	var e *httpError
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
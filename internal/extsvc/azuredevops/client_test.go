@@ -0,0 +1,153 @@
+package azuredevops
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+func TestClient_ListRepositoriesByProjectOrOrg(t *testing.T) {
+	cli, save := NewTestClient(t, "ListRepositoriesByProjectOrOrg", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	resp, err := cli.ListRepositoriesByProjectOrOrg(ctx, ListRepositoriesByProjectOrOrgArgs{
+		ProjectOrOrgName: "sgtestazure",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/ListRepositoriesByProjectOrOrg.json", *update, resp)
+}
+
+func TestClient_GetRepo(t *testing.T) {
+	cli, save := NewTestClient(t, "GetRepo", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	resp, err := cli.GetRepo(ctx, OrgProjectRepoArgs{
+		Org:          "sgtestazure",
+		Project:      "sgtestazure",
+		RepoNameOrID: "sgtestazure",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org, err := resp.Namespace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := org, "sgtestazure"; have != want {
+		t.Fatalf("unexpected namespace: have %q, want %q", have, want)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/GetRepo.json", *update, resp)
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "CreatePullRequest", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	resp, err := cli.CreatePullRequest(ctx, OrgProjectRepoArgs{
+		Org:          "sgtestazure",
+		Project:      "sgtestazure",
+		RepoNameOrID: "sgtestazure",
+	}, CreatePullRequestInput{
+		SourceRefName: "refs/heads/azure-devops-test",
+		TargetRefName: "refs/heads/master",
+		Title:         "This is a test PR",
+		Description:   "This is a description of a test PR",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/CreatePullRequest.json", *update, resp)
+}
+
+func TestClient_GetPullRequestStatuses(t *testing.T) {
+	cli, save := NewTestClient(t, "GetPullRequestStatuses", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	resp, err := cli.GetPullRequestStatuses(ctx, PullRequestCommonArgs{
+		PullRequestID: "42",
+		OrgProjectRepoArgs: OrgProjectRepoArgs{
+			Org:          "sgtestazure",
+			Project:      "sgtestazure",
+			RepoNameOrID: "sgtestazure",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/GetPullRequestStatuses.json", *update, resp)
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.Root().GetHandler()))
+	}
+	os.Exit(m.Run())
+}
+
+// NewTestClient returns an azuredevops.Client that records its interactions
+// to testdata/vcr/.
+func NewTestClient(t testing.TB, name string, update bool) (*Client, func()) {
+	t.Helper()
+
+	cassete := filepath.Join("testdata/vcr/", normalize(name))
+	rec, err := httptestutil.NewRecorder(cassete, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hc, err := httpcli.NewFactory(nil, httptestutil.NewRecorderOpt(rec)).Doer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &schema.AzureDevOpsConnection{
+		Url:      "https://dev.azure.com",
+		Username: os.Getenv("AZURE_DEV_OPS_USERNAME"),
+		Token:    os.Getenv("AZURE_DEV_OPS_TOKEN"),
+	}
+
+	cli, err := NewClient("urn", c, hc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cli, func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("failed to update test data: %s", err)
+		}
+	}
+}
+
+var normalizer = lazyregexp.New("[^A-Za-z0-9-]+")
+
+func normalize(path string) string {
+	return normalizer.ReplaceAllLiteralString(path, "-")
+}
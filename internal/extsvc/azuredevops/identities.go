@@ -0,0 +1,142 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GetAuthorizedProfile returns the profile of the user the client is
+// authenticated as. It can be used to validate the authenticator of the
+// client.
+func (c *Client) GetAuthorizedProfile(ctx context.Context) (Profile, error) {
+	if !c.IsAzureDevOpsServices() {
+		return c.getConnectionDataProfile(ctx)
+	}
+
+	qs := make(url.Values)
+	qs.Set("api-version", previewAPIVersion)
+	reqURL := url.URL{
+		Scheme:   "https",
+		Host:     "app.vssps.visualstudio.com",
+		Path:     "/_apis/profile/profiles/me",
+		RawQuery: qs.Encode(),
+	}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var p Profile
+	if _, err := c.do(ctx, req, &p); err != nil {
+		return Profile{}, err
+	}
+
+	return p, nil
+}
+
+// getConnectionDataProfile returns the authenticated user on Azure DevOps
+// Server, which doesn't support the profile API.
+func (c *Client) getConnectionDataProfile(ctx context.Context) (Profile, error) {
+	req, err := http.NewRequest("GET", "_apis/connectionData", nil)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var resp struct {
+		AuthenticatedUser IdentityRecord `json:"authenticatedUser"`
+	}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return Profile{}, err
+	}
+
+	return Profile{
+		ID:          resp.AuthenticatedUser.ID,
+		DisplayName: resp.AuthenticatedUser.ProviderDisplayName,
+	}, nil
+}
+
+// IdentityRecord is an identity as returned by the Identities API.
+type IdentityRecord struct {
+	ID                  string `json:"id"`
+	Descriptor          string `json:"descriptor"`
+	SubjectDescriptor   string `json:"subjectDescriptor"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	IsActive            bool   `json:"isActive"`
+}
+
+// ListIdentitiesByEmail returns the identities in the given organization that
+// have the given email address.
+func (c *Client) ListIdentitiesByEmail(ctx context.Context, org, email string) ([]IdentityRecord, error) {
+	qs := make(url.Values)
+	qs.Set("searchFilter", "MailAddress")
+	qs.Set("filterValue", email)
+	qs.Set("queryMembership", "None")
+	reqURL := c.graphURL(org).ResolveReference(&url.URL{Path: "_apis/identities", RawQuery: qs.Encode()})
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Value []IdentityRecord `json:"value"`
+		Count int              `json:"count"`
+	}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+// ListGroupMemberships returns the groups the subject with the given
+// descriptor is a direct member of.
+func (c *Client) ListGroupMemberships(ctx context.Context, org, subjectDescriptor string) ([]GraphMembership, error) {
+	qs := make(url.Values)
+	qs.Set("direction", "up")
+	qs.Set("api-version", previewAPIVersion)
+	reqURL := c.graphURL(org).ResolveReference(&url.URL{
+		Path:     fmt.Sprintf("_apis/graph/Memberships/%s", subjectDescriptor),
+		RawQuery: qs.Encode(),
+	})
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Value []GraphMembership `json:"value"`
+		Count int               `json:"count"`
+	}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+// GetGroup returns the group with the given descriptor.
+func (c *Client) GetGroup(ctx context.Context, org, descriptor string) (GraphGroup, error) {
+	qs := make(url.Values)
+	qs.Set("api-version", previewAPIVersion)
+	reqURL := c.graphURL(org).ResolveReference(&url.URL{
+		Path:     fmt.Sprintf("_apis/graph/groups/%s", descriptor),
+		RawQuery: qs.Encode(),
+	})
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return GraphGroup{}, err
+	}
+
+	var g GraphGroup
+	if _, err := c.do(ctx, req, &g); err != nil {
+		return GraphGroup{}, err
+	}
+
+	return g, nil
+}
@@ -0,0 +1,200 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CreatePullRequest opens a new pull request in the repository identified by
+// args.
+//
+// If a pull request for the same source and target branch already exists,
+// Azure DevOps responds with a 409 Conflict, which is returned as an error.
+func (c *Client) CreatePullRequest(ctx context.Context, args OrgProjectRepoArgs, input CreatePullRequestInput) (PullRequest, error) {
+	data, err := json.Marshal(&input)
+	if err != nil {
+		return PullRequest{}, errors.Wrap(err, "marshalling request")
+	}
+
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests", args.Org, args.Project, args.RepoNameOrID)}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return PullRequest{}, err
+	}
+
+	return pr, nil
+}
+
+// GetPullRequest retrieves a single pull request.
+func (c *Client) GetPullRequest(ctx context.Context, args PullRequestCommonArgs) (PullRequest, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return PullRequest{}, err
+	}
+
+	return pr, nil
+}
+
+// ListPullRequestsArgs defines the search criteria for ListPullRequests.
+type ListPullRequestsArgs struct {
+	SourceRefName string
+	TargetRefName string
+	Status        PullRequestStatus
+}
+
+// ListPullRequests returns the pull requests in the repository identified by
+// args that match the given search criteria.
+func (c *Client) ListPullRequests(ctx context.Context, args OrgProjectRepoArgs, opts ListPullRequestsArgs) ([]PullRequest, error) {
+	qs := make(url.Values)
+	if opts.SourceRefName != "" {
+		qs.Set("searchCriteria.sourceRefName", opts.SourceRefName)
+	}
+	if opts.TargetRefName != "" {
+		qs.Set("searchCriteria.targetRefName", opts.TargetRefName)
+	}
+	if opts.Status != "" {
+		qs.Set("searchCriteria.status", string(opts.Status))
+	}
+
+	reqURL := url.URL{
+		Path:     fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests", args.Org, args.Project, args.RepoNameOrID),
+		RawQuery: qs.Encode(),
+	}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Value []PullRequest `json:"value"`
+		Count int           `json:"count"`
+	}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+// UpdatePullRequest updates a pull request. Only the non-nil fields of input
+// are changed on the code host.
+func (c *Client) UpdatePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestUpdateInput) (PullRequest, error) {
+	data, err := json.Marshal(&input)
+	if err != nil {
+		return PullRequest{}, errors.Wrap(err, "marshalling request")
+	}
+
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	req, err := http.NewRequest("PATCH", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return PullRequest{}, err
+	}
+
+	return pr, nil
+}
+
+// AbandonPullRequest closes a pull request without merging it.
+func (c *Client) AbandonPullRequest(ctx context.Context, args PullRequestCommonArgs) (PullRequest, error) {
+	status := PullRequestStatusAbandoned
+	return c.UpdatePullRequest(ctx, args, PullRequestUpdateInput{Status: &status})
+}
+
+// ReactivatePullRequest reopens an abandoned pull request.
+func (c *Client) ReactivatePullRequest(ctx context.Context, args PullRequestCommonArgs) (PullRequest, error) {
+	status := PullRequestStatusActive
+	return c.UpdatePullRequest(ctx, args, PullRequestUpdateInput{Status: &status})
+}
+
+// CompletePullRequest merges a pull request. Azure DevOps requires the commit
+// ID of the source branch the merge is based on, to guard against merging
+// changes that were pushed concurrently.
+func (c *Client) CompletePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestCompleteInput) (PullRequest, error) {
+	status := PullRequestStatusCompleted
+	update := PullRequestUpdateInput{
+		Status:          &status,
+		LastMergeSource: &PullRequestCommit{CommitID: input.CommitID},
+		CompletionOptions: &PullRequestCompleted{
+			DeleteSourceBranch: input.DeleteSourceBranch,
+		},
+	}
+	if input.MergeStrategy != nil {
+		update.CompletionOptions.MergeStrategy = *input.MergeStrategy
+	}
+
+	return c.UpdatePullRequest(ctx, args, update)
+}
+
+// CreatePullRequestCommentThread posts a new comment thread on a pull request.
+func (c *Client) CreatePullRequestCommentThread(ctx context.Context, args PullRequestCommonArgs, input PullRequestCommentInput) (PullRequestCommentResponse, error) {
+	data, err := json.Marshal(&input)
+	if err != nil {
+		return PullRequestCommentResponse{}, errors.Wrap(err, "marshalling request")
+	}
+
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/threads", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return PullRequestCommentResponse{}, err
+	}
+
+	var resp PullRequestCommentResponse
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return PullRequestCommentResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// GetPullRequestStatuses returns the statuses posted to a pull request, e.g.
+// by CI systems.
+func (c *Client) GetPullRequestStatuses(ctx context.Context, args PullRequestCommonArgs) ([]*PullRequestBuildStatus, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/statuses", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Value []*PullRequestBuildStatus `json:"value"`
+		Count int                       `json:"count"`
+	}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
+// PullRequestCommonArgs identifies a single pull request.
+type PullRequestCommonArgs struct {
+	PullRequestID string
+	OrgProjectRepoArgs
+}
@@ -0,0 +1,57 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ListRepositoriesByProjectOrOrgArgs defines the arguments for
+// ListRepositoriesByProjectOrOrg. If ProjectOrOrgName is of the form
+// "org/project", only the repositories of that project are returned, otherwise
+// all repositories of the organization are returned.
+type ListRepositoriesByProjectOrOrgArgs struct {
+	ProjectOrOrgName string
+}
+
+// ListRepositoriesByProjectOrOrg returns all repositories of the given
+// organization or project. Azure DevOps does not paginate this endpoint.
+func (c *Client) ListRepositoriesByProjectOrOrg(ctx context.Context, opts ListRepositoriesByProjectOrOrgArgs) ([]Repository, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/_apis/git/repositories", opts.ProjectOrOrgName)}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos ListRepositoriesResponse
+	if _, err = c.do(ctx, req, &repos); err != nil {
+		return nil, err
+	}
+
+	return repos.Value, nil
+}
+
+// ListRepositoriesResponse is the response of the list repositories endpoint.
+type ListRepositoriesResponse struct {
+	Value []Repository `json:"value"`
+	Count int          `json:"count"`
+}
+
+// GetRepo returns the repository identified by args.
+func (c *Client) GetRepo(ctx context.Context, args OrgProjectRepoArgs) (Repository, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s", args.Org, args.Project, args.RepoNameOrID)}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return Repository{}, err
+	}
+
+	var repo Repository
+	if _, err = c.do(ctx, req, &repo); err != nil {
+		return Repository{}, err
+	}
+
+	return repo, nil
+}
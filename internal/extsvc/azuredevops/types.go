@@ -0,0 +1,324 @@
+package azuredevops
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// OrgProjectRepoArgs identifies a single repository within an Azure DevOps
+// organization and project.
+type OrgProjectRepoArgs struct {
+	Org          string
+	Project      string
+	RepoNameOrID string
+}
+
+// Repository is a Git repository hosted on Azure DevOps.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	CloneURL      string  `json:"remoteUrl"`
+	APIURL        string  `json:"url"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	Size          int     `json:"size"`
+	IsDisabled    bool    `json:"isDisabled"`
+	IsFork        bool    `json:"isFork"`
+	Project       Project `json:"project"`
+}
+
+// Namespace returns the organization the repository belongs to. Azure DevOps
+// doesn't return the organization as part of the repository, so we have to
+// extract it from the API URL of the repository.
+func (r Repository) Namespace() (string, error) {
+	return orgFromAPIURL(r.APIURL)
+}
+
+// OrgProjectRepoArgs returns the arguments identifying this repository in
+// subsequent API calls.
+func (r Repository) OrgProjectRepoArgs() (OrgProjectRepoArgs, error) {
+	org, err := r.Namespace()
+	if err != nil {
+		return OrgProjectRepoArgs{}, err
+	}
+	return OrgProjectRepoArgs{
+		Org:          org,
+		Project:      r.Project.Name,
+		RepoNameOrID: r.Name,
+	}, nil
+}
+
+// orgFromAPIURL extracts the organization from an API URL of the form
+// https://dev.azure.com/{org}/{projectID}/_apis/git/repositories/{repoID}.
+func orgFromAPIURL(apiURL string) (string, error) {
+	idx := strings.Index(apiURL, "/_apis/")
+	if idx < 0 {
+		return "", errors.Errorf("unable to determine organization from API URL %q", apiURL)
+	}
+	parts := strings.Split(strings.TrimSuffix(apiURL[:idx], "/"), "/")
+	// The last two path components are the organization and the project.
+	if len(parts) < 2 {
+		return "", errors.Errorf("unable to determine organization from API URL %q", apiURL)
+	}
+	return parts[len(parts)-2], nil
+}
+
+// Project is an Azure DevOps project.
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	Revision   int    `json:"revision"`
+	Visibility string `json:"visibility"`
+	URL        string `json:"url"`
+}
+
+// PullRequestStatus is the status of a pull request. Azure DevOps doesn't
+// distinguish between merged and closed pull requests by status alone:
+// "completed" means merged, "abandoned" means closed without merging.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+	PullRequestStatusNotSet    PullRequestStatus = "notSet"
+)
+
+// PullRequestMergeStrategy is the strategy used when completing a pull request.
+type PullRequestMergeStrategy string
+
+const (
+	PullRequestMergeStrategySquash        PullRequestMergeStrategy = "squash"
+	PullRequestMergeStrategyNoFastForward PullRequestMergeStrategy = "noFastForward"
+	PullRequestMergeStrategyRebase        PullRequestMergeStrategy = "rebase"
+	PullRequestMergeStrategyRebaseMerge   PullRequestMergeStrategy = "rebaseMerge"
+)
+
+// PullRequest is a pull request on Azure DevOps.
+type PullRequest struct {
+	Repository            Repository            `json:"repository"`
+	ID                    int                   `json:"pullRequestId"`
+	CodeReviewID          int                   `json:"codeReviewId"`
+	Status                PullRequestStatus     `json:"status"`
+	CreatedBy             Identity              `json:"createdBy"`
+	CreationDate          time.Time             `json:"creationDate"`
+	ClosedDate            *time.Time            `json:"closedDate,omitempty"`
+	Title                 string                `json:"title"`
+	Description           string                `json:"description"`
+	SourceRefName         string                `json:"sourceRefName"`
+	TargetRefName         string                `json:"targetRefName"`
+	MergeStatus           string                `json:"mergeStatus"`
+	MergeID               string                `json:"mergeId"`
+	LastMergeSourceCommit PullRequestCommit     `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit PullRequestCommit     `json:"lastMergeTargetCommit"`
+	Reviewers             []Reviewer            `json:"reviewers"`
+	URL                   string                `json:"url"`
+	SupportsIterations    bool                  `json:"supportsIterations"`
+	IsDraft               bool                  `json:"isDraft"`
+	ForkSource            *ForkRef              `json:"forkSource,omitempty"`
+	CompletionOptions     *PullRequestCompleted `json:"completionOptions,omitempty"`
+}
+
+// WebURL returns the URL of the pull request in the Azure DevOps web UI. The
+// API doesn't return it, so it's derived from the URLs of the repository. The
+// repository embedded in a pull request doesn't always include its web URL,
+// but the web UI also accepts the IDs used in the API URL.
+func (pr *PullRequest) WebURL() string {
+	repoURL := pr.Repository.WebURL
+	if repoURL == "" {
+		repoURL = strings.Replace(pr.Repository.APIURL, "/_apis/git/repositories/", "/_git/", 1)
+	}
+	return repoURL + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
+
+// PullRequestCommit is a reference to a commit in a pull request.
+type PullRequestCommit struct {
+	CommitID string `json:"commitId"`
+	URL      string `json:"url"`
+}
+
+// ForkRef references the source of a pull request opened from a fork.
+type ForkRef struct {
+	Name       string     `json:"name"`
+	Repository Repository `json:"repository"`
+}
+
+// PullRequestCompleted contains the options used when completing a pull
+// request.
+type PullRequestCompleted struct {
+	MergeStrategy      PullRequestMergeStrategy `json:"mergeStrategy,omitempty"`
+	DeleteSourceBranch bool                     `json:"deleteSourceBranch,omitempty"`
+	MergeCommitMessage string                   `json:"mergeCommitMessage,omitempty"`
+}
+
+// Identity is a user or group on Azure DevOps, as referenced by pull
+// requests.
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	URL         string `json:"url"`
+	ImageURL    string `json:"imageUrl"`
+}
+
+// Reviewer vote values, as documented at
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-reviewers.
+const (
+	PullRequestVoteApproved                int = 10
+	PullRequestVoteApprovedWithSuggestions int = 5
+	PullRequestVoteNoVote                  int = 0
+	PullRequestVoteWaitingForAuthor        int = -5
+	PullRequestVoteRejected                int = -10
+)
+
+// Reviewer is a reviewer on a pull request, including their vote.
+type Reviewer struct {
+	Identity
+	ReviewerURL string `json:"reviewerUrl"`
+	Vote        int    `json:"vote"`
+	HasDeclined bool   `json:"hasDeclined"`
+	IsRequired  bool   `json:"isRequired"`
+	IsFlagged   bool   `json:"isFlagged"`
+}
+
+// PullRequestStatusState is the state of a status posted to a pull request,
+// e.g. by a CI system.
+type PullRequestStatusState string
+
+const (
+	PullRequestBuildStatusStateSucceeded     PullRequestStatusState = "succeeded"
+	PullRequestBuildStatusStateError         PullRequestStatusState = "error"
+	PullRequestBuildStatusStateFailed        PullRequestStatusState = "failed"
+	PullRequestBuildStatusStatePending       PullRequestStatusState = "pending"
+	PullRequestBuildStatusStateNotApplicable PullRequestStatusState = "notApplicable"
+	PullRequestBuildStatusStateNotSet        PullRequestStatusState = "notSet"
+)
+
+// PullRequestBuildStatus is a status posted to a pull request.
+type PullRequestBuildStatus struct {
+	ID           int                      `json:"id"`
+	State        PullRequestStatusState   `json:"state"`
+	Description  string                   `json:"description"`
+	Context      PullRequestStatusContext `json:"context"`
+	CreationDate time.Time                `json:"creationDate"`
+	UpdatedDate  time.Time                `json:"updatedDate"`
+	CreatedBy    Identity                 `json:"createdBy"`
+	TargetURL    string                   `json:"targetUrl"`
+}
+
+// PullRequestStatusContext identifies the source of a PullRequestBuildStatus.
+type PullRequestStatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre"`
+}
+
+// Key returns a unique key for the status, which can be used to deduplicate
+// statuses posted by the same source.
+func (s *PullRequestBuildStatus) Key() string {
+	return s.Context.Genre + "/" + s.Context.Name
+}
+
+// PullRequestCommonOptions contains the fields that can be set on creation
+// and update of a pull request.
+type PullRequestCommonOptions struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreatePullRequestInput is the payload used to open a pull request.
+type CreatePullRequestInput struct {
+	SourceRefName string     `json:"sourceRefName"`
+	TargetRefName string     `json:"targetRefName"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Reviewers     []Reviewer `json:"reviewers,omitempty"`
+	ForkSource    *ForkRef   `json:"forkSource,omitempty"`
+	IsDraft       bool       `json:"isDraft,omitempty"`
+}
+
+// PullRequestUpdateInput is the payload used to update a pull request. Only
+// non-nil fields are changed.
+type PullRequestUpdateInput struct {
+	Status            *PullRequestStatus    `json:"status,omitempty"`
+	Title             *string               `json:"title,omitempty"`
+	Description       *string               `json:"description,omitempty"`
+	TargetRefName     *string               `json:"targetRefName,omitempty"`
+	IsDraft           *bool                 `json:"isDraft,omitempty"`
+	LastMergeSource   *PullRequestCommit    `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions *PullRequestCompleted `json:"completionOptions,omitempty"`
+}
+
+// PullRequestCompleteInput is the payload used to complete (merge) a pull
+// request.
+type PullRequestCompleteInput struct {
+	CommitID           string
+	MergeStrategy      *PullRequestMergeStrategy
+	DeleteSourceBranch bool
+}
+
+// PullRequestCommentInput is the payload used to comment on a pull request.
+type PullRequestCommentInput struct {
+	Comments []PullRequestCommentForInput `json:"comments"`
+}
+
+// PullRequestCommentForInput is a single comment in a PullRequestCommentInput.
+type PullRequestCommentForInput struct {
+	ParentCommentID int    `json:"parentCommentId"`
+	Content         string `json:"content"`
+	CommentType     int    `json:"commentType"`
+}
+
+// PullRequestCommentResponse is the comment thread created on a pull request.
+type PullRequestCommentResponse struct {
+	ID            int                           `json:"id"`
+	Comments      []PullRequestCommentForThread `json:"comments"`
+	PublishedDate time.Time                     `json:"publishedDate"`
+}
+
+// PullRequestCommentForThread is a single comment in a comment thread.
+type PullRequestCommentForThread struct {
+	ID          int       `json:"id"`
+	Author      Identity  `json:"author"`
+	Content     string    `json:"content"`
+	CommentType string    `json:"commentType"`
+	PublishedAt time.Time `json:"publishedDate"`
+}
+
+// Profile is the profile of the authenticated user on Azure DevOps Services.
+type Profile struct {
+	ID           string `json:"id"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	PublicAlias  string `json:"publicAlias"`
+}
+
+// GraphGroup is a group as returned by the Graph API. Groups scoped to a
+// project have a Domain of the form "vstfs:///Classification/TeamProject/{projectID}".
+type GraphGroup struct {
+	Descriptor    string `json:"descriptor"`
+	DisplayName   string `json:"displayName"`
+	PrincipalName string `json:"principalName"`
+	Domain        string `json:"domain"`
+}
+
+// ProjectID returns the ID of the project the group is scoped to, or an empty
+// string if the group isn't scoped to a project.
+func (g GraphGroup) ProjectID() string {
+	const prefix = "vstfs:///Classification/TeamProject/"
+	if !strings.HasPrefix(g.Domain, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(g.Domain, prefix)
+}
+
+// GraphMembership is a membership relation between a user or group and the
+// group it is contained in.
+type GraphMembership struct {
+	ContainerDescriptor string `json:"containerDescriptor"`
+	MemberDescriptor    string `json:"memberDescriptor"`
+}
@@ -0,0 +1,254 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Labels that are part of every default Gerrit installation and that are used
+// to track the review and CI state of a change.
+const (
+	CodeReviewLabel = "Code-Review"
+	VerifiedLabel   = "Verified"
+)
+
+// ChangeStatus is the status of a change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a Gerrit change, as returned by the changes endpoints. See
+// https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	ID              string                 `json:"id"`
+	Project         string                 `json:"project"`
+	Branch          string                 `json:"branch"`
+	Topic           string                 `json:"topic,omitempty"`
+	ChangeID        string                 `json:"change_id"`
+	Subject         string                 `json:"subject"`
+	Status          ChangeStatus           `json:"status"`
+	Created         Timestamp              `json:"created"`
+	Updated         Timestamp              `json:"updated"`
+	Submitted       *Timestamp             `json:"submitted,omitempty"`
+	Mergeable       bool                   `json:"mergeable"`
+	Insertions      int                    `json:"insertions"`
+	Deletions       int                    `json:"deletions"`
+	Number          int                    `json:"_number"`
+	WorkInProgress  bool                   `json:"work_in_progress"`
+	Owner           Account                `json:"owner"`
+	Labels          map[string]ChangeLabel `json:"labels"`
+	CurrentRevision string                 `json:"current_revision"`
+	Revisions       map[string]Revision    `json:"revisions"`
+}
+
+// ChangeLabel is the state of a single label on a change. The account fields
+// are only set for the label values with the respective meaning, e.g.
+// Approved is set if someone voted with the maximum value.
+type ChangeLabel struct {
+	Approved     *Account   `json:"approved,omitempty"`
+	Rejected     *Account   `json:"rejected,omitempty"`
+	Recommended  *Account   `json:"recommended,omitempty"`
+	Disliked     *Account   `json:"disliked,omitempty"`
+	Blocking     bool       `json:"blocking,omitempty"`
+	Value        int        `json:"value,omitempty"`
+	DefaultValue int        `json:"default_value,omitempty"`
+	All          []Approval `json:"all,omitempty"`
+}
+
+// Approval is a single vote on a label.
+type Approval struct {
+	Account
+	Value int        `json:"value"`
+	Date  *Timestamp `json:"date,omitempty"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Kind    string    `json:"kind"`
+	Number  int       `json:"_number"`
+	Created Timestamp `json:"created"`
+	Ref     string    `json:"ref"`
+	Commit  *Commit   `json:"commit,omitempty"`
+}
+
+// Commit is the commit of a revision.
+type Commit struct {
+	Commit  string   `json:"commit,omitempty"`
+	Parents []Commit `json:"parents,omitempty"`
+	Subject string   `json:"subject"`
+	Message string   `json:"message,omitempty"`
+}
+
+// Reviewer is a reviewer of a change, together with their current votes.
+type Reviewer struct {
+	Account
+	// Approvals maps label names to the formatted vote, e.g. "+2", "-1" or " 0".
+	Approvals map[string]string `json:"approvals"`
+}
+
+// Vote returns the reviewer's vote on the given label. The second return value
+// is false if the reviewer can't vote on the label or the vote can't be parsed.
+func (r *Reviewer) Vote(label string) (int, bool) {
+	v, ok := r.Approvals[label]
+	if !ok {
+		return 0, false
+	}
+	vote, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(v), "+"))
+	if err != nil {
+		return 0, false
+	}
+	return vote, true
+}
+
+// timestampLayout is the format Gerrit uses for all timestamps. They are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp wraps time.Time to support Gerrit's timestamp format.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation(timestampLayout, s, time.UTC)
+	if err != nil {
+		return errors.Wrapf(err, "parsing Gerrit timestamp %q", s)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// GetChange returns the change with the given ID, including the labels and the
+// current revision.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	qs := make(url.Values)
+	for _, o := range []string{"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_LABELS", "DETAILED_ACCOUNTS"} {
+		qs.Add("o", o)
+	}
+	req, err := http.NewRequest("GET", changePath(changeID)+"?"+qs.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ListChangeReviewers returns the reviewers of the given change.
+func (c *Client) ListChangeReviewers(ctx context.Context, changeID string) ([]Reviewer, error) {
+	req, err := http.NewRequest("GET", changePath(changeID)+"/reviewers/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviewers []Reviewer
+	if _, err = c.do(ctx, req, &reviewers); err != nil {
+		return nil, err
+	}
+	return reviewers, nil
+}
+
+// AbandonChange abandons the given change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) error {
+	return c.changeAction(ctx, changeID, "abandon", nil)
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) error {
+	return c.changeAction(ctx, changeID, "restore", nil)
+}
+
+// SubmitChange submits the given change to be merged into its branch.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) error {
+	return c.changeAction(ctx, changeID, "submit", nil)
+}
+
+// MoveChange moves the given change to another branch.
+func (c *Client) MoveChange(ctx context.Context, changeID, branch string) error {
+	return c.changeAction(ctx, changeID, "move", struct {
+		DestinationBranch string `json:"destination_branch"`
+	}{DestinationBranch: branch})
+}
+
+// SetWorkInProgress marks the given change as work in progress.
+func (c *Client) SetWorkInProgress(ctx context.Context, changeID string) error {
+	return c.changeAction(ctx, changeID, "wip", nil)
+}
+
+// SetReadyForReview marks the given work in progress change as ready for
+// review.
+func (c *Client) SetReadyForReview(ctx context.Context, changeID string) error {
+	return c.changeAction(ctx, changeID, "ready", nil)
+}
+
+// WriteReviewComment posts a message on the current revision of the given
+// change, without voting on any label.
+func (c *Client) WriteReviewComment(ctx context.Context, changeID, message string) error {
+	return c.changeAction(ctx, changeID, "revisions/current/review", struct {
+		Message string `json:"message"`
+	}{Message: message})
+}
+
+// changeAction posts to the given action endpoint of a change. The actions
+// respond with a summary of the change that lacks the details requested by
+// GetChange, so it is discarded.
+func (c *Client) changeAction(ctx context.Context, changeID, action string, body any) error {
+	req, err := newJSONRequest("POST", changePath(changeID)+"/"+action, body)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// changePath returns the API path of the given change. The change ID can be
+// anything Gerrit accepts as an identifier, such as the Change-Id or the
+// change number.
+func changePath(changeID string) string {
+	return "a/changes/" + url.PathEscape(changeID)
+}
+
+func newJSONRequest(method, path string, body any) (*http.Request, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, errors.Wrap(err, "marshalling request body")
+		}
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return req, nil
+}
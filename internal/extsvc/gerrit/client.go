@@ -3,15 +3,16 @@ package gerrit
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -26,6 +27,10 @@ type Client struct {
 	// URL is the base URL of Gerrit.
 	URL *url.URL
 
+	// Auth is the authenticator used for requests. It defaults to basic auth
+	// with the username and password from Config.
+	Auth auth.Authenticator
+
 	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a concept
 	// of rate limiting in HTTP response headers).
 	rateLimit *ratelimit.InstrumentedLimiter
@@ -48,10 +53,47 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 		httpClient: httpClient,
 		Config:     config,
 		URL:        u,
+		Auth:       &auth.BasicAuth{Username: config.Username, Password: config.Password},
 		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
 	}, nil
 }
 
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.Auth
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTPClient, and RateLimiter as the current Client, except authenticated with
+// the given authenticator instance.
+//
+// Gerrit only supports HTTP basic auth, so using any other Authenticator
+// implementation will result in errors.
+func (c *Client) WithAuthenticator(a auth.Authenticator) *Client {
+	return &Client{
+		httpClient: c.httpClient,
+		Config:     c.Config,
+		URL:        c.URL,
+		Auth:       a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// GetAuthenticatedAccount returns the account of the currently authenticated
+// user.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err = c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -171,7 +213,9 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 	req.URL = c.URL.ResolveReference(req.URL)
 
 	// Add Basic Auth headers for authenticated requests.
-	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Config.Username+":"+c.Config.Password)))
+	if err := c.Auth.Authenticate(req); err != nil {
+		return nil, err
+	}
 
 	if err := c.rateLimit.Wait(ctx); err != nil {
 		return nil, err
@@ -199,6 +243,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints don't return a body at all, in which case the caller
+	// doesn't expect a result.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
@@ -265,3 +315,13 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// HTTPErrorCode returns err's HTTP status code, if it is an HTTP error from
+// this package. Otherwise it returns 0.
+func HTTPErrorCode(err error) int {
+	var e *httpError
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
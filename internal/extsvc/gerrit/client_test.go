@@ -38,6 +38,20 @@ func TestClient_ListProjects(t *testing.T) {
 	testutil.AssertGolden(t, "testdata/golden/ListProjects.json", *update, resp)
 }
 
+func TestClient_GetChange(t *testing.T) {
+	cli, save := NewTestClient(t, "GetChange", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	change, err := cli.GetChange(ctx, "I5de4ad1b4d1c1bfb33b0b0b5d44b0a1ef5ca3c2e")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/GetChange.json", *update, change)
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 	if !testing.Verbose() {
@@ -0,0 +1,153 @@
+// Package hgweb implements a client for hgweb, the web interface Mercurial
+// uses to serve repositories over HTTP.
+package hgweb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Client lists the repositories of an hgweb server.
+type Client struct {
+	// URL is the base URL of the hgweb server, without credentials.
+	URL *url.URL
+
+	user       *url.Userinfo
+	httpClient httpcli.Doer
+}
+
+// NewClient returns a client for the hgweb server at rawURL. Credentials in
+// rawURL are sent using HTTP basic authentication. If a nil httpClient is
+// provided, httpcli.ExternalDoer will be used.
+func NewClient(rawURL string, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("hgweb URL must use http or https, got %q", rawURL)
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	user := u.User
+	u.User = nil
+	// Repository paths are relative to the URL, so it must end with a slash
+	// to be resolved against.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return &Client{
+		URL:        u,
+		user:       user,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Repo is a Mercurial repository served by hgweb.
+type Repo struct {
+	// Path is the path of the repository relative to the URL of the server.
+	Path string
+	// Description is taken from the web.description setting of the
+	// repository.
+	Description string
+	// Contact is taken from the web.contact setting of the repository.
+	Contact string
+}
+
+// RepoURL returns the URL of the repository at path, including the
+// credentials of the client. Mercurial clones it from there.
+func (c *Client) RepoURL(path string) *url.URL {
+	u := c.URL.ResolveReference(&url.URL{Path: strings.Trim(path, "/")})
+	u.User = c.user
+	return u
+}
+
+// indexEntry is an entry of the index page of hgweb in its JSON style.
+type indexEntry struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Contact     string `json:"contact"`
+}
+
+// ListRepos returns all repositories listed on the index page of the server.
+// A server that serves a single repository rather than a collection has no
+// index page.
+func (c *Client) ListRepos(ctx context.Context) ([]*Repo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.URL.String()+"?style=json", nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.user != nil {
+		password, _ := c.user.Password()
+		req.SetBasicAuth(c.user.Username(), password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	var index struct {
+		Entries []indexEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(bs, &index); err != nil {
+		return nil, errors.Wrap(err, "failed to decode hgweb index, the server might not serve a collection of repositories")
+	}
+
+	repos := make([]*Repo, 0, len(index.Entries))
+	for _, e := range index.Entries {
+		path := strings.Trim(e.Name, "/")
+		if path == "" {
+			continue
+		}
+		repos = append(repos, &Repo{
+			Path:        path,
+			Description: e.Description,
+			Contact:     e.Contact,
+		})
+	}
+	return repos, nil
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("hgweb HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
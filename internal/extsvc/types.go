@@ -123,6 +123,7 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
 	KindGerrit          = "GERRIT"
@@ -138,6 +139,7 @@ const (
 	KindRubyPackages    = "RUBYPACKAGES"
 	KindNpmPackages     = "NPMPACKAGES"
 	KindPagure          = "PAGURE"
+	KindMercurial       = "MERCURIAL"
 	KindOther           = "OTHER"
 )
 
@@ -149,6 +151,11 @@ const (
 	// suffix (e.g., "arn:aws:codecommit:us-west-1:123456789:").
 	TypeAWSCodeCommit = "awscodecommit"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+	// ServiceID value is the base URL to the Azure DevOps instance (https://dev.azure.com or the Azure
+	// DevOps Server collection URL).
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeBitbucketServer is the (api.ExternalRepoSpec).ServiceType value for Bitbucket Server projects. The
 	// ServiceID value is the base URL to the Bitbucket Server instance.
 	TypeBitbucketServer = "bitbucketServer"
@@ -183,6 +190,10 @@ const (
 	// TypePagure is the (api.ExternalRepoSpec).ServiceType value for Pagure projects.
 	TypePagure = "pagure"
 
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories
+	// served by hgweb. The ServiceID value is the base URL of the hgweb server.
+	TypeMercurial = "mercurial"
+
 	// TypeNpmPackages is the (api.ExternalRepoSpec).ServiceType value for Npm packages (JavaScript/TypeScript ecosystem libraries).
 	TypeNpmPackages = "npmPackages"
 
@@ -208,6 +219,8 @@ func KindToType(kind string) string {
 	switch kind {
 	case KindAWSCodeCommit:
 		return TypeAWSCodeCommit
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindBitbucketServer:
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
@@ -238,6 +251,8 @@ func KindToType(kind string) string {
 		return TypeGoModules
 	case KindPagure:
 		return TypePagure
+	case KindMercurial:
+		return TypeMercurial
 	case KindOther:
 		return TypeOther
 	default:
@@ -251,6 +266,8 @@ func TypeToKind(t string) string {
 	switch t {
 	case TypeAWSCodeCommit:
 		return KindAWSCodeCommit
+	case TypeAzureDevOps:
+		return KindAzureDevOps
 	case TypeBitbucketServer:
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
@@ -281,6 +298,8 @@ func TypeToKind(t string) string {
 		return KindGoPackages
 	case TypePagure:
 		return KindPagure
+	case TypeMercurial:
+		return KindMercurial
 	case TypeOther:
 		return KindOther
 	default:
@@ -306,6 +325,8 @@ func ParseServiceType(s string) (string, bool) {
 	switch strings.ToLower(s) {
 	case TypeAWSCodeCommit:
 		return TypeAWSCodeCommit, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case bbsLower:
 		return TypeBitbucketServer, true
 	case bbcLower:
@@ -336,6 +357,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeRubyPackages, true
 	case TypePagure:
 		return TypePagure, true
+	case TypeMercurial:
+		return TypeMercurial, true
 	case TypeOther:
 		return TypeOther, true
 	default:
@@ -349,6 +372,8 @@ func ParseServiceKind(s string) (string, bool) {
 	switch strings.ToUpper(s) {
 	case KindAWSCodeCommit:
 		return KindAWSCodeCommit, true
+	case KindAzureDevOps:
+		return KindAzureDevOps, true
 	case KindBitbucketServer:
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
@@ -377,6 +402,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindRubyPackages, true
 	case KindPagure:
 		return KindPagure, true
+	case KindMercurial:
+		return KindMercurial, true
 	case KindOther:
 		return KindOther, true
 	default:
@@ -443,6 +470,8 @@ func getConfigPrototype(kind string) (any, error) {
 	switch strings.ToUpper(kind) {
 	case KindAWSCodeCommit:
 		return &schema.AWSCodeCommitConnection{}, nil
+	case KindAzureDevOps:
+		return &schema.AzureDevOpsConnection{}, nil
 	case KindBitbucketServer:
 		return &schema.BitbucketServerConnection{}, nil
 	case KindBitbucketCloud:
@@ -465,6 +494,8 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.JVMPackagesConnection{}, nil
 	case KindPagure:
 		return &schema.PagureConnection{}, nil
+	case KindMercurial:
+		return &schema.MercurialConnection{}, nil
 	case KindNpmPackages:
 		return &schema.NpmPackagesConnection{}, nil
 	case KindPythonPackages:
@@ -544,6 +575,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.AzureDevOpsConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -611,6 +644,11 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.AzureDevOpsConnection:
+		limit = rate.Limit(2)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.PerforceConnection:
 		limit = rate.Limit(5000.0 / 3600.0)
 		if c != nil && c.RateLimit != nil {
@@ -756,6 +794,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -783,6 +823,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return KindRubyPackages, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
 	default:
 		return "", errors.Errorf("unknown external service kind: %s", kind)
 	}
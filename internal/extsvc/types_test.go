@@ -289,6 +289,11 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "https://example.com"}`,
 			want:   "https://example.com/",
 		},
+		{
+			kind:   KindAzureDevOps,
+			config: `{"url": "https://dev.azure.com"}`,
+			want:   "https://dev.azure.com/",
+		},
 		{
 			kind:   KindBitbucketServer,
 			config: `{"url": "https://bitbucket.sgdev.org/"}`,
@@ -1,6 +1,7 @@
 package gitserver
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/md5"
@@ -10,10 +11,12 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -451,16 +454,33 @@ func (c *clientImplementor) Addrs() []string {
 }
 
 func (c *clientImplementor) AddrForRepo(ctx context.Context, repo api.RepoName) (string, error) {
+	return AddrForRepo(ctx, c.userAgent, c.db, repo, c.gitServerAddresses())
+}
+
+// addrsForRepo returns the addresses of the primary and the replicas of repo.
+// See ReplicaAddrsForRepo.
+func (c *clientImplementor) addrsForRepo(ctx context.Context, repo api.RepoName) ([]string, error) {
+	return ReplicaAddrsForRepo(ctx, c.userAgent, c.db, repo, c.gitServerAddresses())
+}
+
+func (c *clientImplementor) gitServerAddresses() GitServerAddresses {
 	addrs := c.Addrs()
 	if len(addrs) == 0 {
 		panic("unexpected state: no gitserver addresses")
 	}
-	return AddrForRepo(ctx, c.userAgent, c.db, repo, GitServerAddresses{
-		Addresses:     addrs,
-		PinnedServers: c.pinned(),
-	})
+	return GitServerAddresses{
+		Addresses:         addrs,
+		PinnedServers:     c.pinned(),
+		ShardingAlgorithm: shardingAlgorithmFromConfig(),
+		ReplicatedRepos:   replicatedReposFromConfig(),
+	}
 }
 
+var replicaFallbackCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_replica_fallback_total",
+	Help: "Number of read requests retried against the primary because a gitserver replica did not have the repository or revision yet",
+})
+
 var addrForRepoInvoked = promauto.NewCounterVec(prometheus.CounterOpts{
 	Name: "src_gitserver_addr_for_repo_invoked",
 	Help: "Number of times gitserver.AddrForRepo was invoked",
@@ -477,12 +497,74 @@ func AddrForRepo(ctx context.Context, userAgent string, db database.DB, repo api
 		return addr, nil
 	}
 
-	return addrForKey(rs, addresses.Addresses), nil
+	return addresses.ShardingAlgorithm.addrForKey(rs, addresses.Addresses), nil
+}
+
+// ReplicaAddrsForRepo returns the addresses of the gitserver instances which
+// store a copy of the given repo. The first address is the primary, as
+// returned by AddrForRepo, and the rest are its read replicas. Repositories
+// which are not listed in ReplicatedRepos only have a primary.
+func ReplicaAddrsForRepo(ctx context.Context, userAgent string, db database.DB, repo api.RepoName, addresses GitServerAddresses) ([]string, error) {
+	primary, err := AddrForRepo(ctx, userAgent, db, repo, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := []string{primary}
+	copies := addresses.ReplicatedRepos[string(protocol.NormalizeRepo(repo))]
+	if copies <= 1 {
+		return addrs, nil
+	}
+
+	// Replicas are placed with rendezvous hashing independently of the
+	// sharding algorithm, so that changing the number of copies or addresses
+	// moves as few replicas as possible.
+	for _, addr := range rendezvousRankedAddrs(string(protocol.NormalizeRepo(repo)), addresses.Addresses) {
+		if len(addrs) >= copies {
+			break
+		}
+		if addr != primary {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil
 }
 
 type GitServerAddresses struct {
 	Addresses     []string
 	PinnedServers map[string]string
+
+	// ShardingAlgorithm determines how repositories which are not pinned are
+	// assigned to Addresses. The zero value behaves like
+	// ShardingAlgorithmModulo.
+	ShardingAlgorithm ShardingAlgorithm
+
+	// ReplicatedRepos maps repository names to the number of gitserver
+	// instances which store a copy of them, including the primary.
+	ReplicatedRepos map[string]int
+}
+
+// ShardingAlgorithm is the strategy used to assign a repository to one of the
+// gitserver addresses.
+type ShardingAlgorithm string
+
+const (
+	// ShardingAlgorithmModulo hashes the repository name modulo the number of
+	// addresses. Adding or removing an address remaps most repositories.
+	ShardingAlgorithmModulo ShardingAlgorithm = "modulo"
+
+	// ShardingAlgorithmRendezvous uses rendezvous (highest random weight)
+	// hashing. Adding an address only moves the repositories that the new
+	// address wins, and removing an address only moves the repositories it
+	// owned.
+	ShardingAlgorithmRendezvous ShardingAlgorithm = "rendezvous"
+)
+
+func (a ShardingAlgorithm) addrForKey(key string, addrs []string) string {
+	if a == ShardingAlgorithmRendezvous {
+		return rendezvousAddrForKey(key, addrs)
+	}
+	return addrForKey(key, addrs)
 }
 
 // addrForKey returns the gitserver address to use for the given string key,
@@ -493,11 +575,58 @@ func addrForKey(key string, addrs []string) string {
 	return addrs[serverIndex]
 }
 
+// rendezvousAddrForKey returns the gitserver address with the highest weight
+// for the given string key. The weight of an address is the hash of the
+// address and key, so the assignment does not depend on the order or number
+// of the other addresses.
+func rendezvousAddrForKey(key string, addrs []string) string {
+	var (
+		best       string
+		bestWeight uint64
+	)
+	for _, addr := range addrs {
+		weight := rendezvousWeight(key, addr)
+		if best == "" || weight > bestWeight || (weight == bestWeight && addr < best) {
+			best, bestWeight = addr, weight
+		}
+	}
+	return best
+}
+
+// rendezvousRankedAddrs returns addrs ordered by descending weight for the
+// given string key. The first address is the one rendezvousAddrForKey returns.
+func rendezvousRankedAddrs(key string, addrs []string) []string {
+	ranked := make([]string, len(addrs))
+	copy(ranked, addrs)
+	weights := make(map[string]uint64, len(addrs))
+	for _, addr := range addrs {
+		weights[addr] = rendezvousWeight(key, addr)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		wi, wj := weights[ranked[i]], weights[ranked[j]]
+		if wi != wj {
+			return wi > wj
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked
+}
+
+func rendezvousWeight(key, addr string) uint64 {
+	sum := md5.Sum([]byte(addr + "\x00" + key))
+	return binary.BigEndian.Uint64(sum[:])
+}
+
 // ArchiveOptions contains options for the Archive func.
 type ArchiveOptions struct {
 	Treeish   string               // the tree or commit to produce an archive for
 	Format    ArchiveFormat        // format of the resulting archive (usually "tar" or "zip")
 	Pathspecs []gitdomain.Pathspec // if nonempty, only include these pathspecs.
+
+	// SkipMissingBlobs leaves out the files whose blob is missing from a
+	// partial clone instead of fetching them from the code host. These are
+	// the files larger than the partialCloneBlobSizeLimit of the code host.
+	SkipMissingBlobs bool
 }
 
 type BatchLogOptions protocol.BatchLogRequest
@@ -535,8 +664,8 @@ func (a *archiveReader) Close() error {
 }
 
 // archiveURL returns a URL from which an archive of the given Git repository can
-// be downloaded from.
-func (c *clientImplementor) archiveURL(ctx context.Context, repo api.RepoName, opt ArchiveOptions) (*url.URL, error) {
+// be downloaded from the gitserver at addr.
+func archiveURL(addr string, repo api.RepoName, opt ArchiveOptions) *url.URL {
 	q := url.Values{
 		"repo":    {string(repo)},
 		"treeish": {opt.Treeish},
@@ -547,16 +676,16 @@ func (c *clientImplementor) archiveURL(ctx context.Context, repo api.RepoName, o
 		q.Add("path", string(pathspec))
 	}
 
-	addrForRepo, err := c.AddrForRepo(ctx, repo)
-	if err != nil {
-		return nil, err
+	if opt.SkipMissingBlobs {
+		q.Set("skipMissingBlobs", "true")
 	}
+
 	return &url.URL{
 		Scheme:   "http",
-		Host:     addrForRepo,
+		Host:     addr,
 		Path:     "/archive",
 		RawQuery: q.Encode(),
-	}, nil
+	}
 }
 
 type badRequestError struct{ error }
@@ -638,15 +767,28 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		return false, err
 	}
 
-	addrForRepo, err := c.AddrForRepo(ctx, repoName)
+	addr, primary, err := c.readAddrs(ctx, repoName)
 	if err != nil {
 		return false, err
 	}
 
-	uri := "http://" + addrForRepo + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	limitHit, matched, err := c.search(ctx, addr, repoName, buf.Bytes(), onMatches)
+	if err != nil && !matched && addr != primary {
+		// Replicas never clone or fetch the repository themselves, so they
+		// may not have it or the searched revisions yet. We retry against
+		// the primary unless the replica already sent us matches.
+		replicaFallbackCounter.Inc()
+		limitHit, _, err = c.search(ctx, primary, repoName, buf.Bytes(), onMatches)
+	}
+	return limitHit, err
+}
+
+// search sends a search request with the gob encoded payload to the gitserver
+// at addr. It also returns whether any matches were passed to onMatches.
+func (c *clientImplementor) search(ctx context.Context, addr string, repoName api.RepoName, payload []byte, onMatches func([]protocol.CommitMatch)) (limitHit, matched bool, err error) {
+	resp, err := c.do(ctx, repoName, "POST", "http://"+addr+"/search", payload)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 	defer resp.Body.Close()
 
@@ -656,6 +798,7 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	)
 	dec := StreamSearchDecoder{
 		OnMatches: func(e protocol.SearchEventMatches) {
+			matched = true
 			onMatches(e)
 		},
 		OnDone: func(e protocol.SearchEventDone) {
@@ -667,14 +810,14 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	}
 
 	if err := dec.ReadAll(resp.Body); err != nil {
-		return false, err
+		return false, matched, err
 	}
 
 	if decodeErr != nil {
-		return false, decodeErr
+		return false, matched, decodeErr
 	}
 
-	return eventDone.LimitHit, eventDone.Err()
+	return eventDone.LimitHit, matched, eventDone.Err()
 }
 
 func (c *clientImplementor) P4Exec(ctx context.Context, host, user, password string, args ...string) (_ io.ReadCloser, _ http.Header, errRes error) {
@@ -884,7 +1027,7 @@ func (c *clientImplementor) gitCommand(repo api.RepoName, arg ...string) GitComm
 	}
 	return &RemoteGitCommand{
 		repo:   repo,
-		execFn: c.httpPost,
+		execFn: c.execPost,
 		args:   append([]string{git}, arg...),
 	}
 }
@@ -1153,6 +1296,88 @@ func (c *clientImplementor) httpPost(ctx context.Context, repo api.RepoName, op
 	return c.do(ctx, repo, "POST", uri, b)
 }
 
+// execPost sends an exec request for repo. Read-only git commands are spread
+// across the replicas of repo. Everything else, including commands which need
+// to ensure a revision exists, is sent to the primary.
+func (c *clientImplementor) execPost(ctx context.Context, repo api.RepoName, op string, payload any) (*http.Response, error) {
+	req, ok := payload.(*protocol.ExecRequest)
+	if !ok || req.EnsureRevision != "" || !gitdomain.IsReadOnlyGitCmd(req.Args) {
+		return c.httpPost(ctx, repo, op, payload)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.doRead(ctx, repo, "POST", func(addr string) string {
+		return "http://" + addr + "/" + op
+	}, b)
+}
+
+// readAddrs returns the address of a randomly picked gitserver instance
+// storing repo to read it from, and the address of its primary.
+func (c *clientImplementor) readAddrs(ctx context.Context, repo api.RepoName) (addr, primary string, err error) {
+	addrs, err := c.addrsForRepo(ctx, repo)
+	if err != nil {
+		return "", "", err
+	}
+	return addrs[rand.Intn(len(addrs))], addrs[0], nil
+}
+
+// doRead performs a read-only exec request against a randomly picked gitserver
+// instance storing repo. uri returns the request URI for a gitserver address.
+//
+// Replicas never clone or fetch repo from its code host, and report it as not
+// found until they have synced with the primary. They can still lag behind the
+// primary, so a request a replica fails without producing any output, for
+// example because it does not have a revision yet, is retried against the
+// primary.
+func (c *clientImplementor) doRead(ctx context.Context, repo api.RepoName, method string, uri func(addr string) string, payload []byte) (*http.Response, error) {
+	addr, primary, err := c.readAddrs(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, repo, method, uri(addr), payload)
+	if err != nil || addr == primary {
+		return resp, err
+	}
+	if !execFailedWithoutOutput(resp) {
+		return resp, nil
+	}
+	resp.Body.Close()
+	replicaFallbackCounter.Inc()
+	return c.do(ctx, repo, method, uri(primary), payload)
+}
+
+// execFailedWithoutOutput returns true if resp is the response of an exec
+// request which failed before writing any output. This includes requests for
+// a repository which is not cloned. It peeks at the body of resp, which still
+// returns the complete output afterwards.
+func execFailedWithoutOutput(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusNotFound {
+		return true
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	br := bufio.NewReader(resp.Body)
+	resp.Body = &peekedBody{Reader: br, Closer: resp.Body}
+	if _, err := br.Peek(1); err != io.EOF {
+		// The command produced output, or reading it failed, which the
+		// caller will see when reading the body.
+		return false
+	}
+	// The trailers are set once the body has been read completely.
+	return resp.Trailer.Get("X-Exec-Error") != "" || resp.Trailer.Get("X-Exec-Exit-Status") != "0"
+}
+
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
 // do performs a request to a gitserver instance based on the address in the uri
 // argument.
 //
@@ -1362,6 +1587,22 @@ func readResponseBody(body io.Reader) string {
 	return strings.TrimSpace(string(content))
 }
 
+func shardingAlgorithmFromConfig() ShardingAlgorithm {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures != nil {
+		return ShardingAlgorithm(cfg.ExperimentalFeatures.GitServerShardingAlgorithm)
+	}
+	return ShardingAlgorithmModulo
+}
+
+func replicatedReposFromConfig() map[string]int {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures != nil {
+		return cfg.ExperimentalFeatures.GitServerReplicatedRepos
+	}
+	return nil
+}
+
 func pinnedReposFromConfig() map[string]string {
 	cfg := conf.Get()
 	if cfg.ExperimentalFeatures != nil && cfg.ExperimentalFeatures.GitServerPinnedRepos != nil {
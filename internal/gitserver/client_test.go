@@ -35,6 +35,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -42,6 +43,12 @@ func newMockDB() database.DB {
 	db := database.NewMockDB()
 	gr := database.NewMockGitserverRepoStore()
 	db.GitserverReposFunc.SetDefaultReturn(gr)
+	repos := database.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{Name: name}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.OutboundWebhookJobsFunc.SetDefaultReturn(database.NewMockOutboundWebhookJobStore())
 	return db
 }
 
@@ -325,6 +332,59 @@ func TestAddrForRepo(t *testing.T) {
 	}
 }
 
+func TestReplicaAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3", "gitserver-4"}
+	addresses := gitserver.GitServerAddresses{
+		Addresses:     addrs,
+		PinnedServers: map[string]string{"pinned": "gitserver-4"},
+		ReplicatedRepos: map[string]int{
+			"repo1":  3,
+			"pinned": 2,
+			"many":   10,
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		repo      api.RepoName
+		wantCount int
+	}{
+		{name: "not replicated", repo: "repo2", wantCount: 1},
+		{name: "replicated", repo: "repo1", wantCount: 3},
+		{name: "check we normalise", repo: "repo1.git", wantCount: 3},
+		{name: "pinned", repo: "pinned", wantCount: 2},
+		{name: "more copies than addresses", repo: "many", wantCount: len(addrs)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := gitserver.ReplicaAddrsForRepo(context.Background(), "gitserver", newMockDB(), tc.repo, addresses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tc.wantCount {
+				t.Fatalf("want %d addresses, got %q", tc.wantCount, got)
+			}
+
+			primary, err := gitserver.AddrForRepo(context.Background(), "gitserver", newMockDB(), tc.repo, addresses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got[0] != primary {
+				t.Fatalf("want primary %q first, got %q", primary, got)
+			}
+
+			seen := map[string]bool{}
+			for _, addr := range got {
+				if seen[addr] {
+					t.Fatalf("duplicate address %q in %q", addr, got)
+				}
+				seen[addr] = true
+			}
+		})
+	}
+}
+
 func TestClient_P4Exec(t *testing.T) {
 	_ = gitserver.CreateRepoDir(t)
 	tests := []struct {
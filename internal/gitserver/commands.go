@@ -2444,12 +2444,9 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
-	u, err := c.archiveURL(ctx, repo, options)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.do(ctx, repo, "POST", u.String(), nil)
+	resp, err := c.doRead(ctx, repo, "POST", func(addr string) string {
+		return archiveURL(addr, repo, options).String()
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
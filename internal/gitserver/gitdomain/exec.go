@@ -52,6 +52,25 @@ var (
 		"testcat":     {},
 	}
 
+	// readOnlyGitCmds are allowed commands which never modify a repository,
+	// so they can run against any replica of it.
+	readOnlyGitCmds = map[string]struct{}{
+		"log":          {},
+		"show":         {},
+		"diff":         {},
+		"blame":        {},
+		"rev-parse":    {},
+		"rev-list":     {},
+		"archive":      {},
+		"ls-tree":      {},
+		"ls-files":     {},
+		"for-each-ref": {},
+		"merge-base":   {},
+		"show-ref":     {},
+		"shortlog":     {},
+		"cat-file":     {},
+	}
+
 	// `git log`, `git show`, `git diff`, etc., share a large common set of allowed args.
 	gitCommonAllowlist = []string{
 		"--name-only", "--name-status", "--full-history", "-M", "--date", "--format", "-i", "-n", "-n1", "-m", "--", "-n200", "-n2", "--follow", "--author", "--grep", "--date-order", "--decorate", "--skip", "--max-count", "--numstat", "--pretty", "--parents", "--topo-order", "--raw", "--follow", "--all", "--before", "--no-merges", "--fixed-strings",
@@ -178,3 +197,13 @@ func IsAllowedGitCmd(logger log.Logger, args []string) bool {
 	}
 	return true
 }
+
+// IsReadOnlyGitCmd returns true if the git command args (without the leading
+// "git") never modify the repository.
+func IsReadOnlyGitCmd(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	_, ok := readOnlyGitCmds[args[0]]
+	return ok
+}
@@ -62,3 +62,24 @@ func TestIsAllowedDiffGitCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReadOnlyGitCmd(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"log", "--format=%H", "HEAD"}, want: true},
+		{args: []string{"rev-parse", "HEAD"}, want: true},
+		{args: []string{"archive", "--format=zip", "HEAD"}, want: true},
+		{args: []string{"fetch", "origin"}, want: false},
+		{args: []string{"update-ref", "--"}, want: false},
+		{args: []string{"commit", "-m", "message"}, want: false},
+		{args: []string{}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			assert.Equal(t, tc.want, IsReadOnlyGitCmd(tc.args))
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -50,6 +51,12 @@ func InitGitserver() {
 	db := database.NewMockDB()
 	gr := database.NewMockGitserverRepoStore()
 	db.GitserverReposFunc.SetDefaultReturn(gr)
+	repos := database.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{Name: name}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.OutboundWebhookJobsFunc.SetDefaultReturn(database.NewMockOutboundWebhookJobStore())
 
 	srv := &http.Server{
 		Handler: (&server.Server{
@@ -2,10 +2,23 @@ package gitserver
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func BenchmarkAddrForKey(b *testing.B) {
@@ -23,6 +36,41 @@ func BenchmarkAddrForKey(b *testing.B) {
 	}
 }
 
+func TestRendezvousAddrForKey(t *testing.T) {
+	var nodes []string
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, fmt.Sprintf("gitserver-%d", i))
+	}
+	grown := append(append([]string{}, nodes...), "gitserver-10")
+	reversed := make([]string, 0, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		reversed = append(reversed, nodes[i])
+	}
+
+	moved := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("github.com/foo/repo-%d", i)
+		before := rendezvousAddrForKey(key, nodes)
+		if got := rendezvousAddrForKey(key, reversed); got != before {
+			t.Fatalf("assignment of %q depends on address order: %q != %q", key, got, before)
+		}
+
+		after := rendezvousAddrForKey(key, grown)
+		if after == before {
+			continue
+		}
+		if after != "gitserver-10" {
+			t.Fatalf("%q moved from %q to %q, but may only move to the new address", key, before, after)
+		}
+		moved++
+	}
+
+	// We expect roughly 1/11 of the keys to move to the new address.
+	if moved == 0 || moved > 200 {
+		t.Fatalf("unexpected number of moved keys: %d", moved)
+	}
+}
+
 func Test_readResponseBody(t *testing.T) {
 	// The \n in the end is important to test that readResponseBody correctly removes it from the returned string.
 	reader := bytes.NewReader([]byte("A test string that is more than 40 bytes long. Lorem ipsum whatever whatever\n"))
@@ -33,3 +81,84 @@ func Test_readResponseBody(t *testing.T) {
 		t.Fatalf("Mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestReadReplicaFallback(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			GitServerReplicatedRepos: map[string]int{"repo": 2},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	addrs := []string{"gitserver-1", "gitserver-2"}
+	db := database.NewMockDB()
+	primary, err := AddrForRepo(context.Background(), "test", db, "repo", GitServerAddresses{Addresses: addrs})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replicaRequests := 0
+	cli := NewTestClient(httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		synced := r.URL.Host == primary
+		if !synced {
+			replicaRequests++
+		}
+
+		switch r.URL.Path {
+		case "/exec":
+			// The replica does not have the revision yet, so the command
+			// fails without output.
+			body, exitStatus := "", "128"
+			if synced {
+				body, exitStatus = "deadbeef", "0"
+			}
+			trailer := http.Header{}
+			trailer.Set("X-Exec-Exit-Status", exitStatus)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Trailer: trailer}, nil
+
+		case "/search":
+			var body bytes.Buffer
+			if synced {
+				matches, _ := json.Marshal([]protocol.CommitMatch{{Oid: "deadbeef"}})
+				fmt.Fprintf(&body, "event: matches\ndata: %s\n\n", matches)
+			}
+			var searchErr error
+			if !synced {
+				searchErr = &gitdomain.RevisionNotFoundError{Repo: "repo", Spec: "deadbeef"}
+			}
+			done, _ := json.Marshal(protocol.NewSearchEventDone(false, searchErr))
+			fmt.Fprintf(&body, "event: done\ndata: %s\n\n", done)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(&body)}, nil
+		}
+		return nil, errors.Newf("unexpected URL: %q", r.URL.String())
+	}), db, addrs).(*clientImplementor)
+
+	// Reads are spread across the primary and the replica at random.
+	for i := 0; i < 20; i++ {
+		out, err := cli.gitCommand("repo", "rev-parse", "deadbeef").Output(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != "deadbeef" {
+			t.Fatalf("want output from the primary, got %q", out)
+		}
+
+		var matches []protocol.CommitMatch
+		_, err = cli.Search(context.Background(), &protocol.SearchRequest{
+			Repo:      "repo",
+			Revisions: []protocol.RevisionSpecifier{{RevSpec: "deadbeef"}},
+			Query:     &protocol.MessageMatches{Expr: "fix"},
+		}, func(m []protocol.CommitMatch) {
+			matches = append(matches, m...)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) != 1 {
+			t.Fatalf("want 1 match from the primary, got %d", len(matches))
+		}
+	}
+	if replicaRequests == 0 {
+		t.Fatal("expected reads against the replica")
+	}
+}
@@ -176,7 +176,9 @@ type RepoUpdateRequest struct {
 
 	// CloneFromShard is the hostname of the gitserver instance that is the current owner of the
 	// repository. If this is set, then the RepoUpdateRequest is to migrate the repo from
-	// that gitserver instance to the new home of the repo.
+	// that gitserver instance to the new home of the repo. If the repo is already cloned, it
+	// is fetched from that gitserver instance instead, which is how replicas of a repo are
+	// kept in sync with the primary.
 	CloneFromShard string `json:"cloneFromShard"`
 }
 
@@ -192,6 +194,12 @@ type RepoUpdateResponse struct {
 // RepoCloneRequest is a request to clone a repository asynchronously.
 type RepoCloneRequest struct {
 	Repo api.RepoName `json:"repo"`
+
+	// CloneFromShard is the address of the gitserver instance that is the
+	// current owner of the repository. If this is a non-zero string, then
+	// gitserver will clone the repository from that gitserver instance instead
+	// of the upstream repo URL of the external service.
+	CloneFromShard string `json:"cloneFromShard,omitempty"`
 }
 
 // RepoCloneResponse returns an error if the repo clone request failed.
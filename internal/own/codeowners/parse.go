@@ -3,6 +3,7 @@ package codeowners
 import (
 	"bufio"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -34,25 +35,43 @@ func Parse(codeownersFile io.Reader) (*codeownerspb.File, error) {
 		// error metadata.
 		r := codeownerspb.Rule{
 			Pattern:     unescape(pattern),
-			SectionName: strings.TrimSpace(strings.ToLower(p.section)),
+			SectionName: sectionName(p.section),
 		}
 		for _, ownerText := range owners {
-			var o codeownerspb.Owner
-			if strings.HasPrefix(ownerText, "@") {
-				o.Handle = strings.TrimPrefix(ownerText, "@")
-			} else {
-				// Note: we assume owner text is an email if it does not
-				// start with an `@` which would make it a handle.
-				o.Email = ownerText
-			}
-			r.Owner = append(r.Owner, &o)
+			r.Owner = append(r.Owner, parseOwner(ownerText))
 		}
 		rs = append(rs, &r)
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
-	return &codeownerspb.File{Rule: rs}, nil
+	f := &codeownerspb.File{Rule: rs, Section: p.sections}
+	// Sections are a GitLab feature, so a file that declares them is meant
+	// to be evaluated with GitLab semantics.
+	if len(p.sections) > 0 {
+		f.MatchingMode = codeownerspb.MatchingMode_MATCHING_MODE_GITLAB
+	}
+	return f, nil
+}
+
+// parseOwner returns the owner denoted by the given text, which is either
+// a handle starting with `@` or an email.
+func parseOwner(ownerText string) *codeownerspb.Owner {
+	var o codeownerspb.Owner
+	if strings.HasPrefix(ownerText, "@") {
+		o.Handle = strings.TrimPrefix(ownerText, "@")
+	} else {
+		// Note: we assume owner text is an email if it does not
+		// start with an `@` which would make it a handle.
+		o.Email = ownerText
+	}
+	return &o
+}
+
+// sectionName normalizes a section name as declared in the file, since
+// section names are case-insensitive.
+func sectionName(name string) string {
+	return strings.TrimSpace(strings.ToLower(name))
 }
 
 // parsing implements matching and parsing primitives for CODEOWNERS files
@@ -64,6 +83,8 @@ type parsing struct {
 	line string
 	// The most recently defined section, or "" if none.
 	section string
+	// All the sections defined so far, in order of first declaration.
+	sections []*codeownerspb.Section
 }
 
 // nextLine advances parsing to focus on the next line.
@@ -104,15 +125,54 @@ func (p *parsing) matchRule() (string, []string, bool) {
 	return filePattern, owners, true
 }
 
-var sectionPattern = lazyregexp.New(`^\s*\[([^\]]+)\]\s*$`)
+// sectionPattern is expected to match a section header line like:
+// `^[Section name][2] @default-owner owner@example.com`.
+//
+//	^ ^^^^^^^^^^^^  ^  ^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^^
+//
+// The optional `^` marks an optional section, the first bracketed group
+// holds the section name, the optional second bracketed group holds the
+// number of required approvals, and the remaining text lists the default
+// owners of the section.
+var sectionPattern = lazyregexp.New(`^\s*(\^)?\[([^\]]+)\](?:\[(\d+)\])?((?:\s+\S+)*)\s*$`)
 
-// matchSection tries to extract a section which looks like `[section name]`.
+// matchSection tries to extract a section which looks like `[section name]`,
+// and records its properties.
 func (p *parsing) matchSection() bool {
 	match := sectionPattern.FindStringSubmatch(p.lineWithoutComments())
-	if len(match) != 2 {
+	if len(match) != 5 {
 		return false
 	}
-	p.section = match[1]
+	p.section = match[2]
+
+	name := sectionName(p.section)
+	var section *codeownerspb.Section
+	for _, s := range p.sections {
+		if s.Name == name {
+			section = s
+			break
+		}
+	}
+	// A section declared more than once is combined with its first
+	// declaration, which determines whether it is optional and how many
+	// approvals it requires.
+	if section == nil {
+		section = &codeownerspb.Section{
+			Name:     name,
+			Optional: match[1] != "",
+		}
+		if match[3] != "" {
+			approvals, err := strconv.Atoi(match[3])
+			if err != nil {
+				return false
+			}
+			section.RequiredApprovals = int32(approvals)
+		}
+		p.sections = append(p.sections, section)
+	}
+	for _, ownerText := range strings.Fields(match[4]) {
+		section.DefaultOwner = append(section.DefaultOwner, parseOwner(ownerText))
+	}
 	return true
 }
 
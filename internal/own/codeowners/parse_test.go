@@ -336,7 +336,15 @@ README.md  @docs
 			SectionName: "documentation",
 		},
 	}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{
+		{Name: "documentation"},
+		{Name: "database"},
+	}
+	assert.Equal(t, &codeownerspb.File{
+		Rule:         want,
+		Section:      wantSections,
+		MatchingMode: codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	}, got)
 }
 
 func TestParseAtHandle(t *testing.T) {
@@ -412,7 +420,14 @@ func TestParseSection(t *testing.T) {
 			{Handle: "own-pms"},
 		},
 	}}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{
+		{Name: "pm"},
+	}
+	assert.Equal(t, &codeownerspb.File{
+		Rule:         want,
+		Section:      wantSections,
+		MatchingMode: codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	}, got)
 }
 
 func TestParseManySections(t *testing.T) {
@@ -445,7 +460,15 @@ func TestParseManySections(t *testing.T) {
 			},
 		},
 	}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{
+		{Name: "pm"},
+		{Name: "docs"},
+	}
+	assert.Equal(t, &codeownerspb.File{
+		Rule:         want,
+		Section:      wantSections,
+		MatchingMode: codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	}, got)
 }
 
 func TestParseEmptyString(t *testing.T) {
@@ -496,5 +519,70 @@ func TestParseSectionWithComment(t *testing.T) {
 			},
 		},
 	}
-	assert.Equal(t, &codeownerspb.File{Rule: want}, got)
+	wantSections := []*codeownerspb.Section{
+		{Name: "section"},
+	}
+	assert.Equal(t, &codeownerspb.File{
+		Rule:         want,
+		Section:      wantSections,
+		MatchingMode: codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	}, got)
+}
+
+func TestParseSectionProperties(t *testing.T) {
+	got, err := codeowners.Parse(strings.NewReader(
+		`^[Optional] @optional-default
+		docs/
+		[Required][2] @team alice@example.com
+		/cmd/ @cmd-owner
+		[required] @another-default
+		/lib/`))
+	require.NoError(t, err)
+	want := []*codeownerspb.Rule{
+		{
+			Pattern:     "docs/",
+			SectionName: "optional",
+		},
+		{
+			Pattern:     "/cmd/",
+			SectionName: "required",
+			Owner: []*codeownerspb.Owner{
+				{Handle: "cmd-owner"},
+			},
+		},
+		{
+			Pattern:     "/lib/",
+			SectionName: "required",
+		},
+	}
+	wantSections := []*codeownerspb.Section{
+		{
+			Name:     "optional",
+			Optional: true,
+			DefaultOwner: []*codeownerspb.Owner{
+				{Handle: "optional-default"},
+			},
+		},
+		{
+			// The second declaration of the section is combined with the first.
+			Name:              "required",
+			RequiredApprovals: 2,
+			DefaultOwner: []*codeownerspb.Owner{
+				{Handle: "team"},
+				{Email: "alice@example.com"},
+				{Handle: "another-default"},
+			},
+		},
+	}
+	assert.Equal(t, &codeownerspb.File{
+		Rule:         want,
+		Section:      wantSections,
+		MatchingMode: codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	}, got)
+	assert.Equal(t, `^[optional] @optional-default
+docs/
+[required][2] @team alice@example.com @another-default
+/cmd/ @cmd-owner
+/lib/
+`, got.Repr())
 }
@@ -24,6 +24,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// MatchingMode determines the semantics of evaluating owners for a path,
+// which differ between GitHub and GitLab.
+type MatchingMode int32
+
+const (
+	// GitHub matching applies the owners of the last rule that matches
+	// the path in the whole file. Sections are disregarded.
+	MatchingMode_MATCHING_MODE_GITHUB MatchingMode = 0
+	// GitLab matching applies the owners of the last rule that matches
+	// the path within every section, and combines owners from all sections.
+	// Rules without owners apply the default owners of their section.
+	MatchingMode_MATCHING_MODE_GITLAB MatchingMode = 1
+)
+
+// Enum value maps for MatchingMode.
+var (
+	MatchingMode_name = map[int32]string{
+		0: "MATCHING_MODE_GITHUB",
+		1: "MATCHING_MODE_GITLAB",
+	}
+	MatchingMode_value = map[string]int32{
+		"MATCHING_MODE_GITHUB": 0,
+		"MATCHING_MODE_GITLAB": 1,
+	}
+)
+
+func (x MatchingMode) Enum() *MatchingMode {
+	p := new(MatchingMode)
+	*p = x
+	return p
+}
+
+func (x MatchingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_codeowners_proto_enumTypes[0].Descriptor()
+}
+
+func (MatchingMode) Type() protoreflect.EnumType {
+	return &file_codeowners_proto_enumTypes[0]
+}
+
+func (x MatchingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchingMode.Descriptor instead.
+func (MatchingMode) EnumDescriptor() ([]byte, []int) {
+	return file_codeowners_proto_rawDescGZIP(), []int{0}
+}
+
 // File represents the contents of a single CODEOWNERS file.
 // As specified by various CODEOWNERS implementations the following apply:
 //   - There is at most one CODEOWNERS file per repository.
@@ -40,6 +93,13 @@ type File struct {
 	unknownFields protoimpl.UnknownFields
 
 	Rule []*Rule `protobuf:"bytes,1,rep,name=rule,proto3" json:"rule,omitempty"`
+	// Sections holds the properties of every section declared in the file,
+	// in order of first declaration. Rules refer to their section by name.
+	// Sections are only supported by GitLab.
+	Section []*Section `protobuf:"bytes,2,rep,name=section,proto3" json:"section,omitempty"`
+	// Matching mode determines how owners are evaluated for a path.
+	// It is set to GitLab matching when the file declares any sections.
+	MatchingMode MatchingMode `protobuf:"varint,3,opt,name=matching_mode,json=matchingMode,proto3,enum=codeowners.MatchingMode" json:"matching_mode,omitempty"`
 }
 
 func (x *File) Reset() {
@@ -81,6 +141,105 @@ func (x *File) GetRule() []*Rule {
 	return nil
 }
 
+func (x *File) GetSection() []*Section {
+	if x != nil {
+		return x.Section
+	}
+	return nil
+}
+
+func (x *File) GetMatchingMode() MatchingMode {
+	if x != nil {
+		return x.MatchingMode
+	}
+	return MatchingMode_MATCHING_MODE_GITHUB
+}
+
+// Section holds the properties of a GitLab CODEOWNERS section, which is
+// declared with a header like `^[Section name][2] @default-owner`.
+// If the same section is declared more than once, the declarations are
+// combined: the first declaration determines whether the section is optional
+// and how many approvals it requires, and default owners are accumulated.
+type Section struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the section, in lowercase, as section names
+	// are case-insensitive. Matches the section_name of its rules.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional sections are declared with a `^` prefix. Approval
+	// from owners of optional sections is not required.
+	Optional bool `protobuf:"varint,2,opt,name=optional,proto3" json:"optional,omitempty"`
+	// Number of approvals required from the owners of the section,
+	// as declared by a `[2]` suffix. Zero if not declared, in which
+	// case a single approval is required.
+	RequiredApprovals int32 `protobuf:"varint,3,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	// Default owners are listed after the section header, and apply
+	// to rules in the section that do not list any owners.
+	DefaultOwner []*Owner `protobuf:"bytes,4,rep,name=default_owner,json=defaultOwner,proto3" json:"default_owner,omitempty"`
+}
+
+func (x *Section) Reset() {
+	*x = Section{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_codeowners_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Section) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Section) ProtoMessage() {}
+
+func (x *Section) ProtoReflect() protoreflect.Message {
+	mi := &file_codeowners_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Section.ProtoReflect.Descriptor instead.
+func (*Section) Descriptor() ([]byte, []int) {
+	return file_codeowners_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Section) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Section) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+func (x *Section) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *Section) GetDefaultOwner() []*Owner {
+	if x != nil {
+		return x.DefaultOwner
+	}
+	return nil
+}
+
 // Rule associates a single pattern to match a path with an owner.
 type Rule struct {
 	state         protoimpl.MessageState
@@ -137,7 +296,7 @@ type Rule struct {
 func (x *Rule) Reset() {
 	*x = Rule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_codeowners_proto_msgTypes[1]
+		mi := &file_codeowners_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -150,7 +309,7 @@ func (x *Rule) String() string {
 func (*Rule) ProtoMessage() {}
 
 func (x *Rule) ProtoReflect() protoreflect.Message {
-	mi := &file_codeowners_proto_msgTypes[1]
+	mi := &file_codeowners_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -163,7 +322,7 @@ func (x *Rule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Rule.ProtoReflect.Descriptor instead.
 func (*Rule) Descriptor() ([]byte, []int) {
-	return file_codeowners_proto_rawDescGZIP(), []int{1}
+	return file_codeowners_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Rule) GetPattern() string {
@@ -207,7 +366,7 @@ type Owner struct {
 func (x *Owner) Reset() {
 	*x = Owner{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_codeowners_proto_msgTypes[2]
+		mi := &file_codeowners_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -220,7 +379,7 @@ func (x *Owner) String() string {
 func (*Owner) ProtoMessage() {}
 
 func (x *Owner) ProtoReflect() protoreflect.Message {
-	mi := &file_codeowners_proto_msgTypes[2]
+	mi := &file_codeowners_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -233,7 +392,7 @@ func (x *Owner) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Owner.ProtoReflect.Descriptor instead.
 func (*Owner) Descriptor() ([]byte, []int) {
-	return file_codeowners_proto_rawDescGZIP(), []int{2}
+	return file_codeowners_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Owner) GetHandle() string {
@@ -254,25 +413,47 @@ var File_codeowners_proto protoreflect.FileDescriptor
 
 var file_codeowners_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x2c,
-	0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72,
-	0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0x6c, 0x0a, 0x04,
-	0x52, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x27,
-	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x9a,
+	0x01, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x73, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x2d, 0x0a,
+	0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0d,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73,
+	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0c, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x07,
+	0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72,
-	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x35, 0x0a, 0x05, 0x4f, 0x77,
-	0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69,
-	0x6c, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x6f, 0x77, 0x6e, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x6c,
+	0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x12, 0x27, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x35, 0x0a, 0x05,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x2a, 0x42, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x47, 0x49, 0x54, 0x48, 0x55, 0x42, 0x10, 0x00, 0x12, 0x18, 0x0a,
+	0x14, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x47,
+	0x49, 0x54, 0x4c, 0x41, 0x42, 0x10, 0x01, 0x42, 0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x77, 0x6e, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -287,20 +468,26 @@ func file_codeowners_proto_rawDescGZIP() []byte {
 	return file_codeowners_proto_rawDescData
 }
 
-var file_codeowners_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_codeowners_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_codeowners_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_codeowners_proto_goTypes = []interface{}{
-	(*File)(nil),  // 0: codeowners.File
-	(*Rule)(nil),  // 1: codeowners.Rule
-	(*Owner)(nil), // 2: codeowners.Owner
+	(MatchingMode)(0), // 0: codeowners.MatchingMode
+	(*File)(nil),      // 1: codeowners.File
+	(*Section)(nil),   // 2: codeowners.Section
+	(*Rule)(nil),      // 3: codeowners.Rule
+	(*Owner)(nil),     // 4: codeowners.Owner
 }
 var file_codeowners_proto_depIdxs = []int32{
-	1, // 0: codeowners.File.rule:type_name -> codeowners.Rule
-	2, // 1: codeowners.Rule.owner:type_name -> codeowners.Owner
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: codeowners.File.rule:type_name -> codeowners.Rule
+	2, // 1: codeowners.File.section:type_name -> codeowners.Section
+	0, // 2: codeowners.File.matching_mode:type_name -> codeowners.MatchingMode
+	4, // 3: codeowners.Section.default_owner:type_name -> codeowners.Owner
+	4, // 4: codeowners.Rule.owner:type_name -> codeowners.Owner
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_codeowners_proto_init() }
@@ -322,7 +509,7 @@ func file_codeowners_proto_init() {
 			}
 		}
 		file_codeowners_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Rule); i {
+			switch v := v.(*Section); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -334,6 +521,18 @@ func file_codeowners_proto_init() {
 			}
 		}
 		file_codeowners_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_codeowners_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Owner); i {
 			case 0:
 				return &v.state
@@ -351,13 +550,14 @@ func file_codeowners_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_codeowners_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_codeowners_proto_goTypes,
 		DependencyIndexes: file_codeowners_proto_depIdxs,
+		EnumInfos:         file_codeowners_proto_enumTypes,
 		MessageInfos:      file_codeowners_proto_msgTypes,
 	}.Build()
 	File_codeowners_proto = out.File
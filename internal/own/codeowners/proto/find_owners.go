@@ -11,20 +11,72 @@ import (
 // FindOwners returns the Owners associated with given path as per this CODEOWNERS file.
 // Rules are evaluated in order: Returned owners come from the rule which pattern matches
 // given path, that is the furthest down the file.
+//
+// With GitLab matching mode, this applies within every section separately, and the
+// owners from all sections are returned in the order the sections are declared.
+// A matching rule that lists no owners takes the default owners of its section.
 func (x *File) FindOwners(path string) []*Owner {
+	if x.GetMatchingMode() == MatchingMode_MATCHING_MODE_GITLAB {
+		return x.findOwnersGitLab(path)
+	}
 	var owners []*Owner
 	for _, rule := range x.GetRule() {
-		glob, err := compile(rule.GetPattern())
-		if err != nil {
+		if rule.matches(path) {
+			owners = rule.GetOwner()
+		}
+	}
+	return owners
+}
+
+func (x *File) findOwnersGitLab(path string) []*Owner {
+	// The last matching rule of every section, keyed by section name.
+	// Rules outside of any section are in the section named "".
+	matching := map[string]*Rule{}
+	var sectionNames []string
+	for _, rule := range x.GetRule() {
+		name := rule.GetSectionName()
+		if _, ok := matching[name]; !ok {
+			sectionNames = append(sectionNames, name)
+			matching[name] = nil
+		}
+		if rule.matches(path) {
+			matching[name] = rule
+		}
+	}
+
+	var owners []*Owner
+	for _, name := range sectionNames {
+		rule := matching[name]
+		if rule == nil {
 			continue
 		}
-		if glob.match(path) {
-			owners = rule.GetOwner()
+		if len(rule.GetOwner()) > 0 {
+			owners = append(owners, rule.GetOwner()...)
+		} else if section := x.findSection(name); section != nil {
+			owners = append(owners, section.GetDefaultOwner()...)
 		}
 	}
 	return owners
 }
 
+// findSection returns the section with the given name, or nil if there is none.
+func (x *File) findSection(name string) *Section {
+	for _, s := range x.GetSection() {
+		if s.GetName() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func (r *Rule) matches(path string) bool {
+	glob, err := compile(r.GetPattern())
+	if err != nil {
+		return false
+	}
+	return glob.match(path)
+}
+
 const separator = "/"
 
 // patternPart implements matching for a single chunk of a glob pattern
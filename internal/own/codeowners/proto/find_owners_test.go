@@ -160,3 +160,50 @@ func TestFileOwnersOrder(t *testing.T) {
 	got := file.FindOwners("/top-level-directory/some/path/main.go")
 	assert.Equal(t, wantOwner, got)
 }
+
+func TestFileOwnersGitLabSections(t *testing.T) {
+	file := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern: "*",
+				Owner:   []*codeownerspb.Owner{{Handle: "default-owner"}},
+			},
+			{
+				Pattern:     "/docs/",
+				Owner:       []*codeownerspb.Owner{{Handle: "docs-owner"}},
+				SectionName: "documentation",
+			},
+			{
+				// The last matching rule within a section is picked.
+				Pattern:     "/docs/internal/",
+				Owner:       []*codeownerspb.Owner{{Handle: "internal-docs-owner"}},
+				SectionName: "documentation",
+			},
+			{
+				// A rule without owners takes the section default owners.
+				Pattern:     "/docs/",
+				SectionName: "pm",
+			},
+		},
+		Section: []*codeownerspb.Section{
+			{Name: "documentation"},
+			{Name: "pm", DefaultOwner: []*codeownerspb.Owner{{Email: "pm@example.com"}}},
+		},
+		MatchingMode: codeownerspb.MatchingMode_MATCHING_MODE_GITLAB,
+	}
+
+	got := file.FindOwners("/docs/internal/index.md")
+	assert.Equal(t, []*codeownerspb.Owner{
+		{Handle: "default-owner"},
+		{Handle: "internal-docs-owner"},
+		{Email: "pm@example.com"},
+	}, got)
+
+	got = file.FindOwners("/main.go")
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "default-owner"}}, got)
+
+	// GitHub matching disregards sections, so the last matching rule wins.
+	file.MatchingMode = codeownerspb.MatchingMode_MATCHING_MODE_GITHUB
+	got = file.FindOwners("/docs/internal/index.md")
+	assert.Empty(t, got)
+}
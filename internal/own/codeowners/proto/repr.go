@@ -15,19 +15,35 @@ func (f *File) Repr() string {
 	var lastSeenSection string
 	for _, r := range f.GetRule() {
 		if s := r.SectionName; s != lastSeenSection {
-			fmt.Fprintf(w, "[%s]\n", s)
+			reprSectionHeader(w, s, f.findSection(s))
 			lastSeenSection = s
 		}
 		fmt.Fprint(w, r.Pattern)
-		for _, o := range r.GetOwner() {
-			if h := o.GetHandle(); h != "" {
-				fmt.Fprintf(w, " @%s", h)
-			}
-			if e := o.GetEmail(); e != "" {
-				fmt.Fprintf(w, " %s", e)
-			}
-		}
+		reprOwners(w, r.GetOwner())
 		fmt.Fprintln(w)
 	}
 	return w.String()
 }
+
+func reprSectionHeader(w *strings.Builder, name string, section *Section) {
+	if section.GetOptional() {
+		fmt.Fprint(w, "^")
+	}
+	fmt.Fprintf(w, "[%s]", name)
+	if n := section.GetRequiredApprovals(); n > 0 {
+		fmt.Fprintf(w, "[%d]", n)
+	}
+	reprOwners(w, section.GetDefaultOwner())
+	fmt.Fprintln(w)
+}
+
+func reprOwners(w *strings.Builder, owners []*Owner) {
+	for _, o := range owners {
+		if h := o.GetHandle(); h != "" {
+			fmt.Fprintf(w, " @%s", h)
+		}
+		if e := o.GetEmail(); e != "" {
+			fmt.Fprintf(w, " %s", e)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// The namespaces and actions of the permissions defined in schema.yaml.
+const (
+	BatchChangesNamespace = "BATCHCHANGES"
+	CodeInsightsNamespace = "CODEINSIGHTS"
+	CodeMonitorsNamespace = "CODEMONITORS"
+
+	ReadAction  = "READ"
+	WriteAction = "WRITE"
+)
+
+// ErrNotAuthorized is returned when the current user is missing the permission
+// required to perform an action.
+type ErrNotAuthorized struct {
+	Namespace string
+	Action    string
+}
+
+func (e *ErrNotAuthorized) Error() string {
+	return fmt.Sprintf("user is missing permission %s:%s", e.Namespace, e.Action)
+}
+
+func (e *ErrNotAuthorized) Unauthorized() bool { return true }
+
+// CheckCurrentUserHasPermission returns an error if the current user isn't
+// granted the given permission globally. Site admins and internal actors are
+// granted all permissions.
+func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, namespace, action string) error {
+	return checkCurrentUserHasPermission(ctx, db, 0, namespace, action)
+}
+
+// CheckCurrentUserHasOrgPermission returns an error if the current user isn't
+// granted the given permission, either globally or within the organization with
+// the given ID.
+func CheckCurrentUserHasOrgPermission(ctx context.Context, db database.DB, orgID int32, namespace, action string) error {
+	return checkCurrentUserHasPermission(ctx, db, orgID, namespace, action)
+}
+
+// CheckCurrentUserHasPermissionInAnyOrg returns an error if the current user
+// isn't granted the given permission, neither globally nor within any
+// organization. It guards entry points that aren't tied to a namespace yet,
+// which have to check the permission for the namespace once it is known.
+func CheckCurrentUserHasPermissionInAnyOrg(ctx context.Context, db database.DB, namespace, action string) error {
+	return checkCurrentUserHasPermission(ctx, db, anyOrg, namespace, action)
+}
+
+// anyOrg matches permissions granted within any organization.
+const anyOrg int32 = -1
+
+func checkCurrentUserHasPermission(ctx context.Context, db database.DB, orgID int32, namespace, action string) error {
+	a := actor.FromContext(ctx)
+	if a.IsInternal() {
+		return nil
+	}
+	if !a.IsAuthenticated() {
+		return auth.ErrNotAuthenticated
+	}
+
+	set, err := permissionsFromContext(ctx, db)
+	if err != nil {
+		return err
+	}
+	if !set.has(orgID, namespace, action) {
+		return &ErrNotAuthorized{Namespace: namespace, Action: action}
+	}
+	return nil
+}
+
+// permissionSet is the set of permissions granted to a user.
+type permissionSet struct {
+	siteAdmin bool
+	granted   []*types.GrantedPermission
+}
+
+func (s *permissionSet) has(orgID int32, namespace, action string) bool {
+	if s.siteAdmin {
+		return true
+	}
+	// Permissions which aren't granted, including ones which don't exist in
+	// the database, are denied.
+	for _, p := range s.granted {
+		if p.Namespace != namespace || p.Action != action {
+			continue
+		}
+		if p.OrgID == 0 || p.OrgID == orgID || orgID == anyOrg {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchPermissionSet(ctx context.Context, db database.DB) (*permissionSet, error) {
+	user, err := auth.CurrentUser(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, auth.ErrNotAuthenticated
+	}
+	if user.SiteAdmin {
+		return &permissionSet{siteAdmin: true}, nil
+	}
+
+	granted, err := db.Permissions().ListForUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &permissionSet{granted: granted}, nil
+}
+
+type permissionsContextKey struct{}
+
+// permissionSetFetcher lazily fetches the permission set of the actor of a
+// request, so that checking multiple permissions while serving a single request
+// only queries the database once.
+type permissionSetFetcher struct {
+	db database.DB
+
+	once sync.Once
+	// actor is the actor that was used to populate set
+	actor *actor.Actor
+	set   *permissionSet
+	err   error
+}
+
+func (f *permissionSetFetcher) fetch(ctx context.Context) (*permissionSet, error) {
+	f.once.Do(func() {
+		f.actor = actor.FromContext(ctx)
+		f.set, f.err = fetchPermissionSet(ctx, f.db)
+	})
+
+	if f.actor == actor.FromContext(ctx) {
+		return f.set, f.err
+	}
+
+	// The actor changed since the permission set was populated, so we can't
+	// use the cached set.
+	return fetchPermissionSet(ctx, f.db)
+}
+
+func permissionsFromContext(ctx context.Context, db database.DB) (*permissionSet, error) {
+	if f, ok := ctx.Value(permissionsContextKey{}).(*permissionSetFetcher); ok {
+		return f.fetch(ctx)
+	}
+	return fetchPermissionSet(ctx, db)
+}
+
+// WithPermissionCache adds a permission set cache to the context, so that
+// permission checks using the returned context share the permissions fetched
+// from the database.
+func WithPermissionCache(ctx context.Context, db database.DB) context.Context {
+	return context.WithValue(ctx, permissionsContextKey{}, &permissionSetFetcher{db: db})
+}
+
+// Middleware caches the permissions of the current user for the duration of
+// each request.
+func Middleware(db database.DB, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithPermissionCache(r.Context(), db)))
+	})
+}
@@ -0,0 +1,109 @@
+package rbac
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCheckCurrentUserHasPermission(t *testing.T) {
+	newDB := func(siteAdmin bool, granted ...*types.GrantedPermission) (*database.MockDB, *database.MockPermissionStore) {
+		users := database.NewMockUserStore()
+		users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: siteAdmin}, nil)
+
+		permissions := database.NewMockPermissionStore()
+		permissions.ListForUserFunc.SetDefaultReturn(granted, nil)
+
+		db := database.NewMockDB()
+		db.UsersFunc.SetDefaultReturn(users)
+		db.PermissionsFunc.SetDefaultReturn(permissions)
+		return db, permissions
+	}
+
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(1))
+
+	t.Run("internal actor", func(t *testing.T) {
+		db, _ := newDB(false)
+		ctx := actor.WithInternalActor(context.Background())
+		assert.NoError(t, CheckCurrentUserHasPermission(ctx, db, BatchChangesNamespace, WriteAction))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		db, _ := newDB(false)
+		err := CheckCurrentUserHasPermission(context.Background(), db, BatchChangesNamespace, WriteAction)
+		assert.ErrorIs(t, err, auth.ErrNotAuthenticated)
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db, permissions := newDB(true)
+		assert.NoError(t, CheckCurrentUserHasPermission(userCtx, db, BatchChangesNamespace, WriteAction))
+		mockassert.NotCalled(t, permissions.ListForUserFunc)
+	})
+
+	t.Run("granted", func(t *testing.T) {
+		db, _ := newDB(false, &types.GrantedPermission{Namespace: BatchChangesNamespace, Action: WriteAction})
+		assert.NoError(t, CheckCurrentUserHasPermission(userCtx, db, BatchChangesNamespace, WriteAction))
+	})
+
+	t.Run("not granted", func(t *testing.T) {
+		db, _ := newDB(false, &types.GrantedPermission{Namespace: BatchChangesNamespace, Action: ReadAction})
+		err := CheckCurrentUserHasPermission(userCtx, db, BatchChangesNamespace, WriteAction)
+		assert.Equal(t, &ErrNotAuthorized{Namespace: BatchChangesNamespace, Action: WriteAction}, err)
+	})
+
+	t.Run("unknown permission", func(t *testing.T) {
+		db, _ := newDB(false, &types.GrantedPermission{Namespace: BatchChangesNamespace, Action: WriteAction})
+		err := CheckCurrentUserHasPermission(userCtx, db, "BATCHCHANGE", WriteAction)
+		assert.Equal(t, &ErrNotAuthorized{Namespace: "BATCHCHANGE", Action: WriteAction}, err)
+		err = CheckCurrentUserHasPermission(userCtx, db, CodeMonitorsNamespace, WriteAction)
+		assert.Equal(t, &ErrNotAuthorized{Namespace: CodeMonitorsNamespace, Action: WriteAction}, err)
+
+		db, _ = newDB(true)
+		assert.NoError(t, CheckCurrentUserHasPermission(userCtx, db, "BATCHCHANGE", WriteAction))
+	})
+
+	t.Run("org scoped", func(t *testing.T) {
+		db, _ := newDB(false, &types.GrantedPermission{Namespace: BatchChangesNamespace, Action: WriteAction, OrgID: 2})
+		assert.Error(t, CheckCurrentUserHasPermission(userCtx, db, BatchChangesNamespace, WriteAction))
+		assert.Error(t, CheckCurrentUserHasOrgPermission(userCtx, db, 3, BatchChangesNamespace, WriteAction))
+		assert.NoError(t, CheckCurrentUserHasOrgPermission(userCtx, db, 2, BatchChangesNamespace, WriteAction))
+		assert.NoError(t, CheckCurrentUserHasPermissionInAnyOrg(userCtx, db, BatchChangesNamespace, WriteAction))
+		assert.Error(t, CheckCurrentUserHasPermissionInAnyOrg(userCtx, db, BatchChangesNamespace, ReadAction))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	permissions := database.NewMockPermissionStore()
+	permissions.ListForUserFunc.SetDefaultReturn([]*types.GrantedPermission{{Namespace: BatchChangesNamespace, Action: ReadAction}}, nil)
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.PermissionsFunc.SetDefaultReturn(permissions)
+
+	handler := Middleware(db, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, CheckCurrentUserHasPermission(r.Context(), db, BatchChangesNamespace, ReadAction))
+		}
+
+		// A different actor doesn't use the cached permission set.
+		ctx := actor.WithActor(r.Context(), actor.FromUser(2))
+		require.NoError(t, CheckCurrentUserHasPermission(ctx, db, BatchChangesNamespace, ReadAction))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req = req.WithContext(actor.WithActor(req.Context(), actor.FromUser(1)))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	mockassert.CalledN(t, permissions.ListForUserFunc, 2)
+}
@@ -93,3 +93,14 @@ func ComparePermissions(dbPerms []*types.Permission) (added []database.CreatePer
 
 	return
 }
+
+// IsDefaultApplied returns whether the permissions of the given namespace are
+// granted to the DEFAULT role when they are created.
+func IsDefaultApplied(namespace string) bool {
+	for _, n := range schemaYaml.Namespaces {
+		if n.Name == namespace {
+			return n.DefaultApply
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Package rbactest provides helpers for tests that exercise RBAC permission
+// checks against a real database.
+package rbactest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// GrantToDefaultRole creates the read and write permissions of namespace and
+// grants them to the DEFAULT role, which all users hold. The frontend does the
+// same at startup for the namespaces that are applied by default, but test
+// databases start out without any permissions, so every permission check
+// fails for users who aren't site admins.
+func GrantToDefaultRole(t *testing.T, db database.DB, namespace string) {
+	t.Helper()
+
+	q := sqlf.Sprintf(grantToDefaultRoleQueryFmtstr, types.DefaultRoleName, namespace, namespace)
+	if _, err := db.ExecContext(context.Background(), q.Query(sqlf.PostgresBindVar), q.Args()...); err != nil {
+		t.Fatalf("failed to grant %s permissions to the default role: %s", namespace, err)
+	}
+}
+
+const grantToDefaultRoleQueryFmtstr = `
+WITH default_role AS (
+	INSERT INTO roles (name, readonly) VALUES (%s, true)
+	ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+	RETURNING id
+),
+perms AS (
+	INSERT INTO permissions (namespace, action) VALUES (%s, 'READ'), (%s, 'WRITE')
+	ON CONFLICT (namespace, action) DO UPDATE SET action = EXCLUDED.action
+	RETURNING id
+)
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT default_role.id, perms.id FROM default_role, perms
+ON CONFLICT DO NOTHING
+`
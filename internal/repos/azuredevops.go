@@ -0,0 +1,162 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps
+// connection configured in Sourcegraph via the external services
+// configuration.
+type AzureDevOpsSource struct {
+	svc       *types.ExternalService
+	cli       *azuredevops.Client
+	serviceID string
+	config    schema.AzureDevOpsConnection
+	exclude   excludeFunc
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external
+// service.
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:       svc,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		config:    c,
+		exclude:   exclude,
+	}, nil
+}
+
+// CheckConnection checks whether the configured credentials can be used to
+// talk to Azure DevOps.
+func (s *AzureDevOpsSource) CheckConnection(ctx context.Context) error {
+	_, err := s.cli.GetAuthorizedProfile(ctx)
+	return err
+}
+
+// ListRepos returns all Azure DevOps repositories configured with this
+// AzureDevOpsSource's config.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	// Projects and organizations may overlap, so we have to make sure every
+	// repository is only sent once.
+	seen := make(map[string]struct{})
+
+	processRepos := func(repos []azuredevops.Repository) {
+		for _, r := range repos {
+			if _, ok := seen[r.ID]; ok {
+				continue
+			}
+			seen[r.ID] = struct{}{}
+
+			if r.IsDisabled || s.excludes(r) {
+				continue
+			}
+
+			repo, err := s.makeRepo(r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+	}
+
+	names := make([]string, 0, len(s.config.Projects)+len(s.config.Orgs))
+	names = append(names, s.config.Projects...)
+	names = append(names, s.config.Orgs...)
+
+	for _, name := range names {
+		repos, err := s.cli.ListRepositoriesByProjectOrOrg(ctx, azuredevops.ListRepositoriesByProjectOrOrgArgs{
+			ProjectOrOrgName: name,
+		})
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "listing repositories of %q", name)}
+			continue
+		}
+		processRepos(repos)
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *AzureDevOpsSource) makeRepo(r azuredevops.Repository) (*types.Repo, error) {
+	org, err := r.Namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	name := path.Join(s.cli.URL.Host, s.cli.URL.Path, org, r.Project.Name, r.Name)
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name:    api.RepoName(name),
+		URI:     name,
+		Fork:    r.IsFork,
+		Private: r.Project.Visibility != "public",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.CloneURL,
+			},
+		},
+		Metadata: &r,
+	}, nil
+}
+
+// excludes returns true if the repository matches one of the exclude rules of
+// the connection. Exact rules match against "org/project/repo".
+func (s *AzureDevOpsSource) excludes(r azuredevops.Repository) bool {
+	org, _ := r.Namespace()
+	return s.exclude(fmt.Sprintf("%s/%s/%s", org, r.Project.Name, r.Name)) || s.exclude(r.ID)
+}
@@ -0,0 +1,45 @@
+package repos
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	conf := &schema.AzureDevOpsConnection{
+		Url:      "https://dev.azure.com",
+		Username: os.Getenv("AZURE_DEV_OPS_USERNAME"),
+		Token:    os.Getenv("AZURE_DEV_OPS_TOKEN"),
+		Projects: []string{"sgtestazure/sgtestazure"},
+		Orgs:     []string{"sgtestazure"},
+		Exclude: []*schema.ExcludedAzureDevOpsRepo{
+			{Name: "sgtestazure/sgtestazure/sgtestazure3"},
+		},
+	}
+	cf, save := newClientFactory(t, t.Name())
+	defer save(t)
+
+	svc := &types.ExternalService{
+		Kind:   extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	ctx := context.Background()
+	src, err := NewAzureDevOpsSource(ctx, svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.AssertGolden(t, "testdata/sources/AZUREDEVOPS/"+t.Name(), update(t.Name()), repos)
+}
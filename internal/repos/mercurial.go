@@ -0,0 +1,133 @@
+package repos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hgweb"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields Mercurial repositories from a single hgweb server
+// configured in Sourcegraph via the external services configuration.
+// Gitserver converts them to Git when cloning.
+type MercurialSource struct {
+	svc       *types.ExternalService
+	config    *schema.MercurialConnection
+	cli       *hgweb.Client
+	serviceID string
+	excluded  map[string]struct{}
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external
+// service.
+func NewMercurialSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*MercurialSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := hgweb.NewClient(c.Url, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]struct{}, len(c.Exclude))
+	for _, path := range c.Exclude {
+		excluded[strings.Trim(path, "/")] = struct{}{}
+	}
+
+	return &MercurialSource{
+		svc:       svc,
+		config:    &c,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		excluded:  excluded,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors returned
+// from the subsequent calls. This is going to be expanded as part of issue #44683
+// to actually only return true if the source can serve requests.
+func (s *MercurialSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns the repositories configured in the repos field of the
+// connection, or all repositories listed by the hgweb server if it is empty.
+func (s *MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	var hgRepos []*hgweb.Repo
+	if len(s.config.Repos) > 0 {
+		for _, path := range s.config.Repos {
+			hgRepos = append(hgRepos, &hgweb.Repo{Path: strings.Trim(path, "/")})
+		}
+	} else {
+		var err error
+		if hgRepos, err = s.cli.ListRepos(ctx); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+	}
+
+	for _, r := range hgRepos {
+		if _, ok := s.excluded[r.Path]; ok {
+			continue
+		}
+		results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *MercurialSource) makeRepo(r *hgweb.Repo) *types.Repo {
+	urn := s.svc.URN()
+
+	pattern := s.config.RepositoryPathPattern
+	if pattern == "" {
+		pattern = "{host}/{path}"
+	}
+	name := strings.NewReplacer(
+		"{host}", s.cli.URL.Hostname(),
+		"{path}", r.Path,
+	).Replace(pattern)
+
+	return &types.Repo{
+		Name:        api.RepoName(name),
+		URI:         name,
+		Description: r.Description,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.Path,
+			ServiceType: extsvc.TypeMercurial,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.cli.RepoURL(r.Path).String(),
+			},
+		},
+		Metadata: r,
+	}
+}
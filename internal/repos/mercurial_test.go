@@ -0,0 +1,133 @@
+package repos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/hgweb"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMercurialSource_ListRepos(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "alice" || password != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/hg/" || r.URL.Query().Get("style") != "json" {
+			http.Error(w, r.URL.String()+" not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+  "entries": [{
+    "name": "project",
+    "description": "The project",
+    "contact": "alice",
+    "lastchange": [1674494000.0, 0],
+    "labels": []
+  }, {
+    "name": "group/other",
+    "description": "unknown",
+    "contact": "",
+    "lastchange": [1674494000.0, -3600],
+    "labels": []
+  }, {
+    "name": "archive/old",
+    "description": "unknown",
+    "contact": "",
+    "lastchange": [1674494000.0, 0],
+    "labels": []
+  }]
+}`))
+	}))
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.User = url.UserPassword("alice", "secret")
+	u.Path = "/hg"
+
+	host := u.Hostname()
+	serviceID := s.URL + "/hg/"
+	cloneURL := func(path string) string {
+		return u.String() + "/" + path
+	}
+	repo := func(name, path, description string, metadata *hgweb.Repo) *types.Repo {
+		return &types.Repo{
+			Name:        api.RepoName(name),
+			URI:         name,
+			Description: description,
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          path,
+				ServiceType: extsvc.TypeMercurial,
+				ServiceID:   serviceID,
+			},
+			Sources: map[string]*types.SourceInfo{
+				"extsvc:mercurial:1": {
+					ID:       "extsvc:mercurial:1",
+					CloneURL: cloneURL(path),
+				},
+			},
+			Metadata: metadata,
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		conn *schema.MercurialConnection
+		want []*types.Repo
+	}{{
+		name: "index",
+		conn: &schema.MercurialConnection{
+			Url:     u.String(),
+			Exclude: []string{"archive/old"},
+		},
+		want: []*types.Repo{
+			repo(host+"/project", "project", "The project", &hgweb.Repo{Path: "project", Description: "The project", Contact: "alice"}),
+			repo(host+"/group/other", "group/other", "unknown", &hgweb.Repo{Path: "group/other", Description: "unknown"}),
+		},
+	}, {
+		name: "repos",
+		conn: &schema.MercurialConnection{
+			Url:                   u.String(),
+			Repos:                 []string{"/project/", "group/other"},
+			RepositoryPathPattern: "hg/{path}",
+		},
+		want: []*types.Repo{
+			repo("hg/project", "project", "", &hgweb.Repo{Path: "project"}),
+			repo("hg/group/other", "group/other", "", &hgweb.Repo{Path: "group/other"}),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &types.ExternalService{
+				ID:     1,
+				Kind:   extsvc.KindMercurial,
+				Config: extsvc.NewUnencryptedConfig(marshalJSON(t, tc.conn)),
+			}
+
+			ctx := context.Background()
+			src, err := NewMercurialSource(ctx, svc, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			repos, err := listAll(ctx, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, repos); diff != "" {
+				t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,12 +15,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // schedulerConfig tracks the active scheduler configuration.
@@ -38,6 +40,10 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 
 	logger = logger.Scoped("RunScheduler", "git fetch scheduler")
 
+	// The schedule is persisted independently of the configuration, so that
+	// it is up to date when the scheduler is started again.
+	go scheduler.runPersistLoop(ctx)
+
 	conf.Watch(func() {
 		c := conf.Get()
 
@@ -88,6 +94,9 @@ const (
 
 	// maxDelay is the maximum amount of time between scheduled updates for a single repository.
 	maxDelay = 8 * time.Hour
+
+	// persistInterval is how often changes to the schedule are written to the database.
+	persistInterval = 10 * time.Second
 )
 
 // UpdateScheduler schedules repo update (or clone) requests to gitserver.
@@ -108,11 +117,20 @@ const (
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
 // is limited by the gitMaxConcurrentClones site configuration.
+//
+// The schedule and the priorities of queued repos are periodically persisted in the
+// repo_update_schedule table, and restored by LoadSchedule when repo-updater starts, so that
+// a restart doesn't reset all backoff intervals and cause every repo to be fetched at once.
 type UpdateScheduler struct {
 	db          database.DB
 	updateQueue *updateQueue
 	schedule    *schedule
 	logger      log.Logger
+
+	// changes tracks the repos whose state has to be persisted.
+	changes *scheduleChanges
+	// persistMu ensures that changes are persisted in order.
+	persistMu sync.Mutex
 }
 
 // A configuredRepo represents the configuration data for a given repo from
@@ -131,20 +149,24 @@ const notifyChanBuffer = 1
 // NewUpdateScheduler returns a new scheduler.
 func NewUpdateScheduler(logger log.Logger, db database.DB) *UpdateScheduler {
 	updateSchedLogger := logger.Scoped("UpdateScheduler", "repo update scheduler")
+	changes := &scheduleChanges{ids: make(map[api.RepoID]bool)}
 
 	return &UpdateScheduler{
 		db: db,
 		updateQueue: &updateQueue{
 			index:         make(map[api.RepoID]*repoUpdate),
 			notifyEnqueue: make(chan struct{}, notifyChanBuffer),
+			changes:       changes,
 		},
 		schedule: &schedule{
 			index:         make(map[api.RepoID]*scheduledRepoUpdate),
 			wakeup:        make(chan struct{}, notifyChanBuffer),
 			randGenerator: rand.New(rand.NewSource(time.Now().UnixNano())),
 			logger:        updateSchedLogger.Scoped("Schedule", ""),
+			changes:       changes,
 		},
-		logger: updateSchedLogger,
+		logger:  updateSchedLogger,
+		changes: changes,
 	}
 }
 
@@ -178,6 +200,7 @@ func (s *UpdateScheduler) runSchedule() {
 		s.updateQueue.enqueue(repoUpdate.Repo, priorityLow)
 		repoUpdate.Due = timeNow().Add(repoUpdate.Interval)
 		heap.Fix(s.schedule, 0)
+		s.changes.updated(repoUpdate.Repo.ID)
 	}
 }
 
@@ -227,6 +250,8 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 					if !strings.Contains(resp.Error, ratelimit.ErrBlockAll.Error()) {
 						subLogger.Error("error updating repo", log.String("err", resp.Error), log.String("uri", string(repo.Name)))
 					}
+				} else if resp != nil && resp.LastFetched != nil {
+					s.schedule.setLastFetched(repo, *resp.LastFetched)
 				}
 
 				if interval := getCustomInterval(subLogger, conf.Get(), string(repo.Name)); interval > 0 {
@@ -414,22 +439,19 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 		Name: "repos",
 	}
 
-	s.schedule.mu.Lock()
-	schedule := schedule{
-		heap: make([]*scheduledRepoUpdate, len(s.schedule.heap)),
-	}
-	for i, update := range s.schedule.heap {
-		// Copy the scheduledRepoUpdate as a value so that
-		// popping off the heap here won't update the index value of the real heap, and
-		// we don't do a racy read on the repo pointer which may change concurrently in the real heap.
-		updateCopy := *update
-		schedule.heap[i] = &updateCopy
+	// The schedule is read from its last persisted state, which runPersistLoop
+	// keeps at most persistInterval behind.
+	schedules, err := s.db.RepoUpdateSchedule().List(ctx)
+	if err != nil {
+		s.logger.Warn("getting persisted schedule for debug page", log.Error(err))
 	}
-	s.schedule.mu.Unlock()
-
-	for len(schedule.heap) > 0 {
-		update := heap.Pop(&schedule).(*scheduledRepoUpdate)
-		data.Schedule = append(data.Schedule, update)
+	for _, sched := range schedules {
+		data.Schedule = append(data.Schedule, &scheduledRepoUpdate{
+			Repo:        configuredRepo{ID: sched.RepoID, Name: sched.RepoName},
+			Interval:    sched.Interval,
+			Due:         sched.DueAt,
+			LastFetched: sched.LastFetched,
+		})
 	}
 
 	s.updateQueue.mu.Lock()
@@ -452,7 +474,6 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 		data.UpdateQueue = append(data.UpdateQueue, update)
 	}
 
-	var err error
 	data.SyncJobs, err = s.db.ExternalServices().GetSyncJobs(ctx, database.ExternalServicesGetSyncJobsOptions{})
 	if err != nil {
 		s.logger.Warn("getting external service sync jobs for debug page", log.Error(err))
@@ -461,20 +482,24 @@ func (s *UpdateScheduler) DebugDump(ctx context.Context) any {
 	return &data
 }
 
-// ScheduleInfo returns the current schedule info for a repo.
-func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
+// ScheduleInfo returns the current schedule info for a repo. The schedule is
+// read from its last persisted state, which may be up to persistInterval old,
+// while the queue state, which includes in-flight updates, is read from memory.
+func (s *UpdateScheduler) ScheduleInfo(ctx context.Context, id api.RepoID) (*protocol.RepoUpdateSchedulerInfoResult, error) {
 	var result protocol.RepoUpdateSchedulerInfoResult
 
-	s.schedule.mu.Lock()
-	if update := s.schedule.index[id]; update != nil {
+	sched, err := s.db.RepoUpdateSchedule().GetByRepoID(ctx, id)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "getting persisted schedule")
+	}
+	if sched != nil {
 		result.Schedule = &protocol.RepoScheduleState{
-			Index:           update.Index,
-			Total:           len(s.schedule.index),
-			IntervalSeconds: int(update.Interval / time.Second),
-			Due:             update.Due,
+			Index:           sched.Index,
+			Total:           sched.Total,
+			IntervalSeconds: int(sched.Interval / time.Second),
+			Due:             sched.DueAt,
 		}
 	}
-	s.schedule.mu.Unlock()
 
 	s.updateQueue.mu.Lock()
 	if update := s.updateQueue.index[id]; update != nil {
@@ -487,7 +512,191 @@ func (s *UpdateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 	}
 	s.updateQueue.mu.Unlock()
 
-	return &result
+	return &result, nil
+}
+
+// LoadSchedule restores the schedule and the queued repos from the persisted
+// state. It should be called once before the scheduler is started.
+//
+// Repos that became due while the scheduler wasn't running are spread over
+// their update interval instead of being enqueued all at once.
+func (s *UpdateScheduler) LoadSchedule(ctx context.Context) error {
+	schedules, err := s.db.RepoUpdateSchedule().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := timeNow()
+
+	s.schedule.mu.Lock()
+	for _, sched := range schedules {
+		interval := sched.Interval
+		switch {
+		case interval > maxDelay:
+			interval = maxDelay
+		case interval < minDelay:
+			interval = minDelay
+		}
+
+		due := sched.DueAt
+		if due.Before(now) {
+			due = now.Add(time.Duration(s.schedule.randGenerator.Int63n(int64(interval))))
+			s.changes.updated(sched.RepoID)
+		}
+
+		if update := s.schedule.index[sched.RepoID]; update != nil {
+			update.Interval = interval
+			update.Due = due
+			update.LastFetched = sched.LastFetched
+			heap.Fix(s.schedule, update.Index)
+			continue
+		}
+
+		heap.Push(s.schedule, &scheduledRepoUpdate{
+			Repo:        configuredRepo{ID: sched.RepoID, Name: sched.RepoName},
+			Interval:    interval,
+			Due:         due,
+			LastFetched: sched.LastFetched,
+		})
+	}
+	s.schedule.rescheduleTimer()
+	s.schedule.mu.Unlock()
+
+	for _, sched := range schedules {
+		if sched.Priority != nil {
+			s.updateQueue.enqueue(configuredRepo{ID: sched.RepoID, Name: sched.RepoName}, priority(*sched.Priority))
+		}
+	}
+
+	s.logger.Info("loaded persisted schedule", log.Int("repos", len(schedules)))
+	return nil
+}
+
+// runPersistLoop periodically persists the changes to the schedule.
+func (s *UpdateScheduler) runPersistLoop(ctx context.Context) {
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := s.persist(ctx); err != nil {
+			schedError.WithLabelValues("persist").Inc()
+			s.logger.Warn("error persisting schedule", log.Error(err))
+		}
+	}
+}
+
+// persist writes the state of all repos that changed since the last call to
+// the database. If that fails, the changes are kept to be retried later.
+func (s *UpdateScheduler) persist(ctx context.Context) error {
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	changes := s.changes.take()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var (
+		upserts []*database.RepoUpdateSchedule
+		deletes []api.RepoID
+	)
+
+	s.schedule.mu.Lock()
+	for id, removed := range changes {
+		if update := s.schedule.index[id]; update != nil {
+			upserts = append(upserts, &database.RepoUpdateSchedule{
+				RepoID:      id,
+				Interval:    update.Interval,
+				DueAt:       update.Due,
+				LastFetched: update.LastFetched,
+			})
+		} else if removed {
+			deletes = append(deletes, id)
+		}
+	}
+	s.schedule.mu.Unlock()
+
+	s.updateQueue.mu.Lock()
+	for _, sched := range upserts {
+		if update := s.updateQueue.index[sched.RepoID]; update != nil {
+			p := int(update.Priority)
+			sched.Priority = &p
+		}
+	}
+	s.updateQueue.mu.Unlock()
+
+	store := s.db.RepoUpdateSchedule()
+	err := store.Upsert(ctx, upserts...)
+	if err == nil {
+		err = store.Delete(ctx, deletes...)
+	}
+	if err != nil {
+		s.changes.restore(changes)
+		return err
+	}
+
+	return nil
+}
+
+// scheduleChanges tracks the repos whose state in the schedule or the update
+// queue changed since it was last persisted.
+type scheduleChanges struct {
+	mu sync.Mutex
+	// ids maps the changed repos to whether they might have been removed from
+	// the schedule.
+	ids map[api.RepoID]bool
+}
+
+// updated records that the state of the repo changed.
+func (c *scheduleChanges) updated(id api.RepoID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.ids[id]; !ok {
+		c.ids[id] = false
+	}
+}
+
+// removed records that the repo was removed from the schedule.
+func (c *scheduleChanges) removed(id api.RepoID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ids[id] = true
+}
+
+// take returns all recorded changes and resets them.
+func (c *scheduleChanges) take() map[api.RepoID]bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := c.ids
+	c.ids = make(map[api.RepoID]bool)
+	return ids
+}
+
+// restore records the given changes again, after persisting them failed.
+func (c *scheduleChanges) restore(ids map[api.RepoID]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, removed := range ids {
+		c.ids[id] = c.ids[id] || removed
+	}
 }
 
 // updateQueue is a priority queue of repos to update.
@@ -505,6 +714,8 @@ type updateQueue struct {
 	// when a new value is enqueued so that the update loop
 	// can wake up if it is idle.
 	notifyEnqueue chan struct{}
+
+	changes *scheduleChanges
 }
 
 type priority int
@@ -556,6 +767,7 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 			Repo:     repo,
 			Priority: p,
 		})
+		q.changes.updated(repo.ID)
 		notify(q.notifyEnqueue)
 		return false
 	}
@@ -574,6 +786,7 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 	update.Priority = p      // bump the priority
 	update.Seq = q.nextSeq() // put it after all existing updates with this priority
 	heap.Fix(q, update.Index)
+	q.changes.updated(repo.ID)
 	notify(q.notifyEnqueue)
 
 	return true
@@ -598,6 +811,7 @@ func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool)
 	update := q.index[repo.ID]
 	if update != nil && update.Updating == updating {
 		heap.Remove(q, update.Index)
+		q.changes.updated(repo.ID)
 		return true
 	}
 
@@ -690,14 +904,17 @@ type schedule struct {
 	randGenerator interface {
 		Int63n(n int64) int64
 	}
+
+	changes *scheduleChanges
 }
 
 // scheduledRepoUpdate is the update schedule for a single repo.
 type scheduledRepoUpdate struct {
-	Repo     configuredRepo // the repo to update
-	Interval time.Duration  // how regularly the repo is updated
-	Due      time.Time      // the next time that the repo will be enqueued for a update
-	Index    int            `json:"-"` // the index in the heap
+	Repo        configuredRepo // the repo to update
+	Interval    time.Duration  // how regularly the repo is updated
+	Due         time.Time      // the next time that the repo will be enqueued for a update
+	LastFetched time.Time      // the last time that the repo was successfully fetched
+	Index       int            `json:"-"` // the index in the heap
 }
 
 // upsert inserts or updates a repo in the schedule.
@@ -719,6 +936,7 @@ func (s *schedule) upsert(repo configuredRepo) (updated bool) {
 		Interval: minDelay,
 		Due:      timeNow().Add(minDelay),
 	})
+	s.changes.updated(repo.ID)
 
 	s.rescheduleTimer()
 
@@ -744,10 +962,12 @@ func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
 				Interval: minDelay,
 				Due:      notClonedDue,
 			})
+			s.changes.updated(repo.ID)
 			rescheduleTimer = true
 		} else if repoUpdate.Due.After(notClonedDue) {
 			repoUpdate.Due = notClonedDue
 			heap.Fix(s, repoUpdate.Index)
+			s.changes.updated(repo.ID)
 			rescheduleTimer = true
 		}
 	}
@@ -788,6 +1008,7 @@ func (s *schedule) insertNew(repos []types.MinimalRepo) {
 			Interval: minDelay,
 			Due:      due,
 		})
+		s.changes.updated(repo.ID)
 		rescheduleTimer = true
 	}
 
@@ -824,11 +1045,24 @@ func (s *schedule) updateInterval(repo configuredRepo, interval time.Duration) {
 			log.Object("repo", log.String("name", string(repo.Name)), log.Duration("due", update.Due.Sub(timeNow()))),
 		)
 		heap.Fix(s, update.Index)
+		s.changes.updated(repo.ID)
 		s.rescheduleTimer()
 	}
 	s.mu.Unlock()
 }
 
+// setLastFetched records when a repo in the schedule was last fetched.
+// It does nothing if the repo is not in the schedule.
+func (s *schedule) setLastFetched(repo configuredRepo, lastFetched time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if update := s.index[repo.ID]; update != nil {
+		update.LastFetched = lastFetched
+		s.changes.updated(repo.ID)
+	}
+}
+
 // getCurrentInterval gets the current interval for the supplied repo and a bool
 // indicating whether it was found.
 func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool) {
@@ -860,6 +1094,7 @@ func (s *schedule) remove(repo configuredRepo) (removed bool) {
 	if heap.Remove(s, update.Index); reschedule {
 		s.rescheduleTimer()
 	}
+	s.changes.removed(repo.ID)
 
 	return true
 }
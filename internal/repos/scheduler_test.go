@@ -17,7 +17,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -1395,7 +1397,7 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 				},
 			},
 			finalSchedule: []*scheduledRepoUpdate{
-				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute)},
+				{Repo: a, Interval: time.Minute, Due: defaultTime.Add(time.Minute), LastFetched: defaultTime.Add(2 * time.Minute)},
 			},
 			timeAfterFuncDelays: []time.Duration{time.Minute},
 			expectedNotifications: func(s *UpdateScheduler) []chan struct{} {
@@ -1480,6 +1482,161 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 	}
 }
 
+func TestUpdateScheduler_LoadSchedule(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	store := database.NewMockRepoUpdateScheduleStore()
+	store.ListFunc.SetDefaultReturn([]*database.RepoUpdateSchedule{
+		{
+			RepoID:      a.ID,
+			RepoName:    a.Name,
+			Interval:    time.Hour,
+			DueAt:       defaultTime.Add(30 * time.Minute),
+			LastFetched: defaultTime.Add(-30 * time.Minute),
+		},
+		{
+			// Overdue repos are spread over their interval.
+			RepoID:   b.ID,
+			RepoName: b.Name,
+			Interval: 2 * time.Hour,
+			DueAt:    defaultTime.Add(-time.Hour),
+			Priority: intPtr(int(priorityHigh)),
+		},
+		{
+			// Intervals are clamped to the allowed range.
+			RepoID:   c.ID,
+			RepoName: c.Name,
+			DueAt:    defaultTime.Add(time.Minute),
+		},
+	}, nil)
+	db := database.NewMockDB()
+	db.RepoUpdateScheduleFunc.SetDefaultReturn(store)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.schedule.randGenerator = &mockRandomGenerator{}
+
+	if err := s.LoadSchedule(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: c, Interval: minDelay, Due: defaultTime.Add(time.Minute)},
+		{Repo: a, Interval: time.Hour, Due: defaultTime.Add(30 * time.Minute), LastFetched: defaultTime.Add(-30 * time.Minute)},
+		{Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(time.Hour)},
+	})
+	verifyQueue(t, s, []*repoUpdate{
+		{Repo: b, Priority: priorityHigh, Seq: 1},
+	})
+}
+
+func TestUpdateScheduler_persist(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	ctx := context.Background()
+
+	store := database.NewMockRepoUpdateScheduleStore()
+	db := database.NewMockDB()
+	db.RepoUpdateScheduleFunc.SetDefaultReturn(store)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+
+	s.schedule.upsert(a)
+	s.schedule.upsert(b)
+	s.updateQueue.enqueue(a, priorityHigh)
+	s.schedule.remove(b)
+
+	if err := s.persist(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := len(store.UpsertFunc.History()), 1; have != want {
+		t.Fatalf("wrong number of Upsert calls: have %d, want %d", have, want)
+	}
+	wantUpserts := []*database.RepoUpdateSchedule{
+		{RepoID: a.ID, Interval: minDelay, DueAt: defaultTime.Add(minDelay), Priority: intPtr(int(priorityHigh))},
+	}
+	if diff := cmp.Diff(wantUpserts, store.UpsertFunc.History()[0].Arg1); diff != "" {
+		t.Fatalf("unexpected upserts (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]api.RepoID{b.ID}, store.DeleteFunc.History()[0].Arg1); diff != "" {
+		t.Fatalf("unexpected deletes (-want +got):\n%s", diff)
+	}
+
+	// Nothing changed, so nothing is persisted.
+	if err := s.persist(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(store.UpsertFunc.History()), 1; have != want {
+		t.Fatalf("wrong number of Upsert calls: have %d, want %d", have, want)
+	}
+
+	// Changes are retried if persisting them fails.
+	s.schedule.updateInterval(a, time.Hour)
+	store.UpsertFunc.PushReturn(errors.New("boom"))
+	if err := s.persist(ctx); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := s.persist(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(store.UpsertFunc.History()), 3; have != want {
+		t.Fatalf("wrong number of Upsert calls: have %d, want %d", have, want)
+	}
+	if have, want := store.UpsertFunc.History()[2].Arg1[0].Interval, s.schedule.index[a.ID].Interval; have != want {
+		t.Fatalf("wrong persisted interval: have %s, want %s", have, want)
+	}
+}
+
+func TestUpdateScheduler_ScheduleInfo(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	ctx := context.Background()
+
+	dueAt := defaultTime.Add(time.Hour)
+	store := database.NewMockRepoUpdateScheduleStore()
+	store.GetByRepoIDFunc.SetDefaultReturn(&database.RepoUpdateSchedule{RepoID: a.ID, Interval: time.Hour, DueAt: dueAt}, nil)
+	db := database.NewMockDB()
+	db.RepoUpdateScheduleFunc.SetDefaultReturn(store)
+
+	s := NewUpdateScheduler(logtest.Scoped(t), db)
+	s.schedule.upsert(a)
+	s.updateQueue.enqueue(a, priorityHigh)
+
+	info, err := s.ScheduleInfo(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &protocol.RepoUpdateSchedulerInfoResult{
+		Schedule: &protocol.RepoScheduleState{IntervalSeconds: 3600, Due: dueAt},
+		Queue:    &protocol.RepoQueueState{Total: 1, Priority: int(priorityHigh)},
+	}
+	if diff := cmp.Diff(want, info); diff != "" {
+		t.Fatalf("unexpected schedule info (-want +got):\n%s", diff)
+	}
+
+	// Reading the schedule info leaves persisting the changes to the
+	// schedule to the persist loop.
+	if have := len(store.UpsertFunc.History()); have != 0 {
+		t.Fatalf("ScheduleInfo persisted the schedule %d times", have)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func verifyRecording(t *testing.T, s *UpdateScheduler, timeAfterFuncDelays []time.Duration, expectedNotifications func(s *UpdateScheduler) []chan struct{}, r *recording) {
 	if !reflect.DeepEqual(timeAfterFuncDelays, r.timeAfterFuncDelays) {
 		t.Fatalf("\nexpected timeAfterFuncDelays\n%s\ngot\n%s", spew.Sdump(timeAfterFuncDelays), spew.Sdump(r.timeAfterFuncDelays))
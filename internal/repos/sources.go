@@ -51,6 +51,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewGitLabSource(ctx, logger.Scoped("GitLabSource", "GitLab repo source"), svc, cf)
 	case extsvc.KindGerrit:
 		return NewGerritSource(ctx, svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, svc, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(ctx, logger.Scoped("BitbucketServerSource", "bitbucket server repo source"), svc, cf)
 	case extsvc.KindBitbucketCloud:
@@ -70,6 +72,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewJVMPackagesSource(ctx, svc)
 	case extsvc.KindPagure:
 		return NewPagureSource(ctx, svc, cf)
+	case extsvc.KindMercurial:
+		return NewMercurialSource(ctx, svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(ctx, svc, cf)
 	case extsvc.KindPythonPackages:
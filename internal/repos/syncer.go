@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/repos/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -448,6 +449,8 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 	}
 	observeDiff(d)
 
+	s.enqueueRemovedWebhooks(ctx, deleted...)
+
 	if s.Synced != nil && d.Len() > 0 {
 		select {
 		case <-ctx.Done():
@@ -456,6 +459,31 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 	}
 }
 
+// enqueueRemovedWebhooks enqueues outbound webhooks for the given repos that
+// were removed from an external service. Repos that are still synced by
+// another external service haven't been deleted and are skipped.
+func (s *Syncer) enqueueRemovedWebhooks(ctx context.Context, ids ...api.RepoID) {
+	if len(ids) == 0 {
+		return
+	}
+
+	rs, err := s.Store.RepoStore().List(ctx, database.ReposListOptions{
+		IDs:            ids,
+		IncludeBlocked: true,
+		IncludeDeleted: true,
+	})
+	if err != nil {
+		s.ObsvCtx.Logger.Warn("listing removed repos for webhooks", log.Error(err))
+		return
+	}
+
+	for _, r := range rs {
+		if r.IsDeleted() {
+			webhooks.EnqueueRepo(ctx, s.ObsvCtx.Logger, s.Store, webhooks.RepoRemoved, r)
+		}
+	}
+}
+
 // ErrCloudDefaultSync is returned by SyncExternalService if an attempt to
 // sync a cloud default external service is done. We can't sync these external services
 // because their repos are added via the lazy-syncing mechanism on sourcegraph.com
@@ -753,6 +781,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		if err = tx.RepoStore().Delete(ctx, conflicting.ID); err != nil {
 			return Diff{}, errors.Wrap(err, "syncer: failed to delete conflicting repo")
 		}
+		if !conflicting.IsDeleted() {
+			webhooks.EnqueueRepo(ctx, s.ObsvCtx.Logger, tx, webhooks.RepoRemoved, conflicting)
+		}
 
 		// We fallthrough to the next case after removing the conflicting repo in order to update
 		// the winner (i.e. existing). This works because we mutate stored to contain it, which the case expects.
@@ -761,6 +792,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		fallthrough
 	case 1: // Existing repo, update.
 		s.ObsvCtx.Logger.Debug("existing repo")
+		previousName, wasDeleted := stored[0].Name, stored[0].IsDeleted()
 		modified := stored[0].Update(sourced)
 		if modified == types.RepoUnmodified {
 			d.Unmodified = append(d.Unmodified, stored[0])
@@ -771,6 +803,17 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return Diff{}, errors.Wrap(err, "syncer: failed to update external service repo")
 		}
 
+		// Updating a soft-deleted repo restores it, which looks like a rename
+		// from its soft-deleted name.
+		if wasDeleted {
+			webhooks.EnqueueRepo(ctx, s.ObsvCtx.Logger, tx, webhooks.RepoAdded, stored[0])
+		} else if modified&types.RepoModifiedName == types.RepoModifiedName {
+			webhooks.EnqueueRepoRename(ctx, s.ObsvCtx.Logger, tx, webhooks.RepoRename{
+				Repo:         stored[0],
+				PreviousName: previousName,
+			})
+		}
+
 		*sourced = *stored[0]
 		d.Modified = append(d.Modified, RepoModified{Repo: stored[0], Modified: modified})
 		s.ObsvCtx.Logger.Debug("appended to modified repos")
@@ -780,6 +823,8 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return Diff{}, errors.Wrap(err, "syncer: failed to create external service repo")
 		}
 
+		webhooks.EnqueueRepo(ctx, s.ObsvCtx.Logger, tx, webhooks.RepoAdded, sourced)
+
 		d.Added = append(d.Added, sourced)
 		s.ObsvCtx.Logger.Debug("appended to added repos")
 	default: // Impossible since we have two separate unique constraints on name and external repo spec
@@ -0,0 +1,38 @@
+package webhooks
+
+import "github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+
+const (
+	RepoAdded          = "repo:added"
+	RepoRemoved        = "repo:removed"
+	RepoRenamed        = "repo:renamed"
+	RepoCloneCompleted = "repo:clone_completed"
+	RepoCloneFailed    = "repo:clone_failed"
+)
+
+func init() {
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         RepoAdded,
+		Description: "sent when a repository is added by syncing a code host connection",
+	})
+
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         RepoRemoved,
+		Description: "sent when a repository is removed because it is no longer synced by any code host connection",
+	})
+
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         RepoRenamed,
+		Description: "sent when a repository is renamed on the code host",
+	})
+
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         RepoCloneCompleted,
+		Description: "sent when gitserver finishes cloning a repository",
+	})
+
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         RepoCloneFailed,
+		Description: "sent when an attempt to clone a repository fails",
+	})
+}
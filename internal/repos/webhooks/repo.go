@@ -0,0 +1,126 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// repository represents a repository in a webhook payload.
+type repository struct {
+	ID                  graphql.ID `json:"id"`
+	Name                string     `json:"name"`
+	URL                 string     `json:"url"`
+	ExternalServiceType string     `json:"external_service_type"`
+	ExternalID          string     `json:"external_id"`
+	Private             bool       `json:"private"`
+	Fork                bool       `json:"fork"`
+	Archived            bool       `json:"archived"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func newRepository(repo *types.Repo) (repository, error) {
+	// Removed repositories are renamed when they are soft deleted, so we
+	// restore the name they had before.
+	name := api.UndeletedRepoName(repo.Name)
+
+	u, err := repoURL(name)
+	if err != nil {
+		return repository{}, err
+	}
+
+	return repository{
+		ID:                  relay.MarshalID("Repository", repo.ID),
+		Name:                string(name),
+		URL:                 u,
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalID:          repo.ExternalRepo.ID,
+		Private:             repo.Private,
+		Fork:                repo.Fork,
+		Archived:            repo.Archived,
+		CreatedAt:           repo.CreatedAt,
+	}, nil
+}
+
+func repoURL(name api.RepoName) (string, error) {
+	extURL, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return "", errors.Wrap(err, "parsing external Sourcegraph URL")
+	}
+
+	return extURL.JoinPath(string(name)).String(), nil
+}
+
+func MarshalRepo(_ context.Context, _ basestore.ShareableStore, repo *types.Repo) ([]byte, error) {
+	payload, err := newRepository(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&payload)
+}
+
+// RepoRename describes a repository that was renamed on the code host.
+type RepoRename struct {
+	Repo         *types.Repo
+	PreviousName api.RepoName
+}
+
+// repositoryRename represents a renamed repository in a webhook payload.
+type repositoryRename struct {
+	repository
+	PreviousName string `json:"previous_name"`
+}
+
+func MarshalRepoRename(_ context.Context, _ basestore.ShareableStore, rename RepoRename) ([]byte, error) {
+	repo, err := newRepository(rename.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := repositoryRename{
+		repository:   repo,
+		PreviousName: string(rename.PreviousName),
+	}
+
+	return json.Marshal(&payload)
+}
+
+// RepoClone describes the outcome of cloning a repository on gitserver.
+type RepoClone struct {
+	Repo *types.Repo
+	// Err is the error that caused the clone to fail, or nil if the repository
+	// was cloned successfully. It must not contain any credentials.
+	Err error
+}
+
+// repositoryClone represents the outcome of a clone in a webhook payload.
+type repositoryClone struct {
+	repository
+	Error *string `json:"error"`
+}
+
+func MarshalRepoClone(_ context.Context, _ basestore.ShareableStore, clone RepoClone) ([]byte, error) {
+	repo, err := newRepository(clone.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := repositoryClone{repository: repo}
+	if clone.Err != nil {
+		msg := clone.Err.Error()
+		payload.Error = &msg
+	}
+
+	return json.Marshal(&payload)
+}
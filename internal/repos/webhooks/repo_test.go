@@ -0,0 +1,79 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMarshalRepo(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExternalURL: "https://sourcegraph.test"}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	ctx := context.Background()
+	repo := &types.Repo{
+		ID:   42,
+		Name: "github.com/sourcegraph/sourcegraph",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA==",
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+		Fork:      true,
+		CreatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("repo", func(t *testing.T) {
+		have, err := MarshalRepo(ctx, nil, repo)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id": "UmVwb3NpdG9yeTo0Mg==",
+			"name": "github.com/sourcegraph/sourcegraph",
+			"url": "https://sourcegraph.test/github.com/sourcegraph/sourcegraph",
+			"external_service_type": "github",
+			"external_id": "MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA==",
+			"private": false,
+			"fork": true,
+			"archived": false,
+			"created_at": "2023-01-02T03:04:05Z"
+		}`, string(have))
+	})
+
+	t.Run("removed repo", func(t *testing.T) {
+		removed := *repo
+		removed.Name = "DELETED-1674035302.123456-github.com/sourcegraph/sourcegraph"
+		removed.DeletedAt = time.Now()
+
+		have, err := MarshalRepo(ctx, nil, &removed)
+		require.NoError(t, err)
+		assert.Contains(t, string(have), `"name":"github.com/sourcegraph/sourcegraph"`)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		have, err := MarshalRepoRename(ctx, nil, RepoRename{Repo: repo, PreviousName: "github.com/sourcegraph/old"})
+		require.NoError(t, err)
+		assert.Contains(t, string(have), `"name":"github.com/sourcegraph/sourcegraph"`)
+		assert.Contains(t, string(have), `"previous_name":"github.com/sourcegraph/old"`)
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		have, err := MarshalRepoClone(ctx, nil, RepoClone{Repo: repo})
+		require.NoError(t, err)
+		assert.Contains(t, string(have), `"id":"UmVwb3NpdG9yeTo0Mg=="`)
+		assert.Contains(t, string(have), `"error":null`)
+
+		have, err = MarshalRepoClone(ctx, nil, RepoClone{Repo: repo, Err: errors.New("repository not found")})
+		require.NoError(t, err)
+		assert.Contains(t, string(have), `"error":"repository not found"`)
+	})
+}
@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+)
+
+func EnqueueRepo(
+	ctx context.Context, logger log.Logger, db basestore.ShareableStore,
+	eventType string, repo *types.Repo,
+) {
+	outbound.Enqueue(ctx, logger, db, eventType, MarshalRepo, repo)
+}
+
+func EnqueueRepoRename(
+	ctx context.Context, logger log.Logger, db basestore.ShareableStore,
+	rename RepoRename,
+) {
+	outbound.Enqueue(ctx, logger, db, RepoRenamed, MarshalRepoRename, rename)
+}
+
+// EnqueueRepoClone enqueues an outbound webhook for the outcome of cloning the
+// given repository. Unlike the other helpers, it goes through the stores of
+// the given database.DB, since gitserver only knows the name of the repository
+// it cloned.
+func EnqueueRepoClone(
+	ctx context.Context, logger log.Logger, db database.DB,
+	name api.RepoName, cloneErr error,
+) {
+	eventType := RepoCloneCompleted
+	if cloneErr != nil {
+		eventType = RepoCloneFailed
+	}
+
+	logger = logger.With(
+		log.String("repo", string(name)),
+		log.String("event_type", eventType),
+	)
+
+	repo, err := db.Repos().GetByName(ctx, name)
+	if err != nil {
+		logger.Error("error getting repository for webhook", log.Error(err))
+		return
+	}
+
+	payload, err := MarshalRepoClone(ctx, db, RepoClone{Repo: repo, Err: cloneErr})
+	if err != nil {
+		logger.Error("error marshalling webhook payload", log.Error(err))
+		return
+	}
+
+	store := db.OutboundWebhookJobs(keyring.Default().OutboundWebhookKey)
+	if _, err := store.Create(ctx, eventType, nil, payload); err != nil {
+		logger.Error("error enqueuing webhook job", log.Error(err))
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"sort"
+	"strings"
 
 	"github.com/grafana/regexp"
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/zoekt"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -88,6 +90,11 @@ type RepoIndexOptions struct {
 	// error is encoded in the body. If the revision is missing, an empty
 	// string should be returned rather than an error.
 	GetVersion func(branch string) (string, error)
+
+	// ListRefs is used to expand ref globs referenced by search contexts
+	// into the branches they match. If it fails, the error is encoded in the
+	// body. If nil, ref globs are not indexed.
+	ListRefs func() ([]gitdomain.Ref, error)
 }
 
 type getRepoIndexOptsFn func(repoID int32) (*RepoIndexOptions, error)
@@ -167,6 +174,10 @@ func getIndexOptions(
 		branches[rev] = struct{}{}
 	}
 
+	if err := expandRefGlobs(branches, opts.ListRefs); err != nil {
+		return marshal(&zoektIndexOptions{Error: err.Error()})
+	}
+
 	// empty string means HEAD which is already in the set. Rather than
 	// sanitize all inputs, just adjust the set before we start resolving.
 	delete(branches, "")
@@ -211,6 +222,46 @@ func getIndexOptions(
 	return marshal(o)
 }
 
+// expandRefGlobs replaces the ref globs in branches, written in the "*glob"
+// and "*!glob" syntax of the repo: filter, with the names of the refs they
+// match. Exclude globs are dropped rather than applied: branches is the union
+// of the revisions of all search contexts, so an exclude glob of one context
+// must not drop the branches of another. Indexing extra branches is harmless
+// since searches only ask for the branches they resolved.
+func expandRefGlobs(branches map[string]struct{}, listRefs func() ([]gitdomain.Ref, error)) error {
+	var globs []gitdomain.RefGlob
+	for branch := range branches {
+		if !strings.HasPrefix(branch, "*") {
+			continue
+		}
+		delete(branches, branch)
+		if !strings.HasPrefix(branch, "*!") {
+			globs = append(globs, gitdomain.RefGlob{Include: branch[1:]})
+		}
+	}
+
+	if len(globs) == 0 || listRefs == nil {
+		return nil
+	}
+
+	rg, err := gitdomain.CompileRefGlobs(globs)
+	if err != nil {
+		return err
+	}
+
+	refs, err := listRefs()
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if rg.Match(ref.Name) {
+			branches[strings.TrimPrefix(ref.Name, "refs/heads/")] = struct{}{}
+		}
+	}
+	return nil
+}
+
 type revsRuleFunc func(*RepoIndexOptions) (revs []string)
 
 func siteConfigRevisionsRuleFunc(c *schema.SiteConfiguration) revsRuleFunc {
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/zoekt"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -196,6 +197,22 @@ func TestGetIndexOptions(t *testing.T) {
 				{Name: "rev2", Version: "!rev2"},
 			},
 		},
+	}, {
+		name:              "with search context ref globs",
+		conf:              schema.SiteConfiguration{},
+		repo:              REPO,
+		searchContextRevs: []string{"rev1", "*refs/heads/release/*", "*!refs/heads/release/old"},
+		want: zoektIndexOptions{
+			RepoID:  1,
+			Name:    "repo-01",
+			Symbols: true,
+			Branches: []zoekt.RepositoryBranch{
+				{Name: "HEAD", Version: "!HEAD"},
+				{Name: "release/new", Version: "!release/new"},
+				{Name: "release/old", Version: "!release/old"},
+				{Name: "rev1", Version: "!rev1"},
+			},
+		},
 	}, {
 		name: "with a priority value",
 		conf: schema.SiteConfiguration{},
@@ -269,6 +286,14 @@ func TestGetIndexOptions(t *testing.T) {
 			GetVersion: func(branch string) (string, error) {
 				return "!" + branch, nil
 			},
+			ListRefs: func() ([]gitdomain.Ref, error) {
+				return []gitdomain.Ref{
+					{Name: "refs/heads/main"},
+					{Name: "refs/heads/release/new"},
+					{Name: "refs/heads/release/old"},
+					{Name: "refs/tags/v1"},
+				}, nil
+			},
 
 			DocumentRanksVersion: documentRanksVersion,
 		}, nil
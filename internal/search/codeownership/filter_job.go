@@ -0,0 +1,156 @@
+package codeownership
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// NewFilterJob creates a job that filters the file results streamed by child
+// by the owners declared in the CODEOWNERS file of their repository, as
+// specified by file:has.owner(). Files are only kept if they are owned by all
+// of includeOwners and none of excludeOwners. Other results are dropped.
+func NewFilterJob(child job.Job, includeOwners, excludeOwners []string) job.Job {
+	return &filterJob{
+		child:         child,
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+	}
+}
+
+type filterJob struct {
+	child job.Job
+
+	includeOwners []string
+	excludeOwners []string
+}
+
+func (s *filterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rules := newRulesCache(backend.NewOwnService(clients.Gitserver, clients.DB))
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = applyCodeOwnershipFiltering(ctx, rules, s.includeOwners, s.excludeOwners, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *filterJob) Name() string {
+	return "CodeOwnershipFilterJob"
+}
+
+func (s *filterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		if len(s.includeOwners) > 0 {
+			res = append(res, trace.Strings("includeOwners", s.includeOwners))
+		}
+		if len(s.excludeOwners) > 0 {
+			res = append(res, trace.Strings("excludeOwners", s.excludeOwners))
+		}
+	}
+	return res
+}
+
+func (s *filterJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *filterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+func applyCodeOwnershipFiltering(ctx context.Context, rules *rulesCache, includeOwners, excludeOwners []string, matches []result.Match) ([]result.Match, error) {
+	var errs error
+
+	filtered := matches[:0]
+	for _, m := range matches {
+		mm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		file, err := rules.GetFromCacheOrFetch(ctx, mm.Repo.Name, mm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		owners := file.FindOwners(mm.Path)
+		if containsAllOwners(owners, includeOwners) && !containsAnyOwner(owners, excludeOwners) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered, errs
+}
+
+func containsAllOwners(owners []*codeownerspb.Owner, wantOwners []string) bool {
+	for _, want := range wantOwners {
+		if !containsOwner(owners, want) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAnyOwner(owners []*codeownerspb.Owner, wantOwners []string) bool {
+	for _, want := range wantOwners {
+		if containsOwner(owners, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsOwner returns true if want matches the handle or email of one of
+// owners. The comparison is case-insensitive, and the leading `@` of a handle
+// is optional.
+func containsOwner(owners []*codeownerspb.Owner, want string) bool {
+	want = strings.ToLower(want)
+	handle := strings.TrimPrefix(want, "@")
+	for _, o := range owners {
+		if o.GetHandle() != "" && strings.ToLower(o.GetHandle()) == handle {
+			return true
+		}
+		if o.GetEmail() != "" && strings.ToLower(o.GetEmail()) == want {
+			return true
+		}
+	}
+	return false
+}
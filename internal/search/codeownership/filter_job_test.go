@@ -0,0 +1,150 @@
+package codeownership
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+type fakeOwnService map[api.RepoName]string
+
+func (s fakeOwnService) OwnersFile(_ context.Context, repoName api.RepoName, _ api.CommitID) (*codeownerspb.File, error) {
+	content, ok := s[repoName]
+	if !ok {
+		return nil, nil
+	}
+	return codeowners.Parse(strings.NewReader(content))
+}
+
+func (s fakeOwnService) Ownership(context.Context, types.MinimalRepo, api.CommitID, string) ([]*backend.Owner, error) {
+	return nil, nil
+}
+
+func fileMatch(repo api.RepoName, path string) *result.FileMatch {
+	return &result.FileMatch{
+		File: result.File{
+			Repo:     types.MinimalRepo{Name: repo},
+			CommitID: "deadbeef",
+			Path:     path,
+		},
+	}
+}
+
+func TestApplyCodeOwnershipFiltering(t *testing.T) {
+	ownService := fakeOwnService{
+		"github.com/sourcegraph/sourcegraph": `
+/cmd/ @backend
+/client/ @frontend alice@example.com
+/client/web/ @frontend @web
+`,
+	}
+
+	matches := func() []result.Match {
+		return []result.Match{
+			fileMatch("github.com/sourcegraph/sourcegraph", "cmd/main.go"),
+			fileMatch("github.com/sourcegraph/sourcegraph", "client/index.ts"),
+			fileMatch("github.com/sourcegraph/sourcegraph", "client/web/app.tsx"),
+			fileMatch("github.com/sourcegraph/sourcegraph", "README.md"),
+			fileMatch("github.com/sourcegraph/other", "main.go"),
+			&result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph"},
+		}
+	}
+
+	paths := func(ms []result.Match) []string {
+		var res []string
+		for _, m := range ms {
+			fm := m.(*result.FileMatch)
+			res = append(res, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{
+			name:    "include handle",
+			include: []string{"@frontend"},
+			want: []string{
+				"github.com/sourcegraph/sourcegraph/client/index.ts",
+				"github.com/sourcegraph/sourcegraph/client/web/app.tsx",
+			},
+		},
+		{
+			name:    "include handle without @ and different case",
+			include: []string{"BACKEND"},
+			want:    []string{"github.com/sourcegraph/sourcegraph/cmd/main.go"},
+		},
+		{
+			name:    "include email",
+			include: []string{"alice@example.com"},
+			want:    []string{"github.com/sourcegraph/sourcegraph/client/index.ts"},
+		},
+		{
+			name:    "include all",
+			include: []string{"@frontend", "@web"},
+			want:    []string{"github.com/sourcegraph/sourcegraph/client/web/app.tsx"},
+		},
+		{
+			name:    "exclude",
+			include: []string{"@frontend"},
+			exclude: []string{"@web"},
+			want:    []string{"github.com/sourcegraph/sourcegraph/client/index.ts"},
+		},
+		{
+			name:    "exclude only",
+			exclude: []string{"@frontend"},
+			want: []string{
+				"github.com/sourcegraph/sourcegraph/cmd/main.go",
+				"github.com/sourcegraph/sourcegraph/README.md",
+				"github.com/sourcegraph/other/main.go",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := newRulesCache(ownService)
+			got, err := applyCodeOwnershipFiltering(context.Background(), rules, tc.include, tc.exclude, matches())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, paths(got))
+		})
+	}
+}
+
+func TestGetCodeOwnersFromMatches(t *testing.T) {
+	ownService := fakeOwnService{
+		"github.com/sourcegraph/sourcegraph": `
+/cmd/ @backend
+/client/ @frontend alice@example.com
+`,
+	}
+	rules := newRulesCache(ownService)
+
+	got, err := getCodeOwnersFromMatches(context.Background(), rules, []result.Match{
+		fileMatch("github.com/sourcegraph/sourcegraph", "cmd/main.go"),
+		fileMatch("github.com/sourcegraph/sourcegraph", "client/index.ts"),
+		fileMatch("github.com/sourcegraph/other", "main.go"),
+	})
+	require.NoError(t, err)
+
+	var owners []string
+	for _, m := range got {
+		owners = append(owners, m.(*result.OwnerMatch).Identifier())
+	}
+	assert.Equal(t, []string{"@backend", "@frontend", "alice@example.com"}, owners)
+}
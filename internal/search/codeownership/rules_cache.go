@@ -0,0 +1,65 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+type cacheKey struct {
+	repoName api.RepoName
+	commitID api.CommitID
+}
+
+// rulesCache caches the CODEOWNERS file of each repository and commit
+// encountered while running a search, so that it is only read once.
+type rulesCache struct {
+	ownService backend.OwnService
+
+	// fetches deduplicates concurrent fetches of the same file, which run
+	// without holding mu.
+	fetches singleflight.Group
+
+	mu    sync.Mutex
+	files map[cacheKey]*codeownerspb.File
+}
+
+func newRulesCache(ownService backend.OwnService) *rulesCache {
+	return &rulesCache{
+		ownService: ownService,
+		files:      make(map[cacheKey]*codeownerspb.File),
+	}
+}
+
+// GetFromCacheOrFetch returns the CODEOWNERS file of the repository at the
+// given commit, or nil if the repository has no CODEOWNERS file.
+func (c *rulesCache) GetFromCacheOrFetch(ctx context.Context, repoName api.RepoName, commitID api.CommitID) (*codeownerspb.File, error) {
+	key := cacheKey{repoName, commitID}
+	c.mu.Lock()
+	file, ok := c.files[key]
+	c.mu.Unlock()
+	if ok {
+		return file, nil
+	}
+
+	v, err, _ := c.fetches.Do(string(repoName)+"@"+string(commitID), func() (interface{}, error) {
+		file, err := c.ownService.OwnersFile(ctx, repoName, commitID)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.files[key] = file
+		c.mu.Unlock()
+		return file, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*codeownerspb.File), nil
+}
@@ -0,0 +1,77 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// blockingOwnService blocks reading the CODEOWNERS file of the blocked
+// repository until unblock is closed.
+type blockingOwnService struct {
+	fakeOwnService
+	blocked api.RepoName
+	unblock chan struct{}
+	calls   int32
+}
+
+func (s *blockingOwnService) OwnersFile(ctx context.Context, repoName api.RepoName, commitID api.CommitID) (*codeownerspb.File, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if repoName == s.blocked {
+		<-s.unblock
+	}
+	return s.fakeOwnService.OwnersFile(ctx, repoName, commitID)
+}
+
+func TestRulesCache(t *testing.T) {
+	ctx := context.Background()
+	ownService := &blockingOwnService{
+		fakeOwnService: fakeOwnService{
+			"slow": "* @slow",
+			"fast": "* @fast",
+		},
+		blocked: "slow",
+		unblock: make(chan struct{}),
+	}
+	cache := newRulesCache(ownService)
+
+	var wg sync.WaitGroup
+	files := make([]*codeownerspb.File, 3)
+	for i := range files {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			file, err := cache.GetFromCacheOrFetch(ctx, "slow", "deadbeef")
+			require.NoError(t, err)
+			files[i] = file
+		}()
+	}
+
+	// Reading another repository doesn't wait for the slow one.
+	file, err := cache.GetFromCacheOrFetch(ctx, "fast", "deadbeef")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+
+	close(ownService.unblock)
+	wg.Wait()
+	for _, file := range files {
+		require.NotNil(t, file)
+		require.Same(t, files[0], file)
+	}
+
+	// Cached files are not read again.
+	calls := atomic.LoadInt32(&ownService.calls)
+	_, err = cache.GetFromCacheOrFetch(ctx, "slow", "deadbeef")
+	require.NoError(t, err)
+	_, err = cache.GetFromCacheOrFetch(ctx, "fast", "deadbeef")
+	require.NoError(t, err)
+	require.Equal(t, calls, atomic.LoadInt32(&ownService.calls))
+}
@@ -0,0 +1,116 @@
+package codeownership
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectOwnersJob creates a job that replaces the file results streamed by
+// child with the owners of the files, as declared in the CODEOWNERS file of
+// their repository. This implements select:file.owners. Each owner is only
+// streamed once per repository revision.
+func NewSelectOwnersJob(child job.Job) job.Job {
+	return &selectOwnersJob{child: child}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (s *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu    sync.Mutex
+		errs  error
+		dedup = result.NewDeduper()
+	)
+
+	rules := newRulesCache(backend.NewOwnService(clients.Gitserver, clients.DB))
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		matches, err := getCodeOwnersFromMatches(ctx, rules, event.Results)
+
+		mu.Lock()
+		if err != nil {
+			errs = errors.Append(errs, err)
+		}
+		selected := matches[:0]
+		for _, m := range matches {
+			if dedup.Seen(m) {
+				continue
+			}
+			dedup.Add(m)
+			selected = append(selected, m)
+		}
+		mu.Unlock()
+
+		event.Results = selected
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (s *selectOwnersJob) Fields(_ job.Verbosity) []otlog.Field {
+	return nil
+}
+
+func (s *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+func getCodeOwnersFromMatches(ctx context.Context, rules *rulesCache, matches []result.Match) ([]result.Match, error) {
+	var (
+		errs         error
+		ownerMatches []result.Match
+	)
+
+	for _, m := range matches {
+		mm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		file, err := rules.GetFromCacheOrFetch(ctx, mm.Repo.Name, mm.CommitID)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+
+		for _, o := range file.FindOwners(mm.Path) {
+			ownerMatches = append(ownerMatches, &result.OwnerMatch{
+				Repo:     mm.Repo,
+				CommitID: mm.CommitID,
+				Handle:   o.GetHandle(),
+				Email:    o.GetEmail(),
+			})
+		}
+	}
+
+	return ownerMatches, errs
+}
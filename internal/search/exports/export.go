@@ -0,0 +1,99 @@
+package exports
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Export runs the search query as the actor of ctx and writes all of its
+// results to w in the given format. The count: of the query is set to
+// maxResults unless the query sets a lower one. It returns the number of
+// written rows.
+func Export(ctx context.Context, searchClient client.SearchClient, searchQuery, format string, maxResults int, w io.Writer) (int, error) {
+	writer, err := NewWriter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		withCount(searchQuery, maxResults),
+		search.Precise,
+		search.Streaming,
+		&schema.Settings{},
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		mu       sync.Mutex
+		rowCount int
+		writeErr error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if writeErr != nil {
+			return
+		}
+		for _, m := range event.Results {
+			for _, row := range Rows(m) {
+				if writeErr = writer.Write(row); writeErr != nil {
+					return
+				}
+				rowCount++
+			}
+		}
+	})
+
+	if _, err := searchClient.Execute(ctx, stream, inputs); err != nil {
+		return rowCount, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if writeErr != nil {
+		return rowCount, writeErr
+	}
+	return rowCount, writer.Flush()
+}
+
+// withCount sets the count: of the query to maxResults unless it already
+// specifies a lower count. count:all and larger counts are clamped to
+// maxResults.
+func withCount(searchQuery string, maxResults int) string {
+	nodes, err := query.Parse(searchQuery, query.SearchTypeStandard)
+	if err != nil {
+		// Let planning the search report the error.
+		return searchQuery
+	}
+
+	hasCount, exceedsMax := false, false
+	query.VisitField(nodes, query.FieldCount, func(value string, _ bool, _ query.Annotation) {
+		hasCount = true
+		if count, err := strconv.Atoi(value); err != nil || count > maxResults {
+			exceedsMax = true
+		}
+	})
+	if hasCount && !exceedsMax {
+		return searchQuery
+	}
+	if exceedsMax {
+		searchQuery = query.OmitField(nodes, query.FieldCount)
+	}
+	return searchQuery + " count:" + strconv.Itoa(maxResults)
+}
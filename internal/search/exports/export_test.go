@@ -0,0 +1,58 @@
+package exports
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestExport(t *testing.T) {
+	searchClient := client.NewMockSearchClient()
+	searchClient.PlanFunc.SetDefaultHook(func(_ context.Context, _ string, _ *string, q string, _ search.Mode, _ search.Protocol, _ *schema.Settings, _ bool) (*search.Inputs, error) {
+		if want := "foo count:10"; q != want {
+			t.Errorf("unexpected query: want %q, got %q", want, q)
+		}
+		return &search.Inputs{}, nil
+	})
+	searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, stream streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		stream.Send(streaming.SearchEvent{Results: result.Matches{
+			&result.RepoMatch{Name: "a"},
+			&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "b"}, Path: "c.go"}},
+		}})
+		return nil, nil
+	})
+
+	var buf bytes.Buffer
+	n, err := Export(context.Background(), searchClient, "foo", FormatJSONL, 10, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("unexpected row count: want 2, got %d", n)
+	}
+	if want := "{\"repo\":\"a\"}\n{\"repo\":\"b\",\"path\":\"c.go\"}\n"; buf.String() != want {
+		t.Errorf("unexpected export: want %q, got %q", want, buf.String())
+	}
+}
+
+func TestWithCount(t *testing.T) {
+	cases := map[string]string{
+		"foo":                "foo count:100",
+		"foo count:5":        "foo count:5",
+		"foo count:5000":     "foo count:100",
+		"foo count:all":      "foo count:100",
+		"(a or b) type:repo": "(a or b) type:repo count:100",
+	}
+	for q, want := range cases {
+		if got := withCount(q, 100); got != want {
+			t.Errorf("withCount(%q): want %q, got %q", q, want, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package exports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+// NewStore returns the upload store holding search exports.
+func NewStore(ctx context.Context, observationCtx *observation.Context, conf *Config) (uploadstore.Store, error) {
+	c := uploadstore.Config{
+		Backend:      conf.Backend,
+		ManageBucket: conf.ManageBucket,
+		Bucket:       conf.Bucket,
+		TTL:          conf.TTL,
+		S3: uploadstore.S3Config{
+			Region:          conf.S3Region,
+			Endpoint:        conf.S3Endpoint,
+			UsePathStyle:    conf.S3UsePathStyle,
+			AccessKeyID:     conf.S3AccessKeyID,
+			SecretAccessKey: conf.S3SecretAccessKey,
+			SessionToken:    conf.S3SessionToken,
+		},
+		GCS: uploadstore.GCSConfig{
+			ProjectID:               conf.GCSProjectID,
+			CredentialsFile:         conf.GCSCredentialsFile,
+			CredentialsFileContents: conf.GCSCredentialsFileContents,
+		},
+	}
+
+	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "search", "exports"))
+}
+
+// ObjectKeyPrefix is the prefix of the keys of all exports in the upload store.
+const ObjectKeyPrefix = "search-exports/"
+
+// ObjectKey returns the key of the results of the export job with the given
+// ID in the upload store.
+func ObjectKey(jobID int64, format string) string {
+	return fmt.Sprintf("%s%d.%s", ObjectKeyPrefix, jobID, format)
+}
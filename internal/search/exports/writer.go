@@ -0,0 +1,144 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// ValidateFormat returns an error if format is not a supported export format.
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatCSV, FormatJSONL:
+		return nil
+	}
+	return errors.Errorf("unsupported export format %q: must be %q or %q", format, FormatCSV, FormatJSONL)
+}
+
+// ContentType returns the MIME type of exports in the given format.
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Row is a single exported search result. Line is 1-based and 0 for results
+// that are not tied to a line, such as path, repository and commit matches.
+type Row struct {
+	Repo    string `json:"repo"`
+	Path    string `json:"path,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Preview string `json:"preview,omitempty"`
+	Commit  string `json:"commit,omitempty"`
+}
+
+var csvHeader = []string{"repo", "path", "line", "preview", "commit"}
+
+// Rows converts a search match into the rows of an export. A file match
+// yields a row per matched line and symbol.
+func Rows(m result.Match) []Row {
+	switch v := m.(type) {
+	case *result.FileMatch:
+		base := Row{Repo: string(v.Repo.Name), Path: v.Path, Commit: string(v.CommitID)}
+		if v.IsPathMatch() {
+			return []Row{base}
+		}
+
+		rows := make([]Row, 0, len(v.Symbols)+len(v.ChunkMatches))
+		for _, lm := range v.ChunkMatches.AsLineMatches() {
+			row := base
+			row.Line = int(lm.LineNumber) + 1
+			row.Preview = lm.Preview
+			rows = append(rows, row)
+		}
+		for _, sm := range v.Symbols {
+			row := base
+			row.Line = sm.Symbol.Line
+			row.Preview = sm.Symbol.Name
+			rows = append(rows, row)
+		}
+		return rows
+
+	case *result.RepoMatch:
+		return []Row{{Repo: string(v.Name), Commit: v.Rev}}
+
+	case *result.CommitMatch:
+		row := Row{Repo: string(v.Repo.Name), Commit: string(v.Commit.ID)}
+		if v.DiffPreview != nil {
+			row.Preview = v.DiffPreview.Content
+		} else {
+			row.Preview = v.Commit.Message.Subject()
+		}
+		return []Row{row}
+
+	case *result.OwnerMatch:
+		row := Row{Repo: string(v.Repo.Name), Commit: string(v.CommitID), Preview: v.Email}
+		if v.Handle != "" {
+			row.Preview = "@" + v.Handle
+		}
+		return []Row{row}
+	}
+	return nil
+}
+
+// Writer writes the rows of an export in a specific format.
+type Writer interface {
+	Write(Row) error
+	// Flush writes any buffered rows and returns the first error encountered
+	// while writing.
+	Flush() error
+}
+
+// NewWriter returns a Writer writing rows in the given format to w.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatJSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, ValidateFormat(format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(row Row) error {
+	line := ""
+	if row.Line > 0 {
+		line = strconv.Itoa(row.Line)
+	}
+	return w.w.Write([]string{row.Repo, row.Path, line, row.Preview, row.Commit})
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(row Row) error {
+	return w.enc.Encode(row)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
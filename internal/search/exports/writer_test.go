@@ -0,0 +1,102 @@
+package exports
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRows(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	cases := []struct {
+		name  string
+		match result.Match
+		want  []Row
+	}{{
+		name: "content match",
+		match: &result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "deadbeef", Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {",
+				ContentStart: result.Location{Line: 4},
+				Ranges: result.Ranges{{
+					Start: result.Location{Offset: 5, Line: 4, Column: 5},
+					End:   result.Location{Offset: 9, Line: 4, Column: 9},
+				}},
+			}},
+		},
+		want: []Row{{Repo: "github.com/sourcegraph/sourcegraph", Path: "main.go", Line: 5, Preview: "func main() {", Commit: "deadbeef"}},
+	}, {
+		name: "symbol match",
+		match: &result.FileMatch{
+			File:    result.File{Repo: repo, CommitID: "deadbeef", Path: "main.go"},
+			Symbols: []*result.SymbolMatch{{Symbol: result.Symbol{Name: "main", Line: 5}}},
+		},
+		want: []Row{{Repo: "github.com/sourcegraph/sourcegraph", Path: "main.go", Line: 5, Preview: "main", Commit: "deadbeef"}},
+	}, {
+		name:  "path match",
+		match: &result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "main.go"}},
+		want:  []Row{{Repo: "github.com/sourcegraph/sourcegraph", Path: "main.go", Commit: "deadbeef"}},
+	}, {
+		name:  "repo match",
+		match: &result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph", ID: 1, Rev: "main"},
+		want:  []Row{{Repo: "github.com/sourcegraph/sourcegraph", Commit: "main"}},
+	}, {
+		name:  "owner match",
+		match: &result.OwnerMatch{Repo: repo, CommitID: "deadbeef", Handle: "alice"},
+		want:  []Row{{Repo: "github.com/sourcegraph/sourcegraph", Preview: "@alice", Commit: "deadbeef"}},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Rows(tc.match)); diff != "" {
+				t.Errorf("unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	rows := []Row{
+		{Repo: "r", Path: "a.go", Line: 3, Preview: `say "hi", bye`, Commit: "c"},
+		{Repo: "r"},
+	}
+
+	cases := map[string]string{
+		FormatCSV: "repo,path,line,preview,commit\n" +
+			"r,a.go,3,\"say \"\"hi\"\", bye\",c\n" +
+			"r,,,,\n",
+		FormatJSONL: `{"repo":"r","path":"a.go","line":3,"preview":"say \"hi\", bye","commit":"c"}` + "\n" +
+			`{"repo":"r"}` + "\n",
+	}
+
+	for format, want := range cases {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range rows {
+				if err := w.Write(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, buf.String()); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := NewWriter("xml", &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
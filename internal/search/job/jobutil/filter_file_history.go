@@ -0,0 +1,237 @@
+package jobutil
+
+import (
+	"context"
+
+	"github.com/grafana/regexp"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// NewFileHistoryFilterJob creates a filter job to post-filter file results for
+// the file:has.commit.after() and file:has.author() predicates by consulting
+// the history of each file on gitserver.
+//
+// A file is kept if it was (or, if negated, was not) modified after each of
+// the commitAfter times, and if the author of the last commit that modified
+// the file matches all of includeAuthors and none of excludeAuthors. Authors
+// are matched case-insensitively against the name and email of the commit
+// author. Results other than file matches are dropped, as are files whose
+// history can't be read.
+func NewFileHistoryFilterJob(child job.Job, commitAfter []query.FileHasCommitAfterArgs, includeAuthors, excludeAuthors []string) job.Job {
+	toMatchers := func(patterns []string) []*regexp.Regexp {
+		matchers := make([]*regexp.Regexp, 0, len(patterns))
+		for _, pattern := range patterns {
+			matchers = append(matchers, regexp.MustCompile("(?i:"+pattern+")"))
+		}
+		return matchers
+	}
+
+	return &fileHistoryFilterJob{
+		child:          child,
+		commitAfter:    commitAfter,
+		includeAuthors: toMatchers(includeAuthors),
+		excludeAuthors: toMatchers(excludeAuthors),
+	}
+}
+
+const (
+	// fileHistoryRepoConcurrency is the number of repositories whose file
+	// histories are read concurrently for a single search event.
+	fileHistoryRepoConcurrency = 4
+	// fileHistoryFileConcurrency is the number of files whose history is
+	// read concurrently within each repository.
+	fileHistoryFileConcurrency = 8
+)
+
+type fileHistoryFilterJob struct {
+	child job.Job
+
+	commitAfter    []query.FileHasCommitAfterArgs
+	includeAuthors []*regexp.Regexp
+	excludeAuthors []*regexp.Regexp
+}
+
+func (j *fileHistoryFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	logger := clients.Logger.Scoped("fileHistoryFilterJob", "filters file matches by their history")
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		event.Results = j.filterMatches(ctx, logger, clients.Gitserver, event.Results)
+		stream.Send(event)
+	})
+
+	return j.child.Run(ctx, clients, filteredStream)
+}
+
+// filterMatches returns the file matches whose history matches the
+// predicates. The histories of the files of each repository are read
+// concurrently. A file whose history can't be read is logged and dropped
+// rather than failing the search.
+func (j *fileHistoryFilterJob) filterMatches(ctx context.Context, logger log.Logger, gs gitserver.Client, matches []result.Match) []result.Match {
+	byRepo := make(map[api.RepoName][]int)
+	var repos []api.RepoName
+	for i, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		if _, ok := byRepo[fm.Repo.Name]; !ok {
+			repos = append(repos, fm.Repo.Name)
+		}
+		byRepo[fm.Repo.Name] = append(byRepo[fm.Repo.Name], i)
+	}
+
+	keep := make([]bool, len(matches))
+	repoPool := pool.New().WithMaxGoroutines(fileHistoryRepoConcurrency)
+	for _, repo := range repos {
+		indexes := byRepo[repo]
+		repoPool.Go(func() {
+			filePool := pool.New().WithMaxGoroutines(fileHistoryFileConcurrency)
+			for _, i := range indexes {
+				i := i
+				filePool.Go(func() {
+					fm := matches[i].(*result.FileMatch)
+					ok, err := j.matchesHistory(ctx, gs, fm)
+					if err != nil {
+						logger.Warn("failed to read file history",
+							log.String("repo", string(fm.Repo.Name)),
+							log.String("path", fm.Path),
+							log.Error(err))
+						return
+					}
+					// Each goroutine writes to its own index.
+					keep[i] = ok
+				})
+			}
+			filePool.Wait()
+		})
+	}
+	repoPool.Wait()
+
+	filtered := matches[:0]
+	for i, m := range matches {
+		if keep[i] {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+func (j *fileHistoryFilterJob) matchesHistory(ctx context.Context, gs gitserver.Client, fm *result.FileMatch) (bool, error) {
+	rev := string(fm.CommitID)
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	for _, arg := range j.commitAfter {
+		commits, err := gs.Commits(ctx, authz.DefaultSubRepoPermsChecker, fm.Repo.Name, gitserver.CommitsOptions{
+			Range:            rev,
+			Path:             fm.Path,
+			After:            arg.TimeRef,
+			N:                1,
+			NoEnsureRevision: true,
+		})
+		if err != nil {
+			return false, err
+		}
+		if hasCommitAfter := len(commits) > 0; hasCommitAfter == arg.Negated {
+			return false, nil
+		}
+	}
+
+	if len(j.includeAuthors) == 0 && len(j.excludeAuthors) == 0 {
+		return true, nil
+	}
+
+	commits, err := gs.Commits(ctx, authz.DefaultSubRepoPermsChecker, fm.Repo.Name, gitserver.CommitsOptions{
+		Range:            rev,
+		Path:             fm.Path,
+		N:                1,
+		NoEnsureRevision: true,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var authors []string
+	if len(commits) > 0 {
+		authors = []string{commits[0].Author.Name, commits[0].Author.Email}
+	}
+	for _, re := range j.includeAuthors {
+		if !anyMatches(re, authors) {
+			return false, nil
+		}
+	}
+	for _, re := range j.excludeAuthors {
+		if anyMatches(re, authors) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func anyMatches(re *regexp.Regexp, vals []string) bool {
+	for _, val := range vals {
+		if re.MatchString(val) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *fileHistoryFilterJob) MapChildren(f job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, f)
+	return &cp
+}
+
+func (j *fileHistoryFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHistoryFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		for _, arg := range j.commitAfter {
+			if arg.Negated {
+				res = append(res, otlog.String("notCommitAfter", arg.TimeRef))
+			} else {
+				res = append(res, otlog.String("commitAfter", arg.TimeRef))
+			}
+		}
+		if len(j.includeAuthors) > 0 {
+			res = append(res, trace.Strings("includeAuthors", regexpStrings(j.includeAuthors)))
+		}
+		if len(j.excludeAuthors) > 0 {
+			res = append(res, trace.Strings("excludeAuthors", regexpStrings(j.excludeAuthors)))
+		}
+	}
+	return res
+}
+
+func regexpStrings(res []*regexp.Regexp) []string {
+	strs := make([]string, 0, len(res))
+	for _, re := range res {
+		strs = append(strs, re.String())
+	}
+	return strs
+}
+
+func (j *fileHistoryFilterJob) Name() string {
+	return "FileHistoryFilterJob"
+}
@@ -0,0 +1,115 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFileHistoryFilterJob(t *testing.T) {
+	// The history of each file: whether it was modified after the requested
+	// time, and who modified it last.
+	history := map[string]struct {
+		recent bool
+		author gitdomain.Signature
+	}{
+		"recent.go":  {recent: true, author: gitdomain.Signature{Name: "Alice", Email: "alice@example.com"}},
+		"old.go":     {recent: false, author: gitdomain.Signature{Name: "Alice", Email: "alice@example.com"}},
+		"bot.go":     {recent: true, author: gitdomain.Signature{Name: "renovate[bot]", Email: "bot@example.com"}},
+		"by-bob.go":  {recent: true, author: gitdomain.Signature{Name: "Bob", Email: "bob@example.com"}},
+		"missing.go": {},
+	}
+
+	gs := gitserver.NewMockClient()
+	gs.CommitsFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, opts gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
+		require.Equal(t, "deadbeef", opts.Range)
+		if opts.Path == "broken.go" {
+			return nil, errors.New("gitserver error")
+		}
+		h, ok := history[opts.Path]
+		if !ok || (opts.After != "" && !h.recent) {
+			return nil, nil
+		}
+		return []*gitdomain.Commit{{Author: h.author}}, nil
+	})
+
+	matches := func() []result.Match {
+		var ms []result.Match
+		// The history of broken.go can't be read, so it is always dropped.
+		for _, path := range []string{"recent.go", "old.go", "bot.go", "by-bob.go", "missing.go", "broken.go"} {
+			ms = append(ms, &result.FileMatch{File: result.File{
+				Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"},
+				CommitID: "deadbeef",
+				Path:     path,
+			}})
+		}
+		return append(ms, &result.RepoMatch{Name: "github.com/sourcegraph/sourcegraph"})
+	}
+
+	paths := func(ms []result.Match) []string {
+		var res []string
+		for _, m := range ms {
+			res = append(res, m.(*result.FileMatch).Path)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		commitAfter []query.FileHasCommitAfterArgs
+		include     []string
+		exclude     []string
+		want        []string
+	}{
+		{
+			name:        "commit after",
+			commitAfter: []query.FileHasCommitAfterArgs{{TimeRef: "1 month ago"}},
+			want:        []string{"recent.go", "bot.go", "by-bob.go"},
+		},
+		{
+			name:        "negated commit after",
+			commitAfter: []query.FileHasCommitAfterArgs{{TimeRef: "1 month ago", Negated: true}},
+			want:        []string{"old.go", "missing.go"},
+		},
+		{
+			name:    "author name is case-insensitive",
+			include: []string{"alice"},
+			want:    []string{"recent.go", "old.go"},
+		},
+		{
+			name:    "author email",
+			include: []string{`^bob@example\.com$`},
+			want:    []string{"by-bob.go"},
+		},
+		{
+			name:    "exclude author",
+			exclude: []string{`\[bot\]`},
+			want:    []string{"recent.go", "old.go", "by-bob.go", "missing.go"},
+		},
+		{
+			name:        "commit after and author",
+			commitAfter: []query.FileHasCommitAfterArgs{{TimeRef: "1 month ago"}},
+			include:     []string{"alice"},
+			want:        []string{"recent.go"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			j := NewFileHistoryFilterJob(nil, tc.commitAfter, tc.include, tc.exclude).(*fileHistoryFilterJob)
+			filtered := j.filterMatches(context.Background(), logtest.Scoped(t), gs, matches())
+			require.Equal(t, tc.want, paths(filtered))
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
@@ -60,6 +61,12 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan) (job.Job, error) {
 	return job, nil
 }
 
+// isSelectOwners returns true if sp is select:file.owners, which is
+// implemented by the code ownership select job rather than by Match.Select.
+func isSelectOwners(sp filter.SelectPath) bool {
+	return len(sp) > 1 && sp[0] == filter.File && sp[1] == "owners"
+}
+
 // NewBasicJob converts a query.Basic into its job tree representation.
 func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	var children []job.Job
@@ -184,10 +191,28 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.owner() post-filter
+		if includeOwners, excludeOwners := b.FileHasOwner(); len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = codeownership.NewFilterJob(basicJob, includeOwners, excludeOwners)
+		}
+	}
+
+	{ // Apply file:has.commit.after() and file:has.author() post-filter
+		commitAfter := b.FileHasCommitAfter()
+		includeAuthors, excludeAuthors := b.FileHasAuthor()
+		if len(commitAfter) > 0 || len(includeAuthors) > 0 || len(excludeAuthors) > 0 {
+			basicJob = NewFileHistoryFilterJob(basicJob, commitAfter, includeAuthors, excludeAuthors)
+		}
+	}
+
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if isSelectOwners(sp) {
+				basicJob = codeownership.NewSelectOwnersJob(basicJob)
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
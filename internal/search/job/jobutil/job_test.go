@@ -776,6 +776,56 @@ func TestNewPlanJob(t *testing.T) {
           (STRUCTURALSEARCH
             (patternInfo.pattern . (:[_]))(patternInfo.isStructural . true)(patternInfo.fileMatchLimit . 500)
             ))))))`),
+		}, {
+			query:      `foo file:has.owner(@team) select:file.owners`,
+			protocol:   search.Streaming,
+			searchType: query.SearchTypeLiteral,
+			want: autogold.Want("select file owners with file has owner", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . literal)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (SELECTOWNERS
+          (CODEOWNERSHIPFILTER
+            (includeOwners.0 . @team)
+            (PARALLEL
+              (ZOEKTGLOBALTEXTSEARCH
+                (query . substr:"foo")
+                (type . text)
+                )
+              (REPOSCOMPUTEEXCLUDED
+                )
+              NoopJob)))))))`),
+		}, {
+			query:      `foo file:has.commit.after(1 month ago) file:has.author(alice)`,
+			protocol:   search.Streaming,
+			searchType: query.SearchTypeLiteral,
+			want: autogold.Want("file has commit after and file has author", `
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . literal)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (FILEHISTORYFILTER
+          (commitAfter . 1 month ago)
+          (includeAuthors.0 . (?i:alice))
+          (PARALLEL
+            (ZOEKTGLOBALTEXTSEARCH
+              (query . substr:"foo")
+              (type . text)
+              )
+            (REPOSCOMPUTEEXCLUDED
+              )
+            NoopJob))))))`),
 		},
 	}
 
@@ -117,6 +117,10 @@ func applySubRepoFiltering(ctx context.Context, checker authz.SubRepoPermissionC
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.OwnerMatch:
+			// Owners are read from the CODEOWNERS file, which is already
+			// checked against sub-repo permissions when it is read.
+			filtered = append(filtered, m)
 		}
 
 	}
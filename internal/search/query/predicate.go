@@ -42,6 +42,9 @@ var DefaultPredicateRegistry = PredicateRegistry{
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.commit.after": func() Predicate { return &FileHasCommitAfterPredicate{} },
+		"has.author":       func() Predicate { return &FileHasAuthorPredicate{} },
 	},
 }
 
@@ -341,3 +344,63 @@ func (f *FileContainsContentPredicate) Unmarshal(params string, negated bool) er
 
 func (f FileContainsContentPredicate) Field() string { return FieldFile }
 func (f FileContainsContentPredicate) Name() string  { return "contains.content" }
+
+/* file:has.owner(pattern) */
+
+type FileHasOwnerPredicate struct {
+	Owner   string
+	Negated bool
+}
+
+func (f *FileHasOwnerPredicate) Unmarshal(params string, negated bool) error {
+	if params == "" {
+		return errors.Errorf("file:has.owner argument should not be empty")
+	}
+	f.Owner = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.commit.after(time) */
+
+type FileHasCommitAfterPredicate struct {
+	TimeRef string
+	Negated bool
+}
+
+func (f *FileHasCommitAfterPredicate) Unmarshal(params string, negated bool) error {
+	if params == "" {
+		return errors.Errorf("file:has.commit.after argument should not be empty")
+	}
+	f.TimeRef = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasCommitAfterPredicate) Field() string { return FieldFile }
+func (f FileHasCommitAfterPredicate) Name() string  { return "has.commit.after" }
+
+/* file:has.author(pattern) */
+
+type FileHasAuthorPredicate struct {
+	Author  string
+	Negated bool
+}
+
+func (f *FileHasAuthorPredicate) Unmarshal(params string, negated bool) error {
+	if _, err := syntax.Parse(params, syntax.Perl); err != nil {
+		return errors.Errorf("file:has.author argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("file:has.author argument should not be empty")
+	}
+	f.Author = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasAuthorPredicate) Field() string { return FieldFile }
+func (f FileHasAuthorPredicate) Name() string  { return "has.author" }
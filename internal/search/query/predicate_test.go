@@ -170,3 +170,103 @@ func TestRepoHasKVPPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasOwnerPredicate
+		}
+
+		valid := []test{
+			{`handle`, `@payments`, false, &FileHasOwnerPredicate{Owner: "@payments"}},
+			{`email`, `alice@example.com`, false, &FileHasOwnerPredicate{Owner: "alice@example.com"}},
+			{`negated`, `@payments`, true, &FileHasOwnerPredicate{Owner: "@payments", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		t.Run("empty", func(t *testing.T) {
+			p := &FileHasOwnerPredicate{}
+			if err := p.Unmarshal(``, false); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	})
+}
+
+func TestFileHasCommitAfterPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		p := &FileHasCommitAfterPredicate{}
+		if err := p.Unmarshal(`1 month ago`, true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := &FileHasCommitAfterPredicate{TimeRef: "1 month ago", Negated: true}
+		if !reflect.DeepEqual(expected, p) {
+			t.Fatalf("expected %#v, got %#v", expected, p)
+		}
+
+		if err := (&FileHasCommitAfterPredicate{}).Unmarshal(``, false); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestFileHasAuthorPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasAuthorPredicate
+		}
+
+		valid := []test{
+			{`name`, `alice`, false, &FileHasAuthorPredicate{Author: "alice"}},
+			{`regexp`, `^alice@example\.com$`, false, &FileHasAuthorPredicate{Author: `^alice@example\.com$`}},
+			{`negated`, `bot`, true, &FileHasAuthorPredicate{Author: "bot", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasAuthorPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`invalid regexp`, `(alice`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasAuthorPredicate{}
+				if err := p.Unmarshal(tc.params, tc.negated); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
@@ -398,6 +398,49 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+// FileHasOwner returns the owners specified by file:has.owner() predicates,
+// partitioned into included and excluded owners.
+func (p Parameters) FileHasOwner() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Owner)
+		} else {
+			include = append(include, pred.Owner)
+		}
+	})
+	return include, exclude
+}
+
+type FileHasCommitAfterArgs struct {
+	TimeRef string
+	Negated bool
+}
+
+// FileHasCommitAfter returns the arguments of file:has.commit.after()
+// predicates.
+func (p Parameters) FileHasCommitAfter() (res []FileHasCommitAfterArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasCommitAfterPredicate) {
+		res = append(res, FileHasCommitAfterArgs{
+			TimeRef: pred.TimeRef,
+			Negated: pred.Negated,
+		})
+	})
+	return res
+}
+
+// FileHasAuthor returns the author patterns specified by file:has.author()
+// predicates, partitioned into included and excluded authors.
+func (p Parameters) FileHasAuthor() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasAuthorPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Author)
+		} else {
+			include = append(include, pred.Author)
+		}
+	})
+	return include, exclude
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
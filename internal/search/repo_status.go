@@ -15,10 +15,11 @@ import (
 type RepoStatus uint8
 
 const (
-	RepoStatusCloning  RepoStatus = 1 << iota // could not be searched because they were still being cloned
-	RepoStatusMissing                         // could not be searched because they do not exist
-	RepoStatusLimitHit                        // searched, but have results that were not returned due to exceeded limits
-	RepoStatusTimedout                        // repos that were not searched due to timeout
+	RepoStatusCloning   RepoStatus = 1 << iota // could not be searched because they were still being cloned
+	RepoStatusMissing                          // could not be searched because they do not exist
+	RepoStatusLimitHit                         // searched, but have results that were not returned due to exceeded limits
+	RepoStatusTimedout                         // repos that were not searched due to timeout
+	RepoStatusUnindexed                        // repos that were not searched because the search requires an index
 )
 
 var repoStatusName = []struct {
@@ -29,6 +30,7 @@ var repoStatusName = []struct {
 	{RepoStatusMissing, "missing"},
 	{RepoStatusLimitHit, "limithit"},
 	{RepoStatusTimedout, "timedout"},
+	{RepoStatusUnindexed, "unindexed"},
 }
 
 func (s RepoStatus) String() string {
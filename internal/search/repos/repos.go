@@ -228,7 +228,7 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		for _, repoRev := range scRepoRevs {
 			revSpecs := make([]query.RevisionSpecifier, 0, len(repoRev.Revs))
 			for _, rev := range repoRev.Revs {
-				revSpecs = append(revSpecs, query.RevisionSpecifier{RevSpec: rev})
+				revSpecs = append(revSpecs, searchcontexts.ParseRevision(rev))
 			}
 			searchContextRepositoryRevisions[repoRev.Repo.ID] = RepoRevSpecs{
 				Repo: repoRev.Repo,
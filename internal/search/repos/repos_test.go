@@ -418,13 +418,23 @@ func TestResolveRepositoriesWithSearchContext(t *testing.T) {
 	repoB := types.MinimalRepo{ID: 2, Name: "example.com/b"}
 	searchContextRepositoryRevisions := []*types.SearchContextRepositoryRevisions{
 		{Repo: repoA, Revisions: []string{"branch-1", "branch-3"}},
-		{Repo: repoB, Revisions: []string{"branch-2"}},
+		{Repo: repoB, Revisions: []string{"branch-2", "refs/heads/release/*"}},
 	}
 
 	gsClient := gitserver.NewMockClient()
 	gsClient.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
 		return api.CommitID(spec), nil
 	})
+	gsClient.ListRefsFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName) ([]gitdomain.Ref, error) {
+		if repo != repoB.Name {
+			t.Fatalf("unexpected refs lookup for %q", repo)
+		}
+		return []gitdomain.Ref{
+			{Name: "refs/heads/main"},
+			{Name: "refs/heads/release/1.0"},
+			{Name: "refs/heads/release/2.0"},
+		}, nil
+	})
 
 	repos := database.NewMockRepoStore()
 	repos.ListMinimalReposFunc.SetDefaultHook(func(ctx context.Context, op database.ReposListOptions) ([]types.MinimalRepo, error) {
@@ -462,7 +472,7 @@ func TestResolveRepositoriesWithSearchContext(t *testing.T) {
 	}
 	wantRepositoryRevisions := []*search.RepositoryRevisions{
 		{Repo: repoA, Revs: searchContextRepositoryRevisions[0].Revisions},
-		{Repo: repoB, Revs: searchContextRepositoryRevisions[1].Revisions},
+		{Repo: repoB, Revs: []string{"branch-2", "release/1.0", "release/2.0"}},
 	}
 	if !reflect.DeepEqual(resolved.RepoRevs, wantRepositoryRevisions) {
 		t.Errorf("got repository revisions %+v, want %+v", resolved.RepoRevs, wantRepositoryRevisions)
@@ -49,6 +49,10 @@ type FileMatch struct {
 
 	LimitHit bool
 
+	// Ranks is the document rank vector of the file, if known. See
+	// PreciseDocumentRanks for how it is interpreted.
+	Ranks []float64 `json:"-"`
+
 	// Debug is optionally set with a debug message explaining the result.
 	//
 	// Note: this is a pointer since usually this is unset. Pointer is 8 bytes
@@ -125,6 +129,9 @@ func (fm *FileMatch) AppendMatches(src *FileMatch) {
 	fm.ChunkMatches = append(fm.ChunkMatches, src.ChunkMatches...)
 	fm.Symbols = append(fm.Symbols, src.Symbols...)
 	fm.LimitHit = fm.LimitHit || src.LimitHit
+	if fm.Ranks == nil {
+		fm.Ranks = src.Ranks
+	}
 }
 
 // Limit will mutate fm such that it only has limit results. limit is a number
@@ -1,6 +1,7 @@
 package result
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/bits-and-blooms/bitset"
@@ -107,6 +108,11 @@ func (lm *merger) addMatch(m Match, source int) Match {
 // returns the union of the sources minus the intersection of the sources.
 // Stated differently, when added to the matches that were already returned
 // by AddMatches, you get the union of sources.
+//
+// The returned matches are ordered by document rank (see SortByRank), with ties
+// broken by key, so that the order does not depend on which source returned a
+// match. The order only covers the returned matches, not the streamable matches
+// that AddMatches returned earlier.
 func (lm *merger) UnsentTracked() Matches {
 	var res Matches
 	for _, val := range lm.matches {
@@ -114,5 +120,7 @@ func (lm *merger) UnsentTracked() Matches {
 			res = append(res, val.match)
 		}
 	}
+	sort.Sort(res)
+	res.SortByRank()
 	return res
 }
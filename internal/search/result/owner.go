@@ -0,0 +1,73 @@
+package result
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of files in a repository, as declared by the
+// CODEOWNERS file of the repository. It is produced by select:file.owners.
+type OwnerMatch struct {
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+
+	// Handle is the handle of the owner, without the leading `@`. Exactly one
+	// of Handle and Email is set.
+	Handle string
+	Email  string
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: o.Repo.Name,
+			ID:   o.Repo.ID,
+		}
+	case filter.File:
+		if len(path) > 1 && path[1] == "owners" {
+			return o
+		}
+	}
+	return nil
+}
+
+// Identifier returns the handle of the owner prefixed with `@`, or the email
+// of the owner if it has no handle.
+func (o *OwnerMatch) Identifier() string {
+	if o.Handle != "" {
+		return "@" + o.Handle
+	}
+	return o.Email
+}
+
+func (o *OwnerMatch) URL() *url.URL {
+	return &url.URL{Path: "/" + string(o.Repo.Name)}
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Repo:     o.Repo.Name,
+		Commit:   o.CommitID,
+		Owner:    o.Identifier(),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -0,0 +1,121 @@
+package result
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// Document ranks are vectors ordering the files of a repository by importance.
+// Vectors are compared by each pairwise component, higher ranks coming earlier.
+// The same vectors are used by Zoekt for indexed search and by the searcher for
+// unindexed search, so that results from both can be ordered consistently.
+//
+// Rank vector index labels:
+//   - precision                   [0 to 1]
+//   - generated                   [0 or 1]
+//   - vendor                      [0 or 1]
+//   - test                        [0 or 1]
+//   - global document rank        [0 to 1] (=0 w/o pagerank)
+//   - name length                 [0 to 1] (=1 w/  pagerank)
+//   - lexicographic order in repo [0 to 1] (=1 w/  pagerank)
+
+// PreciseDocumentRanks returns the rank vector of a path with a global document
+// rank computed by code intelligence at the given precision.
+func PreciseDocumentRanks(path string, precision, rank float64) []float64 {
+	return []float64{
+		precision,                           // precision level (0, 1]
+		1 - boolRank(isPathGenerated(path)), // rank generated paths lower
+		1 - boolRank(isPathVendored(path)),  // rank vendored paths lower
+		1 - boolRank(isPathTest(path)),      // rank test paths lower
+		squashRange(rank),                   // global document rank
+		1,                                   // name length
+		1,                                   // lexicographic order in repo
+	}
+}
+
+// ImpreciseDocumentRanks returns the rank vector of a path without a global
+// document rank. lexicographicOrder is the position of the path among all paths
+// of the repository mapped to [0, 1], earlier paths being closer to 1. Callers
+// that do not know all paths of the repository should pass 0.
+func ImpreciseDocumentRanks(path string, lexicographicOrder float64) []float64 {
+	return []float64{
+		0,                                   // imprecise
+		1 - boolRank(isPathGenerated(path)), // rank generated paths lower
+		1 - boolRank(isPathVendored(path)),  // rank vendored paths lower
+		1 - boolRank(isPathTest(path)),      // rank test paths lower
+		0,                                   // no global document rank
+		1 - squashRange(float64(len(path))), // name length (prefer short names)
+		lexicographicOrder,                  // lexicographic order in repo
+	}
+}
+
+// CompareRanks compares two rank vectors. It returns a positive number if a
+// ranks before b, a negative number if b ranks before a, and 0 if neither does.
+// A missing rank vector ranks after any other.
+func CompareRanks(a, b []float64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] > b[i] {
+			return 1
+		}
+		if a[i] < b[i] {
+			return -1
+		}
+	}
+	return 0
+}
+
+// SortByRank stably sorts the matches so that file matches with a higher
+// document rank come first. Matches without ranks keep their relative order
+// after all ranked matches.
+func (m Matches) SortByRank() {
+	sort.SliceStable(m, func(i, j int) bool {
+		return CompareRanks(matchRanks(m[i]), matchRanks(m[j])) > 0
+	})
+}
+
+func matchRanks(m Match) []float64 {
+	if fm, ok := m.(*FileMatch); ok {
+		return fm.Ranks
+	}
+	return nil
+}
+
+func isPathGenerated(path string) bool {
+	return strings.HasSuffix(path, "min.js") || strings.HasSuffix(path, "js.map")
+}
+
+func isPathVendored(path string) bool {
+	return strings.Contains(path, "vendor/") || strings.Contains(path, "node_modules/")
+}
+
+var testPattern = lazyregexp.New("test")
+
+func isPathTest(path string) bool {
+	return testPattern.MatchString(path)
+}
+
+// Converts a boolean to a [0, 1] rank (where true is ordered before false).
+func boolRank(v bool) float64 {
+	if v {
+		return 1.0
+	}
+
+	return 0.0
+}
+
+// squashRange maps a value in the range [0, inf) to a value in the range
+// [0, 1) monotonically (i.e., (a < b) <-> (squashRange(a) < squashRange(b))).
+func squashRange(j float64) float64 {
+	return j / (1 + j)
+}
@@ -0,0 +1,85 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDocumentRanks(t *testing.T) {
+	if diff := cmp.Diff([]float64{0.5, 1, 1, 0, 0.5, 1, 1}, PreciseDocumentRanks("foo_test.go", 0.5, 1)); diff != "" {
+		t.Errorf("unexpected precise ranks (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]float64{0, 0, 0, 1, 0, 0.0625, 0.25}, ImpreciseDocumentRanks("vendor/a.min.js", 0.25)); diff != "" {
+		t.Errorf("unexpected imprecise ranks (-want +got):\n%s", diff)
+	}
+}
+
+func TestCompareRanks(t *testing.T) {
+	testCases := []struct {
+		a, b     []float64
+		expected int
+	}{
+		{nil, nil, 0},
+		{nil, []float64{0}, -1},
+		{[]float64{0}, nil, 1},
+		{[]float64{1, 0}, []float64{0, 1}, 1},
+		{[]float64{0, 0.5}, []float64{0, 1}, -1},
+		{[]float64{0, 1}, []float64{0, 1}, 0},
+	}
+
+	for _, testCase := range testCases {
+		if actual := CompareRanks(testCase.a, testCase.b); actual != testCase.expected {
+			t.Errorf("unexpected comparison of %v and %v. want=%d have=%d", testCase.a, testCase.b, testCase.expected, actual)
+		}
+	}
+}
+
+func TestSortByRank(t *testing.T) {
+	fileMatch := func(path string, ranks ...float64) *FileMatch {
+		return &FileMatch{File: File{Path: path}, Ranks: ranks}
+	}
+	repoMatch := &RepoMatch{Name: "r"}
+
+	matches := Matches{
+		fileMatch("unranked-1"),
+		repoMatch,
+		fileMatch("low", 0, 1),
+		fileMatch("high", 1, 0),
+		fileMatch("unranked-2"),
+		fileMatch("mid", 0, 1.5),
+	}
+	matches.SortByRank()
+
+	var paths []string
+	for _, m := range matches {
+		if fm, ok := m.(*FileMatch); ok {
+			paths = append(paths, fm.Path)
+		} else {
+			paths = append(paths, "repo")
+		}
+	}
+	if diff := cmp.Diff([]string{"high", "mid", "low", "unranked-1", "repo", "unranked-2"}, paths); diff != "" {
+		t.Errorf("unexpected order (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergerUnsentTrackedOrder(t *testing.T) {
+	merger := NewMerger(2)
+	merger.AddMatches(Matches{
+		&FileMatch{File: File{Path: "b"}},
+		&FileMatch{File: File{Path: "a"}},
+		&FileMatch{File: File{Path: "c"}, Ranks: []float64{0.5}},
+	}, 0)
+	merger.AddMatches(Matches{
+		&FileMatch{File: File{Path: "d"}, Ranks: []float64{1}},
+	}, 1)
+
+	var paths []string
+	for _, m := range merger.UnsentTracked() {
+		paths = append(paths, m.(*FileMatch).Path)
+	}
+	if diff := cmp.Diff([]string{"d", "c", "a", "b"}, paths); diff != "" {
+		t.Errorf("unexpected order (-want +got):\n%s", diff)
+	}
+}
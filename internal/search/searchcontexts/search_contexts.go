@@ -16,9 +16,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -168,20 +170,53 @@ func validateSearchContextDescription(description string) error {
 
 func validateSearchContextRepositoryRevisions(repositoryRevisions []*types.SearchContextRepositoryRevisions) error {
 	for _, repository := range repositoryRevisions {
+		revs := make([]query.RevisionSpecifier, 0, len(repository.Revisions))
 		for _, revision := range repository.Revisions {
 			if len(revision) > maxRevisionLength {
 				return errors.Errorf("revision %q exceeds maximum allowed length (%d)", revision, maxRevisionLength)
 			}
+			revs = append(revs, ParseRevision(revision))
+		}
+		if err := validateRefGlobs(revs); err != nil {
+			return errors.Wrapf(err, "invalid revisions for repository %q", repository.Repo.Name)
 		}
 	}
 	return nil
 }
 
+// ParseRevision parses a revision of a search context repository. Besides
+// revspecs, a revision can be a ref glob that is expanded to the matching refs
+// of the repository when searching and indexing. Ref globs are written either
+// in the "*glob" and "*!glob" syntax of the repo: filter, or as a ref pattern
+// containing glob characters, such as "refs/heads/release/*". The latter is
+// unambiguous since such patterns are not valid ref names.
+func ParseRevision(rev string) query.RevisionSpecifier {
+	if !strings.HasPrefix(rev, "*") && strings.ContainsAny(rev, "*?[") {
+		return query.RevisionSpecifier{RefGlob: rev}
+	}
+	return query.ParseRevisionSpecifier(rev)
+}
+
+// validateRefGlobs returns an error if the ref globs among revs don't compile.
+func validateRefGlobs(revs []query.RevisionSpecifier) error {
+	var globs []gitdomain.RefGlob
+	for _, rev := range revs {
+		switch {
+		case rev.RefGlob != "":
+			globs = append(globs, gitdomain.RefGlob{Include: rev.RefGlob})
+		case rev.ExcludeRefGlob != "":
+			globs = append(globs, gitdomain.RefGlob{Exclude: rev.ExcludeRefGlob})
+		}
+	}
+	_, err := gitdomain.CompileRefGlobs(globs)
+	return err
+}
+
 // validateSearchContextQuery validates that the search context query complies to the
-// necessary restrictions. We need to limit what we accept so that the query can
-// be converted to an efficient database lookup when determing which revisions
-// to index in RepoRevs. We don't want to run a search to determine which revisions
-// we need to index. That would be brittle, recursive and possibly impossible.
+// necessary restrictions. We need to limit what we accept so that the query only
+// selects repositories and revisions. Searches evaluate the query itself, while
+// RepoRevs determines which revisions to index from the repositories the query
+// resolved to when it was last evaluated in the background.
 func validateSearchContextQuery(contextQuery string) error {
 	if contextQuery == "" {
 		return nil
@@ -199,11 +234,12 @@ func validateSearchContextQuery(contextQuery string) error {
 		switch field {
 		case query.FieldRepo:
 			if a.Labels.IsSet(query.IsPredicate) {
+				// All repo predicates are supported, since the repositories
+				// of a query-based context are evaluated with a search.
 				predName, _ := query.ParseAsPredicate(value)
-				if predName != "has" && predName != "has.tag" && predName != "has.key" {
+				if _, ok := query.DefaultPredicateRegistry[query.FieldRepo][predName]; !ok {
 					errs = errors.Append(errs,
 						errors.Errorf("unsupported repo field predicate in search context query: %q", value))
-					return
 				}
 				return
 			}
@@ -215,12 +251,10 @@ func validateSearchContextQuery(contextQuery string) error {
 				return
 			}
 
-			for _, rev := range repoRevs.Revs {
-				if rev.HasRefGlob() {
-					errs = errors.Append(errs,
-						errors.Errorf("unsupported rev glob in search context query: %q", value))
-					return
-				}
+			if err := validateRefGlobs(repoRevs.Revs); err != nil {
+				errs = errors.Append(errs,
+					errors.Errorf("invalid rev glob in search context query %q: %v", value, err))
+				return
 			}
 
 		case query.FieldFork:
@@ -368,6 +402,7 @@ func DeleteSearchContext(ctx context.Context, db database.DB, searchContext *typ
 }
 
 // RepoRevs returns all the revisions for the given repo IDs defined across all search contexts.
+// Ref globs are returned unexpanded in the "*glob" and "*!glob" syntax of the repo: filter.
 func RepoRevs(ctx context.Context, db database.DB, repoIDs []api.RepoID) (map[api.RepoID][]string, error) {
 	if a := actor.FromContext(ctx); !a.IsInternal() {
 		return nil, errors.New("searchcontexts.RepoRevs can only be accessed by an internal actor")
@@ -379,12 +414,31 @@ func RepoRevs(ctx context.Context, db database.DB, repoIDs []api.RepoID) (map[ap
 	if err != nil {
 		return nil, err
 	}
+	for _, repoRevs := range revs {
+		for i, rev := range repoRevs {
+			repoRevs[i] = ParseRevision(rev).String()
+		}
+	}
 
 	if !conf.ExperimentalFeatures().SearchIndexQueryContexts {
 		return revs, nil
 	}
 
-	contextQueries, err := sc.GetAllQueries(ctx)
+	// The repositories of query-based contexts that have been evaluated in the
+	// background honour repo predicates.
+	queryRevs, err := sc.GetAllQueryRevisionsForRepos(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+	for repoID, repoRevs := range queryRevs {
+		for _, rev := range repoRevs {
+			revs[repoID] = append(revs[repoID], ParseRevision(rev).String())
+		}
+	}
+
+	// Contexts that haven't been evaluated yet fall back to a database lookup,
+	// which ignores repo predicates and so may select more repositories.
+	contextQueries, err := sc.GetAllUnevaluatedQueries(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -442,6 +496,8 @@ func RepoRevs(ctx context.Context, db database.DB, repoIDs []api.RepoID) (map[ap
 // a search context query.
 type RepoOpts struct {
 	database.ReposListOptions
+	// RevSpecs are the revisions of the matched repositories. Ref globs are
+	// written in the "*glob" and "*!glob" syntax of the repo: filter.
 	RevSpecs []string
 }
 
@@ -487,9 +543,7 @@ func ParseRepoOpts(contextQuery string) ([]RepoOpts, error) {
 
 		for _, r := range repoFilters {
 			for _, rev := range r.Revs {
-				if !rev.HasRefGlob() {
-					rq.RevSpecs = append(rq.RevSpecs, rev.RevSpec)
-				}
+				rq.RevSpecs = append(rq.RevSpecs, rev.String())
 			}
 			rq.IncludePatterns = append(rq.IncludePatterns, r.Repo)
 		}
@@ -500,6 +554,45 @@ func ParseRepoOpts(contextQuery string) ([]RepoOpts, error) {
 	return qs, nil
 }
 
+// QueryRepositoriesSearchQuery returns the search query that evaluates which
+// repositories and revisions the given search context query resolves to.
+func QueryRepositoriesSearchQuery(contextQuery string) string {
+	return "(" + contextQuery + ") select:repo count:all"
+}
+
+// QueryRepositoryRevisions converts the repository matches of the search returned
+// by QueryRepositoriesSearchQuery into the repositories and revisions of a search
+// context. Matches without a revision are searched at the default branch.
+func QueryRepositoryRevisions(matches result.Matches) []*types.SearchContextRepositoryRevisions {
+	revsByRepo := map[api.RepoID]*types.SearchContextRepositoryRevisions{}
+	seen := map[api.RepoID]map[string]struct{}{}
+	var out []*types.SearchContextRepositoryRevisions
+	for _, m := range matches {
+		rm, ok := m.(*result.RepoMatch)
+		if !ok {
+			continue
+		}
+
+		rev := rm.Rev
+		if rev == "" {
+			rev = "HEAD"
+		}
+
+		repoRevs, ok := revsByRepo[rm.ID]
+		if !ok {
+			repoRevs = &types.SearchContextRepositoryRevisions{Repo: rm.RepoName()}
+			revsByRepo[rm.ID] = repoRevs
+			seen[rm.ID] = map[string]struct{}{}
+			out = append(out, repoRevs)
+		}
+		if _, ok := seen[rm.ID][rev]; !ok {
+			seen[rm.ID][rev] = struct{}{}
+			repoRevs.Revisions = append(repoRevs.Revisions, rev)
+		}
+	}
+	return out
+}
+
 func GetRepositoryRevisions(ctx context.Context, db database.DB, searchContextID int64) ([]search.RepositoryRevisions, error) {
 	searchContextRepositoryRevisions, err := db.SearchContexts().GetSearchContextRepositoryRevisions(ctx, searchContextID)
 	if err != nil {
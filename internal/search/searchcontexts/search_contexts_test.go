@@ -18,10 +18,15 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestResolvingValidSearchContextSpecs(t *testing.T) {
@@ -444,10 +449,19 @@ func TestCreatingSearchContexts(t *testing.T) {
 			userID:        user1.ID,
 		},
 		{
-			name:          "cannot create search context with unsupported repo field predicate in query",
-			searchContext: &types.SearchContext{Name: "unsupported_repo_predicate", Query: "repo:has.content(foo)"},
+			name:          "can create search context with repo:has.file query",
+			searchContext: &types.SearchContext{Name: "repo_has_file", Query: "repo:has.file(path:go.mod)"},
+			userID:        user1.ID,
+		},
+		{
+			name:          "can create search context with repo:has.commit.after query",
+			searchContext: &types.SearchContext{Name: "repo_has_commit_after", Query: "repo:has.commit.after(1 month ago)"},
+			userID:        user1.ID,
+		},
+		{
+			name:          "can create search context with repo:has.content query",
+			searchContext: &types.SearchContext{Name: "repo_has_content", Query: "repo:has.content(foo)"},
 			userID:        user1.ID,
-			wantErr:       fmt.Sprintf("unsupported repo field predicate in search context query: %q", "has.content(foo)"),
 		},
 		{
 			name:          "can create search context query with empty revision",
@@ -455,16 +469,20 @@ func TestCreatingSearchContexts(t *testing.T) {
 			userID:        user1.ID,
 		},
 		{
-			name:          "cannot create search context query with ref glob",
-			searchContext: &types.SearchContext{Name: "unsupported_ref_glob", Query: "repo:foo/bar@*refs/tags/*"},
+			name:          "can create search context query with ref glob",
+			searchContext: &types.SearchContext{Name: "ref_glob", Query: "repo:foo/bar@*refs/tags/*"},
 			userID:        user1.ID,
-			wantErr:       fmt.Sprintf("unsupported rev glob in search context query: %q", "foo/bar@*refs/tags/*"),
 		},
 		{
-			name:          "cannot create search context query with exclude ref glob",
-			searchContext: &types.SearchContext{Name: "uunsupported_ref_glob", Query: "repo:foo/bar@*!refs/tags/*"},
+			name:          "can create search context query with exclude ref glob",
+			searchContext: &types.SearchContext{Name: "exclude_ref_glob", Query: "repo:foo/bar@*refs/heads/*:*!refs/heads/dev/*"},
 			userID:        user1.ID,
-			wantErr:       fmt.Sprintf("unsupported rev glob in search context query: %q", "foo/bar@*!refs/tags/*"),
+		},
+		{
+			name:          "cannot create search context query with invalid exclude ref glob",
+			searchContext: &types.SearchContext{Name: "invalid_ref_glob", Query: "repo:foo/bar@*!heads/dev/*"},
+			userID:        user1.ID,
+			wantErr:       fmt.Sprintf("invalid rev glob in search context query %q", "foo/bar@*!heads/dev/*"),
 		},
 	}
 
@@ -652,6 +670,19 @@ func TestParseRepoOpts(t *testing.T) {
 				},
 			},
 		},
+		{
+			in: "r:foo@*refs/heads/release/*:*!refs/heads/release/old",
+			out: []RepoOpts{
+				{
+					ReposListOptions: database.ReposListOptions{
+						IncludePatterns: []string{"foo"},
+						NoForks:         true,
+						NoArchived:      true,
+					},
+					RevSpecs: []string{"*refs/heads/release/*", "*!refs/heads/release/old"},
+				},
+			},
+		},
 		{
 			in: "r:foo|bar@HEAD:TAIL archived:yes",
 			out: []RepoOpts{
@@ -721,3 +752,83 @@ func TestParseRepoOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryRepositoryRevisions(t *testing.T) {
+	matches := result.Matches{
+		&result.RepoMatch{Name: "github.com/example/a", ID: 1},
+		&result.RepoMatch{Name: "github.com/example/b", ID: 2, Rev: "main"},
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{ID: 3, Name: "github.com/example/c"}, Path: "README.md"}},
+		&result.RepoMatch{Name: "github.com/example/b", ID: 2, Rev: "dev"},
+		&result.RepoMatch{Name: "github.com/example/a", ID: 1, Rev: "HEAD"},
+	}
+
+	want := []*types.SearchContextRepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: 1, Name: "github.com/example/a"}, Revisions: []string{"HEAD"}},
+		{Repo: types.MinimalRepo{ID: 2, Name: "github.com/example/b"}, Revisions: []string{"main", "dev"}},
+	}
+	if diff := cmp.Diff(want, QueryRepositoryRevisions(matches)); diff != "" {
+		t.Fatalf("unexpected repository revisions (-want +got):\n%s", diff)
+	}
+}
+
+func TestRepoRevs(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{SearchIndexQueryContexts: true},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	sc := database.NewMockSearchContextsStore()
+	sc.GetAllRevisionsForReposFunc.SetDefaultReturn(map[api.RepoID][]string{1: {"main"}}, nil)
+	// Evaluated query-based contexts resolved to repo 2, even if its name
+	// matches no query, since the queries can contain repo predicates.
+	sc.GetAllQueryRevisionsForReposFunc.SetDefaultReturn(map[api.RepoID][]string{2: {"HEAD"}}, nil)
+	sc.GetAllUnevaluatedQueriesFunc.SetDefaultReturn([]string{`repo:^github\.com/example/c$@dev`}, nil)
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{{ID: 3, Name: "github.com/example/c"}}, nil)
+	db := database.NewMockDB()
+	db.SearchContextsFunc.SetDefaultReturn(sc)
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	ctx := actor.WithInternalActor(context.Background())
+	got, err := RepoRevs(ctx, db, []api.RepoID{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, map[api.RepoID][]string{1: {"main"}, 2: {"HEAD"}, 3: {"dev"}}, got)
+
+	// Queries of evaluated contexts aren't looked up in the database again.
+	mockrequire.NotCalled(t, sc.GetAllQueriesFunc)
+}
+
+func TestParseRevision(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want query.RevisionSpecifier
+	}{
+		{in: "", want: query.RevisionSpecifier{}},
+		{in: "main", want: query.RevisionSpecifier{RevSpec: "main"}},
+		{in: "HEAD~1", want: query.RevisionSpecifier{RevSpec: "HEAD~1"}},
+		{in: "refs/heads/release/*", want: query.RevisionSpecifier{RefGlob: "refs/heads/release/*"}},
+		{in: "refs/tags/v1.?", want: query.RevisionSpecifier{RefGlob: "refs/tags/v1.?"}},
+		{in: "*refs/heads/release", want: query.RevisionSpecifier{RefGlob: "refs/heads/release"}},
+		{in: "*!refs/heads/release/old*", want: query.RevisionSpecifier{ExcludeRefGlob: "refs/heads/release/old*"}},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ParseRevision(tc.in)); diff != "" {
+				t.Fatalf("unexpected revision specifier (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSearchContextRepositoryRevisions(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/example/a"}
+
+	err := validateSearchContextRepositoryRevisions([]*types.SearchContextRepositoryRevisions{
+		{Repo: repo, Revisions: []string{"main", "refs/heads/release/*", "*!refs/heads/release/old"}},
+	})
+	require.NoError(t, err)
+
+	err = validateSearchContextRepositoryRevisions([]*types.SearchContextRepositoryRevisions{
+		{Repo: repo, Revisions: []string{"*!heads/release/old"}},
+	})
+	require.ErrorContains(t, err, `invalid revisions for repository "github.com/example/a"`)
+}
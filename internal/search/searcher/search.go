@@ -109,7 +109,7 @@ func (s *TextSearchJob) Run(ctx context.Context, clients job.RuntimeClients, str
 					ctx, done := limitCtx, limitDone
 					defer done()
 
-					repoLimitHit, err := s.searchFilesInRepo(ctx, clients.DB, clients.SearcherURLs, repo, repo.Name, rev, s.Indexed, s.PatternInfo, fetchTimeout, stream)
+					repoLimitHit, err := s.searchFilesInRepo(ctx, clients.Logger, clients.DB, clients.SearcherURLs, repo, repo.Name, rev, s.Indexed, s.PatternInfo, fetchTimeout, stream)
 					if err != nil {
 						tr.SetAttributes(
 							attribute.String("repo", string(repo.Name)),
@@ -174,6 +174,7 @@ var MockSearchFilesInRepo func(
 
 func (s *TextSearchJob) searchFilesInRepo(
 	ctx context.Context,
+	logger log.Logger,
 	db database.DB,
 	searcherURLs *endpoint.Map,
 	repo types.MinimalRepo,
@@ -198,17 +199,61 @@ func (s *TextSearchJob) searchFilesInRepo(
 	}
 
 	onMatches := func(searcherMatches []*protocol.FileMatch) {
+		matches := convertMatches(repo, commit, &rev, searcherMatches, s.PathRegexps)
+		if s.Features.Ranking {
+			rankMatches(matches, getPathRanks(ctx, logger, db, repo.Name, searcherMatches))
+		}
 		stream.Send(streaming.SearchEvent{
-			Results: convertMatches(repo, commit, &rev, searcherMatches, s.PathRegexps),
+			Results: matches,
 		})
 	}
 
 	return Search(ctx, searcherURLs, gitserverRepo, repo.ID, rev, commit, index, info, fetchTimeout, s.Features, onMatches)
 }
 
+// getPathRanks returns the stored ranks of the paths of the given searcher
+// matches. Only the matched paths are fetched, since a repository may have
+// far more ranked paths than matches.
+func getPathRanks(ctx context.Context, logger log.Logger, db database.DB, repoName api.RepoName, searcherMatches []*protocol.FileMatch) map[string]database.PathRank {
+	paths := make([]string, 0, len(searcherMatches))
+	for _, fm := range searcherMatches {
+		paths = append(paths, fm.Path)
+	}
+
+	pathRanks, err := db.PathRanks().GetDocumentRanks(ctx, repoName, paths)
+	if err != nil {
+		// Ranks only affect the order of results, so rank without the stored
+		// ranks rather than failing.
+		logger.Warn("failed to get document ranks", log.String("repo", string(repoName)), log.Error(err))
+		return nil
+	}
+	return pathRanks
+}
+
+// rankMatches sets the document ranks of the file matches from the stored path
+// ranks of their repository and sorts them by rank. Paths without a stored rank
+// are ranked with the same heuristics Zoekt uses for indexed search.
+func rankMatches(matches result.Matches, pathRanks map[string]database.PathRank) {
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		if r, ok := pathRanks[fm.Path]; ok {
+			fm.Ranks = result.PreciseDocumentRanks(fm.Path, r.Precision, r.Rank)
+		} else {
+			// We don't know the other paths in the repository, so we can't
+			// compute the lexicographic order of this one.
+			fm.Ranks = result.ImpreciseDocumentRanks(fm.Path, 0)
+		}
+	}
+	matches.SortByRank()
+}
+
 // convert converts a set of searcher matches into []result.Match
-func convertMatches(repo types.MinimalRepo, commit api.CommitID, rev *string, searcherMatches []*protocol.FileMatch, pathRegexps []*regexp.Regexp) []result.Match {
-	matches := make([]result.Match, 0, len(searcherMatches))
+func convertMatches(repo types.MinimalRepo, commit api.CommitID, rev *string, searcherMatches []*protocol.FileMatch, pathRegexps []*regexp.Regexp) result.Matches {
+	matches := make(result.Matches, 0, len(searcherMatches))
 	for _, fm := range searcherMatches {
 		chunkMatches := make(result.ChunkMatches, 0, len(fm.ChunkMatches))
 
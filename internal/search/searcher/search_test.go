@@ -0,0 +1,55 @@
+package searcher
+
+import (
+	"context"
+	"testing"
+
+	mockrequire "github.com/derision-test/go-mockgen/testutil/require"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRankMatches(t *testing.T) {
+	matches := result.Matches{
+		&result.FileMatch{File: result.File{Path: "main_test.go"}},
+		&result.FileMatch{File: result.File{Path: "vendor/lib.go"}},
+		&result.FileMatch{File: result.File{Path: "util.go"}},
+		&result.FileMatch{File: result.File{Path: "main.go"}},
+	}
+	rankMatches(matches, map[string]database.PathRank{
+		"util.go": {Precision: 1, Rank: 3},
+		"main.go": {Precision: 1, Rank: 5},
+	})
+
+	var paths []string
+	for _, m := range matches {
+		fm := m.(*result.FileMatch)
+		require.NotNil(t, fm.Ranks)
+		paths = append(paths, fm.Path)
+	}
+	require.Equal(t, []string{"main.go", "util.go", "main_test.go", "vendor/lib.go"}, paths)
+	require.Equal(t, result.PreciseDocumentRanks("main.go", 1, 5), matches[0].(*result.FileMatch).Ranks)
+}
+
+func TestGetPathRanks(t *testing.T) {
+	pathRanks := database.NewMockPathRankStore()
+	pathRanks.GetDocumentRanksFunc.SetDefaultReturn(map[string]database.PathRank{"main.go": {Precision: 1, Rank: 5}}, nil)
+	db := database.NewMockDB()
+	db.PathRanksFunc.SetDefaultReturn(pathRanks)
+
+	ranks := getPathRanks(context.Background(), logtest.Scoped(t), db, "r", []*protocol.FileMatch{{Path: "main.go"}, {Path: "util.go"}})
+	require.Equal(t, map[string]database.PathRank{"main.go": {Precision: 1, Rank: 5}}, ranks)
+
+	// Only the matched paths are requested
+	mockrequire.CalledOnceWith(t, pathRanks.GetDocumentRanksFunc, mockrequire.Values(mockrequire.Skip, api.RepoName("r"), []string{"main.go", "util.go"}))
+
+	// Errors are not fatal
+	pathRanks.GetDocumentRanksFunc.SetDefaultReturn(nil, errors.New("boom"))
+	require.Nil(t, getPathRanks(context.Background(), logtest.Scoped(t), db, "r", []*protocol.FileMatch{{Path: "main.go"}}))
+}
@@ -0,0 +1,118 @@
+package smartsearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	conf.ContributeValidator(func(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+		for _, r := range c.SiteConfig().SearchSmartSearchRules {
+			if _, err := parseSiteRule(r); err != nil {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("Invalid smart search rule in search.smartSearch.rules: %s", err)))
+			}
+		}
+		return
+	})
+}
+
+// siteRules returns the narrowing rules configured by site admins in
+// search.smartSearch.rules.
+var siteRules = conf.Cached[[]rule](func() []rule {
+	var rules []rule
+	for _, r := range conf.Get().SearchSmartSearchRules {
+		parsed, err := parseSiteRule(r)
+		if err != nil {
+			// Skip if there's an error. A user-visible validation error will appear due to the ContributeValidator call above.
+			log15.Error("Site config: invalid smart search rule", "pattern", r.Pattern, "error", err)
+			continue
+		}
+		rules = append(rules, parsed)
+	}
+	return rules
+})
+
+// parseSiteRule converts a rule configured by site admins into a rule that
+// replaces the configured pattern by the configured filters.
+func parseSiteRule(r *schema.SmartSearchRule) (rule, error) {
+	pattern := strings.TrimSpace(r.Pattern)
+	if pattern == "" || strings.ContainsAny(pattern, " \t\n") {
+		return rule{}, errors.Errorf("pattern %q must be a single search term", r.Pattern)
+	}
+
+	plan, err := query.Pipeline(query.Init(r.Filters, query.SearchTypeStandard))
+	if err != nil {
+		return rule{}, errors.Wrapf(err, "filters %q of pattern %q", r.Filters, r.Pattern)
+	}
+	if len(plan) != 1 || len(plan[0].Parameters) == 0 || plan[0].Pattern != nil {
+		return rule{}, errors.Errorf("filters %q of pattern %q must only contain filters", r.Filters, r.Pattern)
+	}
+
+	description := r.Description
+	if description == "" {
+		description = fmt.Sprintf("rewrite %s to %s", pattern, strings.TrimSpace(r.Filters))
+	}
+
+	return rule{
+		description: description,
+		transform:   []transform{patternToFilters(pattern, plan[0].Parameters)},
+	}, nil
+}
+
+// patternToFilters returns a transform that replaces the first pattern equal
+// to the given pattern, ignoring case, by the given filters.
+func patternToFilters(pattern string, filters []query.Parameter) transform {
+	return func(b query.Basic) *query.Basic {
+		rawPatternTree, err := query.Parse(query.StringHuman([]query.Node{b.Pattern}), query.SearchTypeStandard)
+		if err != nil {
+			return nil
+		}
+
+		changed := false
+		newParseTree := query.MapPattern(rawPatternTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+			if !changed && !negated && strings.EqualFold(value, pattern) {
+				changed = true
+				// remove this node
+				return nil
+			}
+			return query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+		})
+
+		if !changed {
+			return nil
+		}
+
+		filterParams := make([]query.Node, 0, len(filters))
+		for _, f := range filters {
+			filterParams = append(filterParams, f)
+		}
+
+		// Reduce with NewOperator to obtain valid partitioning, like
+		// patternsToCodeHostFilters.
+		newParseTree = query.NewOperator(append(newParseTree, filterParams...), query.And)
+		newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+		if err != nil {
+			return nil
+		}
+
+		newBasic, err := query.ToBasicQuery(newNodes)
+		if err != nil {
+			return nil
+		}
+
+		newBasic.Parameters = append(append([]query.Parameter{}, b.Parameters...), newBasic.Parameters...)
+		return &newBasic
+	}
+}
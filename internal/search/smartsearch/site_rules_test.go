@@ -0,0 +1,51 @@
+package smartsearch
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func Test_parseSiteRule(t *testing.T) {
+	r, err := parseSiteRule(&schema.SmartSearchRule{Pattern: "frontend", Filters: `repo:^github\.com/acme/web$ lang:typescript`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `rewrite frontend to repo:^github\.com/acme/web$ lang:typescript`; r.description != want {
+		t.Errorf("unexpected description: want %q, got %q", want, r.description)
+	}
+
+	test := func(input string) string {
+		return apply(input, r.transform)
+	}
+
+	cases := []string{
+		`frontend button`,
+		`repo:foo FRONTEND`,
+		`-frontend button`,
+		`backend`,
+	}
+
+	for _, c := range cases {
+		t.Run("site rule", func(t *testing.T) {
+			autogold.Equal(t, autogold.Raw(test(c)))
+		})
+	}
+}
+
+func Test_parseSiteRule_invalid(t *testing.T) {
+	cases := []*schema.SmartSearchRule{
+		{Pattern: "two terms", Filters: "repo:foo"},
+		{Pattern: "frontend", Filters: "repo:foo button"},
+		{Pattern: "frontend", Filters: "repo:foo or repo:bar"},
+		{Pattern: "frontend", Filters: "notafield:foo"},
+	}
+
+	for _, c := range cases {
+		if _, err := parseSiteRule(c); err == nil {
+			t.Errorf("expected an error for pattern %q and filters %q", c.Pattern, c.Filters)
+		}
+	}
+}
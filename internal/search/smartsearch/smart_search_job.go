@@ -34,9 +34,12 @@ type newJob func(query.Basic) (job.Job, error)
 // not, attempt to search the pattern as a regexp, and so on). There is no
 // random choice when applying rules.
 func NewSmartSearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *FeelingLuckySearchJob {
+	// Rules configured by site admins are tried before the built-in rules.
+	narrow := append(append([]rule{}, siteRules()...), rulesNarrow...)
+
 	generators := make([]next, 0, len(plan))
 	for _, b := range plan {
-		generators = append(generators, NewGenerator(b, rulesNarrow, rulesWiden))
+		generators = append(generators, NewGenerator(b, narrow, rulesWiden))
 	}
 
 	newGeneratedJob := func(autoQ *autoQuery) job.Job {
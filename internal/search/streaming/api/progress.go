@@ -42,9 +42,10 @@ type ProgressStats struct {
 	ExcludedArchived    int
 	ExcludedForks       int
 
-	Timedout []api.RepoID
-	Missing  []api.RepoID
-	Cloning  []api.RepoID
+	Timedout  []api.RepoID
+	Missing   []api.RepoID
+	Cloning   []api.RepoID
+	Unindexed []api.RepoID
 
 	LimitHit bool
 
@@ -108,6 +109,19 @@ func repositoryMissingHandler(resultsResolver ProgressStats) (Skipped, bool) {
 	})
 }
 
+func repositoryUnindexedHandler(resultsResolver ProgressStats) (Skipped, bool) {
+	repos := resultsResolver.Unindexed
+	messageReason := fmt.Sprintf("could not be searched structurally since %s not indexed yet", plural("it is", "they are", len(repos)))
+	return skippedReposHandler(repos, resultsResolver.namer, "unindexed", messageReason, Skipped{
+		Reason:   RepositoryUnindexed,
+		Severity: SeverityInfo,
+		Suggested: &SkippedSuggested{
+			Title:           "search without index",
+			QueryExpression: "index:no",
+		},
+	})
+}
+
 func shardTimeoutHandler(resultsResolver ProgressStats) (Skipped, bool) {
 	// This is not the same, but once we expose this more granular details
 	// from our backend it will be shard specific.
@@ -216,6 +230,7 @@ func excludedArchiveHandler(resultsResolver ProgressStats) (Skipped, bool) {
 var skippedHandlers = []func(stats ProgressStats) (Skipped, bool){
 	repositoryMissingHandler,
 	repositoryCloningHandler,
+	repositoryUnindexedHandler,
 	// documentMatchLimitHandler,
 	shardMatchLimitHandler,
 	// repositoryLimitHandler,
@@ -55,6 +55,11 @@ func TestSearchProgress(t *testing.T) {
 			SuggestedLimit:      1000,
 			DisplayLimit:        math.MaxInt32,
 		},
+		"unindexed": {
+			RepositoriesCount: intPtr(2),
+			Unindexed:         []api.RepoID{6, 7},
+			DisplayLimit:      math.MaxInt32,
+		},
 		"traced": {
 			Trace: "abcd",
 		},
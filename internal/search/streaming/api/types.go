@@ -74,6 +74,9 @@ const (
 	// RepositoryMissing is when we could not search a repository because it
 	// is not cloned and we failed to find it on the remote code host.
 	RepositoryMissing SkippedReason = "repository-missing"
+	// RepositoryUnindexed is when we could not search a repository because
+	// it is not indexed and the search only runs against the index.
+	RepositoryUnindexed SkippedReason = "repository-unindexed"
 	// BackendMissing is when a backend was missing. This means we are unsure
 	// if we found all results, since we do not know which results may have
 	// come back from the backend. This should be a rare event. For example it
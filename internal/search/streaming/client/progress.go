@@ -64,6 +64,7 @@ func (p *ProgressAggregator) currentStats() api.ProgressStats {
 		Timedout:            getRepos(p.Stats, searchshared.RepoStatusTimedout),
 		Missing:             getRepos(p.Stats, searchshared.RepoStatusMissing),
 		Cloning:             getRepos(p.Stats, searchshared.RepoStatusCloning),
+		Unindexed:           getRepos(p.Stats, searchshared.RepoStatusUnindexed),
 		LimitHit:            p.Stats.IsLimitHit,
 		SuggestedLimit:      suggestedLimit,
 		Trace:               p.Trace,
@@ -167,6 +167,20 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventOwnerMatch is an owner declared in the CODEOWNERS file of a repository.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Commit       string `json:"commit,omitempty"`
+	Handle       string `json:"handle,omitempty"`
+	Email        string `json:"email,omitempty"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -212,6 +226,7 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -226,6 +241,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -242,6 +259,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
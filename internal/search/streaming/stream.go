@@ -130,9 +130,26 @@ func NewBatchingStream(maxDelay time.Duration, parent Sender) *batchingStream {
 	}
 }
 
+// NewRankingBatchingStream is like NewBatchingStream, but additionally sorts the
+// results of each batch by document rank before sending them to the parent
+// stream.
+//
+// Results are ranked per batch only: a batch is sent as soon as maxDelay has
+// passed, so a result of a later batch may outrank the results already sent.
+// Ranking within batches orders results from indexed and unindexed search
+// consistently without holding back the whole result set.
+func NewRankingBatchingStream(maxDelay time.Duration, parent Sender) *batchingStream {
+	return &batchingStream{
+		parent:     parent,
+		maxDelay:   maxDelay,
+		sortByRank: true,
+	}
+}
+
 type batchingStream struct {
-	parent   Sender
-	maxDelay time.Duration
+	parent     Sender
+	maxDelay   time.Duration
+	sortByRank bool
 
 	mu             sync.Mutex
 	sentFirstEvent bool
@@ -196,6 +213,9 @@ func (s *batchingStream) Done() {
 // a lock on the batching stream.
 func (s *batchingStream) flush() {
 	if s.dirty {
+		if s.sortByRank {
+			s.batch.Results.SortByRank()
+		}
 		s.parent.Send(s.batch)
 		s.batch = SearchEvent{}
 		s.dirty = false
@@ -130,6 +130,27 @@ func TestBatchingStream(t *testing.T) {
 		s.Done()
 		require.Equal(t, count.Load(), int64(10))
 	})
+
+	t.Run("ranking sorts batches", func(t *testing.T) {
+		var paths []string
+		s := NewRankingBatchingStream(100*time.Millisecond, StreamFunc(func(event SearchEvent) {
+			for _, m := range event.Results {
+				paths = append(paths, m.(*result.FileMatch).Path)
+			}
+		}))
+
+		fileMatch := func(path string, rank float64) *result.FileMatch {
+			return &result.FileMatch{File: result.File{Path: path}, Ranks: []float64{rank}}
+		}
+
+		// The first event is sent immediately, the rest are batched and sorted
+		s.Send(SearchEvent{Results: result.Matches{fileMatch("first", 0)}})
+		s.Send(SearchEvent{Results: result.Matches{fileMatch("low", 0.1)}})
+		s.Send(SearchEvent{Results: result.Matches{fileMatch("high", 0.9), fileMatch("mid", 0.5)}})
+		s.Done()
+
+		require.Equal(t, []string{"first", "high", "mid", "low"}, paths)
+	})
 }
 
 func TestDedupingStream(t *testing.T) {
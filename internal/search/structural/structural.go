@@ -169,19 +169,35 @@ func (s *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		page := it.Current()
 		page.MaybeSendStats(stream)
 
+		// Structural search only runs on indexed revisions, which Zoekt
+		// prefilters, unless the query opts out of the index. We partition
+		// with index:yes so that we learn which repositories are unindexed,
+		// even if the query only searches the index.
+		indexedOnly := s.UseIndex != query.No && !s.ContainsRefGlobs
+		useIndex := s.UseIndex
+		if indexedOnly {
+			useIndex = query.Yes
+		}
 		indexed, unindexed, err := zoektutil.PartitionRepos(
 			ctx,
 			clients.Logger,
 			page.RepoRevs,
 			clients.Zoekt,
 			search.TextRequest,
-			s.UseIndex,
+			useIndex,
 			s.ContainsRefGlobs,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		if indexedOnly && len(unindexed) > 0 {
+			// Don't fall back to fetching archives from searcher for the
+			// rest. Report them as skipped instead.
+			stream.Send(streaming.SearchEvent{Stats: unindexedStats(unindexed)})
+			unindexed = nil
+		}
+
 		repoSet := []repoData{UnindexedList(unindexed)}
 		if indexed != nil {
 			repoSet = append(repoSet, IndexedMap(indexed.RepoRevs))
@@ -195,6 +211,16 @@ func (s *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 	return nil, it.Err()
 }
 
+// unindexedStats returns stats marking repos as not searched because they
+// are not indexed.
+func unindexedStats(repos []*search.RepositoryRevisions) streaming.Stats {
+	var stats streaming.Stats
+	for _, repo := range repos {
+		stats.Status.Update(repo.Repo.ID, search.RepoStatusUnindexed)
+	}
+	return stats
+}
+
 func (*SearchJob) Name() string {
 	return "StructuralSearchJob"
 }
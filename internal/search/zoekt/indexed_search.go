@@ -401,6 +401,7 @@ func sendMatches(event *zoekt.SearchResult, pathRegexps []*regexp.Regexp, getRep
 				ChunkMatches: hms,
 				Symbols:      symbols,
 				PathMatches:  pathMatches,
+				Ranks:        file.Ranks,
 				File: result.File{
 					InputRev: &inputRev,
 					CommitID: api.CommitID(file.Version),
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// SearchExportJob is a background job exporting all results of a search to
+// the upload store.
+type SearchExportJob struct {
+	ID int64
+
+	UserID    int32
+	Query     string
+	Format    string
+	CreatedAt time.Time
+
+	// ResultCount is the number of exported rows. ObjectKey is the key of the
+	// export in the upload store; it is set once the job completed.
+	ResultCount int
+	ObjectKey   *string
+
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt time.Time
+	ExecutionLogs   []workerutil.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+}
+
+func (j *SearchExportJob) RecordID() int {
+	return int(j.ID)
+}
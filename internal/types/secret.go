@@ -59,6 +59,8 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.TokenOauthRefresh, "token.oauth.refresh")
 	case *schema.GerritConnection:
 		es.redactString(c.Password, "password")
+	case *schema.AzureDevOpsConnection:
+		es.redactString(c.Token, "token")
 	case *schema.BitbucketServerConnection:
 		es.redactString(c.Password, "password")
 		es.redactString(c.Token, "token")
@@ -99,6 +101,11 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.NpmPackagesConnection:
 		es.redactString(c.Credentials, "credentials")
+	case *schema.MercurialConnection:
+		err = es.redactURL(c.Url, "url")
+		if err != nil {
+			return "", err
+		}
 	case *schema.OtherExternalServiceConnection:
 		err = es.redactURL(c.Url, "url")
 		if err != nil {
@@ -245,6 +252,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 
 		}
 		es.unredactString(c.Maven.Credentials, o.Maven.Credentials, "maven", "credentials")
+	case *schema.AzureDevOpsConnection:
+		o := oldCfg.(*schema.AzureDevOpsConnection)
+		if c.Token == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "token"}
+		}
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.PagureConnection:
 		o := oldCfg.(*schema.PagureConnection)
 		if c.Token == RedactedSecret && c.Url != o.Url {
@@ -257,32 +270,16 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 			return errCodeHostIdentityChanged{"registry", "credentials"}
 		}
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
-	case *schema.OtherExternalServiceConnection:
-		o := oldCfg.(*schema.OtherExternalServiceConnection)
-		err := es.unredactURL(c.Url, o.Url, "url")
-		if err != nil {
-			return err
-		}
-		oldParsed, err := url.Parse(o.Url)
-		if err != nil {
+	case *schema.MercurialConnection:
+		o := oldCfg.(*schema.MercurialConnection)
+		if err := es.unredactCodeHostURL(c.Url, o.Url, "url"); err != nil {
 			return err
 		}
-		newParsed, err := url.Parse(c.Url)
-		if err != nil {
+	case *schema.OtherExternalServiceConnection:
+		o := oldCfg.(*schema.OtherExternalServiceConnection)
+		if err := es.unredactCodeHostURL(c.Url, o.Url, "url"); err != nil {
 			return err
 		}
-		// compare URLs and see if password changed
-		pwd, ok := newParsed.User.Password()
-
-		// remove UserInfo so we can compare URLs
-		oldParsed.User = nil
-		newParsed.User = nil
-
-		if newParsed.String() != oldParsed.String() {
-			if ok && pwd == RedactedSecret {
-				return errCodeHostIdentityChanged{"url", "password"}
-			}
-		}
 
 	default:
 		// return an error; it's safer to fail than to incorrectly return unsafe data.
@@ -397,6 +394,37 @@ func (es *edits) unredactURL(new, old string, path ...any) error {
 	return nil
 }
 
+// unredactCodeHostURL unredacts the password of the code host URL new. It
+// returns an error if the password is redacted but the rest of the URL
+// changed.
+func (es *edits) unredactCodeHostURL(new, old string, path ...any) error {
+	err := es.unredactURL(new, old, path...)
+	if err != nil {
+		return err
+	}
+	oldParsed, err := url.Parse(old)
+	if err != nil {
+		return err
+	}
+	newParsed, err := url.Parse(new)
+	if err != nil {
+		return err
+	}
+	// compare URLs and see if password changed
+	pwd, ok := newParsed.User.Password()
+
+	// remove UserInfo so we can compare URLs
+	oldParsed.User = nil
+	newParsed.User = nil
+
+	if newParsed.String() != oldParsed.String() {
+		if ok && pwd == RedactedSecret {
+			return errCodeHostIdentityChanged{"url", "password"}
+		}
+	}
+	return nil
+}
+
 type edit struct {
 	path  jsonx.Path
 	value any
@@ -563,6 +563,9 @@ type GitserverRepo struct {
 	// A log of the different types of corruption that was detected on this repo. The order of the log entries are
 	// stored from most recent to least recent and capped at 10 entries. See LogCorruption on Gitserverrepo store.
 	CorruptionLogs []RepoCorruptionLog
+	// A log of the scheduled maintenance tasks that ran on this repo. The order of the log entries are stored from
+	// most recent to least recent and capped at 50 entries. See LogMaintenance on Gitserverrepo store.
+	MaintenanceLogs []RepoMaintenanceLog
 }
 
 // RepoCorruptionLog represents a corruption event that has been detected on a repo.
@@ -573,6 +576,19 @@ type RepoCorruptionLog struct {
 	Reason string `json:"reason"`
 }
 
+// RepoMaintenanceLog represents a run of a scheduled maintenance task on a repo.
+type RepoMaintenanceLog struct {
+	// The maintenance task that ran, for example "commit-graph"
+	Task string `json:"task"`
+	// When the task started and finished
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	// The output of the task if it failed, empty if it succeeded
+	Error string `json:"error,omitempty"`
+	// When the task is scheduled to run next according to the maintenance policy of the repo
+	NextRunAt time.Time `json:"nextRunAt"`
+}
+
 // ExternalService is a connection to an external service.
 type ExternalService struct {
 	ID             int64
@@ -813,6 +829,10 @@ type Role struct {
 	DeletedAt time.Time
 }
 
+// DefaultRoleName is the name of the built-in role that all users implicitly
+// hold.
+const DefaultRoleName = "DEFAULT"
+
 type Permission struct {
 	ID        int32
 	Namespace string
@@ -827,11 +847,23 @@ type RolePermission struct {
 }
 
 type UserRole struct {
-	RoleID    int32
-	UserID    int32
+	RoleID int32
+	UserID int32
+	// OrgID is the organization the role assignment is scoped to, or 0 if it
+	// applies globally.
+	OrgID     int32
 	CreatedAt time.Time
 }
 
+// GrantedPermission is a permission granted to a user through one of their roles.
+type GrantedPermission struct {
+	Namespace string
+	Action    string
+	// OrgID is the organization the permission is scoped to, or 0 if it
+	// applies globally.
+	OrgID int32
+}
+
 type OrgMemberAutocompleteSearchItem struct {
 	ID          int32
 	Username    string
@@ -1698,6 +1730,11 @@ type SearchContext struct {
 	// e.g. repo:^github\.com/org rev:bar archive:no f:sub/dir
 	Query string
 
+	// QueryReposUpdatedAt is when the repositories that Query resolves to were
+	// last evaluated in the background. It is zero if they have not been
+	// evaluated since the query was last changed.
+	QueryReposUpdatedAt time.Time
+
 	// Whether the search context is auto-defined by Sourcegraph. Auto-defined search contexts are not editable by users.
 	AutoDefined bool
 
@@ -4,9 +4,11 @@ import (
 	"context"
 	"net"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/grafana/regexp"
+	"github.com/sourcegraph/log"
 
 	"code.gitea.io/gitea/modules/hostmatcher"
 
@@ -55,6 +57,38 @@ func (s *outboundWebhookService) Enqueue(
 	return nil
 }
 
+// Enqueue creates an outbound webhook job that will dispatch a webhook of the
+// given type with a payload marshalled by the given marshaller. The job is
+// created using the given database handle, so calling this within a
+// transaction only enqueues the webhook if the transaction is committed.
+//
+// Webhooks are generally intended to be fire and forget from the point of view
+// of calling code, so errors are logged rather than returned.
+func Enqueue[T any](
+	ctx context.Context, logger log.Logger, db basestore.ShareableStore,
+	eventType string,
+	marshaller func(context.Context, basestore.ShareableStore, T) ([]byte, error),
+	value T,
+) {
+	svc := NewOutboundWebhookService(db, nil)
+
+	logger = logger.With(
+		log.String("payload_type", reflect.TypeOf(value).String()),
+		log.String("event_type", eventType),
+	)
+
+	payload, err := marshaller(ctx, db, value)
+	if err != nil {
+		logger.Error("error marshalling webhook payload", log.Error(err))
+		return
+	}
+
+	if err := svc.Enqueue(ctx, eventType, nil, payload); err != nil {
+		logger.Error("error enqueuing webhook job", log.Error(err))
+		return
+	}
+}
+
 // Based on https://www.ietf.org/archive/id/draft-chapin-rfc2606bis-00.html
 const reservedTLDs = "localhost|local|test|example|invalid|localdomain|domain|lan|home|host|corp"
 
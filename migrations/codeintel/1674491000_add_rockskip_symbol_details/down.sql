@@ -0,0 +1,6 @@
+ALTER TABLE rockskip_symbols
+    DROP COLUMN IF EXISTS kind,
+    DROP COLUMN IF EXISTS line,
+    DROP COLUMN IF EXISTS character,
+    DROP COLUMN IF EXISTS parent,
+    DROP COLUMN IF EXISTS parent_kind;
@@ -0,0 +1,9 @@
+-- Symbols indexed before this migration keep an empty kind. Rockskip replaces
+-- them as the files that contain them change, and reads their details from
+-- the files when they match a search until then.
+ALTER TABLE rockskip_symbols
+    ADD COLUMN IF NOT EXISTS kind text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS line integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS character integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS parent text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS parent_kind text NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedule (
+    repo_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    interval_seconds integer NOT NULL,
+    due_at timestamp with time zone NOT NULL,
+    last_fetched_at timestamp with time zone,
+    priority integer,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS repo_update_schedule_due_at_idx ON repo_update_schedule (due_at);
+
+COMMENT ON TABLE repo_update_schedule IS 'The update schedule of repo-updater, persisted so that it survives restarts.';
+COMMENT ON COLUMN repo_update_schedule.interval_seconds IS 'How regularly the repository is updated, including jitter.';
+COMMENT ON COLUMN repo_update_schedule.due_at IS 'When the repository will next be enqueued for an update.';
+COMMENT ON COLUMN repo_update_schedule.last_fetched_at IS 'When the repository was last successfully fetched by the scheduler.';
+COMMENT ON COLUMN repo_update_schedule.priority IS 'Priority of the repository in the update queue, or NULL if it is not queued.';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id bigserial PRIMARY KEY,
+    audit_id text NOT NULL,
+    entity text NOT NULL,
+    action text NOT NULL,
+    actor_uid text NOT NULL,
+    ip text NOT NULL,
+    forwarded_for text NOT NULL,
+    fields jsonb NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_created_at_idx ON audit_logs (created_at);
+CREATE INDEX IF NOT EXISTS audit_logs_actor_uid_created_at_idx ON audit_logs (actor_uid, created_at);
+CREATE INDEX IF NOT EXISTS audit_logs_entity_created_at_idx ON audit_logs (entity, created_at);
+
+COMMENT ON TABLE audit_logs IS 'Records written with audit.Log, persisted so that they can be queried and exported.';
+COMMENT ON COLUMN audit_logs.audit_id IS 'The sampling immunity token of the record, also present in the log output.';
+COMMENT ON COLUMN audit_logs.actor_uid IS 'The ID of the user, the anonymous user ID, or "unknown".';
+COMMENT ON COLUMN audit_logs.fields IS 'The additional context of the record.';
@@ -0,0 +1,7 @@
+DELETE FROM user_roles WHERE org_id IS NOT NULL;
+DROP INDEX IF EXISTS user_roles_org_id;
+DROP INDEX IF EXISTS user_roles_user_id_role_id_org_id_unique;
+ALTER TABLE user_roles DROP COLUMN IF EXISTS org_id;
+
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS user_roles_pkey;
+ALTER TABLE user_roles ADD CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id);
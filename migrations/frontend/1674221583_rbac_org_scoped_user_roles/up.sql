@@ -0,0 +1,9 @@
+ALTER TABLE user_roles ADD COLUMN IF NOT EXISTS org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE;
+
+-- A user can hold the same role globally and in any number of organizations,
+-- so the primary key is replaced by a unique index that includes the org.
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS user_roles_pkey;
+CREATE UNIQUE INDEX IF NOT EXISTS user_roles_user_id_role_id_org_id_unique ON user_roles (user_id, role_id, COALESCE(org_id, 0));
+CREATE INDEX IF NOT EXISTS user_roles_org_id ON user_roles (org_id) WHERE org_id IS NOT NULL;
+
+COMMENT ON COLUMN user_roles.org_id IS 'The organization the role assignment is scoped to, or NULL if it applies globally.';
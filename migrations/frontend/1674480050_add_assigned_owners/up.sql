@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS assigned_owners (
+    id serial PRIMARY KEY,
+    owner_user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    file_path text NOT NULL,
+    who_assigned_user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    assigned_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS assigned_owners_repo_id_file_path_owner_user_id ON assigned_owners (repo_id, file_path, owner_user_id);
+
+COMMENT ON TABLE assigned_owners IS 'Owners explicitly assigned to a file or directory of a repository.';
+COMMENT ON COLUMN assigned_owners.file_path IS 'Path of the file or directory relative to the repository root. Empty for the whole repository.';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS saved_search_subscriptions;
+DROP TABLE IF EXISTS saved_search_runs;
+DROP TABLE IF EXISTS saved_search_schedules;
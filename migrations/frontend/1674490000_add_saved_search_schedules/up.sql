@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS saved_search_schedules (
+    saved_search_id integer PRIMARY KEY REFERENCES saved_searches(id) ON DELETE CASCADE DEFERRABLE,
+    interval_minutes integer NOT NULL,
+    next_run_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT saved_search_schedules_interval_positive CHECK (interval_minutes > 0)
+);
+
+COMMENT ON TABLE saved_search_schedules IS 'Saved searches that are run periodically to detect added and removed results.';
+
+CREATE TABLE IF NOT EXISTS saved_search_runs (
+    id serial PRIMARY KEY,
+    saved_search_id integer NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE DEFERRABLE,
+    query_string text,
+    results jsonb,
+    added_results jsonb,
+    removed_results jsonb,
+    state text DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT '',
+    cancel boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_runs_saved_search_id_id ON saved_search_runs (saved_search_id, id);
+CREATE INDEX IF NOT EXISTS saved_search_runs_state ON saved_search_runs (state);
+
+COMMENT ON COLUMN saved_search_runs.results IS 'The result set of the run, used to compute the added and removed results of the next run.';
+COMMENT ON COLUMN saved_search_runs.added_results IS 'The results that were not in the result set of the previous completed run.';
+COMMENT ON COLUMN saved_search_runs.removed_results IS 'The results of the previous completed run that are no longer in the result set.';
+
+CREATE TABLE IF NOT EXISTS saved_search_subscriptions (
+    id serial PRIMARY KEY,
+    saved_search_id integer NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    slack_webhook_url text,
+    webhook_url text,
+    include_results boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT saved_search_subscriptions_single_target CHECK (num_nonnulls(user_id, slack_webhook_url, webhook_url) = 1)
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_subscriptions_saved_search_id ON saved_search_subscriptions (saved_search_id);
+
+COMMENT ON TABLE saved_search_subscriptions IS 'Recipients notified by email, Slack or webhook when the results of a scheduled saved search change.';
+COMMENT ON COLUMN saved_search_subscriptions.user_id IS 'The user notified by email. Exactly one of user_id, slack_webhook_url and webhook_url is set.';
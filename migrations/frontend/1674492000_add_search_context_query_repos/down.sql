@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS search_context_query_repos;
+
+ALTER TABLE search_contexts DROP COLUMN IF EXISTS query_repos_updated_at;
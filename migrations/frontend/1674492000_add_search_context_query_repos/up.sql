@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS search_context_query_repos (
+    search_context_id bigint NOT NULL REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    revision text NOT NULL,
+    PRIMARY KEY (search_context_id, repo_id, revision)
+);
+
+COMMENT ON TABLE search_context_query_repos IS 'The repositories and revisions that the query of a query-based search context resolved to when it was last evaluated.';
+
+ALTER TABLE search_contexts ADD COLUMN IF NOT EXISTS query_repos_updated_at timestamp with time zone;
+
+COMMENT ON COLUMN search_contexts.query_repos_updated_at IS 'When the repositories of the search context query were last evaluated into search_context_query_repos. NULL if they need to be (re-)evaluated.';
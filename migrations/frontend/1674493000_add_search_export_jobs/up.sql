@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS search_export_jobs (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+
+    -- The search to export.
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query TEXT NOT NULL,
+    format TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- The exported results.
+    result_count INTEGER NOT NULL DEFAULT 0,
+    object_key TEXT,
+
+    -- Generic worker fields.
+    state TEXT NOT NULL DEFAULT 'queued',
+    failure_message TEXT,
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    process_after TIMESTAMP WITH TIME ZONE,
+    num_resets INTEGER NOT NULL DEFAULT 0,
+    num_failures INTEGER NOT NULL DEFAULT 0,
+    last_heartbeat_at TIMESTAMP WITH TIME ZONE NULL,
+    execution_logs JSON[],
+    worker_hostname TEXT NOT NULL DEFAULT '',
+    cancel BOOLEAN NOT NULL DEFAULT FALSE,
+
+    CONSTRAINT search_export_jobs_format_check CHECK (format IN ('csv', 'jsonl'))
+);
+
+COMMENT ON TABLE search_export_jobs IS 'Background jobs exporting all results of a search to the upload store.';
+COMMENT ON COLUMN search_export_jobs.object_key IS 'Key of the exported results in the upload store, set once the export completed.';
+
+CREATE INDEX IF NOT EXISTS search_export_jobs_state_idx ON search_export_jobs (state);
+CREATE INDEX IF NOT EXISTS search_export_jobs_user_id_idx ON search_export_jobs (user_id);
@@ -0,0 +1,2 @@
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS maintenance_logs;
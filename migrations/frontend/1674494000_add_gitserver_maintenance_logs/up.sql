@@ -0,0 +1,4 @@
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS maintenance_logs JSONB NOT NULL DEFAULT '[]';
+
+COMMENT ON COLUMN gitserver_repos.maintenance_logs IS 'Log of the scheduled maintenance tasks that ran on the repo - encoded as json';
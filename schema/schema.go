@@ -74,6 +74,8 @@ type AuditLog struct {
 	GraphQL bool `json:"graphQL"`
 	// InternalTraffic description: Capture security events performed by the internal traffic (adds significant noise).
 	InternalTraffic bool `json:"internalTraffic"`
+	// Retention description: How long audit log records are kept in the database, where they can be queried and exported by site admins. Must be a duration string such as "2160h".
+	Retention string `json:"retention,omitempty"`
 	// SeverityLevel description: Severity logging level for the audit log.
 	SeverityLevel string `json:"severityLevel,omitempty"`
 }
@@ -171,6 +173,33 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "bitbucketcloud"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// EnforcePermissions description: A flag to enforce Azure DevOps repository access permissions
+	EnforcePermissions bool `json:"enforcePermissions,omitempty"`
+	// Exclude description: A list of repositories to never mirror from Azure DevOps Services.
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// Projects description: An array of projects "org/project" strings specifying which Azure DevOps projects' repositories should be mirrored on Sourcegraph.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making API requests to Azure DevOps.
+	RateLimit *AzureDevOpsRateLimit `json:"rateLimit,omitempty"`
+	// Token description: The Personal Access Token associated with the Azure DevOps username used for authentication.
+	Token string `json:"token"`
+	// Url description: URL for Azure DevOps Services, set to https://dev.azure.com, or the collection URL of an Azure DevOps Server instance, such as https://azure.example.com/tfs.
+	Url string `json:"url"`
+	// Username description: A username for authentication with the Azure DevOps code host.
+	Username string `json:"username"`
+}
+
+// AzureDevOpsRateLimit description: Rate limit applied when making API requests to Azure DevOps.
+type AzureDevOpsRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -259,6 +288,8 @@ type BitbucketCloudConnection struct {
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form git@bitbucket.org:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// PartialCloneBlobSizeLimit description: If set, gitserver clones repositories of this code host as partial clones which only contain the blobs smaller than this size in bytes. Larger blobs are fetched from the code host when they are first read. Searcher skips them, since by default neither searcher nor indexed search read files larger than 2 MiB. Useful for very large repositories with large binary files in their history.
+	PartialCloneBlobSizeLimit int `json:"partialCloneBlobSizeLimit,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 	RateLimit *BitbucketCloudRateLimit `json:"rateLimit,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Bitbucket Cloud repository.
@@ -315,6 +346,8 @@ type BitbucketServerConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. BitBucket repositories can no longer be enabled or disabled explicitly.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// PartialCloneBlobSizeLimit description: If set, gitserver clones repositories of this code host as partial clones which only contain the blobs smaller than this size in bytes. Larger blobs are fetched from the code host when they are first read. Searcher skips them, since by default neither searcher nor indexed search read files larger than 2 MiB. Useful for very large repositories with large binary files in their history.
+	PartialCloneBlobSizeLimit int `json:"partialCloneBlobSizeLimit,omitempty"`
 	// Password description: The password to use when authenticating to the Bitbucket Server / Bitbucket Data Center instance. Also set the corresponding "username" field.
 	//
 	// For Bitbucket Server / Bitbucket Data Center instances that support personal access tokens (Bitbucket Server / Bitbucket Data Center version 5.5 and newer), it is recommended to provide a token instead (in the "token" field).
@@ -597,6 +630,12 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Name description: The name of an Azure DevOps Services organization, project, and repository ("orgName/projectName/repositoryName") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps Services repo.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -656,6 +695,8 @@ type ExpandedGitCommitDescription struct {
 
 // ExperimentalFeatures description: Experimental features and settings.
 type ExperimentalFeatures struct {
+	// AzureDevOps description: Allow adding Azure DevOps code host connections
+	AzureDevOps string `json:"azureDevOps,omitempty"`
 	// BitbucketServerFastPerm description: DEPRECATED: Configure in Bitbucket Server config.
 	BitbucketServerFastPerm string `json:"bitbucketServerFastPerm,omitempty"`
 	// CustomGitFetch description: JSON array of configuration that maps from Git clone URL domain/path to custom git fetch command. To enable this feature set environment variable `ENABLE_CUSTOM_GIT_FETCH` as `true` on gitserver.
@@ -674,8 +715,16 @@ type ExperimentalFeatures struct {
 	EventLogging string `json:"eventLogging,omitempty"`
 	// Gerrit description: Allow adding Gerrit code host connections
 	Gerrit string `json:"gerrit,omitempty"`
+	// GitServerMaintenancePolicies description: Policies of the gitserver maintenance scheduler, which is enabled with SRC_ENABLE_MAINTENANCE_SCHEDULER=true on gitserver. Each policy runs a maintenance task on the repositories whose size is within the policy's range, at most once per interval. For each task, the first policy that matches a repository applies. The history and next run of each task is shown on the repository's mirroring settings page. Defaults to policies that run commit-graph and geometric-repack daily on repositories smaller than 1 GiB, and commit-graph hourly, multi-pack-index every 6 hours and incremental-repack daily on larger repositories.
+	GitServerMaintenancePolicies []*GitServerMaintenancePolicy `json:"gitServerMaintenancePolicies,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerRebalance description: The target placement of repositories on gitserver instances. While set, every gitserver instance copies the repositories it stores to the instance that owns them in the target placement, and keeps serving them until routing is switched over. Once the src_gitserver_rebalance_pending_repos metric reaches zero, update the gitserver addresses and gitServerShardingAlgorithm to match the target and remove this setting.
+	GitServerRebalance *GitServerRebalance `json:"gitServerRebalance,omitempty"`
+	// GitServerReplicatedRepos description: Number of gitserver instances that store a copy of each listed repository, including its primary instance. Read-only requests for a listed repository are spread across all copies, and the replicas are updated from the primary after each repository update. Replicas never clone from the code host and only serve a repository once they have synced with the primary; reads a replica cannot answer fall back to the primary. Use this for hot repositories, such as a monorepo, which would otherwise overload a single gitserver instance.
+	GitServerReplicatedRepos map[string]int `json:"gitServerReplicatedRepos,omitempty"`
+	// GitServerShardingAlgorithm description: The algorithm used to assign repositories to gitserver instances. "modulo" remaps most repositories when the number of gitserver instances changes. "rendezvous" only moves the repositories of instances that are added or removed. Changing this value moves repositories between instances, so use gitServerRebalance to copy them to their new instance first.
+	GitServerShardingAlgorithm string `json:"gitServerShardingAlgorithm,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// InsightsAlternateLoadingStrategy description: Use an in-memory strategy of loading Code Insights. Should only be used for benchmarking on large instances, not for customer use currently.
@@ -686,6 +735,8 @@ type ExperimentalFeatures struct {
 	InsightsDataRetention *bool `json:"insightsDataRetention,omitempty"`
 	// JvmPackages description: Allow adding JVM package host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// Mercurial description: Allow adding Mercurial code host connections
+	Mercurial string `json:"mercurial,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
@@ -750,6 +801,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &m); err != nil {
 		return err
 	}
+	delete(m, "azureDevOps")
 	delete(m, "bitbucketServerFastPerm")
 	delete(m, "customGitFetch")
 	delete(m, "debug.log")
@@ -759,12 +811,17 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "enablePostSignupFlow")
 	delete(m, "eventLogging")
 	delete(m, "gerrit")
+	delete(m, "gitServerMaintenancePolicies")
 	delete(m, "gitServerPinnedRepos")
+	delete(m, "gitServerRebalance")
+	delete(m, "gitServerReplicatedRepos")
+	delete(m, "gitServerShardingAlgorithm")
 	delete(m, "goPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
 	delete(m, "insightsBackfillerV2")
 	delete(m, "insightsDataRetention")
 	delete(m, "jvmPackages")
+	delete(m, "mercurial")
 	delete(m, "npmPackages")
 	delete(m, "pagure")
 	delete(m, "passwordPolicy")
@@ -950,6 +1007,8 @@ type GitHubConnection struct {
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
 	Orgs []string `json:"orgs,omitempty"`
+	// PartialCloneBlobSizeLimit description: If set, gitserver clones repositories of this code host as partial clones which only contain the blobs smaller than this size in bytes. Larger blobs are fetched from the code host when they are first read. Searcher skips them, since by default neither searcher nor indexed search read files larger than 2 MiB. Useful for very large repositories with large binary files in their history.
+	PartialCloneBlobSizeLimit int `json:"partialCloneBlobSizeLimit,omitempty"`
 	// Pending description: Whether the code host connection is in a pending state.
 	Pending bool `json:"pending,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to GitHub.
@@ -1049,6 +1108,8 @@ type GitLabConnection struct {
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// NameTransformations description: An array of transformations will apply to the repository name. Currently, only regex replacement is supported. All transformations happen after "repositoryPathPattern" is processed.
 	NameTransformations []*GitLabNameTransformation `json:"nameTransformations,omitempty"`
+	// PartialCloneBlobSizeLimit description: If set, gitserver clones repositories of this code host as partial clones which only contain the blobs smaller than this size in bytes. Larger blobs are fetched from the code host when they are first read. Searcher skips them, since by default neither searcher nor indexed search read files larger than 2 MiB. Useful for very large repositories with large binary files in their history.
+	PartialCloneBlobSizeLimit int `json:"partialCloneBlobSizeLimit,omitempty"`
 	// ProjectQuery description: An array of strings specifying which GitLab projects to mirror on Sourcegraph. Each string is a URL path and query that targets a GitLab API endpoint returning a list of projects. If the string only contains a query, then "projects" is used as the path. Examples: "?membership=true&search=foo", "groups/mygroup/projects".
 	//
 	// The special string "none" can be used as the only element to disable this feature. Projects matched by multiple query strings are only imported once. Here are a few endpoints that return a list of projects: https://docs.gitlab.com/ee/api/projects.html#list-all-projects, https://docs.gitlab.com/ee/api/groups.html#list-a-groups-projects, https://docs.gitlab.com/ee/api/search.html#scope-projects.
@@ -1101,6 +1162,26 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitServerMaintenancePolicy description: A policy of the gitserver maintenance scheduler.
+type GitServerMaintenancePolicy struct {
+	// Interval description: The minimum time between two runs of the task on a repository, as a duration such as "1h" or "24h".
+	Interval string `json:"interval"`
+	// MaxRepoSizeBytes description: The policy only applies to repositories smaller than this size. Unlimited if not set.
+	MaxRepoSizeBytes int `json:"maxRepoSizeBytes,omitempty"`
+	// MinRepoSizeBytes description: The policy only applies to repositories of at least this size.
+	MinRepoSizeBytes int `json:"minRepoSizeBytes,omitempty"`
+	// Task description: The maintenance task to run. "commit-graph" and "incremental-repack" run the git maintenance task of the same name, "loose-objects" packs loose objects, "multi-pack-index" writes a multi-pack-index with a reachability bitmap, and "geometric-repack" repacks packfiles into a geometric progression and writes a reachability bitmap.
+	Task string `json:"task"`
+}
+
+// GitServerRebalance description: The target placement of repositories on gitserver instances. While set, every gitserver instance copies the repositories it stores to the instance that owns them in the target placement, and keeps serving them until routing is switched over. Once the src_gitserver_rebalance_pending_repos metric reaches zero, update the gitserver addresses and gitServerShardingAlgorithm to match the target and remove this setting.
+type GitServerRebalance struct {
+	// Addresses description: The gitserver addresses of the target placement. Defaults to the current gitserver addresses.
+	Addresses []string `json:"addresses,omitempty"`
+	// ShardingAlgorithm description: The sharding algorithm of the target placement.
+	ShardingAlgorithm string `json:"shardingAlgorithm,omitempty"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1274,6 +1355,22 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// MercurialConnection description: Configuration for a connection to an hgweb server hosting Mercurial repositories. Gitserver converts the repositories to Git.
+type MercurialConnection struct {
+	// Exclude description: Paths of repositories to never mirror from this hgweb server, relative to the url.
+	Exclude []string `json:"exclude,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{host}" is replaced with the host of the hgweb server, and "{path}" is replaced with the path of the repository relative to the url.
+	//
+	// For example, if your url is https://hg.example.com/repos and a repository is at https://hg.example.com/repos/my/repo, then a repositoryPathPattern of "{host}/{path}" would mean that it is available on Sourcegraph at https://sourcegraph.example.com/hg.example.com/my/repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Repos description: Paths of the repositories to mirror, relative to the url. If not set, all repositories listed on the index page of the hgweb server are mirrored.
+	Repos []string `json:"repos,omitempty"`
+	// Url description: URL of the hgweb server, such as https://hg.example.com. It may contain a username and password for HTTP basic authentication.
+	Url string `json:"url"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -1515,7 +1612,9 @@ type OrganizationInvitations struct {
 
 // OtherExternalServiceConnection description: Configuration for a Connection to Git repositories for which an external service integration isn't yet available.
 type OtherExternalServiceConnection struct {
-	Repos []string `json:"repos"`
+	// PartialCloneBlobSizeLimit description: If set, gitserver clones repositories of this code host as partial clones which only contain the blobs smaller than this size in bytes. Larger blobs are fetched from the code host when they are first read. Searcher skips them, since by default neither searcher nor indexed search read files larger than 2 MiB. Useful for very large repositories with large binary files in their history.
+	PartialCloneBlobSizeLimit int      `json:"partialCloneBlobSizeLimit,omitempty"`
+	Repos                     []string `json:"repos"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -2461,6 +2560,8 @@ type SiteConfiguration struct {
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
 	// SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
+	// SearchSmartSearchRules description: Additional rules applied by smart search. Each rule maps a search term to search filters: when a query contains the term as a standalone pattern, smart search proposes the query with the term replaced by the filters, such as `frontend` by `repo:^github\.com/acme/web$`. These rules are tried before the built-in rules.
+	SearchSmartSearchRules []*SmartSearchRule `json:"search.smartSearch.rules,omitempty"`
 	// SyntaxHighlighting description: Syntax highlighting configuration
 	SyntaxHighlighting *SyntaxHighlighting `json:"syntaxHighlighting,omitempty"`
 	// UpdateChannel description: The channel on which to automatically check for Sourcegraph updates.
@@ -2601,6 +2702,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")
+	delete(m, "search.smartSearch.rules")
 	delete(m, "syntaxHighlighting")
 	delete(m, "update.channel")
 	delete(m, "webhook.logging")
@@ -2613,6 +2715,16 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SmartSearchRule description: A rule applied by smart search that replaces a search term by search filters.
+type SmartSearchRule struct {
+	// Description description: A description of the rule shown to users when smart search applies it. Defaults to a description of the replacement.
+	Description string `json:"description,omitempty"`
+	// Filters description: The search filters replacing the term, such as `repo:^github\.com/acme/web$ lang:typescript`. It may only contain filters, not patterns.
+	Filters string `json:"filters"`
+	// Pattern description: The search term the rule applies to. It matches a standalone pattern of a query, ignoring case.
+	Pattern string `json:"pattern"`
+}
+
 // SrcCliVersionCache description: Configuration related to the src-cli version cache. This should only be used on sourcegraph.com.
 type SrcCliVersionCache struct {
 	// Enabled description: Enables the src-cli version cache API endpoint.